@@ -36,6 +36,9 @@ type LambdaInput struct {
 
 	FullScan     *FullScanInput     `json:"fullScan"`
 	UpdateStatus *UpdateStatusInput `json:"updateStatus"`
+
+	PutCustomLog   *PutCustomLogInput   `json:"putCustomLog"`
+	ListCustomLogs *ListCustomLogsInput `json:"listCustomLogs"`
 }
 
 //
@@ -81,6 +84,11 @@ type PutIntegrationSettings struct {
 	S3Prefix           *string   `json:"s3Prefix,omitempty" validate:"omitempty,min=1"`
 	KmsKey             *string   `json:"kmsKey,omitempty" validate:"omitempty,kmsKeyArn"`
 	LogTypes           []*string `json:"logTypes,omitempty" validate:"omitempty,min=1"`
+	// Log types of the files under specific prefixes, the longest matching prefix takes precedence over LogTypes
+	S3PrefixLogTypes []*S3PrefixLogTypes `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,dive"`
+	// How files are split into events, by default events are newline delimited
+	EventFraming        *string `json:"eventFraming,omitempty" validate:"omitempty,oneof=newline json regex octet-counted"`
+	EventFramingPattern *string `json:"eventFramingPattern,omitempty" validate:"eventFramingPattern,omitempty,regexp"`
 }
 
 //
@@ -103,6 +111,11 @@ type UpdateIntegrationSettingsInput struct {
 	S3Prefix           *string   `json:"s3Prefix,omitempty" validate:"omitempty,min=1"`
 	KmsKey             *string   `json:"kmsKey,omitempty" validate:"omitempty,kmsKeyArn"`
 	LogTypes           []*string `json:"logTypes,omitempty" validate:"omitempty,min=1"`
+	// Log types of the files under specific prefixes, the longest matching prefix takes precedence over LogTypes
+	S3PrefixLogTypes []*S3PrefixLogTypes `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,dive"`
+	// How files are split into events, by default events are newline delimited
+	EventFraming        *string `json:"eventFraming,omitempty" validate:"omitempty,oneof=newline json regex octet-counted"`
+	EventFramingPattern *string `json:"eventFramingPattern,omitempty" validate:"eventFramingPattern,omitempty,regexp"`
 }
 
 // DeleteIntegrationInput is used to delete a specific item from the database.
@@ -168,3 +181,17 @@ type UpdateStatusInput struct {
 	IntegrationID     string    `json:"integrationId" validate:"required,uuid4"`
 	LastEventReceived time.Time `json:"lastEventReceived" validate:"required"`
 }
+
+//
+// CustomLogs: User-defined log types
+//
+
+// PutCustomLogInput adds a user-defined log type or updates an existing one.
+type PutCustomLogInput struct {
+	// Spec is the YAML or JSON definition of the log type
+	Spec   *string `json:"spec" validate:"required,min=1"`
+	UserID *string `json:"userId" validate:"required,uuid4"`
+}
+
+// ListCustomLogsInput is used to list all user-defined log types.
+type ListCustomLogsInput struct{}
@@ -43,21 +43,30 @@ type SourceIntegrationScanInformation struct {
 
 // SourceIntegrationMetadata is general settings and metadata for an integration.
 type SourceIntegrationMetadata struct {
-	AWSAccountID       *string    `json:"awsAccountId,omitempty"`
-	CreatedAtTime      *time.Time `json:"createdAtTime,omitempty"`
-	CreatedBy          *string    `json:"createdBy,omitempty"`
-	IntegrationID      *string    `json:"integrationId,omitempty"`
-	IntegrationLabel   *string    `json:"integrationLabel,omitempty"`
-	IntegrationType    *string    `json:"integrationType,omitempty"`
-	RemediationEnabled *bool      `json:"remediationEnabled,omitempty"`
-	CWEEnabled         *bool      `json:"cweEnabled,omitempty"`
-	ScanIntervalMins   *int       `json:"scanIntervalMins,omitempty"`
-	S3Bucket           *string    `json:"s3Bucket,omitempty"`
-	S3Prefix           *string    `json:"s3Prefix,omitempty"`
-	KmsKey             *string    `json:"kmsKey,omitempty"`
-	LogTypes           []*string  `json:"logTypes,omitempty"`
-	LogProcessingRole  *string    `json:"logProcessingRole,omitempty"`
-	StackName          *string    `json:"stackName,omitempty"`
+	AWSAccountID        *string             `json:"awsAccountId,omitempty"`
+	CreatedAtTime       *time.Time          `json:"createdAtTime,omitempty"`
+	CreatedBy           *string             `json:"createdBy,omitempty"`
+	IntegrationID       *string             `json:"integrationId,omitempty"`
+	IntegrationLabel    *string             `json:"integrationLabel,omitempty"`
+	IntegrationType     *string             `json:"integrationType,omitempty"`
+	RemediationEnabled  *bool               `json:"remediationEnabled,omitempty"`
+	CWEEnabled          *bool               `json:"cweEnabled,omitempty"`
+	ScanIntervalMins    *int                `json:"scanIntervalMins,omitempty"`
+	S3Bucket            *string             `json:"s3Bucket,omitempty"`
+	S3Prefix            *string             `json:"s3Prefix,omitempty"`
+	KmsKey              *string             `json:"kmsKey,omitempty"`
+	LogTypes            []*string           `json:"logTypes,omitempty"`
+	S3PrefixLogTypes    []*S3PrefixLogTypes `json:"s3PrefixLogTypes,omitempty"`
+	EventFraming        *string             `json:"eventFraming,omitempty"`
+	EventFramingPattern *string             `json:"eventFramingPattern,omitempty"`
+	LogProcessingRole   *string             `json:"logProcessingRole,omitempty"`
+	StackName           *string             `json:"stackName,omitempty"`
+}
+
+// S3PrefixLogTypes declares the log types of the files under an S3 prefix of a source
+type S3PrefixLogTypes struct {
+	S3Prefix *string   `json:"s3Prefix" validate:"required,min=1"`
+	LogTypes []*string `json:"logTypes" validate:"required,min=1"`
 }
 
 type SourceIntegrationHealth struct {
@@ -84,3 +93,14 @@ type SourceIntegrationTemplate struct {
 	Body      *string `json:"body"`
 	StackName *string `json:"stackName"`
 }
+
+// CustomLog is a user-defined log type.
+type CustomLog struct {
+	LogType       *string    `json:"logType"`
+	Description   *string    `json:"description"`
+	Spec          *string    `json:"spec"`
+	CreatedAtTime *time.Time `json:"createdAtTime"`
+	CreatedBy     *string    `json:"createdBy"`
+	UpdatedAtTime *time.Time `json:"updatedAtTime"`
+	UpdatedBy     *string    `json:"updatedBy"`
+}
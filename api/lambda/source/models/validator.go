@@ -19,6 +19,7 @@ package models
  */
 
 import (
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -43,6 +44,13 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("kmsKeyArn", validateKmsKeyArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("regexp", validateRegexp); err != nil {
+		return nil, err
+	}
+	// runs even if the pattern is nil since it is required by regex framing
+	if err := result.RegisterValidation("eventFramingPattern", validateEventFramingPattern, true); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -66,3 +74,16 @@ func validateKmsKeyArn(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+func validateRegexp(fl validator.FieldLevel) bool {
+	_, err := regexp.Compile(fl.Field().String())
+	return err == nil
+}
+
+// validateEventFramingPattern checks that a pattern is set if and only if the sibling EventFraming field is regex
+func validateEventFramingPattern(fl validator.FieldLevel) bool {
+	hasPattern := fl.Field().Kind() == reflect.String && fl.Field().String() != ""
+	framing := reflect.Indirect(fl.Parent()).FieldByName("EventFraming")
+	isRegex := framing.Kind() == reflect.Ptr && !framing.IsNil() && framing.Elem().String() == EventFramingRegex
+	return hasPattern == isRegex
+}
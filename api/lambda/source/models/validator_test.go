@@ -81,3 +81,74 @@ func TestValidateKmsKey(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestValidateEventFramingPattern(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&PutIntegrationInput{
+		PutIntegrationSettings: PutIntegrationSettings{
+			AWSAccountID:        aws.String("123456789012"),
+			IntegrationLabel:    aws.String("Test12- "),
+			IntegrationType:     aws.String(IntegrationTypeAWS3),
+			UserID:              aws.String("cb7663c7-80ed-420b-a287-ed7dc50a0bf7"),
+			EventFraming:        aws.String("regex"),
+			EventFramingPattern: aws.String(`^\d{4}-\d{2}-\d{2}`),
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestValidateNotEventFramingPattern(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&PutIntegrationInput{
+		PutIntegrationSettings: PutIntegrationSettings{
+			AWSAccountID:        aws.String("123456789012"),
+			IntegrationLabel:    aws.String("Test12- "),
+			IntegrationType:     aws.String(IntegrationTypeAWS3),
+			UserID:              aws.String("cb7663c7-80ed-420b-a287-ed7dc50a0bf7"),
+			EventFraming:        aws.String("regex"),
+			EventFramingPattern: aws.String(`^(`),
+		},
+	})
+
+	errorMsg := "Key: 'PutIntegrationInput.PutIntegrationSettings.EventFramingPattern' " +
+		"Error:Field validation for 'EventFramingPattern' failed on the 'regexp' tag"
+	require.EqualError(t, err, errorMsg)
+}
+
+func TestValidateEventFramingPatternWithoutRegex(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	settings := PutIntegrationSettings{
+		AWSAccountID:     aws.String("123456789012"),
+		IntegrationLabel: aws.String("Test12- "),
+		IntegrationType:  aws.String(IntegrationTypeAWS3),
+		UserID:           aws.String("cb7663c7-80ed-420b-a287-ed7dc50a0bf7"),
+		EventFraming:     aws.String(EventFramingRegex),
+	}
+	err = validator.Struct(&PutIntegrationInput{PutIntegrationSettings: settings})
+	errorMsg := "Key: 'PutIntegrationInput.PutIntegrationSettings.EventFramingPattern' " +
+		"Error:Field validation for 'EventFramingPattern' failed on the 'eventFramingPattern' tag"
+	require.EqualError(t, err, errorMsg)
+
+	settings.EventFraming = aws.String("json")
+	settings.EventFramingPattern = aws.String(`^\d{4}`)
+	err = validator.Struct(&PutIntegrationInput{PutIntegrationSettings: settings})
+	require.EqualError(t, err, errorMsg)
+
+	settings.EventFraming = nil
+	err = validator.Struct(&PutIntegrationInput{PutIntegrationSettings: settings})
+	require.EqualError(t, err, errorMsg)
+
+	settings.EventFramingPattern = nil
+	err = validator.Struct(&PutIntegrationInput{PutIntegrationSettings: settings})
+	require.NoError(t, err)
+
+	err = validator.Struct(&UpdateIntegrationSettingsInput{
+		IntegrationID:    aws.String("cb7663c7-80ed-420b-a287-ed7dc50a0bf7"),
+		IntegrationLabel: aws.String("Test12- "),
+		EventFraming:     aws.String(EventFramingRegex),
+	})
+	require.Error(t, err)
+}
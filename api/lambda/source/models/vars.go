@@ -24,6 +24,9 @@ const (
 	// IntegrationTypeAWS3 is the integration type for importing data from customer S3 buckets.
 	IntegrationTypeAWS3 = "aws-s3"
 
+	// EventFramingRegex is the event framing of sources whose events start with a line matching EventFramingPattern.
+	EventFramingRegex = "regex"
+
 	// StatusError is the string set in the database when an error occurs in a scan.
 	StatusError = "error"
 	// StatusOK is the string set in the database when a scan is successful.
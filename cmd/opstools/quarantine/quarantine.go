@@ -0,0 +1,68 @@
+package quarantine
+
+import (
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/panther-labs/panther/cmd/opstools/s3queue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
+)
+
+const (
+	pageSize       = 1000
+	progressNotify = 5000 // log a line every this many to show progress
+)
+
+type Stats struct {
+	NumFiles uint64
+	NumBytes uint64
+}
+
+// S3Path returns the s3 path of the quarantined objects under a prefix (e.g., year=2020/month=06/)
+func S3Path(bucket, prefix string) string {
+	return "s3://" + bucket + "/" + quarantine.KeyPrefix + prefix
+}
+
+// List calls listFunc for every quarantined object under a prefix of the processed data bucket
+func List(sess *session.Session, bucket, prefix, s3region string, limit uint64,
+	listFunc func(object *s3.Object), stats *Stats) error {
+
+	return list(s3.New(sess.Copy(&aws.Config{Region: &s3region})), bucket, prefix, limit, listFunc, stats)
+}
+
+func list(s3Client s3iface.S3API, bucket, prefix string, limit uint64,
+	listFunc func(object *s3.Object), stats *Stats) error {
+
+	inputParams := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		Prefix:  aws.String(quarantine.KeyPrefix + prefix),
+		MaxKeys: aws.Int64(pageSize),
+	}
+	return s3Client.ListObjectsV2Pages(inputParams, func(page *s3.ListObjectsV2Output, morePages bool) bool {
+		for _, object := range page.Contents {
+			if limit > 0 && stats.NumFiles >= limit {
+				return false
+			}
+			stats.NumFiles++
+			if stats.NumFiles%progressNotify == 0 {
+				log.Printf("listed %d files ...", stats.NumFiles)
+			}
+			stats.NumBytes += (uint64)(aws.Int64Value(object.Size))
+			listFunc(object)
+		}
+		return limit == 0 || stats.NumFiles < limit // "To stop iterating, return false from the fn function."
+	})
+}
+
+// Reprocess posts s3 notifications for the quarantined objects under a prefix to the log processor queue
+// so their lines are classified again. The log processor deletes the objects once they are processed,
+// lines that still fail classification are quarantined anew so re-runs do not duplicate events.
+func Reprocess(sess *session.Session, account, bucket, prefix, s3region, queueName string,
+	concurrency int, limit uint64, verbose bool, stats *s3queue.Stats) error {
+
+	return s3queue.S3Queue(sess, account, S3Path(bucket, prefix), s3region, queueName, concurrency, limit, verbose, stats)
+}
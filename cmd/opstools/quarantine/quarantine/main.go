@@ -0,0 +1,156 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/panther-labs/panther/cmd/opstools/quarantine"
+	"github.com/panther-labs/panther/cmd/opstools/s3queue"
+)
+
+const (
+	banner = "lists log lines quarantined by the log processor and optionally sends them back to the log processor queue"
+)
+
+var (
+	REGION      = flag.String("region", "", "The Panther AWS region (optional, defaults to session env vars) where the queue exists.")
+	ACCOUNT     = flag.String("account", "", "The Panther AWS account id (optional, defaults to session account)")
+	BUCKET      = flag.String("bucket", "", "The Panther processed data bucket where quarantined log lines are stored.")
+	PREFIX      = flag.String("prefix", "", "The prefix of quarantined objects to select (optional, e.g., year=2020/month=06/)")
+	REPROCESS   = flag.Bool("reprocess", false, "Send the quarantined objects to the log processor queue to be classified again")
+	CONCURRENCY = flag.Int("concurrency", 50, "The number of concurrent sqs writer go routines")
+	LIMIT       = flag.Uint64("limit", 0, "If non-zero, then limit the number of files to this number.")
+	TOQ         = flag.String("queue", "panther-input-data-notifications-queue", "The name of the log processor queue to send notifications.")
+	VERBOSE     = flag.Bool("verbose", false, "Enable verbose logging")
+
+	logger *zap.SugaredLogger
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(),
+		"%s %s\nUsage:\n",
+		filepath.Base(os.Args[0]), banner)
+	flag.PrintDefaults()
+}
+
+func init() {
+	flag.Usage = usage
+
+	config := zap.NewDevelopmentConfig() // DEBUG by default
+	if !*VERBOSE {
+		// In normal mode, hide DEBUG messages and file/line numbers
+		config.DisableCaller = true
+		config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	}
+
+	// Always disable error traces and use color-coded log levels and short timestamps
+	config.DisableStacktrace = true
+	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+
+	rawLogger, err := config.Build()
+	if err != nil {
+		log.Fatalf("failed to build logger: %s", err)
+	}
+	zap.ReplaceGlobals(rawLogger)
+	logger = rawLogger.Sugar()
+}
+
+func main() {
+	flag.Parse()
+
+	validateFlags()
+
+	sess, err := session.NewSession()
+	if err != nil {
+		logger.Fatal(err)
+		return
+	}
+
+	if *REGION != "" { //override
+		sess.Config.Region = REGION
+	} else {
+		REGION = sess.Config.Region
+	}
+
+	s3Region := getS3Region(sess, *BUCKET)
+
+	startTime := time.Now()
+	if !*REPROCESS {
+		stats := &quarantine.Stats{}
+		err = quarantine.List(sess, *BUCKET, *PREFIX, s3Region, *LIMIT, func(object *s3.Object) {
+			fmt.Printf("%s\t%d\ts3://%s/%s\n",
+				aws.TimeValue(object.LastModified).UTC().Format(time.RFC3339), aws.Int64Value(object.Size), *BUCKET, *object.Key)
+		}, stats)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("listed %d quarantined files (%.2fMB) in %s in %v",
+			stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), quarantine.S3Path(*BUCKET, *PREFIX), time.Since(startTime))
+		return
+	}
+
+	if *ACCOUNT == "" {
+		identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		if err != nil {
+			logger.Fatalf("failed to get caller identity: %v", err)
+		}
+		ACCOUNT = identity.Account
+	}
+
+	stats := &s3queue.Stats{}
+	err = quarantine.Reprocess(sess, *ACCOUNT, *BUCKET, *PREFIX, s3Region, *TOQ, *CONCURRENCY, *LIMIT, *VERBOSE, stats)
+	if err != nil {
+		logger.Fatal(err)
+	} else {
+		logger.Infof("sent %d quarantined files (%.2fMB) to %s (%s) in %v",
+			stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), *TOQ, *REGION, time.Since(startTime))
+	}
+}
+
+func validateFlags() {
+	var err error
+	defer func() {
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			flag.Usage()
+			os.Exit(-2)
+		}
+	}()
+
+	if *BUCKET == "" {
+		err = errors.New("-bucket not set")
+		return
+	}
+	if *REPROCESS && *TOQ == "" {
+		err = errors.New("-queue not set")
+		return
+	}
+}
+
+func getS3Region(sess *session.Session, bucket string) string {
+	input := &s3.GetBucketLocationInput{Bucket: aws.String(bucket)}
+	location, err := s3.New(sess).GetBucketLocation(input)
+	if err != nil {
+		logger.Fatalf("failed to find bucket region for %s: %s", bucket, err)
+	}
+
+	// Method may return nil if region is us-east-1,https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLocation.html
+	// and https://docs.aws.amazon.com/general/latest/gr/rande.html#s3_region
+	if location.LocationConstraint == nil {
+		return endpoints.UsEast1RegionID
+	}
+	return *location.LocationConstraint
+}
@@ -0,0 +1,88 @@
+package quarantine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBucket = "processed"
+	testPrefix = "year=2020/month=06/"
+)
+
+func TestS3Path(t *testing.T) {
+	assert.Equal(t, "s3://processed/quarantine/year=2020/month=06/", S3Path(testBucket, testPrefix))
+}
+
+func TestList(t *testing.T) {
+	s3Client := &mockS3{}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{
+				Size: aws.Int64(1),
+				Key:  aws.String("quarantine/year=2020/month=06/day=01/hour=00/a.json.gz"),
+			},
+			{
+				Size: aws.Int64(2),
+				Key:  aws.String("quarantine/year=2020/month=06/day=01/hour=00/b.json.gz"),
+			},
+		},
+	}
+	expectInput := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(testBucket),
+		Prefix:  aws.String("quarantine/" + testPrefix),
+		MaxKeys: aws.Int64(pageSize),
+	}
+	s3Client.On("ListObjectsV2Pages", expectInput, mock.Anything).Return(page, nil).Once()
+
+	var keys []string
+	stats := &Stats{}
+	err := list(s3Client, testBucket, testPrefix, 0, func(object *s3.Object) {
+		keys = append(keys, *object.Key)
+	}, stats)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	assert.Equal(t, []string{*page.Contents[0].Key, *page.Contents[1].Key}, keys)
+	assert.Equal(t, uint64(2), stats.NumFiles)
+	assert.Equal(t, uint64(3), stats.NumBytes)
+}
+
+func TestListLimit(t *testing.T) {
+	s3Client := &mockS3{}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{
+				Size: aws.Int64(1),
+				Key:  aws.String("quarantine/a.json.gz"),
+			},
+			{
+				Size: aws.Int64(1),
+				Key:  aws.String("quarantine/b.json.gz"),
+			},
+		},
+	}
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Once()
+
+	stats := &Stats{}
+	err := list(s3Client, testBucket, "", 1, func(object *s3.Object) {}, stats)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	assert.Equal(t, uint64(1), stats.NumFiles)
+}
+
+type mockS3 struct {
+	s3iface.S3API
+	mock.Mock
+}
+
+func (m *mockS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, f func(page *s3.ListObjectsV2Output, morePages bool) bool) error {
+	args := m.Called(input, f)
+	f(args.Get(0).(*s3.ListObjectsV2Output), false)
+	return args.Error(1)
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/process"
 	"github.com/panther-labs/panther/internal/log_analysis/gluetables"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 )
 
 type UpdateGlueTablesProperties struct {
@@ -62,6 +64,12 @@ func customUpdateGlueTables(_ context.Context, event cfn.Event) (string, map[str
 			}
 		}
 
+		// register the custom log types so their tables and views are updated too
+		if _, err := customlogs.Load(lambdaClient, registry.Default()); err != nil {
+			// the source API is not available on the first deployment
+			zap.L().Warn("failed to load custom log types", zap.Error(err))
+		}
+
 		// update schemas for tables that are deployed
 		deployedLogTables, err := gluetables.DeployedLogTables(glueClient)
 		if err != nil {
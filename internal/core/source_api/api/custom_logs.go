@@ -0,0 +1,108 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/process"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var (
+	putCustomLogInternalError = &genericapi.InternalError{Message: "Failed to save custom log type. Please try again later"}
+)
+
+// PutCustomLog adds a user-defined log type or updates an existing one.
+//
+// The tables of an updated log type are updated and their partitions are synced to the new schema.
+func (API) PutCustomLog(input *models.PutCustomLogInput) (*models.CustomLog, error) {
+	schema, err := customlogs.ParseSchema(*input.Spec)
+	if err != nil {
+		return nil, &genericapi.InvalidInputError{Message: err.Error()}
+	}
+
+	existing, err := dynamoClient.GetCustomLog(&schema.Name)
+	if err != nil {
+		zap.L().Error("failed to get custom log", zap.Error(errors.WithStack(err)))
+		return nil, putCustomLogInternalError
+	}
+
+	now := time.Now()
+	customLog := &models.CustomLog{
+		LogType:       aws.String(schema.Name),
+		Description:   aws.String(schema.Description),
+		Spec:          input.Spec,
+		CreatedAtTime: aws.Time(now),
+		CreatedBy:     input.UserID,
+		UpdatedAtTime: aws.Time(now),
+		UpdatedBy:     input.UserID,
+	}
+	if existing != nil {
+		customLog.CreatedAtTime = existing.CreatedAtTime
+		customLog.CreatedBy = existing.CreatedBy
+	}
+
+	if err := dynamoClient.PutCustomLog(customLog); err != nil {
+		zap.L().Error("failed to store custom log in DDB", zap.Error(errors.WithStack(err)))
+		return nil, putCustomLogInternalError
+	}
+
+	// New log types get their tables when a source uses them
+	if existing == nil {
+		return customLog, nil
+	}
+
+	if err := addGlueTables([]*string{customLog.LogType}); err != nil {
+		zap.L().Error("failed to update glue tables of custom log", zap.Error(errors.WithStack(err)))
+		return nil, putCustomLogInternalError
+	}
+	if err := process.InvokeSyncGluePartitions(lambdaClient, []string{schema.Name}); err != nil {
+		zap.L().Error("failed to sync partitions of custom log", zap.Error(errors.WithStack(err)))
+		return nil, putCustomLogInternalError
+	}
+	return customLog, nil
+}
+
+// ListCustomLogs returns all user-defined log types.
+func (API) ListCustomLogs(_ *models.ListCustomLogsInput) ([]*models.CustomLog, error) {
+	customLogs, err := dynamoClient.ScanCustomLogs()
+	if err != nil {
+		zap.L().Error("failed to list custom logs", zap.Error(errors.WithStack(err)))
+		return nil, &genericapi.InternalError{Message: "Failed to list custom log types"}
+	}
+	return customLogs, nil
+}
+
+// registerCustomLogs adds the user-defined log types to the log type registry so their tables can be created
+func registerCustomLogs() error {
+	customLogs, err := dynamoClient.ScanCustomLogs()
+	if err != nil {
+		return err
+	}
+	customlogs.RegisterAll(registry.Default(), customLogs)
+	return nil
+}
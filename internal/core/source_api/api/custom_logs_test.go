@@ -0,0 +1,153 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testCustomLogSpec = `
+name: Custom.SourceAPITest
+description: Test log type
+format: json
+fields:
+  - name: message
+    type: string
+    description: The log message
+`
+
+func TestPutCustomLog(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test", CustomLogsTableName: "test-custom-logs"}
+
+	mockClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	result, err := apiTest.PutCustomLog(&models.PutCustomLogInput{
+		Spec:   aws.String(testCustomLogSpec),
+		UserID: aws.String(testUserID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Custom.SourceAPITest", aws.StringValue(result.LogType))
+	require.Equal(t, "Test log type", aws.StringValue(result.Description))
+	require.Equal(t, testUserID, aws.StringValue(result.CreatedBy))
+	require.Equal(t, result.CreatedAtTime, result.UpdatedAtTime)
+	mockClient.AssertExpectations(t)
+
+	putInput := mockClient.Calls[1].Arguments.Get(0).(*dynamodb.PutItemInput)
+	require.Equal(t, "test-custom-logs", aws.StringValue(putInput.TableName))
+}
+
+func TestPutCustomLogUpdate(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test", CustomLogsTableName: "test-custom-logs"}
+	mockGlue := &testutils.GlueMock{}
+	glueClient = mockGlue
+	mockAthena := &testutils.AthenaMock{}
+	athenaClient = mockAthena
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	existing, err := dynamodbattribute.MarshalMap(&models.CustomLog{
+		LogType:   aws.String("Custom.SourceAPITest"),
+		Spec:      aws.String(testCustomLogSpec),
+		CreatedBy: aws.String("creator"),
+	})
+	require.NoError(t, err)
+	mockClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: existing}, nil).Once()
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	mockClient.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{existing},
+	}, nil).Once()
+
+	// update the tables
+	mockGlue.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Twice()
+	// create/replace the view
+	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil)
+	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
+		QueryExecutionId: aws.String("test-query-1234"),
+	}, nil).Twice()
+	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("test-query-1234"),
+			Status: &athena.QueryExecutionStatus{
+				State: aws.String(athena.QueryExecutionStateSucceeded),
+			},
+		},
+	}, nil).Twice()
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Twice()
+	// sync the partitions
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Once()
+
+	result, err := apiTest.PutCustomLog(&models.PutCustomLogInput{
+		Spec:   aws.String(testCustomLogSpec),
+		UserID: aws.String(testUserID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "creator", aws.StringValue(result.CreatedBy))
+	require.Equal(t, testUserID, aws.StringValue(result.UpdatedBy))
+	require.NotNil(t, registry.Default().Get("Custom.SourceAPITest"))
+	mockClient.AssertExpectations(t)
+	mockGlue.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+}
+
+func TestPutCustomLogInvalidSpec(t *testing.T) {
+	_, err := apiTest.PutCustomLog(&models.PutCustomLogInput{
+		Spec:   aws.String("name: AWS.CloudTrail"),
+		UserID: aws.String(testUserID),
+	})
+	require.Error(t, err)
+	require.IsType(t, &genericapi.InvalidInputError{}, err)
+}
+
+func TestListCustomLogs(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test", CustomLogsTableName: "test-custom-logs"}
+
+	item, err := dynamodbattribute.MarshalMap(&models.CustomLog{
+		LogType: aws.String("Custom.SourceAPITest"),
+		Spec:    aws.String(testCustomLogSpec),
+	})
+	require.NoError(t, err)
+	mockClient.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item},
+	}, nil).Once()
+
+	result, err := apiTest.ListCustomLogs(&models.ListCustomLogsInput{})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "Custom.SourceAPITest", aws.StringValue(result[0].LogType))
+	mockClient.AssertExpectations(t)
+}
@@ -19,11 +19,16 @@ package api
  */
 
 import (
+	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/log_analysis/athenaviews"
 	"github.com/panther-labs/panther/internal/log_analysis/gluetables"
 )
 
 func addGlueTables(logTypes []*string) error {
+	// custom log types are registered at runtime
+	if err := registerCustomLogs(); err != nil {
+		return err
+	}
 	for _, logType := range logTypes {
 		_, _, err := gluetables.CreateOrUpdateGlueTablesForLogType(glueClient, *logType, env.ProcessedDataBucket)
 		if err != nil {
@@ -34,3 +39,21 @@ func addGlueTables(logTypes []*string) error {
 	// update the views with the new tables
 	return athenaviews.CreateOrReplaceViews(glueClient, athenaClient)
 }
+
+// integrationLogTypes returns the log types of a source including the ones declared for specific prefixes
+func integrationLogTypes(logTypes []*string, prefixLogTypes []*models.S3PrefixLogTypes) (result []*string) {
+	seen := make(map[string]bool)
+	add := func(logTypes []*string) {
+		for _, logType := range logTypes {
+			if logType != nil && !seen[*logType] {
+				seen[*logType] = true
+				result = append(result, logType)
+			}
+		}
+	}
+	add(logTypes)
+	for _, prefix := range prefixLogTypes {
+		add(prefix.LogTypes)
+	}
+	return result
+}
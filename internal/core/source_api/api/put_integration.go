@@ -73,6 +73,12 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (*models.Source
 		return nil, err
 	}
 
+	if aws.StringValue(input.IntegrationType) == models.IntegrationTypeAWS3 {
+		if err := validateLogTypesFraming(input.EventFraming, input.LogTypes, input.S3PrefixLogTypes); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get ready to add appropriate permissions to the SQS queue
 	permissionAdded := false
 	defer func() {
@@ -96,7 +102,7 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (*models.Source
 			zap.L().Error("Failed to add permissions to log processor queue", zap.Error(errors.WithStack(err)))
 			return nil, putIntegrationInternalError
 		}
-		err = addGlueTables(input.LogTypes)
+		err = addGlueTables(integrationLogTypes(input.LogTypes, input.S3PrefixLogTypes))
 		if err != nil {
 			zap.L().Error("Failed to add glue tables to glue catalog", zap.Error(errors.WithStack(err)))
 			return nil, putIntegrationInternalError
@@ -233,6 +239,9 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 		metadata.S3Prefix = input.S3Prefix
 		metadata.KmsKey = input.KmsKey
 		metadata.LogTypes = input.LogTypes
+		metadata.S3PrefixLogTypes = input.S3PrefixLogTypes
+		metadata.EventFraming = input.EventFraming
+		metadata.EventFramingPattern = input.EventFramingPattern
 		metadata.StackName = aws.String(getStackName(*input.IntegrationType, *input.IntegrationLabel))
 		metadata.LogProcessingRole = aws.String(generateLogProcessingRoleArn(*input.AWSAccountID, *input.IntegrationLabel))
 	}
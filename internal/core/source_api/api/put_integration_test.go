@@ -42,6 +42,7 @@ import (
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/genericapi"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
 
@@ -174,6 +175,45 @@ func TestPutLogIntegrationExists(t *testing.T) {
 	assert.Equal(t, "Log source for account 123456789012 with label ProdAWS already onboarded", err.Error())
 }
 
+func TestPutLogIntegrationMixedFraming(t *testing.T) {
+	evaluateIntegrationFunc = func(_ API, _ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	dynamoClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+
+	input := &models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeAWS3),
+			UserID:           aws.String(testUserID),
+			S3Bucket:         aws.String("test-bucket"),
+			LogTypes:         aws.StringSlice([]string{"Auditd.Event", "AWS.CloudTrail"}),
+		},
+	}
+	out, err := apiTest.PutIntegration(input)
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+
+	// log types under an S3 prefix are read on their own
+	input.LogTypes = aws.StringSlice([]string{"AWS.CloudTrail"})
+	input.S3PrefixLogTypes = []*models.S3PrefixLogTypes{
+		{
+			S3Prefix: aws.String("audit/"),
+			LogTypes: aws.StringSlice([]string{"Auditd.Event", "Syslog.RFC3164"}),
+		},
+	}
+	out, err = apiTest.PutIntegration(input)
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	assert.Contains(t, err.Error(), `"audit/"`)
+
+	// OSSEC.EventInfo uses the default framing, it can be combined with other log types
+	require.NoError(t, validateLogTypesFraming(nil, aws.StringSlice([]string{"OSSEC.EventInfo", "AWS.CloudTrail"}), nil))
+	require.Error(t, validateLogTypesFraming(nil, aws.StringSlice([]string{"OSSEC.Alert", "AWS.CloudTrail"}), nil))
+}
+
 func TestPutCloudSecIntegrationExists(t *testing.T) {
 	evaluateIntegrationFunc = func(_ API, _ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
 
@@ -79,12 +79,18 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 		existingIntegrationItem.CWEEnabled = input.CWEEnabled
 		existingIntegrationItem.RemediationEnabled = input.RemediationEnabled
 	case models.IntegrationTypeAWS3:
+		if err := validateLogTypesFraming(input.EventFraming, input.LogTypes, input.S3PrefixLogTypes); err != nil {
+			return nil, err
+		}
 		existingIntegrationItem.S3Bucket = input.S3Bucket
 		existingIntegrationItem.S3Prefix = input.S3Prefix
 		existingIntegrationItem.KmsKey = input.KmsKey
 		existingIntegrationItem.LogTypes = input.LogTypes
+		existingIntegrationItem.S3PrefixLogTypes = input.S3PrefixLogTypes
+		existingIntegrationItem.EventFraming = input.EventFraming
+		existingIntegrationItem.EventFramingPattern = input.EventFramingPattern
 
-		err = addGlueTables(input.LogTypes)
+		err = addGlueTables(integrationLogTypes(input.LogTypes, input.S3PrefixLogTypes))
 		if err != nil {
 			zap.L().Error("Failed to add glue tables to glue catalog", zap.Error(errors.WithStack(err)))
 			return nil, updateIntegrationInternalError
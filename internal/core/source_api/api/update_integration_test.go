@@ -81,6 +81,8 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	}}
 	mockClient.On("GetItem", mock.Anything).Return(getResponse, nil)
 	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	// no custom log types
+	mockClient.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
 
 	// create the tables
 	mockGlue.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Twice()
@@ -19,10 +19,14 @@ package api
  */
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
@@ -43,6 +47,9 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 		item.S3Prefix = input.S3Prefix
 		item.KmsKey = input.KmsKey
 		item.LogTypes = input.LogTypes
+		item.S3PrefixLogTypes = input.S3PrefixLogTypes
+		item.EventFraming = input.EventFraming
+		item.EventFramingPattern = input.EventFramingPattern
 		item.StackName = input.StackName
 		item.LogProcessingRole = aws.String(generateLogProcessingRoleArn(*input.AWSAccountID, *input.IntegrationLabel))
 	case models.IntegrationTypeAWSScan:
@@ -77,6 +84,9 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 		integration.S3Prefix = item.S3Prefix
 		integration.KmsKey = item.KmsKey
 		integration.LogTypes = item.LogTypes
+		integration.S3PrefixLogTypes = item.S3PrefixLogTypes
+		integration.EventFraming = item.EventFraming
+		integration.EventFramingPattern = item.EventFramingPattern
 		integration.StackName = item.StackName
 		integration.LogProcessingRole = item.LogProcessingRole
 	case models.IntegrationTypeAWSScan:
@@ -93,3 +103,26 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 	}
 	return integration
 }
+
+// validateLogTypesFraming checks that the log types of each S3 object of a source can share an event framing.
+// Log types like Auditd.Event need a framing of their own and cannot be mixed with other log types
+// unless the source sets the framing explicitly.
+func validateLogTypesFraming(eventFraming *string, logTypes []*string, prefixLogTypes []*models.S3PrefixLogTypes) error {
+	if eventFraming != nil {
+		return nil
+	}
+	if _, err := registry.Framing(aws.StringValueSlice(logTypes)...); err != nil {
+		return &genericapi.InvalidInputError{
+			Message: fmt.Sprintf("%s, use a separate source or S3 prefix for each", err),
+		}
+	}
+	for _, prefix := range prefixLogTypes {
+		if _, err := registry.Framing(aws.StringValueSlice(prefix.LogTypes)...); err != nil {
+			return &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("%s under S3 prefix %q, use a separate S3 prefix for each",
+					err, aws.StringValue(prefix.S3Prefix)),
+			}
+		}
+	}
+	return nil
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
 	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -51,6 +53,7 @@ var (
 	templateS3Client s3iface.S3API
 	glueClient       glueiface.GlueAPI
 	athenaClient     athenaiface.AthenaAPI
+	lambdaClient     lambdaiface.LambdaAPI
 )
 
 type envConfig struct {
@@ -59,6 +62,7 @@ type envConfig struct {
 	LogProcessorQueueArn    string `required:"true" split_words:"true"`
 	ProcessedDataBucket     string `required:"true" split_words:"true"`
 	TableName               string `required:"true" split_words:"true"`
+	CustomLogsTableName     string `required:"true" split_words:"true"`
 }
 
 // Setup parses the environment and constructs AWS and http clients on a cold Lambda start.
@@ -67,13 +71,14 @@ func Setup() {
 	envconfig.MustProcess("", &env)
 
 	awsSession = session.Must(session.NewSession())
-	dynamoClient = ddb.New(env.TableName)
+	dynamoClient = ddb.New(env.TableName, env.CustomLogsTableName)
 	sqsClient = sqs.New(awsSession)
 	templateS3Client = s3.New(awsSession, &aws.Config{
 		Region: aws.String(templateBucketRegion),
 	})
 	glueClient = glue.New(awsSession)
 	athenaClient = athena.New(awsSession)
+	lambdaClient = lambda.New(awsSession)
 }
 
 // API provides receiver methods for each route handler.
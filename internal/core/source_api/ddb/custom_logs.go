@@ -0,0 +1,89 @@
+package ddb
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// PutCustomLog adds or replaces a user-defined log type in the database
+func (ddb *DDB) PutCustomLog(input *models.CustomLog) error {
+	item, err := dynamodbattribute.MarshalMap(input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal custom log")
+	}
+
+	putRequest := &dynamodb.PutItemInput{
+		TableName: aws.String(ddb.CustomLogsTableName),
+		Item:      item,
+	}
+	_, err = ddb.Client.PutItem(putRequest)
+	if err != nil {
+		return errors.Wrap(err, "failed to put item")
+	}
+	return nil
+}
+
+// GetCustomLog returns a user-defined log type by its name
+func (ddb *DDB) GetCustomLog(logType *string) (*models.CustomLog, error) {
+	output, err := ddb.Client.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(ddb.CustomLogsTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			customLogHashKey: {S: logType},
+		},
+	})
+	if err != nil {
+		return nil, &genericapi.AWSError{Err: err, Method: "Dynamodb.GetItem"}
+	}
+
+	if output.Item == nil {
+		return nil, nil
+	}
+	var customLog models.CustomLog
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &customLog); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal DDB item")
+	}
+
+	return &customLog, nil
+}
+
+// ScanCustomLogs returns all user-defined log types
+func (ddb *DDB) ScanCustomLogs() ([]*models.CustomLog, error) {
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(ddb.CustomLogsTableName),
+	}
+
+	output, err := ddb.Client.Scan(scanInput)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scan table")
+	}
+
+	var customLogs []*models.CustomLog
+	if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &customLogs); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal scan results")
+	}
+
+	return customLogs, nil
+}
@@ -25,19 +25,22 @@ import (
 )
 
 const (
-	hashKey = "integrationId"
+	hashKey          = "integrationId"
+	customLogHashKey = "logType"
 )
 
 // DDB is a struct containing the DynamoDB client, and the table name to retrieve data.
 type DDB struct {
-	Client    dynamodbiface.DynamoDBAPI
-	TableName string
+	Client              dynamodbiface.DynamoDBAPI
+	TableName           string
+	CustomLogsTableName string
 }
 
 // New instantiates a new client.
-func New(tableName string) *DDB {
+func New(tableName, customLogsTableName string) *DDB {
 	return &DDB{
-		Client:    dynamodb.New(session.Must(session.NewSession())),
-		TableName: tableName,
+		Client:              dynamodb.New(session.Must(session.NewSession())),
+		TableName:           tableName,
+		CustomLogsTableName: customLogsTableName,
 	}
 }
@@ -18,7 +18,11 @@ package ddb
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-import "time"
+import (
+	"time"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
 
 // Integration represents an integration item as it is stored in DynamoDB.
 type Integration struct {
@@ -38,12 +42,15 @@ type Integration struct {
 	ScanIntervalMins     *int       `json:"scanIntervalMins"`
 	IntegrationStatus
 
-	S3Bucket          *string   `json:"s3Bucket"`
-	S3Prefix          *string   `json:"s3Prefix"`
-	KmsKey            *string   `json:"kmsKey"`
-	LogTypes          []*string `json:"logTypes" dynamodbav:"logTypes,stringset"`
-	StackName         *string   `json:"stackName,omitempty"`
-	LogProcessingRole *string   `json:"logProcessingRole,omitempty"`
+	S3Bucket            *string                    `json:"s3Bucket"`
+	S3Prefix            *string                    `json:"s3Prefix"`
+	KmsKey              *string                    `json:"kmsKey"`
+	LogTypes            []*string                  `json:"logTypes" dynamodbav:"logTypes,stringset"`
+	S3PrefixLogTypes    []*models.S3PrefixLogTypes `json:"s3PrefixLogTypes,omitempty"`
+	EventFraming        *string                    `json:"eventFraming,omitempty"`
+	EventFramingPattern *string                    `json:"eventFramingPattern,omitempty"`
+	StackName           *string                    `json:"stackName,omitempty"`
+	LogProcessingRole   *string                    `json:"logProcessingRole,omitempty"`
 }
 
 type IntegrationStatus struct {
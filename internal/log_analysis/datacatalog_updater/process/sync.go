@@ -29,6 +29,7 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/box"
 )
@@ -53,6 +54,10 @@ type Continuation struct {
 func Sync(event *SyncEvent, deadline time.Time) error {
 	var zeroStartTime time.Time // setting the startTime to 0, means use createTime for the table
 
+	if err := registerCustomLogs(event); err != nil {
+		return err
+	}
+
 	// first, finish any pending work
 	if event.Continuation != nil {
 		startTime := event.Continuation.NextPartitionTime
@@ -110,6 +115,22 @@ func Sync(event *SyncEvent, deadline time.Time) error {
 	return nil
 }
 
+// registerCustomLogs loads the custom log types from the source API if the event refers to unknown log types
+func registerCustomLogs(event *SyncEvent) error {
+	logTypes := event.LogTypes
+	if event.Continuation != nil {
+		logTypes = append([]string{event.Continuation.LogType}, logTypes...)
+	}
+	for _, logType := range logTypes {
+		if registry.Default().Get(logType) == nil {
+			// custom log types are registered at runtime
+			_, err := customlogs.Load(lambdaClient, registry.Default())
+			return err
+		}
+	}
+	return nil
+}
+
 func syncTable(table *awsglue.GlueTableMetadata, event *SyncEvent, startTime, deadline time.Time) (bool, error) {
 	zap.L().Info("sync'ing partitions for table", zap.String("database", table.DatabaseName()),
 		zap.String("table", table.TableName()))
@@ -25,10 +25,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
 
@@ -178,3 +182,33 @@ func TestSyncContinuationFromRuleData(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterCustomLogs(t *testing.T) {
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	// registered log types do not need a lookup
+	require.NoError(t, registerCustomLogs(&SyncEvent{LogTypes: []string{"AWS.CloudTrail"}}))
+
+	customLogs := []*sourcemodels.CustomLog{
+		{
+			LogType: aws.String("Custom.SyncTest"),
+			Spec: aws.String(`
+name: Custom.SyncTest
+description: Test log type
+format: json
+fields:
+  - name: message
+    type: string
+    description: The log message
+`),
+		},
+	}
+	payload, err := jsoniter.Marshal(customLogs)
+	require.NoError(t, err)
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil).Once()
+
+	require.NoError(t, registerCustomLogs(&SyncEvent{LogTypes: []string{"AWS.CloudTrail", "Custom.SyncTest"}}))
+	require.NotNil(t, registry.Default().Get("Custom.SyncTest"))
+	mockLambda.AssertExpectations(t)
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/box"
 )
 
@@ -102,6 +103,29 @@ func TestClassifyRespectsPriorityOfParsers(t *testing.T) {
 	require.Nil(t, classifier.ParserStats()["fail2"])
 }
 
+func TestClassifyFallbackParsers(t *testing.T) {
+	// nolint:lll
+	cefLog := `<14>1 2020-06-01T15:09:42Z edr01 agent 4242 ALERT - CEF:1|Example|EDR|3.2|malware|Malware detected|High|shost=laptop-12 src=10.0.0.5`
+	// nolint:lll
+	leefLog := `<13>1 2020-06-01T15:09:42Z proxy01 - - - - LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5`
+	syslogLog := `<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed for lonvick on /dev/pts/8`
+
+	classifier := NewClassifier(registry.Parsers("Syslog.RFC3164", "Syslog.RFC5424", "CEF.Event", "LEEF.Event"))
+	// the syslog parsers succeed first so that they would have the highest priority if they were not fallbacks
+	for _, log := range []string{syslogLog, cefLog, syslogLog, leefLog, syslogLog, cefLog} {
+		result := classifier.Classify(log)
+		require.NotNil(t, result.LogType, log)
+		switch log {
+		case cefLog:
+			require.Equal(t, "CEF.Event", *result.LogType)
+		case leefLog:
+			require.Equal(t, "LEEF.Event", *result.LogType)
+		default:
+			require.Contains(t, *result.LogType, "Syslog.")
+		}
+	}
+}
+
 func TestClassifyNoMatch(t *testing.T) {
 	logLine := "log"
 	failingParser := testutil.ParserConfig{
@@ -19,6 +19,8 @@ package classification
  */
 
 import (
+	"container/heap"
+
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
@@ -34,15 +36,17 @@ func NewParserPriorityQueue(parsers map[string]parsers.Interface) *ParserPriorit
 }
 
 // initialize adds all registered parsers to the priority queue
-// All parsers have the same priority
-func (q *ParserPriorityQueue) initialize(parsers map[string]parsers.Interface) {
-	for logType, parser := range parsers {
+// All parsers have the same priority, fallback parsers are always after all other parsers
+func (q *ParserPriorityQueue) initialize(parserMap map[string]parsers.Interface) {
+	for logType, parser := range parserMap {
 		q.items = append(q.items, &ParserQueueItem{
-			logType: logType,
-			parser:  parser,
-			penalty: 1,
+			logType:  logType,
+			parser:   parser,
+			penalty:  1,
+			fallback: parsers.IsFallback(parser),
 		})
 	}
+	heap.Init(q)
 }
 
 // ParserQueueItem contains all the information needed to initialize a schema.
@@ -51,6 +55,8 @@ type ParserQueueItem struct {
 	parser  parsers.Interface
 	// The smaller the number the higher the priority of the parser in the queue
 	penalty int
+	// Fallback parsers are tried after all other parsers regardless of their penalty
+	fallback bool
 }
 
 // Len returns the length of the priority queue
@@ -60,6 +66,9 @@ func (q *ParserPriorityQueue) Len() int {
 
 // Less compares two items of the priority queue
 func (q *ParserPriorityQueue) Less(i, j int) bool {
+	if q.items[i].fallback != q.items[j].fallback {
+		return !q.items[i].fallback
+	}
 	return q.items[i].penalty < q.items[j].penalty
 }
 
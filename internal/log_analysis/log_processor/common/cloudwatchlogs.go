@@ -0,0 +1,89 @@
+package common
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Message types of CloudWatch Logs subscription data
+const (
+	CloudWatchLogsDataMessage    = "DATA_MESSAGE"
+	CloudWatchLogsControlMessage = "CONTROL_MESSAGE"
+)
+
+// CloudWatchLogsData is the envelope of the log events delivered by CloudWatch Logs subscriptions (ie through Firehose).
+// See https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/SubscriptionFilters.html
+type CloudWatchLogsData struct {
+	MessageType         string                   `json:"messageType"`
+	Owner               string                   `json:"owner"`
+	LogGroup            string                   `json:"logGroup"`
+	LogStream           string                   `json:"logStream"`
+	SubscriptionFilters []string                 `json:"subscriptionFilters"`
+	LogEvents           []CloudWatchLogsLogEvent `json:"logEvents"`
+}
+
+// CloudWatchLogsLogEvent is a log event in a CloudWatch Logs subscription envelope
+type CloudWatchLogsLogEvent struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"` // milliseconds since epoch
+	Message   string `json:"message"`
+}
+
+// Hints returns the data stream hints for the log events of the envelope
+func (data *CloudWatchLogsData) Hints() *CloudWatchLogsDataStreamHints {
+	return &CloudWatchLogsDataStreamHints{
+		LogGroup:  data.LogGroup,
+		LogStream: data.LogStream,
+	}
+}
+
+var cloudWatchLogsDataRegex = regexp.MustCompile(`^\s*\{\s*"messageType"\s*:`)
+
+// IsCloudWatchLogsData checks if an event looks like a CloudWatch Logs subscription envelope
+func IsCloudWatchLogsData(event string) bool {
+	return cloudWatchLogsDataRegex.MatchString(event)
+}
+
+// DecodeCloudWatchLogsData decodes the CloudWatch Logs subscription envelopes of an event.
+// Firehose concatenates the envelopes without a delimiter, so an event can contain more than one.
+func DecodeCloudWatchLogsData(event string) ([]*CloudWatchLogsData, error) {
+	var result []*CloudWatchLogsData
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(event), jsonReadBufferSize)
+	for iter.WhatIsNext() != jsoniter.InvalidValue {
+		data := &CloudWatchLogsData{}
+		iter.ReadVal(data)
+		if iter.Error != nil {
+			break
+		}
+		if data.MessageType == "" {
+			return nil, errors.New("missing CloudWatch Logs message type")
+		}
+		result = append(result, data)
+	}
+	if iter.Error != nil && iter.Error != io.EOF {
+		return nil, errors.Wrap(iter.Error, "failed to decode CloudWatch Logs data")
+	}
+	return result, nil
+}
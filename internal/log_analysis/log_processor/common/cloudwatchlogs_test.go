@@ -0,0 +1,54 @@
+package common
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCloudWatchLogsData(t *testing.T) {
+	// nolint:lll
+	event := `{"messageType":"DATA_MESSAGE","owner":"123456789012","logGroup":"/aws/lambda/test","logStream":"2020/04/01/[$LATEST]abcdef","subscriptionFilters":["panther"],"logEvents":[{"id":"35340248331052016476226279624592384131873443093604548608","timestamp":1585735200000,"message":"{\"foo\":\"bar\"}\n"}]}
+{"messageType":"CONTROL_MESSAGE","owner":"CloudwatchLogs","logGroup":"","logStream":"","subscriptionFilters":[],"logEvents":[{"id":"","timestamp":1585735200000,"message":"CWL CONTROL MESSAGE: Checking health of destination Firehose."}]}`
+	require.True(t, IsCloudWatchLogsData(event))
+	require.False(t, IsCloudWatchLogsData(`{"foo":"bar"}`))
+
+	envelopes, err := DecodeCloudWatchLogsData(event)
+	require.NoError(t, err)
+	require.Len(t, envelopes, 2)
+
+	data := envelopes[0]
+	require.Equal(t, CloudWatchLogsDataMessage, data.MessageType)
+	require.Equal(t, []string{"panther"}, data.SubscriptionFilters)
+	require.Len(t, data.LogEvents, 1)
+	require.Equal(t, "{\"foo\":\"bar\"}\n", data.LogEvents[0].Message)
+	expectHints := &CloudWatchLogsDataStreamHints{
+		LogGroup:  "/aws/lambda/test",
+		LogStream: "2020/04/01/[$LATEST]abcdef",
+	}
+	require.Equal(t, expectHints, data.Hints())
+	require.Equal(t, CloudWatchLogsControlMessage, envelopes[1].MessageType)
+
+	_, err = DecodeCloudWatchLogsData(`{"messageType":"DATA_MESSAGE","logEvents":[`)
+	require.Error(t, err)
+	_, err = DecodeCloudWatchLogsData(`{"logEvents":[]}`)
+	require.Error(t, err)
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
 	"github.com/aws/aws-sdk-go/service/sns"
@@ -44,6 +46,7 @@ var (
 	Session      *session.Session
 	LambdaClient lambdaiface.LambdaAPI
 	S3Uploader   s3manageriface.UploaderAPI
+	S3Client     s3iface.S3API
 	SqsClient    sqsiface.SQSAPI
 	SnsClient    snsiface.SNSAPI
 
@@ -61,6 +64,7 @@ func Setup() {
 	Session = session.Must(session.NewSession(aws.NewConfig().WithMaxRetries(MaxRetries)))
 	LambdaClient = lambda.New(Session)
 	S3Uploader = s3manager.NewUploader(Session)
+	S3Client = s3.New(Session)
 	SqsClient = sqs.New(Session)
 	SnsClient = sns.New(Session)
 
@@ -77,11 +81,18 @@ type DataStream struct {
 	// The log type if known
 	// If it is nil, it means the log type hasn't been identified yet
 	LogType *string
+	// The log types the stream may contain
+	// If it is empty, all available log types are considered
+	LogTypes []string
+	// The framing used to split the stream into events
+	// If it is nil, DefaultFraming is used
+	Framing Framing
 }
 
 // Used in a DataStream as meta data to describe the data
 type DataStreamHints struct {
-	S3 *S3DataStreamHints // if nil, no hint
+	S3             *S3DataStreamHints             // if nil, no hint
+	CloudWatchLogs *CloudWatchLogsDataStreamHints // if nil, no hint
 }
 
 // Used in a DataStreamHints as meta data to describe the S3 object backing the stream
@@ -90,3 +101,9 @@ type S3DataStreamHints struct {
 	Key         string
 	ContentType string
 }
+
+// Used in a DataStreamHints as meta data to describe the CloudWatch Logs event being processed
+type CloudWatchLogsDataStreamHints struct {
+	LogGroup  string
+	LogStream string
+}
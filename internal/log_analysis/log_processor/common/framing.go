@@ -0,0 +1,362 @@
+package common
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Names of the available event framings.
+// These are used to select the framing of a source integration.
+const (
+	FramingNewline      = "newline"
+	FramingJSON         = "json"
+	FramingRegex        = "regex"
+	FramingOctetCounted = "octet-counted"
+)
+
+const (
+	// MaxOctetCountedEventSize is the maximum size in bytes of a single octet-counted event
+	MaxOctetCountedEventSize = 1024 * 1024
+	// size of the buffer used by the JSON iterator when reading from the stream
+	jsonReadBufferSize = 64 * 1024
+	// how many bytes to peek at the start of the stream to detect a records document
+	recordsPeekSize = 64
+	recordsField    = "Records"
+	// Azure diagnostic logs use a lowercase field name
+	recordsFieldLower = "records"
+	// AWS Config history files keep their records in a differently named field after a `fileVersion` field
+	recordsFieldConfigItems = "configurationItems"
+)
+
+// Framing splits a data stream into events.
+// Events can span multiple lines, each event is passed to the classifier as a single string.
+type Framing interface {
+	NewEventReader(r io.Reader) EventReader
+}
+
+// EventReader reads the events of a data stream one at a time
+type EventReader interface {
+	// ReadEvent returns the next event in the stream or io.EOF if there are no more events
+	ReadEvent() (string, error)
+}
+
+// NewFraming returns the framing with the given name.
+// The pattern is only used by regex framing to match the first line of each event.
+func NewFraming(name, pattern string) (Framing, error) {
+	switch name {
+	case FramingNewline:
+		return NewlineFraming{}, nil
+	case FramingJSON:
+		return JSONFraming{}, nil
+	case FramingRegex:
+		startOfEvent, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid start of event pattern %q", pattern)
+		}
+		return &RegexFraming{StartOfEvent: startOfEvent}, nil
+	case FramingOctetCounted:
+		return OctetCountedFraming{}, nil
+	default:
+		return nil, errors.Errorf("unknown event framing %q", name)
+	}
+}
+
+// DefaultFraming splits events on EventDelimiter unless the stream is a `{"Records":[...]}` JSON document
+// or a stream of CloudWatch Logs subscription envelopes.
+//
+// Some sources (ie CloudTrail) deliver all events of a file as a single JSON document of that form.
+// These files can be very large, so rather than reading the whole document into memory we decode the array
+// incrementally and read each record on its own. Every record is wrapped in a single element `{"Records":[...]}`
+// document so the same parsers handle both the streamed records and documents delivered on a single line.
+// Azure uses the same layout with a lowercase `records` field and AWS Config history files use
+// `{"fileVersion":"1.0","configurationItems":[...]}`, the field name is kept when wrapping their records.
+//
+// CloudWatch Logs subscription envelopes delivered through Firehose are concatenated without a delimiter,
+// so streams starting with one are read as a stream of JSON values, one envelope per event.
+type DefaultFraming struct{}
+
+var recordsDocumentRegex = regexp.MustCompile(`^\s*\{\s*("fileVersion"\s*:\s*"[^"]*"\s*,\s*)?"(` +
+	recordsField + `|` + recordsFieldLower + `|` + recordsFieldConfigItems + `)"\s*:\s*\[`)
+
+func (DefaultFraming) NewEventReader(r io.Reader) EventReader {
+	stream := bufio.NewReader(r)
+	// errors are ignored here, they will surface again when reading the stream
+	head, _ := stream.Peek(recordsPeekSize)
+	if recordsDocumentRegex.Match(head) {
+		return &recordsReader{
+			iter: jsoniter.Parse(jsoniter.ConfigDefault, stream, jsonReadBufferSize),
+		}
+	}
+	if cloudWatchLogsDataRegex.Match(head) {
+		return newJSONValueReader(stream)
+	}
+	return &lineReader{stream: stream}
+}
+
+// NewlineFraming splits events on EventDelimiter
+type NewlineFraming struct{}
+
+func (NewlineFraming) NewEventReader(r io.Reader) EventReader {
+	return &lineReader{stream: bufio.NewReader(r)}
+}
+
+type lineReader struct {
+	stream *bufio.Reader
+	done   bool
+}
+
+func (r *lineReader) ReadEvent() (string, error) {
+	if r.done {
+		return "", io.EOF
+	}
+	line, err := r.stream.ReadString(EventDelimiter)
+	if err != nil {
+		if err == io.EOF { // the last line, it is returned as an event and EOF will follow
+			r.done = true
+			return line, nil
+		}
+		return "", errors.Wrap(err, "failed to ReadString()")
+	}
+	return line, nil
+}
+
+type recordsReader struct {
+	iter     *jsoniter.Iterator
+	inRecord bool
+	field    string
+}
+
+func (r *recordsReader) ReadEvent() (string, error) {
+	iter := r.iter
+	for {
+		if !r.inRecord {
+			field := iter.ReadObject()
+			if field == "" {
+				if iter.Error != nil {
+					break
+				}
+				// the end of a document, keep reading if more documents are concatenated after it
+				if iter.WhatIsNext() != jsoniter.ObjectValue {
+					break
+				}
+				continue
+			}
+			if !isRecordsField(field) {
+				iter.Skip()
+				continue
+			}
+			r.inRecord = true
+			r.field = field
+		}
+		if !iter.ReadArray() {
+			r.inRecord = false
+			continue
+		}
+		record := iter.SkipAndReturnBytes()
+		if iter.Error != nil {
+			break
+		}
+		// the captured bytes include any whitespace preceding the record
+		return `{"` + r.field + `":[` + string(bytes.TrimSpace(record)) + `]}`, nil
+	}
+	if iter.Error != nil && iter.Error != io.EOF {
+		return "", errors.Wrap(iter.Error, "failed to read JSON records")
+	}
+	return "", io.EOF
+}
+
+func isRecordsField(field string) bool {
+	switch field {
+	case recordsField, recordsFieldLower, recordsFieldConfigItems:
+		return true
+	default:
+		return false
+	}
+}
+
+// JSONFraming reads a stream of JSON values.
+// Values can span multiple lines (ie pretty-printed JSON) and need not be separated by newlines.
+// A malformed value does not abort the stream, the rest of its line is returned as an event so the classifier
+// reports it as a failure and reading resumes on the next line.
+type JSONFraming struct{}
+
+func (JSONFraming) NewEventReader(r io.Reader) EventReader {
+	return newJSONValueReader(r)
+}
+
+func newJSONValueReader(r io.Reader) *jsonValueReader {
+	stream := bufio.NewReader(r)
+	return &jsonValueReader{
+		stream:  stream,
+		decoder: json.NewDecoder(stream),
+	}
+}
+
+type jsonValueReader struct {
+	stream  *bufio.Reader
+	decoder *json.Decoder
+}
+
+func (r *jsonValueReader) ReadEvent() (string, error) {
+	var value json.RawMessage
+	err := r.decoder.Decode(&value)
+	switch err.(type) {
+	case nil:
+		return string(bytes.TrimSpace(value)), nil
+	case *json.SyntaxError:
+		return r.skipLine()
+	}
+	switch err {
+	case io.EOF:
+		return "", io.EOF
+	case io.ErrUnexpectedEOF:
+		return r.skipLine()
+	default:
+		return "", errors.Wrap(err, "failed to read JSON value")
+	}
+}
+
+// skipLine returns the rest of the line of a malformed value and resumes decoding on the next line
+func (r *jsonValueReader) skipLine() (string, error) {
+	// the decoder stops at the start of the malformed value, the bytes it has buffered are read again
+	r.stream = bufio.NewReader(io.MultiReader(r.decoder.Buffered(), r.stream))
+	for {
+		line, err := r.stream.ReadString(EventDelimiter)
+		if err != nil && err != io.EOF {
+			return "", errors.Wrap(err, "failed to ReadString()")
+		}
+		// the buffered bytes can start with the whitespace preceding the value
+		if line = strings.TrimSpace(line); line != "" || err == io.EOF {
+			r.decoder = json.NewDecoder(r.stream)
+			return line, nil
+		}
+	}
+}
+
+// RegexFraming reads events that start with a line matching StartOfEvent.
+// Any lines that do not match are appended to the current event (ie stack traces).
+type RegexFraming struct {
+	StartOfEvent *regexp.Regexp
+}
+
+func (f *RegexFraming) NewEventReader(r io.Reader) EventReader {
+	return &regexReader{
+		lines:        &lineReader{stream: bufio.NewReader(r)},
+		startOfEvent: f.StartOfEvent,
+	}
+}
+
+type regexReader struct {
+	lines        *lineReader
+	startOfEvent *regexp.Regexp
+	next         string // the first line of the next event
+}
+
+func (r *regexReader) ReadEvent() (string, error) {
+	var event strings.Builder
+	event.WriteString(r.next)
+	r.next = ""
+	for {
+		line, err := r.lines.ReadEvent()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if event.Len() > 0 && r.startOfEvent.MatchString(line) {
+			r.next = line
+			break
+		}
+		event.WriteString(line)
+	}
+	if event.Len() == 0 {
+		return "", io.EOF
+	}
+	return strings.TrimRight(event.String(), "\r\n"), nil
+}
+
+// OctetCountedFraming reads events prefixed with their length in bytes and a space (ie `11 hello world`)
+// See https://tools.ietf.org/html/rfc6587#section-3.4.1
+type OctetCountedFraming struct{}
+
+func (OctetCountedFraming) NewEventReader(r io.Reader) EventReader {
+	return &octetCountedReader{stream: bufio.NewReader(r)}
+}
+
+type octetCountedReader struct {
+	stream *bufio.Reader
+}
+
+func (r *octetCountedReader) ReadEvent() (string, error) {
+	// skip whitespace between events
+	for {
+		c, err := r.stream.ReadByte()
+		if err == io.EOF {
+			return "", io.EOF
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read octet count")
+		}
+		if !isSpace(c) {
+			if err := r.stream.UnreadByte(); err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+	count, err := r.stream.ReadString(' ')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", errors.Wrap(err, "failed to read octet count")
+	}
+	size, err := strconv.Atoi(strings.TrimSuffix(count, " "))
+	if err != nil || size < 0 {
+		return "", errors.Errorf("invalid octet count %q", count)
+	}
+	if size > MaxOctetCountedEventSize {
+		return "", errors.Errorf("octet count %d exceeds maximum event size", size)
+	}
+	event := make([]byte, size)
+	if _, err := io.ReadFull(r.stream, event); err != nil {
+		return "", errors.Wrapf(err, "failed to read %d octets", size)
+	}
+	return string(event), nil
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n':
+		return true
+	default:
+		return false
+	}
+}
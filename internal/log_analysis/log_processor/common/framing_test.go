@@ -0,0 +1,187 @@
+package common
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, framing Framing, input string) []string {
+	events := []string{}
+	r := framing.NewEventReader(strings.NewReader(input))
+	for {
+		event, err := r.ReadEvent()
+		if err == io.EOF {
+			return events
+		}
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+}
+
+func TestNewlineFraming(t *testing.T) {
+	events := readEvents(t, NewlineFraming{}, "one\ntwo\n{\"Records\":[]}")
+	require.Equal(t, []string{"one\n", "two\n", `{"Records":[]}`}, events)
+}
+
+func TestDefaultFraming(t *testing.T) {
+	events := readEvents(t, DefaultFraming{}, "one\ntwo\n")
+	require.Equal(t, []string{"one\n", "two\n", ""}, events)
+
+	input := ` {"Records": [
+{"eventName":"one","nested":{"Records":[1,2]}},
+{"eventName":"two"}
+],
+"Other": {"eventName":"skipped"}}
+`
+	events = readEvents(t, DefaultFraming{}, input)
+	expect := []string{
+		`{"Records":[{"eventName":"one","nested":{"Records":[1,2]}}]}`,
+		`{"Records":[{"eventName":"two"}]}`,
+	}
+	require.Equal(t, expect, events)
+
+	// concatenated documents
+	input = `{"Records":[{"eventName":"one"}]}
+{"Records":[{"eventName":"two"},{"eventName":"three"}]}{"Records":[]} {"Records":[{"eventName":"four"}]}
+`
+	events = readEvents(t, DefaultFraming{}, input)
+	expect = []string{
+		`{"Records":[{"eventName":"one"}]}`,
+		`{"Records":[{"eventName":"two"}]}`,
+		`{"Records":[{"eventName":"three"}]}`,
+		`{"Records":[{"eventName":"four"}]}`,
+	}
+	require.Equal(t, expect, events)
+
+	// Azure diagnostic logs
+	input = `{"records": [{"operationName":"one"}, {"operationName":"two"}]}`
+	events = readEvents(t, DefaultFraming{}, input)
+	expect = []string{
+		`{"records":[{"operationName":"one"}]}`,
+		`{"records":[{"operationName":"two"}]}`,
+	}
+	require.Equal(t, expect, events)
+
+	// AWS Config history files
+	input = `{
+  "fileVersion": "1.0",
+  "configurationItems": [
+    {"resourceId": "one"},
+    {"resourceId": "two"}
+  ]
+}`
+	events = readEvents(t, DefaultFraming{}, input)
+	expect = []string{
+		`{"configurationItems":[{"resourceId": "one"}]}`,
+		`{"configurationItems":[{"resourceId": "two"}]}`,
+	}
+	require.Equal(t, expect, events)
+
+	// CloudWatch Logs envelopes concatenated by Firehose
+	input = `{"messageType":"CONTROL_MESSAGE","logEvents":[]}{"messageType":"DATA_MESSAGE","logEvents":[]}`
+	events = readEvents(t, DefaultFraming{}, input)
+	expect = []string{
+		`{"messageType":"CONTROL_MESSAGE","logEvents":[]}`,
+		`{"messageType":"DATA_MESSAGE","logEvents":[]}`,
+	}
+	require.Equal(t, expect, events)
+}
+
+func TestJSONFraming(t *testing.T) {
+	input := `{
+  "foo": "bar",
+  "baz": [1, 2]
+}{"foo":"baz"}
+[1]
+42
+`
+	events := readEvents(t, JSONFraming{}, input)
+	expect := []string{
+		`{
+  "foo": "bar",
+  "baz": [1, 2]
+}`,
+		`{"foo":"baz"}`,
+		`[1]`,
+		`42`,
+	}
+	require.Equal(t, expect, events)
+
+	// malformed values are returned as is and do not abort the stream
+	input = `{"foo":"bar"}
+{"foo":bar}
+{"foo":"baz"} {"foo":`
+	events = readEvents(t, JSONFraming{}, input)
+	expect = []string{
+		`{"foo":"bar"}`,
+		`{"foo":bar}`,
+		`{"foo":"baz"}`,
+		`{"foo":`,
+	}
+	require.Equal(t, expect, events)
+}
+
+func TestRegexFraming(t *testing.T) {
+	framing, err := NewFraming(FramingRegex, `^\d{4}-\d{2}-\d{2}`)
+	require.NoError(t, err)
+	input := `2020-01-01 ERROR failed
+java.lang.NullPointerException
+	at Foo.bar(Foo.java:42)
+2020-01-01 INFO ok
+2020-01-02 ERROR failed again
+	at Foo.baz(Foo.java:7)
+`
+	events := readEvents(t, framing, input)
+	expect := []string{
+		"2020-01-01 ERROR failed\njava.lang.NullPointerException\n\tat Foo.bar(Foo.java:42)",
+		"2020-01-01 INFO ok",
+		"2020-01-02 ERROR failed again\n\tat Foo.baz(Foo.java:7)",
+	}
+	require.Equal(t, expect, events)
+
+	_, err = NewFraming(FramingRegex, `^(`)
+	require.Error(t, err)
+}
+
+func TestOctetCountedFraming(t *testing.T) {
+	events := readEvents(t, OctetCountedFraming{}, "11 hello world5 a\nb c\n3 foo\n")
+	require.Equal(t, []string{"hello world", "a\nb c", "foo"}, events)
+
+	r := OctetCountedFraming{}.NewEventReader(strings.NewReader("11 hello"))
+	_, err := r.ReadEvent()
+	require.Error(t, err)
+	r = OctetCountedFraming{}.NewEventReader(strings.NewReader("foo bar"))
+	_, err = r.ReadEvent()
+	require.Error(t, err)
+}
+
+func TestNewFraming(t *testing.T) {
+	for _, name := range []string{FramingNewline, FramingJSON, FramingOctetCounted} {
+		framing, err := NewFraming(name, "")
+		require.NoError(t, err)
+		require.NotNil(t, framing)
+	}
+	_, err := NewFraming("foo", "")
+	require.Error(t, err)
+}
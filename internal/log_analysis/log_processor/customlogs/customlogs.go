@@ -0,0 +1,283 @@
+package customlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/grok"
+)
+
+const (
+	// LogTypePrefix is required for all custom log types so they never clash with the built-in ones
+	LogTypePrefix = "Custom."
+
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatGrok = "grok"
+
+	TypeString    = "string"
+	TypeInt       = "int"
+	TypeBigInt    = "bigint"
+	TypeFloat     = "float"
+	TypeBoolean   = "boolean"
+	TypeTimestamp = "timestamp"
+	TypeJSON      = "json"
+
+	IndicatorIP     = "ip"
+	IndicatorDomain = "domain"
+	IndicatorMD5    = "md5"
+	IndicatorSHA1   = "sha1"
+	IndicatorSHA256 = "sha256"
+
+	TimeFormatRFC3339   = "rfc3339"
+	TimeFormatUnix      = "unix"
+	TimeFormatUnixMs    = "unix_ms"
+	defaultTimeFormat   = TimeFormatRFC3339
+	defaultCSVDelimiter = ","
+)
+
+var (
+	logTypeRegex   = regexp.MustCompile(`^Custom(\.[A-Za-z][A-Za-z0-9_]*)+$`)
+	fieldNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Schema is a user-defined log type.
+// Schemas are written in YAML (or JSON) and stored by the source API.
+//
+// Example:
+//
+//	name: Custom.MyApp
+//	description: Access logs of MyApp
+//	format: json
+//	eventTimeField: time
+//	fields:
+//	  - name: time
+//	    type: timestamp
+//	    timeFormat: rfc3339
+//	    required: true
+//	    description: The time of the request
+//	  - name: client_ip
+//	    type: string
+//	    indicators: [ip]
+//	    description: The IP address of the client
+type Schema struct {
+	Name           string `json:"name" yaml:"name"`
+	Description    string `json:"description" yaml:"description"`
+	ReferenceURL   string `json:"referenceURL,omitempty" yaml:"referenceURL,omitempty"`
+	Format         string `json:"format" yaml:"format"`
+	Delimiter      string `json:"delimiter,omitempty" yaml:"delimiter,omitempty"` // CSV only, defaults to ','
+	EventTimeField string `json:"eventTimeField,omitempty" yaml:"eventTimeField,omitempty"`
+	// Pattern is the grok pattern of text logs, each capture is a field
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// Patterns are additional grok definitions that can be referenced by Pattern
+	Patterns map[string]string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	// Fields are the columns of the log type, for CSV logs they must be listed in column order
+	Fields []Field `json:"fields" yaml:"fields"`
+}
+
+// Field is a column of a custom log type
+type Field struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"`
+	Description string `json:"description" yaml:"description"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	// TimeFormat is the format of timestamp fields, 'rfc3339', 'unix', 'unix_ms' or a Go time layout
+	TimeFormat string `json:"timeFormat,omitempty" yaml:"timeFormat,omitempty"`
+	// Indicators are the panther fields (p_any_*) the values of this field are added to
+	Indicators []string `json:"indicators,omitempty" yaml:"indicators,omitempty"`
+}
+
+// ParseSchema reads and validates a schema from a YAML or JSON document
+func ParseSchema(spec string) (*Schema, error) {
+	schema := &Schema{}
+	// JSON documents are valid YAML
+	if err := yaml.UnmarshalStrict([]byte(spec), schema); err != nil {
+		return nil, errors.Wrap(err, "invalid custom log schema")
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Validate checks that a schema can be used to build a log type
+func (schema *Schema) Validate() error {
+	if !logTypeRegex.MatchString(schema.Name) {
+		return errors.Errorf("invalid custom log type name %q, names must be of the form %q", schema.Name, LogTypePrefix+"Name")
+	}
+	if schema.Description == "" {
+		return errors.Errorf("missing description for log type %q", schema.Name)
+	}
+	switch schema.Format {
+	case FormatJSON:
+	case FormatCSV:
+		if schema.Delimiter != "" && utf8.RuneCountInString(schema.Delimiter) != 1 {
+			return errors.Errorf("invalid CSV delimiter %q", schema.Delimiter)
+		}
+	case FormatGrok:
+		if schema.Pattern == "" {
+			return errors.Errorf("missing pattern for log type %q", schema.Name)
+		}
+	default:
+		return errors.Errorf("invalid log format %q, must be one of %q, %q or %q", schema.Format, FormatJSON, FormatCSV, FormatGrok)
+	}
+	if schema.Format != FormatCSV && schema.Delimiter != "" {
+		return errors.Errorf("delimiter is only valid for %q logs", FormatCSV)
+	}
+	if schema.Format != FormatGrok && (schema.Pattern != "" || schema.Patterns != nil) {
+		return errors.Errorf("patterns are only valid for %q logs", FormatGrok)
+	}
+	if len(schema.Fields) == 0 {
+		return errors.Errorf("no fields defined for log type %q", schema.Name)
+	}
+	names := make(map[string]bool, len(schema.Fields))
+	for i := range schema.Fields {
+		field := &schema.Fields[i]
+		if err := field.Validate(); err != nil {
+			return err
+		}
+		// Glue columns are case insensitive
+		name := strings.ToLower(field.Name)
+		if names[name] {
+			return errors.Errorf("duplicate field %q", field.Name)
+		}
+		names[name] = true
+	}
+	if schema.EventTimeField != "" {
+		field := schema.field(schema.EventTimeField)
+		if field == nil {
+			return errors.Errorf("event time field %q is not defined", schema.EventTimeField)
+		}
+		if field.Type != TypeTimestamp {
+			return errors.Errorf("event time field %q is not a %s", schema.EventTimeField, TypeTimestamp)
+		}
+	}
+	if schema.Format == FormatGrok {
+		if _, err := schema.compilePattern(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compilePattern compiles the grok pattern of a schema checking that it captures exactly the schema fields
+func (schema *Schema) compilePattern() (*grok.Pattern, error) {
+	pattern, err := grok.Compile(schema.Pattern, schema.Patterns)
+	if err != nil {
+		return nil, err
+	}
+	captured := make(map[string]bool, len(pattern.Fields()))
+	for _, name := range pattern.Fields() {
+		if schema.field(name) == nil {
+			return nil, errors.Errorf("pattern capture %q is not a defined field", name)
+		}
+		captured[name] = true
+	}
+	for i := range schema.Fields {
+		if name := schema.Fields[i].Name; !captured[name] {
+			return nil, errors.Errorf("field %q is not captured by the pattern", name)
+		}
+	}
+	return pattern, nil
+}
+
+func (schema *Schema) field(name string) *Field {
+	if i := schema.fieldIndex(name); i != -1 {
+		return &schema.Fields[i]
+	}
+	return nil
+}
+
+func (schema *Schema) fieldIndex(name string) int {
+	for i := range schema.Fields {
+		if schema.Fields[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validate checks that a field can be used as a column
+func (field *Field) Validate() error {
+	if !fieldNameRegex.MatchString(field.Name) {
+		return errors.Errorf("invalid field name %q", field.Name)
+	}
+	if strings.HasPrefix(field.Name, parsers.PantherFieldPrefix) {
+		return errors.Errorf("invalid field name %q, the %q prefix is reserved", field.Name, parsers.PantherFieldPrefix)
+	}
+	if field.Description == "" {
+		return errors.Errorf("missing description for field %q", field.Name)
+	}
+	switch field.Type {
+	case TypeString, TypeInt, TypeBigInt, TypeFloat, TypeBoolean, TypeJSON:
+		if field.TimeFormat != "" {
+			return errors.Errorf("time format is only valid for %s fields", TypeTimestamp)
+		}
+	case TypeTimestamp:
+	default:
+		return errors.Errorf("invalid type %q for field %q", field.Type, field.Name)
+	}
+	for _, indicator := range field.Indicators {
+		switch indicator {
+		case IndicatorIP, IndicatorDomain, IndicatorMD5, IndicatorSHA1, IndicatorSHA256:
+		default:
+			return errors.Errorf("invalid indicator %q for field %q", indicator, field.Name)
+		}
+		if field.Type != TypeString {
+			return errors.Errorf("indicators are only valid for %s fields", TypeString)
+		}
+	}
+	return nil
+}
+
+// Config returns the log type config for a schema
+func (schema *Schema) Config() (*logtypes.Config, error) {
+	eventType, err := newEventType(schema)
+	if err != nil {
+		return nil, err
+	}
+	referenceURL := schema.ReferenceURL
+	if referenceURL == "" {
+		referenceURL = "-"
+	}
+	return &logtypes.Config{
+		Name:         schema.Name,
+		Description:  schema.Description,
+		ReferenceURL: referenceURL,
+		Schema:       eventType.Schema(),
+		NewParser:    parsers.AdapterFactory(&Parser{eventType: eventType}),
+	}, nil
+}
+
+// Register adds the log type of a schema to a registry, replacing any previous version of it
+func Register(r *logtypes.Registry, schema *Schema) (logtypes.Entry, error) {
+	config, err := schema.Config()
+	if err != nil {
+		return nil, err
+	}
+	return r.Replace(*config)
+}
@@ -0,0 +1,161 @@
+package customlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+const testSchemaYAML = `
+name: Custom.Test
+description: Test log type
+format: json
+eventTimeField: time
+fields:
+  - name: time
+    type: timestamp
+    required: true
+    description: The time of the event
+  - name: client_ip
+    type: string
+    indicators: [ip]
+    description: The IP address of the client
+  - name: bytes
+    type: bigint
+    description: The number of bytes sent
+`
+
+func TestParseSchema(t *testing.T) {
+	schema, err := ParseSchema(testSchemaYAML)
+	require.NoError(t, err)
+	require.Equal(t, "Custom.Test", schema.Name)
+	require.Equal(t, FormatJSON, schema.Format)
+	require.Equal(t, "time", schema.EventTimeField)
+	require.Len(t, schema.Fields, 3)
+	require.Equal(t, []string{IndicatorIP}, schema.Fields[1].Indicators)
+
+	// JSON documents are accepted too
+	schemaJSON, err := ParseSchema(`{
+		"name": "Custom.Test",
+		"description": "Test log type",
+		"format": "json",
+		"eventTimeField": "time",
+		"fields": [
+			{"name": "time", "type": "timestamp", "required": true, "description": "The time of the event"},
+			{"name": "client_ip", "type": "string", "indicators": ["ip"], "description": "The IP address of the client"},
+			{"name": "bytes", "type": "bigint", "description": "The number of bytes sent"}
+		]
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, schema, schemaJSON)
+
+	_, err = ParseSchema(testSchemaYAML + "unknown: true\n")
+	require.Error(t, err)
+}
+
+func TestSchemaValidate(t *testing.T) {
+	valid := func() *Schema {
+		schema, err := ParseSchema(testSchemaYAML)
+		require.NoError(t, err)
+		return schema
+	}
+	for name, update := range map[string]func(schema *Schema){
+		"built-in name":     func(schema *Schema) { schema.Name = "AWS.Test" },
+		"no description":    func(schema *Schema) { schema.Description = "" },
+		"invalid format":    func(schema *Schema) { schema.Format = "xml" },
+		"json delimiter":    func(schema *Schema) { schema.Delimiter = ";" },
+		"no fields":         func(schema *Schema) { schema.Fields = nil },
+		"duplicate field":   func(schema *Schema) { schema.Fields[1].Name = "TIME" },
+		"panther field":     func(schema *Schema) { schema.Fields[1].Name = "p_log_type" },
+		"invalid field":     func(schema *Schema) { schema.Fields[1].Name = "client.ip" },
+		"invalid type":      func(schema *Schema) { schema.Fields[1].Type = "ipaddress" },
+		"no field desc":     func(schema *Schema) { schema.Fields[1].Description = "" },
+		"invalid indicator": func(schema *Schema) { schema.Fields[1].Indicators = []string{"url"} },
+		"int indicator":     func(schema *Schema) { schema.Fields[2].Indicators = []string{IndicatorIP} },
+		"time format":       func(schema *Schema) { schema.Fields[2].TimeFormat = TimeFormatUnix },
+		"no event time":     func(schema *Schema) { schema.EventTimeField = "ts" },
+		"event time type":   func(schema *Schema) { schema.EventTimeField = "bytes" },
+		"json pattern":      func(schema *Schema) { schema.Pattern = "%{GREEDYDATA:time}" },
+	} {
+		schema := valid()
+		update(schema)
+		require.Error(t, schema.Validate(), name)
+	}
+
+	schema := valid()
+	schema.Format = FormatCSV
+	schema.Delimiter = "\t"
+	require.NoError(t, schema.Validate())
+	schema.Delimiter = ";;"
+	require.Error(t, schema.Validate())
+
+	schema = valid()
+	schema.Format = FormatGrok
+	require.Error(t, schema.Validate(), "no pattern")
+	schema.Pattern = `%{TIMESTAMP_ISO8601:time} %{IP:client_ip} %{INT:bytes}`
+	require.NoError(t, schema.Validate())
+	schema.Pattern = `%{TIMESTAMP_ISO8601:time} %{IP:client_ip} %{INT:bytes} %{WORD:method}`
+	require.Error(t, schema.Validate(), "undefined capture")
+	schema.Pattern = `%{TIMESTAMP_ISO8601:time} %{IP:client_ip}`
+	require.Error(t, schema.Validate(), "missing capture")
+	schema.Pattern = `%{TIMESTAMP_ISO8601:time} %{ADDRESS:client_ip} %{INT:bytes}`
+	require.Error(t, schema.Validate(), "undefined definition")
+	schema.Patterns = map[string]string{"ADDRESS": `%{IP}`}
+	require.NoError(t, schema.Validate())
+}
+
+func TestRegister(t *testing.T) {
+	schema, err := ParseSchema(testSchemaYAML)
+	require.NoError(t, err)
+	r := &logtypes.Registry{}
+	entry, err := Register(r, schema)
+	require.NoError(t, err)
+	require.Equal(t, "Custom.Test", entry.Describe().Name)
+	require.Equal(t, "-", entry.Describe().ReferenceURL)
+	require.Equal(t, "custom_test", entry.GlueTableMeta().TableName())
+
+	columns, _ := awsglue.InferJSONColumns(entry.GlueTableMeta().EventStruct(), awsglue.GlueMappings...)
+	require.Equal(t, "time", columns[0].Name)
+	require.Equal(t, "timestamp", columns[0].Type)
+	require.True(t, columns[0].Required)
+	require.Equal(t, "client_ip", columns[1].Name)
+	require.Equal(t, "string", columns[1].Type)
+	require.Equal(t, "bytes", columns[2].Name)
+	require.Equal(t, "bigint", columns[2].Type)
+	require.Equal(t, "p_log_type", columns[3].Name)
+
+	parser, err := entry.NewParser(nil)
+	require.NoError(t, err)
+	results, err := parser.ParseLog(`{"time":"2020-06-01T12:00:00Z","client_ip":"10.0.0.1"}`)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "Custom.Test", results[0].LogType)
+
+	// registering a new version of the schema replaces the entry
+	schema.Description = "Updated"
+	entry, err = Register(r, schema)
+	require.NoError(t, err)
+	require.Equal(t, "Updated", r.MustGet("Custom.Test").Describe().Description)
+	require.Equal(t, entry, r.MustGet("Custom.Test"))
+}
@@ -0,0 +1,64 @@
+package customlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const sourceAPIFunctionName = "panther-source-api"
+
+// Load fetches the custom log types from the source API and registers them in a registry.
+// It returns the names of the registered log types.
+func Load(lambdaClient lambdaiface.LambdaAPI, r *logtypes.Registry) ([]string, error) {
+	input := &models.LambdaInput{
+		ListCustomLogs: &models.ListCustomLogsInput{},
+	}
+	var output []*models.CustomLog
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output); err != nil {
+		return nil, errors.Wrap(err, "failed to list custom log types")
+	}
+	return RegisterAll(r, output), nil
+}
+
+// RegisterAll registers custom log types in a registry, replacing any previous version of them.
+// Invalid log types are skipped so they do not prevent using the rest, it returns the names of the registered log types.
+func RegisterAll(r *logtypes.Registry, customLogs []*models.CustomLog) []string {
+	logTypes := make([]string, 0, len(customLogs))
+	for _, customLog := range customLogs {
+		schema, err := ParseSchema(aws.StringValue(customLog.Spec))
+		if err == nil {
+			_, err = Register(r, schema)
+		}
+		if err != nil {
+			zap.L().Warn("skipping invalid custom log type",
+				zap.String("logType", aws.StringValue(customLog.LogType)), zap.Error(err))
+			continue
+		}
+		logTypes = append(logTypes, schema.Name)
+	}
+	return logTypes
+}
@@ -0,0 +1,86 @@
+package customlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestLoad(t *testing.T) {
+	customLogs := []*models.CustomLog{
+		{
+			LogType: aws.String("Custom.Test"),
+			Spec:    aws.String(testSchemaYAML),
+		},
+	}
+	payload, err := jsoniter.Marshal(customLogs)
+	require.NoError(t, err)
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil).Once()
+
+	r := &logtypes.Registry{}
+	logTypes, err := Load(lambdaMock, r)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Custom.Test"}, logTypes)
+	require.NotNil(t, r.Get("Custom.Test"))
+	lambdaMock.AssertExpectations(t)
+
+	var input models.LambdaInput
+	invokeInput := lambdaMock.Calls[0].Arguments.Get(0).(*lambda.InvokeInput)
+	require.NoError(t, jsoniter.Unmarshal(invokeInput.Payload, &input))
+	require.NotNil(t, input.ListCustomLogs)
+}
+
+func TestLoadError(t *testing.T) {
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, errors.New("failed")).Once()
+	_, err := Load(lambdaMock, &logtypes.Registry{})
+	require.Error(t, err)
+	lambdaMock.AssertExpectations(t)
+}
+
+func TestRegisterAllInvalidSpec(t *testing.T) {
+	customLogs := []*models.CustomLog{
+		{
+			LogType: aws.String("Custom.Invalid"),
+			Spec:    aws.String("name: Custom.Invalid"),
+		},
+		{
+			LogType: aws.String("Custom.Test"),
+			Spec:    aws.String(testSchemaYAML),
+		},
+	}
+	// invalid log types are skipped
+	r := &logtypes.Registry{}
+	logTypes := RegisterAll(r, customLogs)
+	require.Equal(t, []string{"Custom.Test"}, logTypes)
+	require.Nil(t, r.Get("Custom.Invalid"))
+	require.NotNil(t, r.Get("Custom.Test"))
+}
@@ -0,0 +1,399 @@
+package customlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/grok"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// eventType is a struct type built at runtime from a schema.
+// Each field of the schema becomes a pointer field with the json, validate and description tags of a
+// built-in log type so the Glue schema and the JSON output are derived the same way.
+type eventType struct {
+	schema     *Schema
+	structType reflect.Type
+	// index of the embedded parsers.PantherLog field
+	pantherLogIndex int
+	// index of the event time field or -1
+	eventTimeIndex int
+	// pattern of grok logs and the index of the field of each capture
+	pattern       *grok.Pattern
+	patternFields []int
+}
+
+var (
+	typeString    = reflect.TypeOf((*string)(nil))
+	typeInt       = reflect.TypeOf((*int32)(nil))
+	typeBigInt    = reflect.TypeOf((*int64)(nil))
+	typeFloat     = reflect.TypeOf((*float64)(nil))
+	typeBoolean   = reflect.TypeOf((*bool)(nil))
+	typeTimestamp = reflect.TypeOf((*timestamp.RFC3339)(nil))
+	typeJSON      = reflect.TypeOf(jsoniter.RawMessage(nil))
+)
+
+func fieldGoType(fieldType string) reflect.Type {
+	switch fieldType {
+	case TypeString:
+		return typeString
+	case TypeInt:
+		return typeInt
+	case TypeBigInt:
+		return typeBigInt
+	case TypeFloat:
+		return typeFloat
+	case TypeBoolean:
+		return typeBoolean
+	case TypeTimestamp:
+		return typeTimestamp
+	case TypeJSON:
+		return typeJSON
+	default:
+		return nil
+	}
+}
+
+func newEventType(schema *Schema) (*eventType, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	structFields := make([]reflect.StructField, 0, len(schema.Fields)+1)
+	eventTimeIndex := -1
+	for i := range schema.Fields {
+		field := &schema.Fields[i]
+		tag := fmt.Sprintf(`json:"%s,omitempty" description:%q`, field.Name, field.Description)
+		if field.Required {
+			tag += ` validate:"required"`
+		}
+		structFields = append(structFields, reflect.StructField{
+			// Field names must be exported for the values to be serialized
+			Name: fmt.Sprintf("Field%d", i),
+			Type: fieldGoType(field.Type),
+			Tag:  reflect.StructTag(tag),
+		})
+		if field.Name == schema.EventTimeField {
+			eventTimeIndex = i
+		}
+	}
+	// NOTE: PantherLog must be the last field, same as all built-in log types
+	structFields = append(structFields, reflect.StructField{
+		Name:      "PantherLog",
+		Type:      reflect.TypeOf(parsers.PantherLog{}),
+		Anonymous: true,
+	})
+	t := &eventType{
+		schema:          schema,
+		structType:      reflect.StructOf(structFields),
+		pantherLogIndex: len(schema.Fields),
+		eventTimeIndex:  eventTimeIndex,
+	}
+	if schema.Format == FormatGrok {
+		pattern, err := schema.compilePattern()
+		if err != nil {
+			return nil, err
+		}
+		t.pattern = pattern
+		for _, name := range pattern.Fields() {
+			t.patternFields = append(t.patternFields, schema.fieldIndex(name))
+		}
+	}
+	return t, nil
+}
+
+// Schema returns a pointer to an empty event to be used as a log type schema
+func (t *eventType) Schema() interface{} {
+	return reflect.New(t.structType).Interface()
+}
+
+// Parser parses logs of a custom log type
+type Parser struct {
+	eventType *eventType
+	csvReader *csvstream.StreamingCSVReader
+}
+
+var _ parsers.LogParser = (*Parser)(nil)
+
+func (p *Parser) New() parsers.LogParser {
+	parser := &Parser{
+		eventType: p.eventType,
+	}
+	if p.eventType.schema.Format == FormatCSV {
+		delimiter := p.eventType.schema.Delimiter
+		if delimiter == "" {
+			delimiter = defaultCSVDelimiter
+		}
+		reader := csvstream.NewStreamingCSVReader()
+		reader.CVSReader.Comma, _ = utf8.DecodeRuneInString(delimiter)
+		reader.CVSReader.LazyQuotes = true
+		parser.csvReader = reader
+	}
+	return parser
+}
+
+// LogType returns the log type supported by this parser
+func (p *Parser) LogType() string {
+	return p.eventType.schema.Name
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *Parser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := reflect.New(p.eventType.structType)
+	var err error
+	switch p.eventType.schema.Format {
+	case FormatCSV:
+		var isHeader bool
+		isHeader, err = p.populateFromCSV(event.Elem(), log)
+		if isHeader { // return success but no events, same as other parsers with header lines
+			return []*parsers.PantherLog{}, nil
+		}
+	case FormatGrok:
+		err = p.populateFromPattern(event.Elem(), log)
+	default:
+		err = p.populateFromJSON(event.Elem(), log)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pantherLog := p.updatePantherFields(event)
+
+	if err := parsers.Validator.Struct(event.Interface()); err != nil {
+		return nil, err
+	}
+
+	return pantherLog.Logs(), nil
+}
+
+func (p *Parser) populateFromJSON(event reflect.Value, log string) error {
+	var values map[string]jsoniter.RawMessage
+	if err := jsoniter.UnmarshalFromString(log, &values); err != nil {
+		return err
+	}
+	for i := range p.eventType.schema.Fields {
+		field := &p.eventType.schema.Fields[i]
+		value, ok := values[field.Name]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		if err := setJSONValue(event.Field(i), field, value); err != nil {
+			return errors.Wrapf(err, "invalid value for field %q", field.Name)
+		}
+	}
+	return nil
+}
+
+func setJSONValue(dst reflect.Value, field *Field, value jsoniter.RawMessage) error {
+	switch field.Type {
+	case TypeTimestamp:
+		// unix timestamps can be numbers, all other formats are strings
+		s := string(value)
+		if value[0] == '"' {
+			if err := jsoniter.Unmarshal(value, &s); err != nil {
+				return err
+			}
+		}
+		return setTimeValue(dst, field, s)
+	case TypeJSON:
+		dst.Set(reflect.ValueOf(append(jsoniter.RawMessage(nil), value...)))
+		return nil
+	default:
+		return jsoniter.Unmarshal(value, dst.Addr().Interface())
+	}
+}
+
+// populateFromCSV sets the event fields from a CSV record, the columns are in the order of the schema fields
+func (p *Parser) populateFromCSV(event reflect.Value, log string) (isHeader bool, err error) {
+	if !parsers.LooksLikeCSV(log) {
+		return false, errors.New("log is not CSV")
+	}
+	record, err := p.csvReader.Parse(log)
+	if err != nil {
+		return false, err
+	}
+	fields := p.eventType.schema.Fields
+	if len(record) != len(fields) {
+		return false, errors.New("wrong number of columns")
+	}
+	if isCSVHeader(fields, record) {
+		return true, nil
+	}
+	for i := range fields {
+		field := &fields[i]
+		value := record[i]
+		if value == "" || value == "-" { // null values
+			continue
+		}
+		if err := setTextValue(event.Field(i), field, value); err != nil {
+			return false, errors.Wrapf(err, "invalid value for field %q", field.Name)
+		}
+	}
+	return false, nil
+}
+
+// populateFromPattern sets the event fields from the captures of the grok pattern, empty captures are null
+func (p *Parser) populateFromPattern(event reflect.Value, log string) error {
+	values := p.eventType.pattern.Match(log)
+	if values == nil {
+		return errors.New("log does not match pattern")
+	}
+	fields := p.eventType.schema.Fields
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		index := p.eventType.patternFields[i]
+		field := &fields[index]
+		if err := setTextValue(event.Field(index), field, value); err != nil {
+			return errors.Wrapf(err, "invalid value for field %q", field.Name)
+		}
+	}
+	return nil
+}
+
+func isCSVHeader(fields []Field, record []string) bool {
+	for i := range fields {
+		if fields[i].Name != record[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// setTextValue sets a field from the text of a CSV column or a pattern capture
+func setTextValue(dst reflect.Value, field *Field, value string) error {
+	switch field.Type {
+	case TypeString:
+		dst.Set(reflect.ValueOf(&value))
+	case TypeInt:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		i := int32(n)
+		dst.Set(reflect.ValueOf(&i))
+	case TypeBigInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(&n))
+	case TypeFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(&f))
+	case TypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(&b))
+	case TypeTimestamp:
+		return setTimeValue(dst, field, value)
+	case TypeJSON:
+		if !jsoniter.Valid([]byte(value)) {
+			return errors.New("invalid JSON")
+		}
+		dst.Set(reflect.ValueOf(jsoniter.RawMessage(value)))
+	}
+	return nil
+}
+
+func setTimeValue(dst reflect.Value, field *Field, value string) error {
+	ts, err := parseTime(field.TimeFormat, value)
+	if err != nil {
+		return err
+	}
+	dst.Set(reflect.ValueOf(&ts))
+	return nil
+}
+
+func parseTime(format, value string) (timestamp.RFC3339, error) {
+	if format == "" {
+		format = defaultTimeFormat
+	}
+	switch format {
+	case TimeFormatRFC3339:
+		return timestamp.Parse(time.RFC3339Nano, value)
+	case TimeFormatUnix:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return timestamp.RFC3339{}, err
+		}
+		sec, frac := int64(f), f-float64(int64(f))
+		return timestamp.Unix(sec, int64(frac*float64(time.Second))), nil
+	case TimeFormatUnixMs:
+		ms, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return timestamp.RFC3339{}, err
+		}
+		return timestamp.Unix(0, ms*int64(time.Millisecond)), nil
+	default:
+		return timestamp.Parse(format, value)
+	}
+}
+
+func (p *Parser) updatePantherFields(event reflect.Value) *parsers.PantherLog {
+	fields := event.Elem()
+	pantherLog := fields.Field(p.eventType.pantherLogIndex).Addr().Interface().(*parsers.PantherLog)
+
+	var eventTime *timestamp.RFC3339
+	if p.eventType.eventTimeIndex != -1 {
+		eventTime = fields.Field(p.eventType.eventTimeIndex).Interface().(*timestamp.RFC3339)
+	}
+	pantherLog.SetCoreFields(p.LogType(), eventTime, event.Interface())
+
+	for i := range p.eventType.schema.Fields {
+		field := &p.eventType.schema.Fields[i]
+		if len(field.Indicators) == 0 {
+			continue
+		}
+		value := fields.Field(i).Interface().(*string)
+		if value == nil {
+			continue
+		}
+		for _, indicator := range field.Indicators {
+			switch indicator {
+			case IndicatorIP:
+				pantherLog.AppendAnyIPAddress(*value)
+			case IndicatorDomain:
+				pantherLog.AppendAnyDomainNames(*value)
+			case IndicatorMD5:
+				pantherLog.AppendAnyMD5Hashes(*value)
+			case IndicatorSHA1:
+				pantherLog.AppendAnySHA1Hashes(*value)
+			case IndicatorSHA256:
+				pantherLog.AppendAnySHA256Hashes(*value)
+			}
+		}
+	}
+	return pantherLog
+}
@@ -0,0 +1,266 @@
+package customlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+func newTestParser(t *testing.T, spec string) parsers.LogParser {
+	schema, err := ParseSchema(spec)
+	require.NoError(t, err)
+	eventType, err := newEventType(schema)
+	require.NoError(t, err)
+	return (&Parser{eventType: eventType}).New()
+}
+
+// checkEvent compares the JSON of a parsed event ignoring the fields that change on each run
+func checkEvent(t *testing.T, expect string, events []*parsers.PantherLog, err error) {
+	t.Helper()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	result, err := events[0].Result()
+	require.NoError(t, err)
+	var actual map[string]interface{}
+	require.NoError(t, jsoniter.Unmarshal(result.JSON, &actual))
+	require.NotEmpty(t, actual["p_row_id"])
+	require.NotEmpty(t, actual["p_parse_time"])
+	delete(actual, "p_row_id")
+	delete(actual, "p_parse_time")
+	actualJSON, err := jsoniter.MarshalToString(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, expect, actualJSON)
+}
+
+func TestParserJSON(t *testing.T) {
+	parser := newTestParser(t, testSchemaYAML)
+	require.Equal(t, "Custom.Test", parser.LogType())
+
+	events, err := parser.Parse(`{"time":"2020-06-01T12:00:00.5Z","client_ip":"10.0.0.1","bytes":1024,"extra":"ignored"}`)
+	checkEvent(t, `{
+		"time":"2020-06-01 12:00:00.500000000",
+		"client_ip":"10.0.0.1",
+		"bytes":1024,
+		"p_log_type":"Custom.Test",
+		"p_event_time":"2020-06-01 12:00:00.500000000",
+		"p_any_ip_addresses":["10.0.0.1"]
+	}`, events, err)
+
+	// missing required field
+	_, err = parser.Parse(`{"client_ip":"10.0.0.1","bytes":1024}`)
+	require.Error(t, err)
+	// invalid type
+	_, err = parser.Parse(`{"time":"2020-06-01T12:00:00Z","bytes":"1024"}`)
+	require.Error(t, err)
+	// invalid time
+	_, err = parser.Parse(`{"time":"06/01/2020"}`)
+	require.Error(t, err)
+	// not JSON
+	_, err = parser.Parse(`2020-06-01T12:00:00Z,10.0.0.1,1024`)
+	require.Error(t, err)
+}
+
+func TestParserJSONTypes(t *testing.T) {
+	parser := newTestParser(t, `
+name: Custom.Types
+description: All field types
+format: json
+fields:
+  - name: ts
+    type: timestamp
+    timeFormat: unix
+    description: Unix timestamp
+  - name: ts_ms
+    type: timestamp
+    timeFormat: unix_ms
+    description: Unix timestamp in milliseconds
+  - name: ts_layout
+    type: timestamp
+    timeFormat: "02/01/2006 15:04:05"
+    description: Timestamp with a custom layout
+  - name: count
+    type: int
+    description: A number
+  - name: ratio
+    type: float
+    description: A float
+  - name: ok
+    type: boolean
+    description: A flag
+  - name: details
+    type: json
+    description: Nested object
+  - name: host
+    type: string
+    indicators: [domain]
+    description: A domain name
+  - name: digest
+    type: string
+    indicators: [sha256]
+    description: A hash
+`)
+	// nolint:lll
+	events, err := parser.Parse(`{"ts":1591368001.5,"ts_ms":"1591368001500","ts_layout":"05/06/2020 14:40:01","count":42,"ratio":0.5,"ok":true,"details":{"a":[1,2]},"host":"example.com","digest":"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}`)
+	checkEvent(t, `{
+		"ts":"2020-06-05 14:40:01.500000000",
+		"ts_ms":"2020-06-05 14:40:01.500000000",
+		"ts_layout":"2020-06-05 14:40:01.000000000",
+		"count":42,
+		"ratio":0.5,
+		"ok":true,
+		"details":{"a":[1,2]},
+		"host":"example.com",
+		"digest":"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"p_log_type":"Custom.Types",
+		"p_event_time":"`+parsedEventTime(t, events)+`",
+		"p_any_domain_names":["example.com"],
+		"p_any_sha256_hashes":["e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"]
+	}`, events, err)
+}
+
+// parsedEventTime returns the event time of events without an event time field (the parse time)
+func parsedEventTime(t *testing.T, events []*parsers.PantherLog) string {
+	require.Len(t, events, 1)
+	data, err := events[0].PantherEventTime.MarshalJSON()
+	require.NoError(t, err)
+	return string(data[1 : len(data)-1])
+}
+
+func TestParserCSV(t *testing.T) {
+	parser := newTestParser(t, `
+name: Custom.CSV
+description: CSV log type
+format: csv
+delimiter: ";"
+eventTimeField: time
+fields:
+  - name: time
+    type: timestamp
+    timeFormat: "2006-01-02 15:04:05"
+    required: true
+    description: The time of the event
+  - name: user
+    type: string
+    description: The user
+  - name: src
+    type: string
+    indicators: [ip]
+    description: The source address
+  - name: status
+    type: int
+    description: The status code
+`)
+	// header lines are skipped
+	events, err := parser.Parse(`time;user;src;status`)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	events, err = parser.Parse(`2020-06-01 12:00:00;"doe; john";192.168.1.1;-`)
+	checkEvent(t, `{
+		"time":"2020-06-01 12:00:00.000000000",
+		"user":"doe; john",
+		"src":"192.168.1.1",
+		"p_log_type":"Custom.CSV",
+		"p_event_time":"2020-06-01 12:00:00.000000000",
+		"p_any_ip_addresses":["192.168.1.1"]
+	}`, events, err)
+
+	// wrong number of columns
+	_, err = parser.Parse(`2020-06-01 12:00:00;john;192.168.1.1`)
+	require.Error(t, err)
+	// invalid int
+	_, err = parser.Parse(`2020-06-01 12:00:00;john;192.168.1.1;OK`)
+	require.Error(t, err)
+	// missing required field
+	_, err = parser.Parse(`;john;192.168.1.1;200`)
+	require.Error(t, err)
+	// JSON
+	_, err = parser.Parse(`{"time":"2020-06-01 12:00:00"}`)
+	require.Error(t, err)
+}
+
+func TestParserGrok(t *testing.T) {
+	parser := newTestParser(t, `
+name: Custom.Grok
+description: Text log type
+format: grok
+pattern: '%{TIMESTAMP_ISO8601:time} %{HOSTNAME:host} %{PROG:program}(?:\[%{POSINT:pid}\])?: %{LEVEL:level} %{GREEDYDATA:message}'
+patterns:
+  LEVEL: '\[(?:%{LOGLEVEL})\]'
+eventTimeField: time
+fields:
+  - name: time
+    type: timestamp
+    required: true
+    description: The time of the event
+  - name: host
+    type: string
+    indicators: [domain]
+    description: The host name
+  - name: program
+    type: string
+    description: The program name
+  - name: pid
+    type: int
+    description: The process id
+  - name: level
+    type: string
+    description: The log level
+  - name: message
+    type: string
+    description: The message
+`)
+	events, err := parser.Parse(`2020-06-01T12:00:00Z web-1.example.com myapp[42]: [WARN] disk is full`)
+	checkEvent(t, `{
+		"time":"2020-06-01 12:00:00.000000000",
+		"host":"web-1.example.com",
+		"program":"myapp",
+		"pid":42,
+		"level":"[WARN]",
+		"message":"disk is full",
+		"p_log_type":"Custom.Grok",
+		"p_event_time":"2020-06-01 12:00:00.000000000",
+		"p_any_domain_names":["web-1.example.com"]
+	}`, events, err)
+
+	// optional captures are null
+	events, err = parser.Parse(`2020-06-01T12:00:00Z web-1 cron: [INFO] job started`)
+	checkEvent(t, `{
+		"time":"2020-06-01 12:00:00.000000000",
+		"host":"web-1",
+		"program":"cron",
+		"level":"[INFO]",
+		"message":"job started",
+		"p_log_type":"Custom.Grok",
+		"p_event_time":"2020-06-01 12:00:00.000000000",
+		"p_any_domain_names":["web-1"]
+	}`, events, err)
+
+	// no match
+	_, err = parser.Parse(`2020-06-01T12:00:00Z web-1 cron: job started`)
+	require.Error(t, err)
+	// invalid time
+	_, err = parser.Parse(`2020-06-31T12:00:00Z web-1 cron: [INFO] job started`)
+	require.Error(t, err)
+}
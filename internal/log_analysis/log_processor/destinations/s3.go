@@ -40,6 +40,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 )
 
 const (
@@ -94,17 +95,15 @@ func CreateS3Destination(registry *logtypes.Registry) Destination {
 func maxS3BufferMemUsageBytes(lambdaSizeMB int) uint64 {
 	const (
 		/*
-					NOTE:
-					  "More specifically CloudTrail will collect logs for 5 mins or until the max file size of 45MB has been reached.
-					  An important thing worth noting is that these logs get compressed before being sent to S3, once the file size
-					  limit is met or the time limit has been exceeded"
-				    Because CT files are "document" JSON and all on 1 line we currently need to read ALL the uncompressed data into memory.
-			        FIXME: we should switch to streaming JSON reader
-					Below we set the lower bound on memory to be 45MB * 4 (because we convert all the records and parse) plus some for overhead
+			NOTE:
+			  Files with "document" JSON (ie CloudTrail `{"Records":[...]}` files that can be up to 45MB uncompressed)
+			  are decoded as a stream one record at a time, so memory used for processing no longer depends on file size.
+			  Below we set the lower bound on memory to be the largest single event * 4 (because we convert and parse it)
+			  plus some for overhead.
 		*/
-		largestAllInMemFileMB     = 45
+		largestEventMB            = 1 // CloudTrail events are at most 256KB, other sources are well within this
 		processingExpansionFactor = 4
-		memoryFootprint           = largestAllInMemFileMB * processingExpansionFactor
+		memoryFootprint           = largestEventMB * processingExpansionFactor
 		minimumScratchMemMB       = 5 // how much overhead is needed to process
 	)
 	maxBufferUsageMB := lambdaSizeMB - memUsedAtStartupMB - memoryFootprint - minimumScratchMemMB
@@ -268,6 +267,10 @@ func (destination *S3Destination) sendData(buffer *s3EventBuffer, errChan chan e
 		return
 	}
 
+	if buffer.logType == quarantine.LogType { // quarantined lines are not processed any further
+		return
+	}
+
 	err = destination.sendSNSNotification(key, buffer) // if send fails we fail whole operation
 	if err != nil {
 		errChan <- err
@@ -314,13 +317,18 @@ func (destination *S3Destination) sendSNSNotification(key string, buffer *s3Even
 }
 
 func (destination *S3Destination) getS3ObjectKey(logType string, timestamp time.Time) (string, error) {
-	typ := destination.registry.Get(logType)
-	if typ == nil {
-		return "", errors.Errorf(`unknown log type %q`, logType)
+	var prefix string
+	if logType == quarantine.LogType {
+		prefix = quarantine.ObjectKeyPrefix(timestamp)
+	} else {
+		typ := destination.registry.Get(logType)
+		if typ == nil {
+			return "", errors.Errorf(`unknown log type %q`, logType)
+		}
+		prefix = typ.GlueTableMeta().GetPartitionPrefix(timestamp.UTC()) // get the path to store the data in S3
 	}
-	meta := typ.GlueTableMeta()
 	return fmt.Sprintf(s3ObjectKeyFormat,
-		meta.GetPartitionPrefix(timestamp.UTC()), // get the path to store the data in S3
+		prefix,
 		timestamp.Format(S3ObjectTimestampFormat),
 		uuid.New().String(),
 	), nil
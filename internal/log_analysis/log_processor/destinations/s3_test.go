@@ -44,6 +44,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 )
 
 const (
@@ -238,6 +239,36 @@ func TestSendDataToS3BeforeTerminating(t *testing.T) {
 	assert.Equal(t, expectedSnsPublishInput, publishInput)
 }
 
+func TestSendQuarantinedLinesToS3(t *testing.T) {
+	initTest()
+
+	destination := newS3Destination()
+	eventChannel := make(chan *parsers.Result, 1)
+
+	line := &quarantine.Line{
+		Bucket:        "sourcebucket",
+		Key:           "sourcekey",
+		LineNumber:    7,
+		QuarantinedAt: (time.Time)(refTime),
+		Line:          "unknown",
+	}
+	testResult, err := line.Result()
+	require.NoError(t, err)
+	eventChannel <- testResult
+
+	destination.mockS3Uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{}, nil).Once()
+
+	runSendEvents(t, destination, eventChannel, false)
+
+	destination.mockS3Uploader.AssertExpectations(t)
+	// quarantined lines are not sent to the rules engine
+	destination.mockSns.AssertNotCalled(t, "Publish", mock.Anything)
+
+	uploadInput := destination.mockS3Uploader.Calls[0].Arguments.Get(0).(*s3manager.UploadInput)
+	assert.Equal(t, aws.String("testbucket"), uploadInput.Bucket)
+	assert.True(t, strings.HasPrefix(*uploadInput.Key, "quarantine/year=2020/month=01/day=01/hour=00/20200101T000000Z"))
+}
+
 func TestSendDataIfTotalMemSizeLimitHasBeenReached(t *testing.T) {
 	initTest()
 
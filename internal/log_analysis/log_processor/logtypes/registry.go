@@ -27,6 +27,7 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
@@ -94,10 +95,10 @@ func (r *Registry) Del(logType string) bool {
 }
 
 func (r *Registry) Register(config Config) (Entry, error) {
-	if err := config.Validate(); err != nil {
+	newEntry, err := config.buildEntry()
+	if err != nil {
 		return nil, err
 	}
-	newEntry := newEntry(config.Describe(), config.Schema, config.NewParser)
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.entries == nil {
@@ -110,6 +111,22 @@ func (r *Registry) Register(config Config) (Entry, error) {
 	return newEntry, nil
 }
 
+// Replace registers a log type replacing any previous version of it.
+// Readers of the registry find either the previous or the new version, never none.
+func (r *Registry) Replace(config Config) (Entry, error) {
+	newEntry, err := config.buildEntry()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.entries == nil {
+		r.entries = make(map[string]Entry)
+	}
+	r.entries[newEntry.Name] = newEntry
+	return newEntry, nil
+}
+
 func (r *Registry) MustRegister(config Config) Entry {
 	entry, err := r.Register(config)
 	if err != nil {
@@ -126,6 +143,7 @@ type Entry interface {
 	NewParser(params interface{}) (parsers.Interface, error)
 	Schema() interface{}
 	GlueTableMeta() *awsglue.GlueTableMetadata
+	Framing() common.Framing
 }
 
 // Config describes a log event type in a declarative way.
@@ -137,6 +155,12 @@ type Config struct {
 	ReferenceURL string
 	Schema       interface{}
 	NewParser    parsers.Factory
+	// Framing is used to split files of this log type into events.
+	// If it is nil the data stream default applies.
+	Framing common.Framing
+	// Fallback log types parse generic formats that also match more specific log types (ie Syslog.* and CEF.Event).
+	// The classifier tries their parsers after all other parsers.
+	Fallback bool
 }
 
 func (config *Config) Describe() Desc {
@@ -162,6 +186,17 @@ func (config *Config) Validate() error {
 	return nil
 }
 
+// buildEntry validates a config and creates the entry to register
+func (config *Config) buildEntry() (*entry, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	newEntry := newEntry(config.Describe(), config.Schema, config.NewParser)
+	newEntry.framing = config.Framing
+	newEntry.fallback = config.Fallback
+	return newEntry, nil
+}
+
 // Desc describes an registered log type.
 type Desc struct {
 	Name         string
@@ -198,6 +233,8 @@ type entry struct {
 	schema        interface{}
 	newParser     parsers.Factory
 	glueTableMeta *awsglue.GlueTableMetadata
+	framing       common.Framing
+	fallback      bool
 }
 
 func newEntry(desc Desc, schema interface{}, fac parsers.Factory) *entry {
@@ -221,9 +258,18 @@ func (e *entry) GlueTableMeta() *awsglue.GlueTableMetadata {
 	return e.glueTableMeta
 }
 
+// Framing returns the framing for files of this log type or nil if the data stream default should be used
+func (e *entry) Framing() common.Framing {
+	return e.framing
+}
+
 // Parser returns a new parsers.Interface instance for this log type
 func (e *entry) NewParser(params interface{}) (parsers.Interface, error) {
-	return e.newParser(params)
+	parser, err := e.newParser(params)
+	if err != nil || !e.fallback {
+		return parser, err
+	}
+	return parsers.NewFallback(parser), nil
 }
 
 func checkLogEntrySchema(logType string, schema interface{}) error {
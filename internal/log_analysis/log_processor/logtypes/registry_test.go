@@ -64,6 +64,14 @@ func TestRegistry(t *testing.T) {
 	require.Panics(t, func() {
 		r.MustRegister(logTypeConfig)
 	})
+	replaced, err := r.Replace(logTypeConfig)
+	require.NoError(t, err)
+	require.NotEqual(t, api, replaced)
+	require.Equal(t, replaced, r.Get("Foo.Bar"))
+	// the previous version is kept if the config is invalid
+	_, err = r.Replace(Config{Name: "Foo.Bar"})
+	require.Error(t, err)
+	require.Equal(t, replaced, r.Get("Foo.Bar"))
 	require.True(t, r.Del(logTypeConfig.Name))
 	require.NotPanics(t, func() {
 		api = r.MustRegister(logTypeConfig)
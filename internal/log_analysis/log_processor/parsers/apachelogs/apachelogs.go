@@ -33,6 +33,7 @@ import (
 const (
 	TypeAccessCombined = `Apache.AccessCombined`
 	TypeAccessCommon   = `Apache.AccessCommon`
+	TypeError          = `Apache.Error`
 )
 
 func init() {
@@ -51,6 +52,13 @@ func init() {
 			Schema:       AccessCommon{},
 			NewParser:    parsers.AdapterFactory(NewAccessCommonParser()),
 		},
+		logtypes.Config{
+			Name:         TypeError,
+			Description:  `Apache HTTP server error logs using the default error log format, including ModSecurity messages`,
+			ReferenceURL: `https://httpd.apache.org/docs/current/logs.html#errorlog`,
+			Schema:       Error{},
+			NewParser:    parsers.AdapterFactory(NewErrorParser()),
+		},
 	)
 }
 
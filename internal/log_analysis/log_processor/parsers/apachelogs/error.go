@@ -0,0 +1,172 @@
+package apachelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/modsecurity"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// Apache writes the time of error logs in the local time of the server, without a time zone.
+// Apache 2.4 adds microseconds to the seconds, they are parsed even though they are not part of the layout.
+const layoutApacheErrorTimestamp = `Mon Jan 02 15:04:05 2006`
+
+var (
+	rxErrorField   = regexp.MustCompile(`^\[([^\]]*)\]\s*`)
+	rxErrorLevel   = regexp.MustCompile(`^(?:([\w-]*):)?(emerg|alert|crit|error|warn|notice|info|debug|trace[1-8])$`)
+	rxErrorPID     = regexp.MustCompile(`^pid (\d+)(?::tid (\d+))?$`)
+	rxErrorCode    = regexp.MustCompile(`^(AH\d{5}): `)
+	rxErrorReferer = regexp.MustCompile(`, referer: (.*)$`)
+)
+
+// ErrorLogFormat "[%{u}t] [%-m:%l] [pid %P:tid %T] %7F: %E: [client\ %a] %M% ,\ referer\ %{Referer}i" default
+// nolint:lll
+type Error struct {
+	ErrorLog
+	parsers.PantherLog
+}
+
+// nolint:lll
+type ErrorLog struct {
+	Time          *timestamp.RFC3339   `json:"time" validate:"required" description:"The time of the error, in the local time of the server (assumed to be UTC)."`
+	Module        *string              `json:"module,omitempty" description:"The module that logged the error (ie core, ssl or proxy)."`
+	Level         *string              `json:"level" validate:"required" description:"The severity of the error (emerg, alert, crit, error, warn, notice, info, debug or trace1 to trace8)."`
+	PID           *int64               `json:"pid,omitempty" description:"The process id of the server process."`
+	TID           *int64               `json:"tid,omitempty" description:"The thread id of the server thread."`
+	ClientAddress *string              `json:"client_address,omitempty" description:"The IP address of the client (remote host) which made the request to the server."`
+	ClientPort    *uint16              `json:"client_port,omitempty" description:"The port of the client."`
+	ErrorCode     *string              `json:"error_code,omitempty" description:"The APR error code of the message (ie AH00126)."`
+	Message       *string              `json:"message" validate:"required" description:"The error message."`
+	Referer       *string              `json:"referer,omitempty" description:"The HTTP referer of the request if any."`
+	ModSecurity   *modsecurity.Message `json:"mod_security,omitempty" description:"The ModSecurity rule match reported by the error."`
+}
+
+type ErrorParser struct{}
+
+func NewErrorParser() parsers.LogParser {
+	return &ErrorParser{}
+}
+
+func (p *ErrorParser) New() parsers.LogParser {
+	return NewErrorParser()
+}
+func (p *ErrorParser) LogType() string {
+	return TypeError
+}
+
+func (p *ErrorParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := Error{}
+	if err := event.ParseString(log); err != nil {
+		return nil, err
+	}
+	event.updatePantherFields(&event.PantherLog)
+	if err := parsers.Validator.Struct(&event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+func (log *ErrorLog) ParseString(s string) error {
+	match := rxErrorField.FindStringSubmatch(s)
+	if match == nil {
+		return errors.New("invalid log format")
+	}
+	tm, err := timestamp.Parse(layoutApacheErrorTimestamp, match[1])
+	if err != nil {
+		return err
+	}
+	*log = ErrorLog{
+		Time: &tm,
+	}
+	s = s[len(match[0]):]
+	// The fields in brackets after the time are optional, the message starts at the first unknown field
+	for {
+		match := rxErrorField.FindStringSubmatch(s)
+		if match == nil || !log.setField(match[1]) {
+			break
+		}
+		s = s[len(match[0]):]
+	}
+	if log.Level == nil {
+		return errors.New("missing log level")
+	}
+	if match := rxErrorCode.FindStringSubmatch(s); match != nil {
+		log.ErrorCode = &match[1]
+		s = s[len(match[0]):]
+	}
+	if match := rxErrorReferer.FindStringSubmatchIndex(s); match != nil {
+		referer := s[match[2]:match[3]]
+		log.Referer = &referer
+		s = s[:match[0]]
+	}
+	log.Message = &s
+	log.ModSecurity = modsecurity.ParseMessage(s)
+	return nil
+}
+
+// setField sets the value of a bracketed field, it returns false if the field is not known
+func (log *ErrorLog) setField(field string) bool {
+	if match := rxErrorLevel.FindStringSubmatch(field); match != nil {
+		log.Module = nonEmptyLogField(match[1])
+		log.Level = &match[2]
+		return true
+	}
+	if match := rxErrorPID.FindStringSubmatch(field); match != nil {
+		log.PID = parsers.CsvStringToInt64Pointer(match[1])
+		if match[2] != "" {
+			log.TID = parsers.CsvStringToInt64Pointer(match[2])
+		}
+		return true
+	}
+	// ModSecurity repeats the client at the start of its messages
+	if strings.HasPrefix(field, "client ") && log.ClientAddress == nil {
+		log.setClient(strings.TrimPrefix(field, "client "))
+		return true
+	}
+	return false
+}
+
+func (log *ErrorLog) setClient(addr string) {
+	if net.ParseIP(addr) == nil {
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			if n, err := strconv.ParseUint(port, 10, 16); err == nil {
+				clientPort := uint16(n)
+				log.ClientPort = &clientPort
+				addr = host
+			}
+		}
+	}
+	log.ClientAddress = &addr
+}
+
+func (event *Error) updatePantherFields(p *parsers.PantherLog) {
+	p.SetCoreFields(TypeError, event.Time, event)
+	if !p.AppendAnyIPAddressPtr(event.ClientAddress) {
+		// Handle cases where apache config has resolved addresses enabled
+		p.AppendAnyDomainNamePtrs(event.ClientAddress)
+	}
+}
@@ -0,0 +1,119 @@
+package apachelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/modsecurity"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestErrorParser(t *testing.T) {
+	// nolint:lll
+	log := `[Mon Jun 01 18:56:20.123456 2020] [core:error] [pid 1234:tid 140234567] [client 198.51.100.7:51234] AH00126: Invalid URI in request GET /../../etc/passwd HTTP/1.1, referer: http://example.com/`
+	tm := time.Date(2020, 6, 1, 18, 56, 20, 123456000, time.UTC)
+	event := Error{
+		ErrorLog: ErrorLog{
+			Time:          (*timestamp.RFC3339)(&tm),
+			Module:        aws.String("core"),
+			Level:         aws.String("error"),
+			PID:           aws.Int64(1234),
+			TID:           aws.Int64(140234567),
+			ClientAddress: aws.String("198.51.100.7"),
+			ClientPort:    aws.Uint16(51234),
+			ErrorCode:     aws.String("AH00126"),
+			Message:       aws.String("Invalid URI in request GET /../../etc/passwd HTTP/1.1"),
+			Referer:       aws.String("http://example.com/"),
+		},
+	}
+	event.PantherEventTime = (*timestamp.RFC3339)(&tm)
+	event.PantherLogType = aws.String(TypeError)
+	event.SetEvent(&event)
+	event.AppendAnyIPAddress("198.51.100.7")
+	testutil.CheckPantherParser(t, log, NewErrorParser(), &event.PantherLog)
+}
+
+func TestErrorParserApache22(t *testing.T) {
+	log := `[Mon Jun 01 18:56:20 2020] [error] [client 198.51.100.7] File does not exist: /var/www/html/favicon.ico`
+	tm := time.Date(2020, 6, 1, 18, 56, 20, 0, time.UTC)
+	event := Error{
+		ErrorLog: ErrorLog{
+			Time:          (*timestamp.RFC3339)(&tm),
+			Level:         aws.String("error"),
+			ClientAddress: aws.String("198.51.100.7"),
+			Message:       aws.String("File does not exist: /var/www/html/favicon.ico"),
+		},
+	}
+	event.PantherEventTime = (*timestamp.RFC3339)(&tm)
+	event.PantherLogType = aws.String(TypeError)
+	event.SetEvent(&event)
+	event.AppendAnyIPAddress("198.51.100.7")
+	testutil.CheckPantherParser(t, log, NewErrorParser(), &event.PantherLog)
+}
+
+func TestErrorParserModSecurity(t *testing.T) {
+	// nolint:lll
+	log := `[Mon Jun 01 18:56:20.123456 2020] [:error] [pid 1234] [client 198.51.100.7:51234] [client 198.51.100.7] ModSecurity: Warning. Pattern match "(?i)union.*select" at ARGS:id. [file "/etc/modsecurity/crs/REQUEST-942-APPLICATION-ATTACK-SQLI.conf"] [line "45"] [id "942100"] [msg "SQL Injection Attack Detected"] [data "Matched Data: union select found within ARGS:id"] [severity "CRITICAL"] [tag "attack-sqli"] [hostname "example.com"] [uri "/index.php"] [unique_id "XtVRxH8AAQEAAAbXvVUAAAAA"]`
+	tm := time.Date(2020, 6, 1, 18, 56, 20, 123456000, time.UTC)
+	event := Error{
+		ErrorLog: ErrorLog{
+			Time:          (*timestamp.RFC3339)(&tm),
+			Level:         aws.String("error"),
+			PID:           aws.Int64(1234),
+			ClientAddress: aws.String("198.51.100.7"),
+			ClientPort:    aws.Uint16(51234),
+			// nolint:lll
+			Message: aws.String(`[client 198.51.100.7] ModSecurity: Warning. Pattern match "(?i)union.*select" at ARGS:id. [file "/etc/modsecurity/crs/REQUEST-942-APPLICATION-ATTACK-SQLI.conf"] [line "45"] [id "942100"] [msg "SQL Injection Attack Detected"] [data "Matched Data: union select found within ARGS:id"] [severity "CRITICAL"] [tag "attack-sqli"] [hostname "example.com"] [uri "/index.php"] [unique_id "XtVRxH8AAQEAAAbXvVUAAAAA"]`),
+			ModSecurity: &modsecurity.Message{
+				Text:     aws.String(`Warning. Pattern match "(?i)union.*select" at ARGS:id.`),
+				File:     aws.String("/etc/modsecurity/crs/REQUEST-942-APPLICATION-ATTACK-SQLI.conf"),
+				Line:     aws.String("45"),
+				ID:       aws.String("942100"),
+				Msg:      aws.String("SQL Injection Attack Detected"),
+				Data:     aws.String("Matched Data: union select found within ARGS:id"),
+				Severity: aws.String("CRITICAL"),
+				Tags:     []string{"attack-sqli"},
+				Hostname: aws.String("example.com"),
+				URI:      aws.String("/index.php"),
+				UniqueID: aws.String("XtVRxH8AAQEAAAbXvVUAAAAA"),
+			},
+		},
+	}
+	event.PantherEventTime = (*timestamp.RFC3339)(&tm)
+	event.PantherLogType = aws.String(TypeError)
+	event.SetEvent(&event)
+	event.AppendAnyIPAddress("198.51.100.7")
+	testutil.CheckPantherParser(t, log, NewErrorParser(), &event.PantherLog)
+}
+
+func TestErrorParserInvalid(t *testing.T) {
+	parser := NewErrorParser()
+	_, err := parser.Parse(`[Mon Jun 01 18:56:20 2020] [pid 1234] missing level`)
+	require.Error(t, err)
+	_, err = parser.Parse(`2020/06/01 18:56:20 [error] 1234#5678: *42 open() failed, client: 198.51.100.7, server: example.com`)
+	require.Error(t, err)
+	_, err = parser.Parse(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`)
+	require.Error(t, err)
+}
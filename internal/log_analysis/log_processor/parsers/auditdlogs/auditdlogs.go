@@ -0,0 +1,43 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "Auditd"
+	// TypeEvent is the log type of Linux audit events read from audit.log
+	TypeEvent = PantherPrefix + ".Event"
+)
+
+func init() {
+	logtypes.MustRegister(logtypes.Config{
+		Name:         TypeEvent,
+		Description:  `Linux audit daemon (auditd) events, the records of an event in audit.log (ie SYSCALL, EXECVE, CWD and PATH) are grouped into a single event.`,
+		ReferenceURL: `https://access.redhat.com/documentation/en-us/red_hat_enterprise_linux/7/html/security_guide/sec-understanding_audit_log_files`,
+		Schema:       Event{},
+		NewParser:    parsers.AdapterFactory(&EventParser{}),
+		// records of the same event are grouped by their ID
+		Framing: Framing{},
+	})
+}
@@ -0,0 +1,285 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Event struct {
+	Timestamp *timestamp.RFC3339 `json:"timestamp" validate:"required" description:"The time of the event."`
+	Serial    *uint64            `json:"serial" validate:"required" description:"The serial number of the event, unique for each event of a host since the audit daemon started."`
+	Node      *string            `json:"node,omitempty" description:"The name of the host, set by the name_format option of auditd."`
+	Type      *string            `json:"type" validate:"required" description:"The type of the first record of the event, ie SYSCALL or USER_LOGIN."`
+	Records   []Record           `json:"records" validate:"required,min=1,dive" description:"The records of the event in the order they were logged."`
+
+	Arch    *string `json:"arch,omitempty" description:"The CPU architecture of the system call, hex encoded."`
+	Syscall *string `json:"syscall,omitempty" description:"The number of the system call."`
+	Success *bool   `json:"success,omitempty" description:"Whether the system call succeeded."`
+	Exit    *int64  `json:"exit,omitempty" description:"The exit value of the system call, negative values are error codes."`
+	PPID    *int64  `json:"ppid,omitempty" description:"The ID of the parent process."`
+	PID     *int64  `json:"pid,omitempty" description:"The ID of the process."`
+	AUID    *uint32 `json:"auid,omitempty" description:"The audit user ID, the ID of the user that logged in. It does not change when the user switches to another account (ie with su). 4294967295 if unset."`
+	UID     *uint32 `json:"uid,omitempty" description:"The ID of the user that started the process."`
+	GID     *uint32 `json:"gid,omitempty" description:"The ID of the group of the user that started the process."`
+	EUID    *uint32 `json:"euid,omitempty" description:"The effective user ID of the process."`
+	Ses     *uint32 `json:"ses,omitempty" description:"The ID of the login session. 4294967295 if unset."`
+	TTY     *string `json:"tty,omitempty" description:"The terminal of the process."`
+	Comm    *string `json:"comm,omitempty" description:"The command name of the process."`
+	Exe     *string `json:"exe,omitempty" description:"The path of the executable of the process."`
+	Subj    *string `json:"subj,omitempty" description:"The SELinux context of the process."`
+	Key     *string `json:"key,omitempty" description:"The key of the audit rule that generated the event."`
+
+	Argc        *int32   `json:"argc,omitempty" description:"The number of arguments of an execve system call."`
+	Args        []string `json:"args,omitempty" description:"The arguments of an execve system call, including the program name."`
+	CommandLine *string  `json:"commandLine,omitempty" description:"The arguments of an execve system call joined by spaces."`
+	CWD         *string  `json:"cwd,omitempty" description:"The current working directory of the process."`
+	Paths       []string `json:"paths,omitempty" description:"The paths of the files used by the system call, from the PATH records."`
+	Proctitle   *string  `json:"proctitle,omitempty" description:"The full command line of the process, truncated by the kernel."`
+
+	SocketAddress *string `json:"socketAddress,omitempty" description:"The IP address of the socket used by the system call, from the SOCKADDR record."`
+	SocketPort    *uint16 `json:"socketPort,omitempty" description:"The port of the socket used by the system call, from the SOCKADDR record."`
+
+	Op       *string `json:"op,omitempty" description:"The operation of user space events, ie PAM:authentication."`
+	Acct     *string `json:"acct,omitempty" description:"The account name of user space events."`
+	Hostname *string `json:"hostname,omitempty" description:"The remote host name of user space events."`
+	Addr     *string `json:"addr,omitempty" description:"The remote address of user space events."`
+	Terminal *string `json:"terminal,omitempty" description:"The terminal of user space events."`
+	Res      *string `json:"res,omitempty" description:"The result of user space events, success or failed."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// EventParser parses auditd events.
+// Each log is an event made of one or more records on consecutive lines sharing the same `msg=audit(...)` ID.
+type EventParser struct{}
+
+var _ parsers.LogParser = (*EventParser)(nil)
+
+// New returns an initialized LogParser for auditd events
+func (p *EventParser) New() parsers.LogParser {
+	return &EventParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *EventParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event, err := parseEvent(log)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *EventParser) LogType() string {
+	return TypeEvent
+}
+
+func parseEvent(log string) (*Event, error) {
+	event := &Event{}
+	eventID := ""
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		header, record, err := parseRecord(line)
+		if err != nil {
+			return nil, err
+		}
+		if eventID == "" {
+			eventID = header.EventID
+			event.Timestamp = (*timestamp.RFC3339)(&header.Timestamp)
+			event.Serial = &header.Serial
+			if header.Node != "" {
+				event.Node = &header.Node
+			}
+		} else if header.EventID != eventID {
+			return nil, errors.Errorf("audit record %q does not belong to event %q", header.EventID, eventID)
+		}
+		if header.Type == typeEOE {
+			continue
+		}
+		event.Records = append(event.Records, *record)
+	}
+	if len(event.Records) > 0 {
+		event.Type = event.Records[0].Type
+	}
+	for i := range event.Records {
+		event.setRecordFields(&event.Records[i])
+	}
+	return event, nil
+}
+
+// setRecordFields sets the columns of the event from the fields of a record.
+// Fields present in more than one record are taken from the first one.
+func (event *Event) setRecordFields(record *Record) {
+	fields := record.Fields
+	switch *record.Type {
+	case "EXECVE":
+		event.setArgs(fields)
+	case "CWD":
+		setString(&event.CWD, fields["cwd"])
+	case "PATH":
+		if name := fields["name"]; isValue(name) {
+			event.Paths = append(event.Paths, name)
+		}
+	case "PROCTITLE":
+		setString(&event.Proctitle, fields["proctitle"])
+	case "SOCKADDR":
+		if event.SocketAddress == nil {
+			if addr, port, ok := decodeSockaddr(fields["saddr"]); ok {
+				event.SocketAddress = &addr
+				event.SocketPort = &port
+			}
+		}
+	}
+	setString(&event.Arch, fields["arch"])
+	setString(&event.Syscall, fields["syscall"])
+	if event.Success == nil && isValue(fields["success"]) {
+		success := fields["success"] == "yes"
+		event.Success = &success
+	}
+	setInt64(&event.Exit, fields["exit"])
+	setInt64(&event.PPID, fields["ppid"])
+	setInt64(&event.PID, fields["pid"])
+	setUint32(&event.AUID, fields["auid"])
+	setUint32(&event.UID, fields["uid"])
+	setUint32(&event.GID, fields["gid"])
+	setUint32(&event.EUID, fields["euid"])
+	setUint32(&event.Ses, fields["ses"])
+	setString(&event.TTY, fields["tty"])
+	setString(&event.Comm, fields["comm"])
+	setString(&event.Exe, fields["exe"])
+	setString(&event.Subj, fields["subj"])
+	setString(&event.Key, fields["key"])
+	setString(&event.Op, fields["op"])
+	setString(&event.Acct, fields["acct"])
+	setString(&event.Hostname, fields["hostname"])
+	setString(&event.Addr, fields["addr"])
+	setString(&event.Terminal, fields["terminal"])
+	setString(&event.Res, fields["res"])
+}
+
+// setArgs sets the execve arguments, long arguments are split in chunks (ie `a1_len=20000 a1[0]=... a1[1]=...`)
+func (event *Event) setArgs(fields map[string]string) {
+	if event.Args != nil {
+		return
+	}
+	argc, err := strconv.ParseInt(fields["argc"], 10, 32)
+	if err != nil || argc < 0 {
+		return
+	}
+	n := int32(argc)
+	event.Argc = &n
+	// argc is not trusted to size the arguments, only the arguments present in the record are read
+	var args []string
+	for i := 0; i < int(argc); i++ {
+		name := "a" + strconv.Itoa(i)
+		if arg, ok := fields[name]; ok {
+			args = append(args, arg)
+			continue
+		}
+		var arg strings.Builder
+		j := 0
+		for ; ; j++ {
+			chunk, ok := fields[name+"["+strconv.Itoa(j)+"]"]
+			if !ok {
+				break
+			}
+			arg.WriteString(chunk)
+		}
+		if j == 0 { // no more arguments in the record
+			break
+		}
+		args = append(args, arg.String())
+	}
+	if len(args) > 0 {
+		commandLine := strings.Join(args, " ")
+		event.Args = args
+		event.CommandLine = &commandLine
+	}
+}
+
+// isValue checks if a field has a value, auditd logs missing values as `?` or `(null)`
+func isValue(value string) bool {
+	return value != "" && value != "?" && value != "(null)"
+}
+
+func setString(dst **string, value string) {
+	if *dst == nil && isValue(value) {
+		*dst = &value
+	}
+}
+
+func setInt64(dst **int64, value string) {
+	if *dst != nil {
+		return
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		*dst = &n
+	}
+}
+
+func setUint32(dst **uint32, value string) {
+	if *dst != nil {
+		return
+	}
+	if n, err := strconv.ParseUint(value, 10, 32); err == nil {
+		u := uint32(n)
+		*dst = &u
+	}
+}
+
+// usernameFields are the fields added by the ENRICHED log format with the names of user IDs
+var usernameFields = []string{"AUID", "UID", "EUID"}
+
+func (event *Event) updatePantherFields(p *EventParser) {
+	event.SetCoreFields(p.LogType(), event.Timestamp, event)
+
+	for _, value := range []*string{event.Addr, event.Hostname} {
+		if value != nil && !event.AppendAnyIPAddress(*value) {
+			event.AppendAnyDomainNamePtrs(value)
+		}
+	}
+	event.AppendAnyIPAddressPtr(event.SocketAddress)
+	event.AppendAnyUsernamePtrs(event.Acct)
+	for i := range event.Records {
+		for _, field := range usernameFields {
+			if name := event.Records[i].Fields[field]; isValue(name) && name != "unset" {
+				event.AppendAnyUsernames(name)
+			}
+		}
+	}
+}
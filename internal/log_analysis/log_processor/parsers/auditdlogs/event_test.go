@@ -0,0 +1,236 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestEventExecve(t *testing.T) {
+	// nolint:lll
+	log := strings.Join([]string{
+		"type=SYSCALL msg=audit(1591013382.123:1001): arch=c000003e syscall=59 success=yes exit=0 a0=55d2 a1=55d3 a2=55d4 a3=0 items=2 ppid=100 pid=101 auid=1000 uid=0 gid=0 euid=0 tty=pts0 ses=1 comm=\"curl\" exe=\"/usr/bin/curl\" subj=unconfined key=\"exec\"\x1dARCH=x86_64 SYSCALL=execve AUID=\"jdoe\" UID=\"root\" GID=\"root\" EUID=\"root\"",
+		`type=EXECVE msg=audit(1591013382.123:1001): argc=3 a0="curl" a1="-o" a2=2F746D702F6D792066696C65`,
+		`type=CWD msg=audit(1591013382.123:1001): cwd="/root"`,
+		`type=PATH msg=audit(1591013382.123:1001): item=0 name="/usr/bin/curl" inode=1234 nametype=NORMAL`,
+		`type=PATH msg=audit(1591013382.123:1001): item=1 name=(null) inode=5678 nametype=NORMAL`,
+		`type=PROCTITLE msg=audit(1591013382.123:1001): proctitle=6375726C002D6F002F746D702F6D792066696C65`,
+		`type=EOE msg=audit(1591013382.123:1001): `,
+	}, "\n")
+
+	tm := time.Date(2020, 6, 1, 12, 9, 42, 123000000, time.UTC)
+	event := &Event{
+		Timestamp: (*timestamp.RFC3339)(&tm),
+		Serial:    aws.Uint64(1001),
+		Type:      aws.String("SYSCALL"),
+		Records: []Record{
+			{
+				Type: aws.String("SYSCALL"),
+				Fields: map[string]string{
+					"arch": "c000003e", "syscall": "59", "success": "yes", "exit": "0",
+					"a0": "55d2", "a1": "55d3", "a2": "55d4", "a3": "0", "items": "2",
+					"ppid": "100", "pid": "101", "auid": "1000", "uid": "0", "gid": "0", "euid": "0",
+					"tty": "pts0", "ses": "1", "comm": "curl", "exe": "/usr/bin/curl", "subj": "unconfined", "key": "exec",
+					"ARCH": "x86_64", "SYSCALL": "execve", "AUID": "jdoe", "UID": "root", "GID": "root", "EUID": "root",
+				},
+			},
+			{
+				Type:   aws.String("EXECVE"),
+				Fields: map[string]string{"argc": "3", "a0": "curl", "a1": "-o", "a2": "/tmp/my file"},
+			},
+			{
+				Type:   aws.String("CWD"),
+				Fields: map[string]string{"cwd": "/root"},
+			},
+			{
+				Type:   aws.String("PATH"),
+				Fields: map[string]string{"item": "0", "name": "/usr/bin/curl", "inode": "1234", "nametype": "NORMAL"},
+			},
+			{
+				Type:   aws.String("PATH"),
+				Fields: map[string]string{"item": "1", "name": "(null)", "inode": "5678", "nametype": "NORMAL"},
+			},
+			{
+				Type:   aws.String("PROCTITLE"),
+				Fields: map[string]string{"proctitle": "curl -o /tmp/my file"},
+			},
+		},
+		Arch:        aws.String("c000003e"),
+		Syscall:     aws.String("59"),
+		Success:     aws.Bool(true),
+		Exit:        aws.Int64(0),
+		PPID:        aws.Int64(100),
+		PID:         aws.Int64(101),
+		AUID:        aws.Uint32(1000),
+		UID:         aws.Uint32(0),
+		GID:         aws.Uint32(0),
+		EUID:        aws.Uint32(0),
+		Ses:         aws.Uint32(1),
+		TTY:         aws.String("pts0"),
+		Comm:        aws.String("curl"),
+		Exe:         aws.String("/usr/bin/curl"),
+		Subj:        aws.String("unconfined"),
+		Key:         aws.String("exec"),
+		Argc:        aws.Int32(3),
+		Args:        []string{"curl", "-o", "/tmp/my file"},
+		CommandLine: aws.String("curl -o /tmp/my file"),
+		CWD:         aws.String("/root"),
+		Paths:       []string{"/usr/bin/curl"},
+		Proctitle:   aws.String("curl -o /tmp/my file"),
+	}
+	event.SetCoreFields(TypeEvent, event.Timestamp, event)
+	event.AppendAnyUsernames("jdoe", "root")
+
+	testutil.CheckPantherParser(t, log, &EventParser{}, &event.PantherLog)
+}
+
+func TestEventConnect(t *testing.T) {
+	// nolint:lll
+	log := strings.Join([]string{
+		`node=web-1 type=SYSCALL msg=audit(1591013390.5:1010): arch=c000003e syscall=42 success=no exit=-115 a0=3 a1=7ffd a2=10 a3=0 items=0 ppid=1 pid=300 auid=4294967295 uid=33 gid=33 euid=33 tty=(none) ses=4294967295 comm="nc" exe="/bin/nc.openbsd" key="network"`,
+		`node=web-1 type=SOCKADDR msg=audit(1591013390.5:1010): saddr=02000050CB00710A0000000000000000`,
+	}, "\n")
+
+	tm := time.Date(2020, 6, 1, 12, 9, 50, 500000000, time.UTC)
+	event := &Event{
+		Timestamp: (*timestamp.RFC3339)(&tm),
+		Serial:    aws.Uint64(1010),
+		Node:      aws.String("web-1"),
+		Type:      aws.String("SYSCALL"),
+		Records: []Record{
+			{
+				Type: aws.String("SYSCALL"),
+				Fields: map[string]string{
+					"arch": "c000003e", "syscall": "42", "success": "no", "exit": "-115",
+					"a0": "3", "a1": "7ffd", "a2": "10", "a3": "0", "items": "0",
+					"ppid": "1", "pid": "300", "auid": "4294967295", "uid": "33", "gid": "33", "euid": "33",
+					"tty": "(none)", "ses": "4294967295", "comm": "nc", "exe": "/bin/nc.openbsd", "key": "network",
+				},
+			},
+			{
+				Type:   aws.String("SOCKADDR"),
+				Fields: map[string]string{"saddr": "02000050CB00710A0000000000000000"},
+			},
+		},
+		Arch:          aws.String("c000003e"),
+		Syscall:       aws.String("42"),
+		Success:       aws.Bool(false),
+		Exit:          aws.Int64(-115),
+		PPID:          aws.Int64(1),
+		PID:           aws.Int64(300),
+		AUID:          aws.Uint32(4294967295),
+		UID:           aws.Uint32(33),
+		GID:           aws.Uint32(33),
+		EUID:          aws.Uint32(33),
+		Ses:           aws.Uint32(4294967295),
+		TTY:           aws.String("(none)"),
+		Comm:          aws.String("nc"),
+		Exe:           aws.String("/bin/nc.openbsd"),
+		Key:           aws.String("network"),
+		SocketAddress: aws.String("203.0.113.10"),
+		SocketPort:    aws.Uint16(80),
+	}
+	event.SetCoreFields(TypeEvent, event.Timestamp, event)
+	event.AppendAnyIPAddress("203.0.113.10")
+
+	testutil.CheckPantherParser(t, log, &EventParser{}, &event.PantherLog)
+}
+
+func TestEventUserLogin(t *testing.T) {
+	// nolint:lll
+	log := `type=USER_LOGIN msg=audit(1591013383.000:1002): pid=200 uid=0 auid=1000 ses=2 msg='op=login id=1000 exe="/usr/sbin/sshd" hostname=? addr=203.0.113.10 terminal=/dev/pts/1 res=success'`
+
+	tm := time.Date(2020, 6, 1, 12, 9, 43, 0, time.UTC)
+	event := &Event{
+		Timestamp: (*timestamp.RFC3339)(&tm),
+		Serial:    aws.Uint64(1002),
+		Type:      aws.String("USER_LOGIN"),
+		Records: []Record{
+			{
+				Type: aws.String("USER_LOGIN"),
+				Fields: map[string]string{
+					"pid": "200", "uid": "0", "auid": "1000", "ses": "2", "op": "login", "id": "1000",
+					"exe": "/usr/sbin/sshd", "hostname": "?", "addr": "203.0.113.10", "terminal": "/dev/pts/1", "res": "success",
+				},
+			},
+		},
+		PID:      aws.Int64(200),
+		AUID:     aws.Uint32(1000),
+		UID:      aws.Uint32(0),
+		Ses:      aws.Uint32(2),
+		Exe:      aws.String("/usr/sbin/sshd"),
+		Op:       aws.String("login"),
+		Addr:     aws.String("203.0.113.10"),
+		Terminal: aws.String("/dev/pts/1"),
+		Res:      aws.String("success"),
+	}
+	event.SetCoreFields(TypeEvent, event.Timestamp, event)
+	event.AppendAnyIPAddress("203.0.113.10")
+
+	testutil.CheckPantherParser(t, log, &EventParser{}, &event.PantherLog)
+}
+
+func TestEventLongArguments(t *testing.T) {
+	// nolint:lll
+	log := `type=EXECVE msg=audit(1591013382.123:1001): argc=3 a0="sh" a1="-c" a1_len=10 a2[0]=6563686F2068 a2[1]=656C6C6F`
+	event, err := parseEvent(log)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sh", "-c", "echo hello"}, event.Args)
+	require.Equal(t, "sh -c echo hello", *event.CommandLine)
+}
+
+func TestEventInvalidArgc(t *testing.T) {
+	// negative argc is ignored
+	event, err := parseEvent(`type=EXECVE msg=audit(1591013382.123:1001): argc=-1 a0="id"`)
+	require.NoError(t, err)
+	require.Nil(t, event.Argc)
+	require.Nil(t, event.Args)
+
+	// only the arguments present in the record are read
+	event, err = parseEvent(`type=EXECVE msg=audit(1591013382.123:1001): argc=2000000000 a0="ls" a1="-l"`)
+	require.NoError(t, err)
+	require.Equal(t, int32(2000000000), *event.Argc)
+	require.Equal(t, []string{"ls", "-l"}, event.Args)
+	require.Equal(t, "ls -l", *event.CommandLine)
+}
+
+func TestEventInvalid(t *testing.T) {
+	parser := (&EventParser{}).New()
+	// records of different events
+	_, err := parser.Parse("type=CWD msg=audit(1591013382.123:1001): cwd=\"/\"\ntype=CWD msg=audit(1591013382.123:1002): cwd=\"/\"")
+	require.Error(t, err)
+	// only the end of event record
+	_, err = parser.Parse(`type=EOE msg=audit(1591013382.123:1001): `)
+	require.Error(t, err)
+	_, err = parser.Parse(`Jun  1 12:09:42 web-1 sshd[200]: Accepted publickey for jdoe`)
+	require.Error(t, err)
+}
+
+func TestEventLogType(t *testing.T) {
+	parser := &EventParser{}
+	require.Equal(t, "Auditd.Event", parser.LogType())
+}
@@ -0,0 +1,122 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+)
+
+// Framing groups the records of audit.log into events.
+// auditd writes the records of an event (ie SYSCALL, EXECVE, CWD, PATH and PROCTITLE) sharing the same
+// `msg=audit(timestamp:serial)` ID. The records of concurrent events can be interleaved, so records are grouped
+// by their ID until the EOE record of multi-record events, or until maxInterleavedLines lines of other events
+// are read after the last record of an event. Events are read in the order of their first record.
+// Lines that are not audit records are read as events on their own.
+type Framing struct{}
+
+var _ common.Framing = Framing{}
+
+// how many lines of other events can follow the last record of an event before it is complete
+const maxInterleavedLines = 16
+
+func (Framing) NewEventReader(r io.Reader) common.EventReader {
+	return &eventReader{
+		lines: common.NewlineFraming{}.NewEventReader(r),
+	}
+}
+
+type eventReader struct {
+	lines    common.EventReader
+	events   []*pendingEvent // in the order of their first record
+	numLines int
+	eof      bool
+}
+
+type pendingEvent struct {
+	id       string
+	records  []string
+	lastLine int // the line number of the last record
+	complete bool
+}
+
+func (r *eventReader) ReadEvent() (string, error) {
+	for {
+		if len(r.events) > 0 {
+			event := r.events[0]
+			if event.complete || r.eof || r.numLines-event.lastLine >= maxInterleavedLines {
+				r.events[0] = nil
+				r.events = r.events[1:]
+				return strings.Join(event.records, "\n"), nil
+			}
+		} else if r.eof {
+			return "", io.EOF
+		}
+		if err := r.readLine(); err != nil {
+			return "", err
+		}
+	}
+}
+
+// readLine adds the next line of the stream to its event
+func (r *eventReader) readLine() error {
+	line, err := r.lines.ReadEvent()
+	if err == io.EOF {
+		r.eof = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	r.numLines++
+	header, _, err := parseRecordHeader(line)
+	if err != nil || header.EventID == "" {
+		r.events = append(r.events, &pendingEvent{
+			records:  []string{line},
+			lastLine: r.numLines,
+			complete: true,
+		})
+		return nil
+	}
+	event := r.findPending(header.EventID)
+	if event == nil {
+		event = &pendingEvent{id: header.EventID}
+		r.events = append(r.events, event)
+	}
+	event.records = append(event.records, line)
+	event.lastLine = r.numLines
+	event.complete = header.Type == typeEOE
+	return nil
+}
+
+// findPending returns the incomplete event with the given ID
+func (r *eventReader) findPending(id string) *pendingEvent {
+	for _, event := range r.events {
+		if !event.complete && event.id == id {
+			return event
+		}
+	}
+	return nil
+}
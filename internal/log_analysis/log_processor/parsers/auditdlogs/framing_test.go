@@ -0,0 +1,92 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFraming(t *testing.T) {
+	// nolint:lll
+	input := `type=SYSCALL msg=audit(1591013382.123:1001): arch=c000003e syscall=59 success=yes exit=0 a0=55d2 a1=55d3 a2=55d4 a3=0 items=2 ppid=100 pid=101 auid=1000 uid=0 gid=0 euid=0 suid=0 fsuid=0 egid=0 sgid=0 fsgid=0 tty=pts0 ses=1 comm="id" exe="/usr/bin/id" key="exec"
+type=EXECVE msg=audit(1591013382.123:1001): argc=1 a0="id"
+type=EOE msg=audit(1591013382.123:1001): 
+type=USER_LOGIN msg=audit(1591013383.000:1002): pid=200 uid=0 auid=1000 ses=2 msg='op=login acct="jdoe" exe="/usr/sbin/sshd" hostname=? addr=203.0.113.10 terminal=ssh res=success'
+type=USER_START msg=audit(1591013383.001:1003): pid=200 uid=0 auid=1000 ses=2 msg='op=PAM:session_open acct="jdoe" exe="/usr/sbin/sshd" hostname=203.0.113.10 addr=203.0.113.10 terminal=ssh res=success'
+not an audit record
+type=CWD msg=audit(1591013384.000:1004): cwd="/root"
+`
+	events := readEvents(t, input)
+	lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+	expect := []string{
+		strings.Join(lines[0:3], "\n"),
+		lines[3],
+		lines[4],
+		lines[5],
+		lines[6],
+	}
+	require.Equal(t, expect, events)
+}
+
+func TestFramingInterleaved(t *testing.T) {
+	input := `type=SYSCALL msg=audit(1591013382.123:2001): arch=c000003e syscall=59 success=yes exit=0 items=1 ppid=100 pid=101 comm="id" exe="/usr/bin/id"
+type=SYSCALL msg=audit(1591013382.124:2002): arch=c000003e syscall=59 success=yes exit=0 items=1 ppid=100 pid=102 comm="ls" exe="/usr/bin/ls"
+type=EXECVE msg=audit(1591013382.123:2001): argc=1 a0="id"
+type=USER_LOGIN msg=audit(1591013382.125:2003): pid=200 uid=0 auid=1000 ses=2 msg='op=login acct="jdoe" res=success'
+type=EXECVE msg=audit(1591013382.124:2002): argc=1 a0="ls"
+type=EOE msg=audit(1591013382.124:2002): 
+type=EOE msg=audit(1591013382.123:2001): 
+`
+	lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+	expect := []string{
+		strings.Join([]string{lines[0], lines[2], lines[6]}, "\n"),
+		strings.Join([]string{lines[1], lines[4], lines[5]}, "\n"),
+		lines[3],
+	}
+	require.Equal(t, expect, readEvents(t, input))
+
+	// events without an EOE record are complete once enough lines of other events are read
+	var b strings.Builder
+	b.WriteString("type=SYSCALL msg=audit(1591013382.123:3000): syscall=59\n")
+	for i := 0; i < maxInterleavedLines; i++ {
+		b.WriteString("not an audit record\n")
+	}
+	b.WriteString("type=CWD msg=audit(1591013382.123:3000): cwd=\"/root\"\n")
+	events := readEvents(t, b.String())
+	require.Len(t, events, maxInterleavedLines+2)
+	require.Equal(t, "type=SYSCALL msg=audit(1591013382.123:3000): syscall=59", events[0])
+	require.Equal(t, `type=CWD msg=audit(1591013382.123:3000): cwd="/root"`, events[len(events)-1])
+}
+
+func readEvents(t *testing.T, input string) []string {
+	events := []string{}
+	r := Framing{}.NewEventReader(strings.NewReader(input))
+	for {
+		event, err := r.ReadEvent()
+		if err == io.EOF {
+			return events
+		}
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+}
@@ -0,0 +1,238 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// typeEOE is the record type marking the end of a multi-record event
+	typeEOE = "EOE"
+	// enrichedSeparator separates the raw fields from the fields added by `log_format = ENRICHED`
+	enrichedSeparator = '\x1d'
+	// userMessageField holds the fields of records sent by user space programs (ie USER_LOGIN) in single quotes
+	userMessageField = "msg"
+)
+
+// recordHeaderRegex matches the start of an audit record, ie `type=SYSCALL msg=audit(1364481363.243:24287): `
+// Records forwarded by audispd are prefixed with the name of the node.
+var recordHeaderRegex = regexp.MustCompile(`^(?:node=(\S+) )?type=(\S+) msg=audit\(((\d+)(?:\.(\d{1,9}))?:(\d+))\)\s?:\s?`)
+
+// encodedFields are the fields auditd hex encodes when their value contains spaces, quotes or control characters.
+// Unquoted values of these fields are decoded, other unquoted values (ie syscall arguments) are hex numbers.
+var encodedFields = map[string]bool{
+	"acct":      true,
+	"cmd":       true,
+	"comm":      true,
+	"cwd":       true,
+	"data":      true,
+	"dir":       true,
+	"exe":       true,
+	"file":      true,
+	"key":       true,
+	"name":      true,
+	"path":      true,
+	"proctitle": true,
+	"vm":        true,
+	"watch":     true,
+}
+
+// execveArgRegex matches the arguments of EXECVE records, long arguments are split in chunks (ie a1[0], a1[1])
+var execveArgRegex = regexp.MustCompile(`^a\d+(?:\[\d+\])?$`)
+
+// Record is a single line of an audit event
+type Record struct {
+	Type   *string           `json:"type" validate:"required" description:"The type of the record, ie SYSCALL, EXECVE or PATH."`
+	Fields map[string]string `json:"fields,omitempty" description:"The fields of the record with hex encoded values decoded. Uppercase fields are added by the ENRICHED log format."`
+}
+
+// recordHeader is the part of an audit record identifying the event it belongs to
+type recordHeader struct {
+	Node      string
+	Type      string
+	EventID   string
+	Timestamp time.Time
+	Serial    uint64
+}
+
+// parseRecordHeader returns the header of an audit record and the length of the line it spans
+func parseRecordHeader(line string) (*recordHeader, int, error) {
+	match := recordHeaderRegex.FindStringSubmatch(line)
+	if match == nil {
+		return nil, 0, errors.Errorf("invalid audit record %q", line)
+	}
+	sec, err := strconv.ParseInt(match[4], 10, 64)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid audit record timestamp %q", match[3])
+	}
+	var nsec int64
+	if frac := match[5]; frac != "" {
+		// scale the fraction to nanoseconds, ie `.243` is 243ms
+		nsec, _ = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+	}
+	serial, err := strconv.ParseUint(match[6], 10, 64)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid audit record serial %q", match[6])
+	}
+	return &recordHeader{
+		Node:      match[1],
+		Type:      match[2],
+		EventID:   match[3],
+		Timestamp: time.Unix(sec, nsec).UTC(),
+		Serial:    serial,
+	}, len(match[0]), nil
+}
+
+// parseRecord parses an audit record line
+func parseRecord(line string) (*recordHeader, *Record, error) {
+	header, n, err := parseRecordHeader(line)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := make(map[string]string)
+	body := line[n:]
+	if i := strings.IndexByte(body, enrichedSeparator); i != -1 {
+		parseFields(body[i+1:], fields, header.Type)
+		body = body[:i]
+	}
+	parseFields(body, fields, header.Type)
+	// records of user space programs hold their fields in a single quoted message
+	if msg, ok := fields[userMessageField]; ok {
+		delete(fields, userMessageField)
+		parseFields(msg, fields, header.Type)
+	}
+	record := &Record{
+		Type: &header.Type,
+	}
+	if len(fields) > 0 {
+		record.Fields = fields
+	}
+	return header, record, nil
+}
+
+// parseFields adds the `key=value` pairs of text to fields.
+// Values can be double quoted, single quoted, enclosed in braces or hex encoded.
+func parseFields(text string, fields map[string]string, recordType string) {
+	for {
+		text = strings.TrimLeft(text, " ")
+		if text == "" {
+			return
+		}
+		eq := strings.IndexByte(text, '=')
+		if eq == -1 {
+			return
+		}
+		key := text[:eq]
+		if sp := strings.LastIndexByte(key, ' '); sp != -1 {
+			// skip words without a value
+			key = key[sp+1:]
+		}
+		text = text[eq+1:]
+		var value string
+		value, text = readValue(text)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		} else if len(value) >= 2 && (value[0] == '\'' || value[0] == '{') {
+			value = strings.TrimSpace(value[1 : len(value)-1])
+		} else if isEncodedField(key, recordType) {
+			value = decodeHex(key, value)
+		}
+		if key != "" {
+			fields[key] = value
+		}
+	}
+}
+
+// readValue reads a field value returning it and the remaining text
+func readValue(text string) (string, string) {
+	end := ""
+	switch {
+	case strings.HasPrefix(text, `"`):
+		end = `"`
+	case strings.HasPrefix(text, `'`):
+		end = `'`
+	case strings.HasPrefix(text, `{`):
+		end = `}`
+	}
+	if end != "" {
+		if i := strings.Index(text[1:], end); i != -1 {
+			return text[:i+2], text[i+2:]
+		}
+	}
+	if i := strings.IndexByte(text, ' '); i != -1 {
+		return text[:i], text[i:]
+	}
+	return text, ""
+}
+
+func isEncodedField(key, recordType string) bool {
+	if encodedFields[key] {
+		return true
+	}
+	return recordType == "EXECVE" && execveArgRegex.MatchString(key)
+}
+
+// decodeHex decodes a hex encoded value, values that are not hex (ie `(null)`) are returned as is
+func decodeHex(key, value string) string {
+	if value == "" || len(value)%2 != 0 {
+		return value
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return value
+	}
+	if key == "proctitle" {
+		// the arguments of the process title are separated by NUL
+		return strings.TrimRight(strings.ReplaceAll(string(data), "\x00", " "), " ")
+	}
+	return string(data)
+}
+
+const (
+	familyInet  = 2
+	familyInet6 = 10
+)
+
+// decodeSockaddr decodes the address and port of the hex encoded `struct sockaddr` of SOCKADDR records
+func decodeSockaddr(saddr string) (string, uint16, bool) {
+	data, err := hex.DecodeString(saddr)
+	if err != nil || len(data) < 4 {
+		return "", 0, false
+	}
+	// the family is in host byte order, the port in network byte order
+	family := binary.LittleEndian.Uint16(data)
+	port := binary.BigEndian.Uint16(data[2:])
+	switch {
+	case family == familyInet && len(data) >= 8:
+		return net.IP(data[4:8]).String(), port, true
+	case family == familyInet6 && len(data) >= 24:
+		return net.IP(data[8:24]).String(), port, true
+	default:
+		return "", 0, false
+	}
+}
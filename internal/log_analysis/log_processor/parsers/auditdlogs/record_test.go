@@ -0,0 +1,114 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecord(t *testing.T) {
+	// nolint:lll
+	line := "node=web-1 type=SYSCALL msg=audit(1591013382.123:1001): arch=c000003e syscall=59 success=yes exit=0 a0=55d2 comm=6D7920636D64 exe=\"/usr/bin/id\" key=(null)\x1dARCH=x86_64 SYSCALL=execve AUID=\"jdoe\" UID=\"root\""
+	header, record, err := parseRecord(line)
+	require.NoError(t, err)
+	require.Equal(t, &recordHeader{
+		Node:      "web-1",
+		Type:      "SYSCALL",
+		EventID:   "1591013382.123:1001",
+		Timestamp: time.Date(2020, 6, 1, 12, 9, 42, 123000000, time.UTC),
+		Serial:    1001,
+	}, header)
+	require.Equal(t, "SYSCALL", *record.Type)
+	require.Equal(t, map[string]string{
+		"arch":    "c000003e",
+		"syscall": "59",
+		"success": "yes",
+		"exit":    "0",
+		"a0":      "55d2", // syscall arguments are hex numbers
+		"comm":    "my cmd",
+		"exe":     "/usr/bin/id",
+		"key":     "(null)",
+		"ARCH":    "x86_64",
+		"SYSCALL": "execve",
+		"AUID":    "jdoe",
+		"UID":     "root",
+	}, record.Fields)
+}
+
+func TestParseRecordUserMessage(t *testing.T) {
+	// nolint:lll
+	line := `type=USER_AUTH msg=audit(1591013383:1002) : pid=200 uid=0 auid=4294967295 ses=4294967295 msg='op=PAM:authentication grantors=? acct="jdoe" exe="/usr/sbin/sshd" hostname=203.0.113.10 addr=203.0.113.10 terminal=ssh res=failed'`
+	header, record, err := parseRecord(line)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 6, 1, 12, 9, 43, 0, time.UTC), header.Timestamp)
+	require.Equal(t, map[string]string{
+		"pid":      "200",
+		"uid":      "0",
+		"auid":     "4294967295",
+		"ses":      "4294967295",
+		"op":       "PAM:authentication",
+		"grantors": "?",
+		"acct":     "jdoe",
+		"exe":      "/usr/sbin/sshd",
+		"hostname": "203.0.113.10",
+		"addr":     "203.0.113.10",
+		"terminal": "ssh",
+		"res":      "failed",
+	}, record.Fields)
+}
+
+func TestParseRecordInvalid(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"type=SYSCALL arch=c000003e",
+		"msg=audit(1591013382.123:1001): type=SYSCALL",
+		`{"type":"SYSCALL"}`,
+	} {
+		_, _, err := parseRecord(line)
+		require.Error(t, err, line)
+	}
+}
+
+func TestDecodeHex(t *testing.T) {
+	require.Equal(t, "cat /etc/shadow", decodeHex("proctitle", "636174002F6574632F736861646F77"))
+	require.Equal(t, "/tmp/my dir", decodeHex("name", "2F746D702F6D7920646972"))
+	require.Equal(t, "(null)", decodeHex("name", "(null)"))
+	require.Equal(t, "abc", decodeHex("name", "abc"))
+}
+
+func TestDecodeSockaddr(t *testing.T) {
+	addr, port, ok := decodeSockaddr("02000050CB00710A0000000000000000")
+	require.True(t, ok)
+	require.Equal(t, "203.0.113.10", addr)
+	require.Equal(t, uint16(80), port)
+
+	addr, port, ok = decodeSockaddr("0A0001BB0000000020010DB800000000000000000000000100000000")
+	require.True(t, ok)
+	require.Equal(t, "2001:db8::1", addr)
+	require.Equal(t, uint16(443), port)
+
+	// unix sockets have no address
+	_, _, ok = decodeSockaddr("01002F72756E2F73797374656D642F6A6F75726E616C2F736F636B657400")
+	require.False(t, ok)
+	_, _, ok = decodeSockaddr("zz")
+	require.False(t, ok)
+}
@@ -117,4 +117,5 @@ func (event *AuroraMySQLAudit) updatePantherFields(p *AuroraMySQLAuditParser) {
 	event.SetCoreFields(p.LogType(), event.Timestamp, event)
 	event.AppendAnyIPAddressPtr(event.Host)
 	event.AppendAnyDomainNamePtrs(event.ServerHost)
+	event.AppendAnyUsernamePtrs(event.Username)
 }
@@ -25,14 +25,19 @@ import (
 )
 
 const (
-	TypeALB               = "AWS.ALB"
-	TypeAuroraMySQLAudit  = `AWS.AuroraMySQLAudit`
-	TypeCloudTrail        = `AWS.CloudTrail`
-	TypeCloudTrailDigest  = "AWS.CloudTrailDigest"
-	TypeCloudTrailInsight = "AWS.CloudTrailInsight"
-	TypeGuardDuty         = "AWS.GuardDuty"
-	TypeS3ServerAccess    = "AWS.S3ServerAccess"
-	TypeVPCFlow           = "AWS.VPCFlow"
+	TypeALB                  = "AWS.ALB"
+	TypeAuroraMySQLAudit     = `AWS.AuroraMySQLAudit`
+	TypeCloudFront           = "AWS.CloudFront"
+	TypeCloudTrail           = `AWS.CloudTrail`
+	TypeCloudTrailDigest     = "AWS.CloudTrailDigest"
+	TypeCloudTrailInsight    = "AWS.CloudTrailInsight"
+	TypeConfigHistory        = "AWS.ConfigHistory"
+	TypeGuardDuty            = "AWS.GuardDuty"
+	TypeRoute53ResolverQuery = "AWS.Route53ResolverQuery"
+	TypeS3ServerAccess       = "AWS.S3ServerAccess"
+	TypeSecurityHubFinding   = "AWS.SecurityHubFinding"
+	TypeVPCFlow              = "AWS.VPCFlow"
+	TypeWAFWebACL            = "AWS.WAFWebACL"
 )
 
 // nolint:lll
@@ -52,6 +57,13 @@ func init() {
 			Schema:       AuroraMySQLAudit{},
 			NewParser:    parsers.AdapterFactory(&AuroraMySQLAuditParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeCloudFront,
+			Description:  `CloudFront standard logs contain detailed information about every user request that CloudFront receives.`,
+			ReferenceURL: `https://docs.aws.amazon.com/AmazonCloudFront/latest/DeveloperGuide/AccessLogs.html#LogFileFormat`,
+			Schema:       CloudFront{},
+			NewParser:    parsers.AdapterFactory(&CloudFrontParser{}),
+		},
 		logtypes.Config{
 			Name:         TypeCloudTrail,
 			Description:  `AWSCloudTrail represents the content of a CloudTrail S3 object.`,
@@ -73,6 +85,13 @@ func init() {
 			Schema:       CloudTrailInsight{},
 			NewParser:    parsers.AdapterFactory(&CloudTrailInsightParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeConfigHistory,
+			Description:  `AWS Config configuration history files contain one configuration item for each change to a recorded resource.`,
+			ReferenceURL: `https://docs.aws.amazon.com/config/latest/developerguide/config-item-table.html`,
+			Schema:       ConfigHistory{},
+			NewParser:    parsers.AdapterFactory(&ConfigHistoryParser{}),
+		},
 		logtypes.Config{
 			Name:         TypeGuardDuty,
 			Description:  `Amazon GuardDuty is a threat detection service that continuously monitors for malicious activity and unauthorized behavior inside AWS Accounts.`,
@@ -80,6 +99,13 @@ func init() {
 			Schema:       GuardDuty{},
 			NewParser:    parsers.AdapterFactory(&GuardDutyParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeRoute53ResolverQuery,
+			Description:  `Route 53 Resolver query logs contain the DNS queries that originate in your VPCs.`,
+			ReferenceURL: `https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/resolver-query-logs-format.html`,
+			Schema:       Route53ResolverQuery{},
+			NewParser:    parsers.AdapterFactory(&Route53ResolverQueryParser{}),
+		},
 		logtypes.Config{
 			Name:         TypeS3ServerAccess,
 			Description:  `S3ServerAccess is an AWS S3 Access Log.`,
@@ -87,6 +113,13 @@ func init() {
 			Schema:       S3ServerAccess{},
 			NewParser:    parsers.AdapterFactory(&S3ServerAccessParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeSecurityHubFinding,
+			Description:  `AWS Security Hub findings in the AWS Security Finding Format (ASFF), as delivered by EventBridge.`,
+			ReferenceURL: `https://docs.aws.amazon.com/securityhub/latest/userguide/securityhub-findings-format.html`,
+			Schema:       SecurityHubFinding{},
+			NewParser:    parsers.AdapterFactory(&SecurityHubFindingParser{}),
+		},
 		logtypes.Config{
 			Name:         TypeVPCFlow,
 			Description:  `VPCFlow is a VPC NetFlow log, which is a layer 3 representation of network traffic in EC2.`,
@@ -94,5 +127,12 @@ func init() {
 			Schema:       VPCFlow{},
 			NewParser:    parsers.AdapterFactory(&VPCFlowParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeWAFWebACL,
+			Description:  `WAF web ACL logs contain information about the web requests inspected by AWS WAF and the rules they matched.`,
+			ReferenceURL: `https://docs.aws.amazon.com/waf/latest/developerguide/logging-fields.html`,
+			Schema:       WAFWebACL{},
+			NewParser:    parsers.AdapterFactory(&WAFWebACLParser{}),
+		},
 	)
 }
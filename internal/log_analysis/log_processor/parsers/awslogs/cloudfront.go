@@ -0,0 +1,292 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type CloudFront struct {
+	Timestamp              *timestamp.RFC3339 `json:"timestamp" validate:"required" description:"The date and time on which the event occurred (UTC)."`
+	EdgeLocation           *string            `json:"edgeLocation,omitempty" description:"The edge location that served the request. Each edge location is identified by a three-letter code and an arbitrarily assigned number."`
+	BytesSent              *int64             `json:"bytesSent,omitempty" description:"The total number of bytes that CloudFront served to the viewer in response to the request, including headers."`
+	ClientIP               *string            `json:"clientIp,omitempty" description:"The IP address of the viewer that made the request."`
+	Method                 *string            `json:"method,omitempty" description:"The HTTP request method."`
+	Host                   *string            `json:"host,omitempty" description:"The domain name of the CloudFront distribution."`
+	URIStem                *string            `json:"uriStem,omitempty" description:"The portion of the request URL that identifies the path and object."`
+	Status                 *int               `json:"status,omitempty" description:"The HTTP status code of the response, or 000 if the viewer closed the connection before CloudFront could respond."`
+	Referer                *string            `json:"referer,omitempty" description:"The value of the Referer header in the request."`
+	UserAgent              *string            `json:"userAgent,omitempty" description:"The value of the User-Agent header in the request."`
+	URIQuery               *string            `json:"uriQuery,omitempty" description:"The query string portion of the request URL, if any."`
+	Cookie                 *string            `json:"cookie,omitempty" description:"The Cookie header in the request, including name-value pairs and the associated attributes."`
+	EdgeResultType         *string            `json:"edgeResultType,omitempty" description:"How the server classified the response after the last byte left the server (Hit, RefreshHit, Miss, LimitExceeded, CapacityExceeded, Error or Redirect)."`
+	EdgeRequestID          *string            `json:"edgeRequestId,omitempty" description:"An opaque string that uniquely identifies a request."`
+	HostHeader             *string            `json:"hostHeader,omitempty" description:"The value that the viewer included in the Host header of the request."`
+	Protocol               *string            `json:"protocol,omitempty" description:"The protocol of the viewer request (http, https, ws or wss)."`
+	BytesReceived          *int64             `json:"bytesReceived,omitempty" description:"The total number of bytes of data that the viewer included in the request, including headers."`
+	TimeTaken              *float64           `json:"timeTaken,omitempty" description:"The number of seconds (to the thousandth of a second) between the time the server receives the viewer's request and the time the server writes the last byte of the response."`
+	ForwardedFor           []string           `json:"forwardedFor,omitempty" description:"The IP addresses of the origin of the request, if the viewer used an HTTP proxy or a load balancer to send the request."`
+	SSLProtocol            *string            `json:"sslProtocol,omitempty" description:"The SSL/TLS protocol that the viewer and server negotiated for transmitting the request and response, for HTTPS requests."`
+	SSLCipher              *string            `json:"sslCipher,omitempty" description:"The SSL/TLS cipher that the viewer and server negotiated for encrypting the request and response, for HTTPS requests."`
+	EdgeResponseResultType *string            `json:"edgeResponseResultType,omitempty" description:"How the server classified the response just before returning the response to the viewer."`
+	ProtocolVersion        *string            `json:"protocolVersion,omitempty" description:"The HTTP version that the viewer specified in the request."`
+	FLEStatus              *string            `json:"fleStatus,omitempty" description:"When field-level encryption is configured for a distribution, a code that indicates whether the request body was successfully processed."`
+	FLEEncryptedFields     *int               `json:"fleEncryptedFields,omitempty" description:"The number of field-level encryption fields that the server encrypted and forwarded to the origin."`
+	ClientPort             *int               `json:"clientPort,omitempty" description:"The port number of the request from the viewer."`
+	TimeToFirstByte        *float64           `json:"timeToFirstByte,omitempty" description:"The number of seconds between receiving the request and writing the first byte of the response, as measured on the server."`
+	EdgeDetailedResultType *string            `json:"edgeDetailedResultType,omitempty" description:"A more detailed classification of the response, the same value as edgeResultType unless it is Error."`
+	ContentType            *string            `json:"contentType,omitempty" description:"The value of the HTTP Content-Type header of the response."`
+	ContentLength          *int64             `json:"contentLength,omitempty" description:"The value of the HTTP Content-Length header of the response."`
+	RangeStart             *int64             `json:"rangeStart,omitempty" description:"When the response contains the HTTP Content-Range header, the range start value."`
+	RangeEnd               *int64             `json:"rangeEnd,omitempty" description:"When the response contains the HTTP Content-Range header, the range end value."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// CloudFrontParser parses AWS CloudFront standard (access) logs
+type CloudFrontParser struct {
+	columns []string // the W3C field names of the file, in column order
+}
+
+var _ parsers.LogParser = (*CloudFrontParser)(nil)
+
+func (p *CloudFrontParser) New() parsers.LogParser {
+	return &CloudFrontParser{}
+}
+
+const (
+	cloudFrontVersionDirective = "#Version:"
+	cloudFrontFieldsDirective  = "#Fields:"
+	cloudFrontTimestampLayout  = "2006-01-02 15:04:05"
+
+	cloudFrontDate                   = "date"
+	cloudFrontTime                   = "time"
+	cloudFrontEdgeLocation           = "x-edge-location"
+	cloudFrontBytesSent              = "sc-bytes"
+	cloudFrontClientIP               = "c-ip"
+	cloudFrontMethod                 = "cs-method"
+	cloudFrontHost                   = "cs(Host)"
+	cloudFrontURIStem                = "cs-uri-stem"
+	cloudFrontStatus                 = "sc-status"
+	cloudFrontReferer                = "cs(Referer)"
+	cloudFrontUserAgent              = "cs(User-Agent)"
+	cloudFrontURIQuery               = "cs-uri-query"
+	cloudFrontCookie                 = "cs(Cookie)"
+	cloudFrontEdgeResultType         = "x-edge-result-type"
+	cloudFrontEdgeRequestID          = "x-edge-request-id"
+	cloudFrontHostHeader             = "x-host-header"
+	cloudFrontProtocol               = "cs-protocol"
+	cloudFrontBytesReceived          = "cs-bytes"
+	cloudFrontTimeTaken              = "time-taken"
+	cloudFrontForwardedFor           = "x-forwarded-for"
+	cloudFrontSSLProtocol            = "ssl-protocol"
+	cloudFrontSSLCipher              = "ssl-cipher"
+	cloudFrontEdgeResponseResultType = "x-edge-response-result-type"
+	cloudFrontProtocolVersion        = "cs-protocol-version"
+	cloudFrontFLEStatus              = "fle-status"
+	cloudFrontFLEEncryptedFields     = "fle-encrypted-fields"
+	cloudFrontClientPort             = "c-port"
+	cloudFrontTimeToFirstByte        = "time-to-first-byte"
+	cloudFrontEdgeDetailedResultType = "x-edge-detailed-result-type"
+	cloudFrontContentType            = "sc-content-type"
+	cloudFrontContentLength          = "sc-content-len"
+	cloudFrontRangeStart             = "sc-range-start"
+	cloudFrontRangeEnd               = "sc-range-end"
+)
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *CloudFrontParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	if strings.HasPrefix(log, cloudFrontVersionDirective) {
+		return []*parsers.PantherLog{}, nil
+	}
+	if strings.HasPrefix(log, cloudFrontFieldsDirective) {
+		if !p.setColumns(log) {
+			return nil, errors.New("invalid header")
+		}
+		return []*parsers.PantherLog{}, nil
+	}
+	if p.columns == nil { // the #Fields directive must be first
+		return nil, errors.New("invalid header")
+	}
+
+	record := strings.Split(log, "\t")
+	if len(record) != len(p.columns) {
+		return nil, errors.New("wrong number of columns")
+	}
+
+	event, err := p.populateEvent(record)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *CloudFrontParser) LogType() string {
+	return TypeCloudFront
+}
+
+// setColumns reads the field names from the #Fields directive, checking that they are CloudFront fields
+func (p *CloudFrontParser) setColumns(log string) bool {
+	columns := strings.Fields(strings.TrimPrefix(log, cloudFrontFieldsDirective))
+	var hasDate, hasTime, hasEdgeLocation bool
+	for _, column := range columns {
+		switch column {
+		case cloudFrontDate:
+			hasDate = true
+		case cloudFrontTime:
+			hasTime = true
+		case cloudFrontEdgeLocation:
+			hasEdgeLocation = true
+		}
+	}
+	if !(hasDate && hasTime && hasEdgeLocation) {
+		return false
+	}
+	p.columns = columns
+	return true
+}
+
+func (p *CloudFrontParser) populateEvent(record []string) (*CloudFront, error) {
+	event := &CloudFront{}
+	var date, time string
+	for i, value := range record {
+		switch p.columns[i] {
+		case cloudFrontDate:
+			date = value
+		case cloudFrontTime:
+			time = value
+		case cloudFrontEdgeLocation:
+			event.EdgeLocation = parsers.CsvStringToPointer(value)
+		case cloudFrontBytesSent:
+			event.BytesSent = parsers.CsvStringToInt64Pointer(value)
+		case cloudFrontClientIP:
+			event.ClientIP = parsers.CsvStringToPointer(value)
+		case cloudFrontMethod:
+			event.Method = parsers.CsvStringToPointer(value)
+		case cloudFrontHost:
+			event.Host = parsers.CsvStringToPointer(value)
+		case cloudFrontURIStem:
+			event.URIStem = parsers.CsvStringToPointer(value)
+		case cloudFrontStatus:
+			event.Status = parsers.CsvStringToIntPointer(value)
+		case cloudFrontReferer:
+			event.Referer = parsers.CsvStringToPointer(value)
+		case cloudFrontUserAgent:
+			event.UserAgent = cloudFrontUnescape(value)
+		case cloudFrontURIQuery:
+			event.URIQuery = parsers.CsvStringToPointer(value)
+		case cloudFrontCookie:
+			event.Cookie = parsers.CsvStringToPointer(value)
+		case cloudFrontEdgeResultType:
+			event.EdgeResultType = parsers.CsvStringToPointer(value)
+		case cloudFrontEdgeRequestID:
+			event.EdgeRequestID = parsers.CsvStringToPointer(value)
+		case cloudFrontHostHeader:
+			event.HostHeader = parsers.CsvStringToPointer(value)
+		case cloudFrontProtocol:
+			event.Protocol = parsers.CsvStringToPointer(value)
+		case cloudFrontBytesReceived:
+			event.BytesReceived = parsers.CsvStringToInt64Pointer(value)
+		case cloudFrontTimeTaken:
+			event.TimeTaken = parsers.CsvStringToFloat64Pointer(value)
+		case cloudFrontForwardedFor:
+			event.ForwardedFor = cloudFrontForwardedForList(value)
+		case cloudFrontSSLProtocol:
+			event.SSLProtocol = parsers.CsvStringToPointer(value)
+		case cloudFrontSSLCipher:
+			event.SSLCipher = parsers.CsvStringToPointer(value)
+		case cloudFrontEdgeResponseResultType:
+			event.EdgeResponseResultType = parsers.CsvStringToPointer(value)
+		case cloudFrontProtocolVersion:
+			event.ProtocolVersion = parsers.CsvStringToPointer(value)
+		case cloudFrontFLEStatus:
+			event.FLEStatus = parsers.CsvStringToPointer(value)
+		case cloudFrontFLEEncryptedFields:
+			event.FLEEncryptedFields = parsers.CsvStringToIntPointer(value)
+		case cloudFrontClientPort:
+			event.ClientPort = parsers.CsvStringToIntPointer(value)
+		case cloudFrontTimeToFirstByte:
+			event.TimeToFirstByte = parsers.CsvStringToFloat64Pointer(value)
+		case cloudFrontEdgeDetailedResultType:
+			event.EdgeDetailedResultType = parsers.CsvStringToPointer(value)
+		case cloudFrontContentType:
+			event.ContentType = parsers.CsvStringToPointer(value)
+		case cloudFrontContentLength:
+			event.ContentLength = parsers.CsvStringToInt64Pointer(value)
+		case cloudFrontRangeStart:
+			event.RangeStart = parsers.CsvStringToInt64Pointer(value)
+		case cloudFrontRangeEnd:
+			event.RangeEnd = parsers.CsvStringToInt64Pointer(value)
+		}
+	}
+
+	eventTime, err := timestamp.Parse(cloudFrontTimestampLayout, date+" "+time)
+	if err != nil {
+		return nil, err
+	}
+	event.Timestamp = &eventTime
+	return event, nil
+}
+
+// cloudFrontUnescape decodes values that CloudFront URL-encodes (i.e. the user agent)
+func cloudFrontUnescape(value string) *string {
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+	return parsers.CsvStringToPointer(value)
+}
+
+// cloudFrontForwardedForList splits the comma separated list of IP addresses of the x-forwarded-for field
+func cloudFrontForwardedForList(value string) (ips []string) {
+	if value == "-" {
+		return nil
+	}
+	for _, ip := range strings.Split(value, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" && ip != "-" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+func (event *CloudFront) updatePantherFields(p *CloudFrontParser) {
+	event.SetCoreFields(p.LogType(), event.Timestamp, event)
+
+	event.AppendAnyIPAddressPtr(event.ClientIP)
+	for _, ip := range event.ForwardedFor {
+		event.AppendAnyIPAddress(ip)
+	}
+	event.AppendAnyDomainNamePtrs(event.Host, event.HostHeader)
+}
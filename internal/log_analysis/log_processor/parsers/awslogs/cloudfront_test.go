@@ -0,0 +1,140 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	cloudFrontVersionHeader = "#Version: 1.0"
+	//nolint:lll
+	cloudFrontFieldsHeader = "#Fields: date time x-edge-location sc-bytes c-ip cs-method cs(Host) cs-uri-stem sc-status cs(Referer) cs(User-Agent) cs-uri-query cs(Cookie) x-edge-result-type x-edge-request-id x-host-header cs-protocol cs-bytes time-taken x-forwarded-for ssl-protocol ssl-cipher x-edge-response-result-type cs-protocol-version fle-status fle-encrypted-fields c-port time-to-first-byte x-edge-detailed-result-type sc-content-type sc-content-len sc-range-start sc-range-end"
+)
+
+func TestCloudFront(t *testing.T) {
+	//nolint:lll
+	log := "2019-12-04\t21:02:31\tLAX1\t392\t192.0.2.100\tGET\td111111abcdef8.cloudfront.net\t/index.html\t200\t-\tMozilla/5.0%20(Windows%20NT%2010.0;%20Win64;%20x64)\t-\t-\tHit\tSOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ==\td111111abcdef8.cloudfront.net\thttps\t23\t0.001\t203.0.113.5, 10.0.0.1\tTLSv1.2\tECDHE-RSA-AES128-GCM-SHA256\tHit\tHTTP/2.0\t-\t-\t11040\t0.001\tHit\ttext/html\t78\t-\t-"
+
+	expectedTime := time.Date(2019, 12, 4, 21, 2, 31, 0, time.UTC)
+	expectedEvent := &CloudFront{
+		Timestamp:              (*timestamp.RFC3339)(&expectedTime),
+		EdgeLocation:           aws.String("LAX1"),
+		BytesSent:              aws.Int64(392),
+		ClientIP:               aws.String("192.0.2.100"),
+		Method:                 aws.String("GET"),
+		Host:                   aws.String("d111111abcdef8.cloudfront.net"),
+		URIStem:                aws.String("/index.html"),
+		Status:                 aws.Int(200),
+		UserAgent:              aws.String("Mozilla/5.0 (Windows NT 10.0; Win64; x64)"),
+		EdgeResultType:         aws.String("Hit"),
+		EdgeRequestID:          aws.String("SOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ=="),
+		HostHeader:             aws.String("d111111abcdef8.cloudfront.net"),
+		Protocol:               aws.String("https"),
+		BytesReceived:          aws.Int64(23),
+		TimeTaken:              aws.Float64(0.001),
+		ForwardedFor:           []string{"203.0.113.5", "10.0.0.1"},
+		SSLProtocol:            aws.String("TLSv1.2"),
+		SSLCipher:              aws.String("ECDHE-RSA-AES128-GCM-SHA256"),
+		EdgeResponseResultType: aws.String("Hit"),
+		ProtocolVersion:        aws.String("HTTP/2.0"),
+		ClientPort:             aws.Int(11040),
+		TimeToFirstByte:        aws.Float64(0.001),
+		EdgeDetailedResultType: aws.String("Hit"),
+		ContentType:            aws.String("text/html"),
+		ContentLength:          aws.Int64(78),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.CloudFront")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("192.0.2.100")
+	expectedEvent.AppendAnyIPAddress("203.0.113.5")
+	expectedEvent.AppendAnyIPAddress("10.0.0.1")
+	expectedEvent.AppendAnyDomainNames("d111111abcdef8.cloudfront.net")
+
+	parser := (&CloudFrontParser{}).New()
+	checkCloudFrontHeaders(t, parser)
+	checkCloudFrontLog(t, parser, log, expectedEvent)
+}
+
+func TestCloudFrontCustomFields(t *testing.T) {
+	log := "2019-12-04\t21:02:31\t192.0.2.100\tSFO5-C1\t404"
+
+	expectedTime := time.Date(2019, 12, 4, 21, 2, 31, 0, time.UTC)
+	expectedEvent := &CloudFront{
+		Timestamp:    (*timestamp.RFC3339)(&expectedTime),
+		ClientIP:     aws.String("192.0.2.100"),
+		EdgeLocation: aws.String("SFO5-C1"),
+		Status:       aws.Int(404),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.CloudFront")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("192.0.2.100")
+
+	parser := (&CloudFrontParser{}).New()
+	events, err := parser.Parse("#Fields: date time c-ip x-edge-location sc-status")
+	require.NoError(t, err)
+	require.Empty(t, events)
+	checkCloudFrontLog(t, parser, log, expectedEvent)
+}
+
+func TestCloudFrontMissingHeader(t *testing.T) {
+	parser := (&CloudFrontParser{}).New()
+	_, err := parser.Parse("2019-12-04\t21:02:31\tLAX1\t392")
+	require.Error(t, err)
+	_, err = parser.Parse("#Fields: date time c-ip")
+	require.Error(t, err)
+}
+
+func TestCloudFrontWrongNumberOfColumns(t *testing.T) {
+	parser := (&CloudFrontParser{}).New()
+	checkCloudFrontHeaders(t, parser)
+	_, err := parser.Parse("2019-12-04\t21:02:31\tLAX1\t392")
+	require.Error(t, err)
+}
+
+func TestCloudFrontLogType(t *testing.T) {
+	parser := &CloudFrontParser{}
+	require.Equal(t, "AWS.CloudFront", parser.LogType())
+}
+
+func checkCloudFrontHeaders(t *testing.T, parser parsers.LogParser) {
+	for _, header := range []string{cloudFrontVersionHeader, cloudFrontFieldsHeader} {
+		events, err := parser.Parse(header)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	}
+}
+
+func checkCloudFrontLog(t *testing.T, parser parsers.LogParser, log string, expectedEvent *CloudFront) {
+	expectedEvent.SetEvent(expectedEvent)
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
@@ -19,6 +19,8 @@ package awslogs
  */
 
 import (
+	"strings"
+
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
@@ -160,6 +162,13 @@ func (event *CloudTrail) updatePantherFields(p *CloudTrailParser) {
 	if event.UserIdentity != nil {
 		event.AppendAnyAWSAccountIdPtrs(event.UserIdentity.AccountID)
 		event.AppendAnyAWSARNPtrs(event.UserIdentity.ARN)
+		event.AppendAnyUsernamePtrs(event.UserIdentity.Username)
+		// The session name of assumed roles is often the email of a federated user (i.e. AWS SSO)
+		if principalID := event.UserIdentity.PrincipalID; principalID != nil {
+			if i := strings.LastIndexByte(*principalID, ':'); i != -1 {
+				event.AppendAnyEmails((*principalID)[i+1:])
+			}
+		}
 
 		if event.UserIdentity.SessionContext != nil {
 			if event.UserIdentity.SessionContext.SessionIssuer != nil {
@@ -0,0 +1,133 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/pkg/extract"
+)
+
+const configResourceTypeEC2Instance = "AWS::EC2::Instance"
+
+// ConfigHistoryDocument is a configuration history file delivered by AWS Config to S3
+type ConfigHistoryDocument struct {
+	FileVersion        *string          `json:"fileVersion"`
+	ConfigurationItems []*ConfigHistory `json:"configurationItems" validate:"required,dive"`
+}
+
+// ConfigHistory is a configuration item from the configurationItems[*] JSON of an AWS Config history file.
+// nolint:lll
+type ConfigHistory struct {
+	ConfigurationItemVersion     *string                     `json:"configurationItemVersion,omitempty" description:"The version number of the configuration item."`
+	ConfigurationItemCaptureTime *timestamp.RFC3339          `json:"configurationItemCaptureTime" validate:"required" description:"The time when AWS Config recorded the configuration item."`
+	ConfigurationStateID         *int64                      `json:"configurationStateId,omitempty" description:"An identifier that indicates the ordering of the configuration items of a resource."`
+	AWSAccountID                 *string                     `json:"awsAccountId" validate:"required,len=12,numeric" description:"The 12-digit AWS account ID associated with the resource."`
+	ConfigurationItemStatus      *string                     `json:"configurationItemStatus" validate:"required" description:"The configuration item status. Valid values are OK, ResourceDiscovered, ResourceNotRecorded, ResourceDeleted and ResourceDeletedNotRecorded."`
+	ResourceType                 *string                     `json:"resourceType" validate:"required" description:"The type of AWS resource."`
+	ResourceID                   *string                     `json:"resourceId" validate:"required" description:"The ID of the resource (for example, sg-xxxxxx)."`
+	ResourceName                 *string                     `json:"resourceName,omitempty" description:"The custom name of the resource, if available."`
+	ARN                          *string                     `json:"ARN,omitempty" description:"The Amazon Resource Name (ARN) associated with the resource."`
+	AWSRegion                    *string                     `json:"awsRegion,omitempty" description:"The region where the resource resides."`
+	AvailabilityZone             *string                     `json:"availabilityZone,omitempty" description:"The Availability Zone associated with the resource."`
+	ConfigurationStateMD5Hash    *string                     `json:"configurationStateMd5Hash,omitempty" description:"Unique MD5 hash that represents the configuration item's state."`
+	ResourceCreationTime         *timestamp.RFC3339          `json:"resourceCreationTime,omitempty" description:"The time stamp when the resource was created."`
+	Tags                         map[string]string           `json:"tags,omitempty" description:"A mapping of key value tags associated with the resource."`
+	RelatedEvents                []string                    `json:"relatedEvents,omitempty" description:"A list of CloudTrail event IDs that are related to the configuration change."`
+	Relationships                []ConfigHistoryRelationship `json:"relationships,omitempty" description:"A list of related AWS resources."`
+	Configuration                *jsoniter.RawMessage        `json:"configuration,omitempty" description:"The description of the resource configuration."`
+	SupplementaryConfiguration   *jsoniter.RawMessage        `json:"supplementaryConfiguration,omitempty" description:"Configuration attributes that AWS Config returns for certain resource types to supplement the information returned for the configuration parameter."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// ConfigHistoryRelationship is a related AWS resource of a configuration item
+// nolint:lll
+type ConfigHistoryRelationship struct {
+	ResourceID   *string `json:"resourceId,omitempty" description:"The ID of the related resource (for example, sg-xxxxxx)."`
+	ResourceName *string `json:"resourceName,omitempty" description:"The custom name of the related resource, if available."`
+	ResourceType *string `json:"resourceType,omitempty" description:"The resource type of the related resource."`
+	Name         *string `json:"name,omitempty" description:"The type of relationship with the related resource."`
+}
+
+// ConfigHistoryParser parses AWS Config configuration history files
+type ConfigHistoryParser struct{}
+
+var _ parsers.LogParser = (*ConfigHistoryParser)(nil)
+
+func (p *ConfigHistoryParser) New() parsers.LogParser {
+	return &ConfigHistoryParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ConfigHistoryParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	configHistoryDocument := &ConfigHistoryDocument{}
+	err := jsoniter.UnmarshalFromString(log, configHistoryDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range configHistoryDocument.ConfigurationItems {
+		event.updatePantherFields(p)
+	}
+
+	if err := parsers.Validator.Struct(configHistoryDocument); err != nil {
+		return nil, err
+	}
+	result := make([]*parsers.PantherLog, len(configHistoryDocument.ConfigurationItems))
+	for i, event := range configHistoryDocument.ConfigurationItems {
+		result[i] = event.Log()
+	}
+	return result, nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ConfigHistoryParser) LogType() string {
+	return TypeConfigHistory
+}
+
+func (event *ConfigHistory) updatePantherFields(p *ConfigHistoryParser) {
+	event.SetCoreFields(p.LogType(), event.ConfigurationItemCaptureTime, event)
+
+	awsExtractor := NewAWSExtractor(&(event.AWSPantherLog))
+
+	// structured (parsed) fields
+	event.AppendAnyAWSAccountIdPtrs(event.AWSAccountID)
+	if event.ARN != nil {
+		awsExtractor.ExtractARN(*event.ARN)
+	}
+	if event.ResourceType != nil && *event.ResourceType == configResourceTypeEC2Instance {
+		event.AppendAnyAWSInstanceIdPtrs(event.ResourceID)
+	}
+	for key, value := range event.Tags {
+		event.AppendAnyAWSTags(key + ":" + value)
+	}
+	for _, relationship := range event.Relationships {
+		if relationship.ResourceType != nil && *relationship.ResourceType == configResourceTypeEC2Instance {
+			event.AppendAnyAWSInstanceIdPtrs(relationship.ResourceID)
+		}
+	}
+
+	// polymorphic (unparsed) fields
+	extract.Extract(event.Configuration, awsExtractor)
+	extract.Extract(event.SupplementaryConfiguration, awsExtractor)
+}
@@ -0,0 +1,149 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestConfigHistory(t *testing.T) {
+	// nolint:lll
+	log := `{
+  "fileVersion": "1.0",
+  "configurationItems": [
+    {
+      "configurationItemVersion": "1.3",
+      "configurationItemCaptureTime": "2020-06-01T10:15:30.123Z",
+      "configurationStateId": 1591006530123,
+      "awsAccountId": "123456789012",
+      "configurationItemStatus": "OK",
+      "resourceType": "AWS::EC2::Instance",
+      "resourceId": "i-0123456789abcdef0",
+      "ARN": "arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789abcdef0",
+      "awsRegion": "us-west-2",
+      "availabilityZone": "us-west-2a",
+      "configurationStateMd5Hash": "",
+      "resourceCreationTime": "2020-05-31T08:00:00.000Z",
+      "tags": {"Name": "bastion"},
+      "relatedEvents": ["c1b7a4e2-0c7a-4f1d-9e83-8d1b2d9b3f4a"],
+      "relationships": [{"resourceId": "sg-0a1b2c3d", "resourceType": "AWS::EC2::SecurityGroup", "name": "Is associated with SecurityGroup"}],
+      "configuration": {"instanceId": "i-0123456789abcdef0", "privateIpAddress": "172.31.10.5", "publicDnsName": "ec2-54-20-30-40.us-west-2.compute.amazonaws.com"},
+      "supplementaryConfiguration": {}
+    },
+    {
+      "configurationItemVersion": "1.3",
+      "configurationItemCaptureTime": "2020-06-01T10:20:00.000Z",
+      "configurationStateId": 1591006800000,
+      "awsAccountId": "123456789012",
+      "configurationItemStatus": "ResourceDeleted",
+      "resourceType": "AWS::EC2::Volume",
+      "resourceId": "vol-0a1b2c3d4e5f6a7b8",
+      "ARN": "arn:aws:ec2:us-west-2:123456789012:volume/vol-0a1b2c3d4e5f6a7b8",
+      "awsRegion": "us-west-2",
+      "relationships": [{"resourceId": "i-0123456789abcdef0", "resourceType": "AWS::EC2::Instance", "name": "Is attached to Instance"}],
+      "configuration": null
+    }
+  ]
+}`
+
+	captureTime := time.Date(2020, 6, 1, 10, 15, 30, 123000000, time.UTC)
+	creationTime := time.Date(2020, 5, 31, 8, 0, 0, 0, time.UTC)
+	instanceEvent := &ConfigHistory{
+		ConfigurationItemVersion:     aws.String("1.3"),
+		ConfigurationItemCaptureTime: (*timestamp.RFC3339)(&captureTime),
+		ConfigurationStateID:         aws.Int64(1591006530123),
+		AWSAccountID:                 aws.String("123456789012"),
+		ConfigurationItemStatus:      aws.String("OK"),
+		ResourceType:                 aws.String("AWS::EC2::Instance"),
+		ResourceID:                   aws.String("i-0123456789abcdef0"),
+		ARN:                          aws.String("arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789abcdef0"),
+		AWSRegion:                    aws.String("us-west-2"),
+		AvailabilityZone:             aws.String("us-west-2a"),
+		ConfigurationStateMD5Hash:    aws.String(""),
+		ResourceCreationTime:         (*timestamp.RFC3339)(&creationTime),
+		Tags:                         map[string]string{"Name": "bastion"},
+		RelatedEvents:                []string{"c1b7a4e2-0c7a-4f1d-9e83-8d1b2d9b3f4a"},
+		Relationships: []ConfigHistoryRelationship{
+			{
+				ResourceID:   aws.String("sg-0a1b2c3d"),
+				ResourceType: aws.String("AWS::EC2::SecurityGroup"),
+				Name:         aws.String("Is associated with SecurityGroup"),
+			},
+		},
+		Configuration:              newRawMessage(`{"instanceId": "i-0123456789abcdef0", "privateIpAddress": "172.31.10.5", "publicDnsName": "ec2-54-20-30-40.us-west-2.compute.amazonaws.com"}`), // nolint:lll
+		SupplementaryConfiguration: newRawMessage(`{}`),
+	}
+	instanceEvent.PantherLogType = aws.String("AWS.ConfigHistory")
+	instanceEvent.PantherEventTime = (*timestamp.RFC3339)(&captureTime)
+	instanceEvent.AppendAnyAWSAccountIds("123456789012")
+	instanceEvent.AppendAnyAWSARNs("arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789abcdef0")
+	instanceEvent.AppendAnyAWSInstanceIds("i-0123456789abcdef0")
+	instanceEvent.AppendAnyAWSTags("Name:bastion")
+	instanceEvent.AppendAnyIPAddress("172.31.10.5")
+	instanceEvent.AppendAnyDomainNames("ec2-54-20-30-40.us-west-2.compute.amazonaws.com")
+	instanceEvent.SetEvent(instanceEvent)
+
+	deletedTime := time.Date(2020, 6, 1, 10, 20, 0, 0, time.UTC)
+	volumeEvent := &ConfigHistory{
+		ConfigurationItemVersion:     aws.String("1.3"),
+		ConfigurationItemCaptureTime: (*timestamp.RFC3339)(&deletedTime),
+		ConfigurationStateID:         aws.Int64(1591006800000),
+		AWSAccountID:                 aws.String("123456789012"),
+		ConfigurationItemStatus:      aws.String("ResourceDeleted"),
+		ResourceType:                 aws.String("AWS::EC2::Volume"),
+		ResourceID:                   aws.String("vol-0a1b2c3d4e5f6a7b8"),
+		ARN:                          aws.String("arn:aws:ec2:us-west-2:123456789012:volume/vol-0a1b2c3d4e5f6a7b8"),
+		AWSRegion:                    aws.String("us-west-2"),
+		Relationships: []ConfigHistoryRelationship{
+			{
+				ResourceID:   aws.String("i-0123456789abcdef0"),
+				ResourceType: aws.String("AWS::EC2::Instance"),
+				Name:         aws.String("Is attached to Instance"),
+			},
+		},
+	}
+	volumeEvent.PantherLogType = aws.String("AWS.ConfigHistory")
+	volumeEvent.PantherEventTime = (*timestamp.RFC3339)(&deletedTime)
+	volumeEvent.AppendAnyAWSAccountIds("123456789012")
+	volumeEvent.AppendAnyAWSARNs("arn:aws:ec2:us-west-2:123456789012:volume/vol-0a1b2c3d4e5f6a7b8")
+	volumeEvent.AppendAnyAWSInstanceIds("i-0123456789abcdef0")
+	volumeEvent.SetEvent(volumeEvent)
+
+	testutil.CheckPantherParser(t, log, &ConfigHistoryParser{}, &instanceEvent.PantherLog, &volumeEvent.PantherLog)
+}
+
+func TestConfigHistoryInvalid(t *testing.T) {
+	parser := (&ConfigHistoryParser{}).New()
+	_, err := parser.Parse(`{"fileVersion":"1.0"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"fileVersion":"1.0","configurationItems":[{"resourceType":"AWS::EC2::Instance"}]}`)
+	require.Error(t, err)
+}
+
+func TestConfigHistoryLogType(t *testing.T) {
+	parser := &ConfigHistoryParser{}
+	require.Equal(t, "AWS.ConfigHistory", parser.LogType())
+}
@@ -19,12 +19,16 @@ package awslogs
  */
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/tidwall/gjson"
 )
 
+// awsARNRegex finds ARNs in free text, ie `assumed role arn:aws:sts::123456789012:assumed-role/Admin/jdoe`
+var awsARNRegex = regexp.MustCompile(`arn:aws(?:-[a-z]+)*:[a-z0-9-]+:[a-z0-9-]*:(?:\d{12})?:[^\s"'\\,;()\[\]{}<>]+`)
+
 // extracts useful AWS features that can be detected generically (w/context)
 type AWSExtractor struct {
 	pl *AWSPantherLog
@@ -40,28 +44,7 @@ func (e *AWSExtractor) Extract(key, value gjson.Result) {
 
 	// value based matching
 	if strings.HasPrefix(value.Str, "arn:") {
-		/* arns may contain an embedded account id as well as interesting resources
-		   See: https://docs.aws.amazon.com/general/latest/gr/aws-arns-and-namespaces.html
-		   Formats:
-		    arn:partition:service:region:account-id:resource-id
-		    arn:partition:service:region:account-id:resource-type/resource-id
-		    arn:partition:service:region:account-id:resource-type:resource-id
-		*/
-		parsedARN, err := arn.Parse(value.Str)
-		if err == nil {
-			e.pl.AppendAnyAWSARNs(value.Str)
-			e.pl.AppendAnyAWSAccountIds(parsedARN.AccountID)
-			// instanceId: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-policy-structure.html#EC2_ARN_Format
-			if strings.HasPrefix(parsedARN.Resource, "instance/") {
-				slashIndex := strings.LastIndex(parsedARN.Resource, "/")
-				if slashIndex < len(parsedARN.Resource)-2 { // not if ends in "/"
-					instanceID := parsedARN.Resource[slashIndex+1:]
-					if strings.HasPrefix(instanceID, "i-") {
-						e.pl.AppendAnyAWSInstanceIds(instanceID)
-					}
-				}
-			}
-		}
+		e.ExtractARN(value.Str)
 		return
 	}
 
@@ -100,16 +83,77 @@ func (e *AWSExtractor) Extract(key, value gjson.Result) {
 			})
 		}
 
+	case
+		"IpV4Addresses", // found in resource details of Security Hub findings
+		"IpV6Addresses": // found in resource details of Security Hub findings
+		if value.IsArray() {
+			value.ForEach(func(ipListKey, ipListValue gjson.Result) bool {
+				e.pl.AppendAnyIPAddress(ipListValue.Str)
+				return true
+			})
+		}
+
 	case
 		"publicIp",         // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
 		"privateIpAddress", // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
-		"ipAddressV4":      // found in GuardDuty findings
+		"ipAddressV4",      // found in GuardDuty findings
+		"IpAddressV4",      // found in Security Hub findings
+		"SourceIpV4",       // found in Security Hub findings
+		"SourceIpV6",       // found in Security Hub findings
+		"DestinationIpV4",  // found in Security Hub findings
+		"DestinationIpV6":  // found in Security Hub findings
 		e.pl.AppendAnyIPAddress(value.Str)
 
+	case "userName": // found in accessKeyDetails in GuardDuty and in CloudTrail request parameters
+		e.pl.AppendAnyUsernames(value.Str)
+
 	case
-		"publicDnsName",  // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
-		"privateDnsName", // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
-		"domain":         // found in GuardDuty findings
+		"publicDnsName",     // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
+		"privateDnsName",    // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
+		"domain",            // found in GuardDuty findings
+		"Domain",            // found in Security Hub findings
+		"SourceDomain",      // found in Security Hub findings
+		"DestinationDomain": // found in Security Hub findings
 		e.pl.AppendAnyDomainNames(value.Str)
 	}
 }
+
+// ExtractARN extracts the ARN, account id and instance id of an ARN value
+func (e *AWSExtractor) ExtractARN(value string) {
+	/* arns may contain an embedded account id as well as interesting resources
+	   See: https://docs.aws.amazon.com/general/latest/gr/aws-arns-and-namespaces.html
+	   Formats:
+	    arn:partition:service:region:account-id:resource-id
+	    arn:partition:service:region:account-id:resource-type/resource-id
+	    arn:partition:service:region:account-id:resource-type:resource-id
+	*/
+	parsedARN, err := arn.Parse(value)
+	if err != nil {
+		return
+	}
+	e.pl.AppendAnyAWSARNs(value)
+	e.pl.AppendAnyAWSAccountIds(parsedARN.AccountID)
+	// instanceId: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-policy-structure.html#EC2_ARN_Format
+	if strings.HasPrefix(parsedARN.Resource, "instance/") {
+		slashIndex := strings.LastIndex(parsedARN.Resource, "/")
+		if slashIndex < len(parsedARN.Resource)-2 { // not if ends in "/"
+			instanceID := parsedARN.Resource[slashIndex+1:]
+			if strings.HasPrefix(instanceID, "i-") {
+				e.pl.AppendAnyAWSInstanceIds(instanceID)
+			}
+		}
+	}
+}
+
+// ExtractARNsInText extracts the ARNs found in free text.
+// Logs of non-AWS sources (ie GCP, GitLab, Juniper, OSSEC) mention AWS resources in messages and request payloads.
+func (e *AWSExtractor) ExtractARNsInText(values ...*string) {
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		for _, match := range awsARNRegex.FindAllString(*value, -1) {
+			e.ExtractARN(strings.TrimRight(match, ".:"))
+		}
+	}
+}
@@ -119,6 +119,21 @@ func TestAWSExtractor(t *testing.T) {
      },
      "remotePortDetails":{"port":32938,"portName":"Unknown"}
   }
+},
+
+"SecurityHubNetwork":{
+  "Direction":"IN",
+  "SourceIpV4":"198.51.100.10",
+  "SourceDomain":"attacker.example.com",
+  "DestinationIpV6":"2001:db8::10",
+  "DestinationDomain":"victim.example.com"
+},
+
+"SecurityHubResourceDetails":{
+  "AwsEc2Instance":{
+    "IpV4Addresses":["10.0.0.10","203.0.113.10"],
+    "IpV6Addresses":["2001:db8::11"]
+  }
 }
 
 }
@@ -135,11 +150,27 @@ func TestAWSExtractor(t *testing.T) {
 	expectedEvent.AppendAnyIPAddress("2001:0db8:85a3:0000:0000:8a2e:0370:7334")
 	expectedEvent.AppendAnyIPAddress("172.31.81.237")
 	expectedEvent.AppendAnyIPAddress("151.80.19.228")
+	expectedEvent.AppendAnyIPAddress("198.51.100.10")
+	expectedEvent.AppendAnyIPAddress("2001:db8::10")
+	expectedEvent.AppendAnyIPAddress("10.0.0.10")
+	expectedEvent.AppendAnyIPAddress("203.0.113.10")
+	expectedEvent.AppendAnyIPAddress("2001:db8::11")
 	expectedEvent.AppendAnyAWSTags("tag1:val1")
 	expectedEvent.AppendAnyDomainNames("ec2-54-152-215-140.compute-1.amazonaws.com", "GeneratedFindingDomainName",
-		"ip-172-31-81-237.ec2.internal")
+		"ip-172-31-81-237.ec2.internal", "attacker.example.com", "victim.example.com")
 
 	extract.Extract(&json, NewAWSExtractor(&event))
 
 	require.Equal(t, expectedEvent, event)
 }
+
+func TestAWSExtractorARNsInText(t *testing.T) {
+	event := AWSPantherLog{}
+	text := `AssumeRole arn:aws:iam::123456789012:role/Admin, "arn:aws-us-gov:s3:::bucket/key.txt". arn:BUT-I-AM-NOT-REALLY-AN-ARN`
+	NewAWSExtractor(&event).ExtractARNsInText(&text, nil)
+
+	expectedEvent := AWSPantherLog{}
+	expectedEvent.AppendAnyAWSARNs("arn:aws:iam::123456789012:role/Admin", "arn:aws-us-gov:s3:::bucket/key.txt")
+	expectedEvent.AppendAnyAWSAccountIds("123456789012")
+	require.Equal(t, expectedEvent, event)
+}
@@ -0,0 +1,116 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Route53ResolverQuery struct {
+	Version        *string                      `json:"version" validate:"required" description:"The version number of the query log format."`
+	AccountID      *string                      `json:"account_id" validate:"required,len=12,numeric" description:"The ID of the AWS account that created the VPC."`
+	Region         *string                      `json:"region" validate:"required" description:"The AWS Region that you created the VPC in."`
+	VPCID          *string                      `json:"vpc_id" validate:"required" description:"The ID of the VPC that the query originated in."`
+	QueryTimestamp *timestamp.RFC3339           `json:"query_timestamp" validate:"required" description:"The date and time that the query was submitted, in ISO 8601 format and Coordinated Universal Time (UTC)."`
+	QueryName      *string                      `json:"query_name" validate:"required" description:"The domain name (example.com) or subdomain name (www.example.com) that was specified in the query."`
+	QueryType      *string                      `json:"query_type,omitempty" description:"Either the DNS record type that was specified in the request, or ANY."`
+	QueryClass     *string                      `json:"query_class,omitempty" description:"The class of the query."`
+	RCode          *string                      `json:"rcode,omitempty" description:"The DNS response code that Resolver returned in response to the DNS query."`
+	Answers        []Route53ResolverQueryAnswer `json:"answers,omitempty" description:"The answers that Resolver returned in response to the query."`
+	SrcAddr        *string                      `json:"srcaddr,omitempty" description:"The IP address of the instance that the query originated from."`
+	SrcPort        *string                      `json:"srcport,omitempty" description:"The port on the instance that the query originated from."`
+	Transport      *string                      `json:"transport,omitempty" description:"The protocol used to submit the DNS query."`
+	SrcIDs         *Route53ResolverQuerySrcIDs  `json:"srcids,omitempty" description:"The IDs of the resources that the query originated from."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type Route53ResolverQueryAnswer struct {
+	RData *string `json:"Rdata,omitempty" description:"The value that Resolver returned in response to the query. For example, for A records, this is an IP address in IPv4 format. For CNAME records, this is the domain name in the CNAME record."`
+	Type  *string `json:"Type,omitempty" description:"The DNS record type (such as MX, AAAA, or CNAME) that Resolver returned in response to the query."`
+	Class *string `json:"Class,omitempty" description:"The class of the Resolver response to the query."`
+}
+
+// nolint:lll
+type Route53ResolverQuerySrcIDs struct {
+	Instance         *string `json:"instance,omitempty" description:"The ID of the instance that the query originated from."`
+	ResolverEndpoint *string `json:"resolver_endpoint,omitempty" description:"The ID of the resolver endpoint that passes the DNS query to on-premises DNS servers."`
+}
+
+// Route53ResolverQueryParser parses AWS Route 53 Resolver query logs
+type Route53ResolverQueryParser struct{}
+
+var _ parsers.LogParser = (*Route53ResolverQueryParser)(nil)
+
+func (p *Route53ResolverQueryParser) New() parsers.LogParser {
+	return &Route53ResolverQueryParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *Route53ResolverQueryParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Route53ResolverQuery{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *Route53ResolverQueryParser) LogType() string {
+	return TypeRoute53ResolverQuery
+}
+
+func (event *Route53ResolverQuery) updatePantherFields(p *Route53ResolverQueryParser) {
+	event.SetCoreFields(p.LogType(), event.QueryTimestamp, event)
+
+	event.AppendAnyAWSAccountIdPtrs(event.AccountID)
+	event.AppendAnyIPAddressPtr(event.SrcAddr)
+	if event.QueryName != nil {
+		event.AppendAnyDomainNames(strings.TrimSuffix(*event.QueryName, "."))
+	}
+	for _, answer := range event.Answers {
+		if answer.RData == nil || answer.Type == nil {
+			continue
+		}
+		switch *answer.Type {
+		case "A", "AAAA":
+			event.AppendAnyIPAddress(*answer.RData)
+		case "CNAME":
+			event.AppendAnyDomainNames(strings.TrimSuffix(*answer.RData, "."))
+		}
+	}
+	if event.SrcIDs != nil {
+		event.AppendAnyAWSInstanceIdPtrs(event.SrcIDs.Instance)
+	}
+}
@@ -0,0 +1,81 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestRoute53ResolverQuery(t *testing.T) {
+	//nolint:lll
+	log := `{"version":"1.100000","account_id":"111122223333","region":"us-east-1","vpc_id":"vpc-0a1b2c3d4e5f6a7b8","query_timestamp":"2020-06-04T17:51:55Z","query_name":"www.example.com.","query_type":"A","query_class":"IN","rcode":"NOERROR","answers":[{"Rdata":"example.com.","Type":"CNAME","Class":"IN"},{"Rdata":"93.184.216.34","Type":"A","Class":"IN"}],"srcaddr":"172.31.10.21","srcport":"56067","transport":"UDP","srcids":{"instance":"i-0d15cd0d3eb8d0a9b"}}`
+
+	expectedTime := time.Date(2020, 6, 4, 17, 51, 55, 0, time.UTC)
+	expectedEvent := &Route53ResolverQuery{
+		Version:        aws.String("1.100000"),
+		AccountID:      aws.String("111122223333"),
+		Region:         aws.String("us-east-1"),
+		VPCID:          aws.String("vpc-0a1b2c3d4e5f6a7b8"),
+		QueryTimestamp: (*timestamp.RFC3339)(&expectedTime),
+		QueryName:      aws.String("www.example.com."),
+		QueryType:      aws.String("A"),
+		QueryClass:     aws.String("IN"),
+		RCode:          aws.String("NOERROR"),
+		Answers: []Route53ResolverQueryAnswer{
+			{RData: aws.String("example.com."), Type: aws.String("CNAME"), Class: aws.String("IN")},
+			{RData: aws.String("93.184.216.34"), Type: aws.String("A"), Class: aws.String("IN")},
+		},
+		SrcAddr:   aws.String("172.31.10.21"),
+		SrcPort:   aws.String("56067"),
+		Transport: aws.String("UDP"),
+		SrcIDs: &Route53ResolverQuerySrcIDs{
+			Instance: aws.String("i-0d15cd0d3eb8d0a9b"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.Route53ResolverQuery")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyAWSAccountIds("111122223333")
+	expectedEvent.AppendAnyAWSInstanceIds("i-0d15cd0d3eb8d0a9b")
+	expectedEvent.AppendAnyIPAddress("172.31.10.21")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com", "example.com")
+
+	checkRoute53ResolverQueryLog(t, log, expectedEvent)
+}
+
+func TestRoute53ResolverQueryLogType(t *testing.T) {
+	parser := &Route53ResolverQueryParser{}
+	require.Equal(t, "AWS.Route53ResolverQuery", parser.LogType())
+}
+
+func checkRoute53ResolverQueryLog(t *testing.T, log string, expectedEvent *Route53ResolverQuery) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&Route53ResolverQueryParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
@@ -0,0 +1,190 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/pkg/extract"
+)
+
+// SecurityHubEvent is the EventBridge event that delivers imported Security Hub findings
+type SecurityHubEvent struct {
+	Detail *SecurityHubEventDetail `json:"detail"`
+}
+
+type SecurityHubEventDetail struct {
+	Findings []*SecurityHubFinding `json:"findings"`
+}
+
+// SecurityHubFinding is a finding in the AWS Security Finding Format (ASFF)
+// nolint:lll
+type SecurityHubFinding struct {
+	SchemaVersion         *string               `json:"SchemaVersion" validate:"required" description:"The schema version that a finding is formatted for."`
+	ID                    *string               `json:"Id" validate:"required" description:"The security findings provider-specific identifier for a finding."`
+	ProductArn            *string               `json:"ProductArn" validate:"required" description:"The ARN generated by Security Hub that uniquely identifies a product that generates findings."`
+	ProductName           *string               `json:"ProductName,omitempty" description:"The name of the product that generated the finding."`
+	CompanyName           *string               `json:"CompanyName,omitempty" description:"The name of the company for the product that generated the finding."`
+	Region                *string               `json:"Region,omitempty" description:"The Region from which the finding was generated."`
+	GeneratorID           *string               `json:"GeneratorId" validate:"required" description:"The identifier for the solution-specific component (a discrete unit of logic) that generated a finding."`
+	AWSAccountID          *string               `json:"AwsAccountId" validate:"required,len=12,numeric" description:"The AWS account ID that a finding is generated in."`
+	Types                 []string              `json:"Types,omitempty" description:"One or more finding types in the format of namespace/category/classifier that classify a finding."`
+	FirstObservedAt       *timestamp.RFC3339    `json:"FirstObservedAt,omitempty" description:"Indicates when the security-findings provider first observed the potential security issue that a finding captured."`
+	LastObservedAt        *timestamp.RFC3339    `json:"LastObservedAt,omitempty" description:"Indicates when the security-findings provider most recently observed the potential security issue that a finding captured."`
+	CreatedAt             *timestamp.RFC3339    `json:"CreatedAt" validate:"required" description:"Indicates when the security-findings provider created the potential security issue that a finding captured."`
+	UpdatedAt             *timestamp.RFC3339    `json:"UpdatedAt" validate:"required" description:"Indicates when the security-findings provider last updated the finding record."`
+	Severity              *SecurityHubSeverity  `json:"Severity" validate:"required" description:"A finding's severity."`
+	Confidence            *int                  `json:"Confidence,omitempty" description:"A finding's confidence. Confidence is defined as the likelihood that a finding accurately identifies the behavior or issue that it was intended to identify."`
+	Criticality           *int                  `json:"Criticality,omitempty" description:"The level of importance assigned to the resources associated with the finding."`
+	Title                 *string               `json:"Title" validate:"required" description:"A finding's title."`
+	Description           *string               `json:"Description" validate:"required" description:"A finding's description."`
+	Remediation           *jsoniter.RawMessage  `json:"Remediation,omitempty" description:"A data type that describes the remediation options for a finding."`
+	SourceURL             *string               `json:"SourceUrl,omitempty" description:"A URL that links to a page about the current finding in the security-findings provider's solution."`
+	ProductFields         map[string]string     `json:"ProductFields,omitempty" description:"A data type where security-findings providers can include additional solution-specific details that aren't part of the defined AwsSecurityFinding format."`
+	UserDefinedFields     map[string]string     `json:"UserDefinedFields,omitempty" description:"A list of name/value string pairs associated with the finding."`
+	Malware               *jsoniter.RawMessage  `json:"Malware,omitempty" description:"A list of malware related to a finding."`
+	Network               *jsoniter.RawMessage  `json:"Network,omitempty" description:"The details of network-related information about a finding."`
+	NetworkPath           *jsoniter.RawMessage  `json:"NetworkPath,omitempty" description:"Provides information about a network path that is relevant to a finding."`
+	Process               *jsoniter.RawMessage  `json:"Process,omitempty" description:"The details of process-related information about a finding."`
+	ThreatIntelIndicators *jsoniter.RawMessage  `json:"ThreatIntelIndicators,omitempty" description:"Threat intelligence details related to a finding."`
+	Resources             []SecurityHubResource `json:"Resources" validate:"required,min=1" description:"A set of resource data types that describe the resources that the finding refers to."`
+	Compliance            *jsoniter.RawMessage  `json:"Compliance,omitempty" description:"This data type is exclusive to findings that are generated as the result of a check run against a specific rule in a supported security standard."`
+	VerificationState     *string               `json:"VerificationState,omitempty" description:"Indicates the veracity of a finding."`
+	WorkflowState         *string               `json:"WorkflowState,omitempty" description:"The workflow state of a finding (deprecated, replaced by Workflow)."`
+	Workflow              *SecurityHubWorkflow  `json:"Workflow,omitempty" description:"Provides information about the status of the investigation into a finding."`
+	RecordState           *string               `json:"RecordState,omitempty" description:"The record state of a finding."`
+	RelatedFindings       *jsoniter.RawMessage  `json:"RelatedFindings,omitempty" description:"A list of related findings."`
+	Note                  *SecurityHubNote      `json:"Note,omitempty" description:"A user-defined note added to a finding."`
+	Vulnerabilities       *jsoniter.RawMessage  `json:"Vulnerabilities,omitempty" description:"Provides a list of vulnerabilities associated with the findings."`
+	PatchSummary          *jsoniter.RawMessage  `json:"PatchSummary,omitempty" description:"Provides an overview of the patch compliance status for an instance against a selected compliance standard."`
+	Action                *jsoniter.RawMessage  `json:"Action,omitempty" description:"Provides details about an action that affects or that was taken on a resource."`
+	FindingProviderFields *jsoniter.RawMessage  `json:"FindingProviderFields,omitempty" description:"In a BatchImportFindings request, finding providers use FindingProviderFields to provide and update their own values for confidence, criticality, related findings, severity, and types."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type SecurityHubSeverity struct {
+	Label      *string  `json:"Label,omitempty" description:"The severity value of the finding. The allowed values are INFORMATIONAL, LOW, MEDIUM, HIGH and CRITICAL."`
+	Normalized *int     `json:"Normalized,omitempty" description:"Deprecated. The normalized severity of a finding, from 0 to 100."`
+	Original   *string  `json:"Original,omitempty" description:"The native severity from the finding product that generated the finding."`
+	Product    *float64 `json:"Product,omitempty" description:"Deprecated. The native severity as defined by the AWS service or integrated partner product that generated the finding."`
+}
+
+// nolint:lll
+type SecurityHubResource struct {
+	Type         *string              `json:"Type" validate:"required" description:"The type of the resource that details are provided for."`
+	ID           *string              `json:"Id" validate:"required" description:"The canonical identifier for the given resource type."`
+	Partition    *string              `json:"Partition,omitempty" description:"The canonical AWS partition name that the Region is assigned to."`
+	Region       *string              `json:"Region,omitempty" description:"The canonical AWS external Region name where this resource is located."`
+	ResourceRole *string              `json:"ResourceRole,omitempty" description:"Identifies the role of the resource in the finding. A resource is either the actor or target of the finding activity."`
+	Tags         map[string]string    `json:"Tags,omitempty" description:"A list of AWS tags associated with a resource at the time the finding was processed."`
+	Details      *jsoniter.RawMessage `json:"Details,omitempty" description:"Additional details about the resource related to a finding."`
+}
+
+// nolint:lll
+type SecurityHubWorkflow struct {
+	Status *string `json:"Status,omitempty" description:"The status of the investigation into the finding. The allowed values are NEW, NOTIFIED, RESOLVED and SUPPRESSED."`
+}
+
+// nolint:lll
+type SecurityHubNote struct {
+	Text      *string            `json:"Text,omitempty" description:"The text of a note."`
+	UpdatedBy *string            `json:"UpdatedBy,omitempty" description:"The principal that created a note."`
+	UpdatedAt *timestamp.RFC3339 `json:"UpdatedAt,omitempty" description:"The timestamp of when the note was updated."`
+}
+
+// SecurityHubFindingParser parses AWS Security Hub findings
+type SecurityHubFindingParser struct{}
+
+var _ parsers.LogParser = (*SecurityHubFindingParser)(nil)
+
+func (p *SecurityHubFindingParser) New() parsers.LogParser {
+	return &SecurityHubFindingParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed.
+// Logs are either EventBridge events with one or more findings or a single finding.
+func (p *SecurityHubFindingParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	securityHubEvent := &SecurityHubEvent{}
+	err := jsoniter.UnmarshalFromString(log, securityHubEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []*SecurityHubFinding
+	if securityHubEvent.Detail != nil && len(securityHubEvent.Detail.Findings) > 0 {
+		findings = securityHubEvent.Detail.Findings
+	} else {
+		finding := &SecurityHubFinding{}
+		if err := jsoniter.UnmarshalFromString(log, finding); err != nil {
+			return nil, err
+		}
+		findings = []*SecurityHubFinding{finding}
+	}
+
+	result := make([]*parsers.PantherLog, len(findings))
+	for i, event := range findings {
+		if event == nil {
+			return nil, errors.New("null finding")
+		}
+		event.updatePantherFields(p)
+		if err := parsers.Validator.Struct(event); err != nil {
+			return nil, err
+		}
+		result[i] = event.Log()
+	}
+	return result, nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *SecurityHubFindingParser) LogType() string {
+	return TypeSecurityHubFinding
+}
+
+func (event *SecurityHubFinding) updatePantherFields(p *SecurityHubFindingParser) {
+	event.SetCoreFields(p.LogType(), event.UpdatedAt, event)
+
+	awsExtractor := NewAWSExtractor(&(event.AWSPantherLog))
+
+	// structured (parsed) fields
+	event.AppendAnyAWSAccountIdPtrs(event.AWSAccountID)
+	for _, resource := range event.Resources {
+		if resource.ID != nil {
+			// resource ids are ARNs for most resource types
+			awsExtractor.ExtractARN(*resource.ID)
+		}
+		for key, value := range resource.Tags {
+			event.AppendAnyAWSTags(key + ":" + value)
+		}
+	}
+
+	// polymorphic (unparsed) fields
+	for _, resource := range event.Resources {
+		extract.Extract(resource.Details, awsExtractor)
+	}
+	extract.Extract(event.Network, awsExtractor)
+	extract.Extract(event.Process, awsExtractor)
+	extract.Extract(event.Action, awsExtractor)
+	extract.Extract(event.ThreatIntelIndicators, awsExtractor)
+}
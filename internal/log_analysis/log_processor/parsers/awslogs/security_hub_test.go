@@ -0,0 +1,123 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+const securityHubFinding = `{"SchemaVersion":"2018-10-08","Id":"arn:aws:securityhub:us-west-2:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.9/finding/7f5d9c12-3c59-4ed6-a4d5-a7a1b8e0b3b1","ProductArn":"arn:aws:securityhub:us-west-2::product/aws/securityhub","ProductName":"Security Hub","CompanyName":"AWS","Region":"us-west-2","GeneratorId":"aws-foundational-security-best-practices/v/1.0.0/EC2.9","AwsAccountId":"123456789012","Types":["Software and Configuration Checks/Industry and Regulatory Standards/AWS-Foundational-Security-Best-Practices"],"FirstObservedAt":"2020-06-01T12:00:00.000Z","LastObservedAt":"2020-06-02T12:00:00.000Z","CreatedAt":"2020-06-01T12:00:00.000Z","UpdatedAt":"2020-06-02T12:00:00.000Z","Severity":{"Label":"HIGH","Normalized":70,"Original":"HIGH","Product":70},"Title":"EC2.9 EC2 instances should not have a public IP address","Description":"This control checks whether EC2 instances have a public IP address.","Remediation":{"Recommendation":{"Text":"For directions on how to fix this issue, please consult the AWS Security Hub Foundational Security Best Practices documentation.","Url":"https://docs.aws.amazon.com/console/securityhub/EC2.9/remediation"}},"ProductFields":{"StandardsArn":"arn:aws:securityhub:::standards/aws-foundational-security-best-practices/v/1.0.0","ControlId":"EC2.9"},"Resources":[{"Type":"AwsEc2Instance","Id":"arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789abcdef0","Partition":"aws","Region":"us-west-2","Tags":{"Name":"bastion"},"Details":{"AwsEc2Instance":{"Type":"t2.micro","IpV4Addresses":["172.31.10.5","54.20.30.40"],"VpcId":"vpc-0a1b2c3d","SubnetId":"subnet-0a1b2c3d"}}}],"Compliance":{"Status":"FAILED"},"WorkflowState":"NEW","Workflow":{"Status":"NEW"},"RecordState":"ACTIVE"}`
+
+func TestSecurityHubFindingEvent(t *testing.T) {
+	// nolint:lll
+	log := `{"version":"0","id":"8e5622f9-d81c-4d81-612a-9319e7ee2506","detail-type":"Security Hub Findings - Imported","source":"aws.securityhub","account":"123456789012","time":"2020-06-02T12:00:05Z","region":"us-west-2","resources":["arn:aws:securityhub:us-west-2:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.9/finding/7f5d9c12-3c59-4ed6-a4d5-a7a1b8e0b3b1"],"detail":{"findings":[` + securityHubFinding + `]}}`
+
+	checkSecurityHubFinding(t, log, expectedSecurityHubFinding())
+}
+
+func TestSecurityHubFinding(t *testing.T) {
+	checkSecurityHubFinding(t, securityHubFinding, expectedSecurityHubFinding())
+}
+
+func TestSecurityHubFindingInvalid(t *testing.T) {
+	parser := (&SecurityHubFindingParser{}).New()
+	_, err := parser.Parse(`{"version":"0","source":"aws.securityhub","detail":{"findings":[{"SchemaVersion":"2018-10-08"}]}}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"Records":[]}`)
+	require.Error(t, err)
+}
+
+func TestSecurityHubFindingLogType(t *testing.T) {
+	parser := &SecurityHubFindingParser{}
+	require.Equal(t, "AWS.SecurityHubFinding", parser.LogType())
+}
+
+func expectedSecurityHubFinding() *SecurityHubFinding {
+	createdAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2020, 6, 2, 12, 0, 0, 0, time.UTC)
+	// nolint:lll
+	expectedEvent := &SecurityHubFinding{
+		SchemaVersion:   aws.String("2018-10-08"),
+		ID:              aws.String("arn:aws:securityhub:us-west-2:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.9/finding/7f5d9c12-3c59-4ed6-a4d5-a7a1b8e0b3b1"),
+		ProductArn:      aws.String("arn:aws:securityhub:us-west-2::product/aws/securityhub"),
+		ProductName:     aws.String("Security Hub"),
+		CompanyName:     aws.String("AWS"),
+		Region:          aws.String("us-west-2"),
+		GeneratorID:     aws.String("aws-foundational-security-best-practices/v/1.0.0/EC2.9"),
+		AWSAccountID:    aws.String("123456789012"),
+		Types:           []string{"Software and Configuration Checks/Industry and Regulatory Standards/AWS-Foundational-Security-Best-Practices"},
+		FirstObservedAt: (*timestamp.RFC3339)(&createdAt),
+		LastObservedAt:  (*timestamp.RFC3339)(&updatedAt),
+		CreatedAt:       (*timestamp.RFC3339)(&createdAt),
+		UpdatedAt:       (*timestamp.RFC3339)(&updatedAt),
+		Severity: &SecurityHubSeverity{
+			Label:      aws.String("HIGH"),
+			Normalized: aws.Int(70),
+			Original:   aws.String("HIGH"),
+			Product:    aws.Float64(70),
+		},
+		Title:       aws.String("EC2.9 EC2 instances should not have a public IP address"),
+		Description: aws.String("This control checks whether EC2 instances have a public IP address."),
+		Remediation: newRawMessage(`{"Recommendation":{"Text":"For directions on how to fix this issue, please consult the AWS Security Hub Foundational Security Best Practices documentation.","Url":"https://docs.aws.amazon.com/console/securityhub/EC2.9/remediation"}}`),
+		ProductFields: map[string]string{
+			"StandardsArn": "arn:aws:securityhub:::standards/aws-foundational-security-best-practices/v/1.0.0",
+			"ControlId":    "EC2.9",
+		},
+		Resources: []SecurityHubResource{
+			{
+				Type:      aws.String("AwsEc2Instance"),
+				ID:        aws.String("arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789abcdef0"),
+				Partition: aws.String("aws"),
+				Region:    aws.String("us-west-2"),
+				Tags:      map[string]string{"Name": "bastion"},
+				Details:   newRawMessage(`{"AwsEc2Instance":{"Type":"t2.micro","IpV4Addresses":["172.31.10.5","54.20.30.40"],"VpcId":"vpc-0a1b2c3d","SubnetId":"subnet-0a1b2c3d"}}`),
+			},
+		},
+		Compliance:    newRawMessage(`{"Status":"FAILED"}`),
+		WorkflowState: aws.String("NEW"),
+		Workflow:      &SecurityHubWorkflow{Status: aws.String("NEW")},
+		RecordState:   aws.String("ACTIVE"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.SecurityHubFinding")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&updatedAt)
+	expectedEvent.AppendAnyAWSAccountIds("123456789012")
+	expectedEvent.AppendAnyAWSARNs("arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789abcdef0")
+	expectedEvent.AppendAnyAWSInstanceIds("i-0123456789abcdef0")
+	expectedEvent.AppendAnyAWSTags("Name:bastion")
+	expectedEvent.AppendAnyIPAddress("172.31.10.5")
+	expectedEvent.AppendAnyIPAddress("54.20.30.40")
+	return expectedEvent
+}
+
+func checkSecurityHubFinding(t *testing.T, log string, expectedEvent *SecurityHubFinding) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&SecurityHubFindingParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
@@ -33,7 +33,7 @@ import (
 
 // nolint:lll
 type VPCFlow struct { // NOTE: since fields are customizable by users, the only "required" fields are the Start/End times since those are critical and data is useless w/out those
-	Version     *int               `json:"version,omitempty"  description:"The VPC Flow Logs version. If you use the default format, the version is 2. If you specify a custom format, the version is 3."`
+	Version     *int               `json:"version,omitempty"  description:"The VPC Flow Logs version. If you use the default format, the version is 2. If you specify a custom format, the version is the highest version among the specified fields (3, 4 or 5)."`
 	AccountID   *string            `json:"account,omitempty" validate:"omitempty,len=12,numeric" description:"The AWS account ID for the flow log."`
 	InterfaceID *string            `json:"interfaceId,omitempty" description:"The ID of the network interface for which the traffic is recorded."`
 	SrcAddr     *string            `json:"srcAddr,omitempty" description:"The source address for incoming traffic, or the IPv4 or IPv6 address of the network interface for outgoing traffic on the network interface. The IPv4 address of the network interface is always its private IPv4 address. "`
@@ -46,7 +46,7 @@ type VPCFlow struct { // NOTE: since fields are customizable by users, the only
 	Start       *timestamp.RFC3339 `json:"start,omitempty" validate:"required" description:"The time of the start of the flow (UTC)."`
 	End         *timestamp.RFC3339 `json:"end,omitempty" validate:"required" description:"The time of the end of the flow (UTC)."`
 	Action      *string            `json:"action,omitempty" validate:"omitempty,oneof=ACCEPT REJECT" description:"The action that is associated with the traffic. ACCEPT: The recorded traffic was permitted by the security groups or network ACLs. REJECT: The recorded traffic was not permitted by the security groups or network ACLs."`
-	LogStatus   *string            `json:"status,omitempty" validate:"oneof=OK NODATA SKIPDATA" description:"The logging status of the flow log. OK: Data is logging normally to the chosen destinations. NODATA: There was no network traffic to or from the network interface during the capture window. SKIPDATA: Some flow log records were skipped during the capture window. This may be because of an internal capacity constraint, or an internal error."`
+	LogStatus   *string            `json:"status,omitempty" validate:"omitempty,oneof=OK NODATA SKIPDATA" description:"The logging status of the flow log. OK: Data is logging normally to the chosen destinations. NODATA: There was no network traffic to or from the network interface during the capture window. SKIPDATA: Some flow log records were skipped during the capture window. This may be because of an internal capacity constraint, or an internal error."`
 
 	// extended custom fields
 	VpcID         *string `json:"vpcId,omitempty" description:"The ID of the VPC that contains the network interface for which the traffic is recorded."`
@@ -57,6 +57,18 @@ type VPCFlow struct { // NOTE: since fields are customizable by users, the only
 	PacketSrcAddr *string `json:"pktSrcAddr,omitempty" description:"The packet-level (original) source IP address of the traffic. Use this field with the srcaddr field to distinguish between the IP address of an intermediate layer through which traffic flows, and the original source IP address of the traffic. For example, when traffic flows through a network interface for a NAT gateway, or where the IP address of a pod in Amazon EKS is different from the IP address of the network interface of the instance node on which the pod is running."`
 	PacketDstAddr *string `json:"pktDstAddr,omitempty" description:"The packet-level (original) destination IP address for the traffic. Use this field with the dstaddr field to distinguish between the IP address of an intermediate layer through which traffic flows, and the final destination IP address of the traffic. For example, when traffic flows through a network interface for a NAT gateway, or where the IP address of a pod in Amazon EKS is different from the IP address of the network interface of the instance node on which the pod is running."`
 
+	// version 4 custom fields
+	Region          *string `json:"region,omitempty" description:"The Region that contains the network interface for which traffic is recorded."`
+	AZID            *string `json:"azId,omitempty" description:"The ID of the Availability Zone that contains the network interface for which traffic is recorded. If the traffic is from a sublocation, the record displays a '-' symbol for this field."`
+	SublocationType *string `json:"sublocationType,omitempty" description:"The type of sublocation that's returned in the sublocation-id field: wavelength, outpost or localzone."`
+	SublocationID   *string `json:"sublocationId,omitempty" description:"The ID of the sublocation that contains the network interface for which traffic is recorded."`
+
+	// version 5 custom fields
+	PacketSrcAWSService *string `json:"pktSrcAwsService,omitempty" description:"The name of the subset of IP address ranges for the pkt-srcaddr field, if the source IP address is for an AWS service."`
+	PacketDstAWSService *string `json:"pktDstAwsService,omitempty" description:"The name of the subset of IP address ranges for the pkt-dstaddr field, if the destination IP address is for an AWS service."`
+	FlowDirection       *string `json:"flowDirection,omitempty" validate:"omitempty,oneof=ingress egress" description:"The direction of the flow with respect to the interface where traffic is captured. The possible values are: ingress and egress."`
+	TrafficPath         *int    `json:"trafficPath,omitempty" description:"The path that egress traffic takes to the destination. To determine whether the traffic is egress traffic, check the flow-direction field."`
+
 	// NOTE: added to end of struct to allow expansion later
 	AWSPantherLog
 }
@@ -101,6 +113,14 @@ const (
 	vpcFlowType            = "type"
 	vpcFlowPktSrcAddr      = "pkt-srcaddr"
 	vpcFlowPktDstAddr      = "pkt-dstaddr"
+	vpcFlowRegion          = "region"
+	vpcFlowAZID            = "az-id"
+	vpcFlowSublocationType = "sublocation-type"
+	vpcFlowSublocationID   = "sublocation-id"
+	vpcFlowPktSrcService   = "pkt-src-aws-service"
+	vpcFlowPktDstService   = "pkt-dst-aws-service"
+	vpcFlowFlowDirection   = "flow-direction"
+	vpcFlowTrafficPath     = "traffic-path"
 )
 
 var (
@@ -129,6 +149,16 @@ var (
 		vpcFlowType:       {},
 		vpcFlowPktSrcAddr: {},
 		vpcFlowPktDstAddr: {},
+		// version 4 custom fields
+		vpcFlowRegion:          {},
+		vpcFlowAZID:            {},
+		vpcFlowSublocationType: {},
+		vpcFlowSublocationID:   {},
+		// version 5 custom fields
+		vpcFlowPktSrcService: {},
+		vpcFlowPktDstService: {},
+		vpcFlowFlowDirection: {},
+		vpcFlowTrafficPath:   {},
 	}
 )
 
@@ -252,6 +282,26 @@ func (p *VPCFlowParser) populateEvent(columns []string) (event *VPCFlow) {
 			event.PacketSrcAddr = parsers.CsvStringToPointer(columns[i])
 		case vpcFlowPktDstAddr:
 			event.PacketDstAddr = parsers.CsvStringToPointer(columns[i])
+
+			// version 4 custom fields
+		case vpcFlowRegion:
+			event.Region = parsers.CsvStringToPointer(columns[i])
+		case vpcFlowAZID:
+			event.AZID = parsers.CsvStringToPointer(columns[i])
+		case vpcFlowSublocationType:
+			event.SublocationType = parsers.CsvStringToPointer(columns[i])
+		case vpcFlowSublocationID:
+			event.SublocationID = parsers.CsvStringToPointer(columns[i])
+
+			// version 5 custom fields
+		case vpcFlowPktSrcService:
+			event.PacketSrcAWSService = parsers.CsvStringToPointer(columns[i])
+		case vpcFlowPktDstService:
+			event.PacketDstAWSService = parsers.CsvStringToPointer(columns[i])
+		case vpcFlowFlowDirection:
+			event.FlowDirection = parsers.CsvStringToPointer(columns[i])
+		case vpcFlowTrafficPath:
+			event.TrafficPath = parsers.CsvStringToIntPointer(columns[i])
 		default:
 			zap.L().Warn(fmt.Sprintf("unknown %s header %s (could be a new header, check AWS documentation)", p.LogType(), p.columnMap[i]))
 		}
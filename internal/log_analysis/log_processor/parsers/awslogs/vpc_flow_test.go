@@ -32,6 +32,7 @@ import (
 const (
 	vpcFlowDefaultHeader  = "version account-id interface-id srcaddr dstaddr srcport dstport protocol packets bytes start end action log-status"                                                                                                     // nolint:lll
 	vpcFlowExtendedHeader = "version account-id interface-id srcaddr dstaddr srcport dstport protocol packets bytes start end action log-status vpc-id subnet-id instance-id tcp-flags type pkt-srcaddr pkt-dstaddr unknown-header-should-not-break" // nolint:lll
+	vpcFlowV5Header       = "version srcaddr dstaddr start end log-status region az-id sublocation-type sublocation-id pkt-src-aws-service pkt-dst-aws-service flow-direction traffic-path"                                                          // nolint:lll
 )
 
 func TestStandardVpcFlowLog(t *testing.T) {
@@ -109,6 +110,61 @@ func TestExtendedVpcFlowLog(t *testing.T) {
 	checkVPCFlowLog(t, vpcFlowExtendedHeader, log, expectedEvent)
 }
 
+func TestCustomFormatVpcFlowLog(t *testing.T) {
+	log := "5 10.0.1.5 52.95.128.18 1573642242 1573642284 OK us-east-2 use2-az1 outpost op-0123456789abcdef0 - S3 egress 8"
+
+	expectedStartTime := time.Unix(1573642242, 0).UTC()
+	expectedEndTime := time.Unix(1573642284, 0).UTC()
+	expectedEvent := &VPCFlow{
+		Version:   aws.Int(5),
+		SrcAddr:   aws.String("10.0.1.5"),
+		DstAddr:   aws.String("52.95.128.18"),
+		Start:     (*timestamp.RFC3339)(&expectedStartTime),
+		End:       (*timestamp.RFC3339)(&expectedEndTime),
+		LogStatus: aws.String("OK"),
+
+		Region:              aws.String("us-east-2"),
+		AZID:                aws.String("use2-az1"),
+		SublocationType:     aws.String("outpost"),
+		SublocationID:       aws.String("op-0123456789abcdef0"),
+		PacketDstAWSService: aws.String("S3"),
+		FlowDirection:       aws.String("egress"),
+		TrafficPath:         aws.Int(8),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.VPCFlow")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedStartTime)
+	expectedEvent.AppendAnyIPAddress("10.0.1.5")
+	expectedEvent.AppendAnyIPAddress("52.95.128.18")
+
+	checkVPCFlowLog(t, vpcFlowV5Header, log, expectedEvent)
+}
+
+func TestCustomFormatVpcFlowLogWithoutStatus(t *testing.T) {
+	header := "version srcaddr dstaddr start end flow-direction"
+	log := "5 10.0.1.5 52.95.128.18 1573642242 1573642284 ingress"
+
+	expectedStartTime := time.Unix(1573642242, 0).UTC()
+	expectedEndTime := time.Unix(1573642284, 0).UTC()
+	expectedEvent := &VPCFlow{
+		Version:       aws.Int(5),
+		SrcAddr:       aws.String("10.0.1.5"),
+		DstAddr:       aws.String("52.95.128.18"),
+		Start:         (*timestamp.RFC3339)(&expectedStartTime),
+		End:           (*timestamp.RFC3339)(&expectedEndTime),
+		FlowDirection: aws.String("ingress"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.VPCFlow")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedStartTime)
+	expectedEvent.AppendAnyIPAddress("10.0.1.5")
+	expectedEvent.AppendAnyIPAddress("52.95.128.18")
+
+	checkVPCFlowLog(t, header, log, expectedEvent)
+}
+
 func TestVpcFlowLogNoData(t *testing.T) {
 	log := "2 unknown eni-0608192d5c498fbcd - - - - - - - 1538696170 1538696308 - NODATA"
 
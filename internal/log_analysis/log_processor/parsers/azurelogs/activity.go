@@ -0,0 +1,130 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// Claims of the identity of an Activity log caller used as indicators
+const (
+	ClaimUPN       = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn"
+	ClaimIPAddress = "ipaddr"
+)
+
+// ActivityRecords is the document Azure Monitor writes to storage accounts
+type ActivityRecords struct {
+	Records []*Activity `json:"records" validate:"required,dive"`
+}
+
+// nolint:lll
+type Activity struct {
+	Time            *timestamp.RFC3339  `json:"time" validate:"required" description:"Timestamp in UTC of the event."`
+	ResourceID      *string             `json:"resourceId,omitempty" description:"Resource ID of the impacted resource."`
+	OperationName   *string             `json:"operationName" validate:"required" description:"Name of the operation."`
+	Category        *string             `json:"category" validate:"required,oneof=Administrative ServiceHealth ResourceHealth Alert Autoscale Security Policy Recommendation" description:"Category of the event (ie Administrative, ServiceHealth, ResourceHealth, Alert, Autoscale, Security, Policy or Recommendation)."`
+	ResultType      *string             `json:"resultType,omitempty" description:"The status of the event (ie Started, In Progress, Succeeded, Failed, Active or Resolved)."`
+	ResultSignature *string             `json:"resultSignature,omitempty" description:"The sub status of the event."`
+	DurationMs      *numerics.Int64     `json:"durationMs,omitempty" description:"Duration of the operation in milliseconds."`
+	CallerIPAddress *string             `json:"callerIpAddress,omitempty" description:"IP address of the user who has performed the operation, UPN claim, or SPN claim based on availability."`
+	CorrelationID   *string             `json:"correlationId,omitempty" description:"Usually a GUID in the string format. Events that share a correlationId belong to the same uber action."`
+	Identity        *ActivityIdentity   `json:"identity,omitempty" description:"The claims and authorization of the caller."`
+	Level           *string             `json:"level,omitempty" description:"Level of the event (ie Critical, Error, Warning or Informational)."`
+	Location        *string             `json:"location,omitempty" description:"Region in which the event occurred or global."`
+	Properties      jsoniter.RawMessage `json:"properties,omitempty" description:"Set of key/value pairs with details about the event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type ActivityIdentity struct {
+	Authorization *ActivityAuthorization `json:"authorization,omitempty" description:"The RBAC properties of the event."`
+	Claims        map[string]string      `json:"claims,omitempty" description:"The JWT token used by Active Directory to authenticate the caller to perform this operation."`
+}
+
+// nolint:lll
+type ActivityAuthorization struct {
+	Action   *string             `json:"action,omitempty" description:"The action of the operation."`
+	Scope    *string             `json:"scope,omitempty" description:"The scope of the operation."`
+	Evidence jsoniter.RawMessage `json:"evidence,omitempty" description:"The role assignment that granted access to perform the operation."`
+}
+
+// ActivityParser parses Azure Activity logs
+type ActivityParser struct{}
+
+var _ parsers.LogParser = (*ActivityParser)(nil)
+
+func (p *ActivityParser) New() parsers.LogParser {
+	return &ActivityParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ActivityParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	activityRecords := &ActivityRecords{}
+	err := jsoniter.UnmarshalFromString(log, activityRecords)
+	if err != nil {
+		return nil, err
+	}
+	// Records can also be written one per line
+	if activityRecords.Records == nil {
+		activity := &Activity{}
+		if err := jsoniter.UnmarshalFromString(log, activity); err != nil {
+			return nil, err
+		}
+		activityRecords.Records = []*Activity{activity}
+	}
+
+	for _, event := range activityRecords.Records {
+		event.updatePantherFields(p)
+	}
+
+	if err := parsers.Validator.Struct(activityRecords); err != nil {
+		return nil, err
+	}
+	result := make([]*parsers.PantherLog, len(activityRecords.Records))
+	for i, event := range activityRecords.Records {
+		result[i] = event.Log()
+	}
+	return result, nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ActivityParser) LogType() string {
+	return TypeActivity
+}
+
+func (event *Activity) updatePantherFields(p *ActivityParser) {
+	event.SetCoreFields(p.LogType(), event.Time, event)
+
+	event.AppendAnyIPAddressPtr(event.CallerIPAddress)
+	if event.Identity != nil {
+		if upn, ok := event.Identity.Claims[ClaimUPN]; ok {
+			event.AppendAnyUsernames(upn)
+			event.AppendAnyEmails(upn)
+		}
+		if ip, ok := event.Identity.Claims[ClaimIPAddress]; ok {
+			event.AppendAnyIPAddress(ip)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestActivity(t *testing.T) {
+	// nolint:lll
+	log := `{
+  "records": [
+    {
+      "time": "2020-06-01T10:15:30.1234567Z",
+      "resourceId": "/SUBSCRIPTIONS/0D2B5E3A-6F3C-4E2B-9D8A-7C1B2A3D4E5F/RESOURCEGROUPS/PROD/PROVIDERS/MICROSOFT.COMPUTE/VIRTUALMACHINES/WEB-1",
+      "operationName": "MICROSOFT.COMPUTE/VIRTUALMACHINES/WRITE",
+      "category": "Administrative",
+      "resultType": "Start",
+      "resultSignature": "Started.",
+      "durationMs": 0,
+      "callerIpAddress": "203.0.113.25",
+      "correlationId": "8a5e3f6c-2b1d-4c7e-9f0a-1b2c3d4e5f6a",
+      "identity": {
+        "authorization": {
+          "scope": "/subscriptions/0d2b5e3a-6f3c-4e2b-9d8a-7c1b2a3d4e5f/resourceGroups/prod/providers/Microsoft.Compute/virtualMachines/web-1",
+          "action": "Microsoft.Compute/virtualMachines/write",
+          "evidence": {"role": "Contributor"}
+        },
+        "claims": {
+          "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn": "alice@example.com",
+          "ipaddr": "203.0.113.25",
+          "name": "Alice"
+        }
+      },
+      "level": "Information",
+      "location": "global",
+      "properties": {"statusCode": "Created"}
+    },
+    {
+      "time": "2020-06-01T10:16:00.0000000Z",
+      "resourceId": "/SUBSCRIPTIONS/0D2B5E3A-6F3C-4E2B-9D8A-7C1B2A3D4E5F",
+      "operationName": "Microsoft.Security/locations/alerts/activate/action",
+      "category": "Security",
+      "resultType": "Active",
+      "durationMs": "120",
+      "level": "Warning",
+      "location": "westeurope"
+    }
+  ]
+}`
+
+	writeTime := time.Date(2020, 6, 1, 10, 15, 30, 123456700, time.UTC)
+	durationWrite := numerics.Int64(0)
+	writeEvent := &Activity{
+		Time:            (*timestamp.RFC3339)(&writeTime),
+		ResourceID:      aws.String("/SUBSCRIPTIONS/0D2B5E3A-6F3C-4E2B-9D8A-7C1B2A3D4E5F/RESOURCEGROUPS/PROD/PROVIDERS/MICROSOFT.COMPUTE/VIRTUALMACHINES/WEB-1"),
+		OperationName:   aws.String("MICROSOFT.COMPUTE/VIRTUALMACHINES/WRITE"),
+		Category:        aws.String("Administrative"),
+		ResultType:      aws.String("Start"),
+		ResultSignature: aws.String("Started."),
+		DurationMs:      &durationWrite,
+		CallerIPAddress: aws.String("203.0.113.25"),
+		CorrelationID:   aws.String("8a5e3f6c-2b1d-4c7e-9f0a-1b2c3d4e5f6a"),
+		Identity: &ActivityIdentity{
+			Authorization: &ActivityAuthorization{
+				Scope:    aws.String("/subscriptions/0d2b5e3a-6f3c-4e2b-9d8a-7c1b2a3d4e5f/resourceGroups/prod/providers/Microsoft.Compute/virtualMachines/web-1"),
+				Action:   aws.String("Microsoft.Compute/virtualMachines/write"),
+				Evidence: jsoniter.RawMessage(`{"role": "Contributor"}`),
+			},
+			Claims: map[string]string{
+				"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn": "alice@example.com",
+				"ipaddr": "203.0.113.25",
+				"name":   "Alice",
+			},
+		},
+		Level:      aws.String("Information"),
+		Location:   aws.String("global"),
+		Properties: jsoniter.RawMessage(`{"statusCode": "Created"}`),
+	}
+	writeEvent.SetCoreFields(TypeActivity, writeEvent.Time, writeEvent)
+	writeEvent.AppendAnyIPAddress("203.0.113.25")
+	writeEvent.AppendAnyUsernames("alice@example.com")
+	writeEvent.AppendAnyEmails("alice@example.com")
+
+	alertTime := time.Date(2020, 6, 1, 10, 16, 0, 0, time.UTC)
+	durationAlert := numerics.Int64(120)
+	alertEvent := &Activity{
+		Time:          (*timestamp.RFC3339)(&alertTime),
+		ResourceID:    aws.String("/SUBSCRIPTIONS/0D2B5E3A-6F3C-4E2B-9D8A-7C1B2A3D4E5F"),
+		OperationName: aws.String("Microsoft.Security/locations/alerts/activate/action"),
+		Category:      aws.String("Security"),
+		ResultType:    aws.String("Active"),
+		DurationMs:    &durationAlert,
+		Level:         aws.String("Warning"),
+		Location:      aws.String("westeurope"),
+	}
+	alertEvent.SetCoreFields(TypeActivity, alertEvent.Time, alertEvent)
+
+	testutil.CheckPantherParser(t, log, &ActivityParser{}, &writeEvent.PantherLog, &alertEvent.PantherLog)
+}
+
+func TestActivitySingleRecord(t *testing.T) {
+	log := `{"time":"2020-06-01T10:16:00Z","operationName":"Microsoft.Resources/deployments/write","category":"Administrative"}`
+
+	tm := time.Date(2020, 6, 1, 10, 16, 0, 0, time.UTC)
+	event := &Activity{
+		Time:          (*timestamp.RFC3339)(&tm),
+		OperationName: aws.String("Microsoft.Resources/deployments/write"),
+		Category:      aws.String("Administrative"),
+	}
+	event.SetCoreFields(TypeActivity, event.Time, event)
+
+	testutil.CheckPantherParser(t, log, &ActivityParser{}, &event.PantherLog)
+}
+
+func TestActivityInvalid(t *testing.T) {
+	parser := (&ActivityParser{}).New()
+	// Azure AD categories are handled by other log types
+	_, err := parser.Parse(`{"time":"2020-06-01T10:16:00Z","operationName":"Sign-in activity","category":"SignInLogs"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"records":[{"category":"Administrative"}]}`)
+	require.Error(t, err)
+}
+
+func TestActivityLogType(t *testing.T) {
+	parser := &ActivityParser{}
+	require.Equal(t, "Azure.Activity", parser.LogType())
+}
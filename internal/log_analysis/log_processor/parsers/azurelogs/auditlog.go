@@ -0,0 +1,179 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// AuditLogRecords is the document Azure Monitor writes to storage accounts
+type AuditLogRecords struct {
+	Records []*AuditLog `json:"records" validate:"required,dive"`
+}
+
+// nolint:lll
+type AuditLog struct {
+	Time             *timestamp.RFC3339  `json:"time" validate:"required" description:"The date and time in UTC."`
+	ResourceID       *string             `json:"resourceId,omitempty" description:"The resource ID of the Azure AD tenant."`
+	OperationName    *string             `json:"operationName" validate:"required" description:"The name of the operation."`
+	OperationVersion *string             `json:"operationVersion,omitempty" description:"The REST API version that's requested by the client."`
+	Category         *string             `json:"category" validate:"required,eq=AuditLogs" description:"For audit logs, this value is always AuditLogs."`
+	TenantID         *string             `json:"tenantId,omitempty" description:"The tenant GUID that's associated with the logs."`
+	ResultSignature  *string             `json:"resultSignature,omitempty" description:"This field is unmapped, and you can safely ignore it."`
+	ResultType       *string             `json:"resultType,omitempty" description:"The result of the operation."`
+	DurationMs       *numerics.Int64     `json:"durationMs,omitempty" description:"This field is unmapped, and you can safely ignore it."`
+	CallerIPAddress  *string             `json:"callerIpAddress,omitempty" description:"The IP address of the client that made the request."`
+	CorrelationID    *string             `json:"correlationId,omitempty" description:"The optional GUID that's passed by the client. This value can help correlate client-side operations with server-side operations."`
+	Identity         *string             `json:"identity,omitempty" description:"The identity from the token that was presented when you made the request."`
+	Level            *numerics.Integer   `json:"Level,omitempty" description:"Provides the type of message. For audit logs, it is always 4 (Informational)."`
+	Properties       *AuditLogProperties `json:"properties" validate:"required" description:"Lists all the properties that are associated with the audit event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type AuditLogProperties struct {
+	ID                  *string                  `json:"id,omitempty" description:"Unique ID representing the activity."`
+	Category            *string                  `json:"category,omitempty" description:"Indicates which resource category is targeted by the activity (ie UserManagement, GroupManagement or ApplicationManagement)."`
+	CorrelationID       *string                  `json:"correlationId,omitempty" description:"Indicates a unique ID that helps correlate activities that span across various services."`
+	Result              *string                  `json:"result,omitempty" description:"Indicates the result of the activity (ie success, failure or timeout)."`
+	ResultReason        *string                  `json:"resultReason,omitempty" description:"Describes cause of failure or timeout results."`
+	ActivityDisplayName *string                  `json:"activityDisplayName,omitempty" description:"Indicates the activity name or the operation name."`
+	ActivityDateTime    *timestamp.RFC3339       `json:"activityDateTime,omitempty" description:"Indicates the date and time the activity was performed."`
+	LoggedByService     *string                  `json:"loggedByService,omitempty" description:"Indicates information on which service initiated the activity (ie Core Directory, Self-service Password Management or Invited Users)."`
+	OperationType       *string                  `json:"operationType,omitempty" description:"Indicates the type of operation that was performed (ie Add, Assign, Update, Unassign or Delete)."`
+	InitiatedBy         *AuditLogInitiatedBy     `json:"initiatedBy,omitempty" description:"Indicates information about the user or app initiated the activity."`
+	TargetResources     []AuditLogTargetResource `json:"targetResources,omitempty" description:"Indicates information on which resource was changed due to the activity."`
+	AdditionalDetails   []AuditLogKeyValue       `json:"additionalDetails,omitempty" description:"Indicates additional details on the activity."`
+}
+
+// nolint:lll
+type AuditLogInitiatedBy struct {
+	User *AuditLogUserIdentity `json:"user,omitempty" description:"The user that initiated the activity."`
+	App  *AuditLogAppIdentity  `json:"app,omitempty" description:"The application that initiated the activity."`
+}
+
+// nolint:lll
+type AuditLogUserIdentity struct {
+	ID                *string             `json:"id,omitempty" description:"The ID of the user."`
+	DisplayName       *string             `json:"displayName,omitempty" description:"The display name of the user."`
+	UserPrincipalName *string             `json:"userPrincipalName,omitempty" description:"The user principal name of the user."`
+	IPAddress         *string             `json:"ipAddress,omitempty" description:"The IP address the user initiated the activity from."`
+	Roles             jsoniter.RawMessage `json:"roles,omitempty" description:"The roles of the user."`
+}
+
+// nolint:lll
+type AuditLogAppIdentity struct {
+	AppID                *string `json:"appId,omitempty" description:"The ID of the application."`
+	DisplayName          *string `json:"displayName,omitempty" description:"The display name of the application."`
+	ServicePrincipalID   *string `json:"servicePrincipalId,omitempty" description:"The ID of the service principal of the application."`
+	ServicePrincipalName *string `json:"servicePrincipalName,omitempty" description:"The name of the service principal of the application."`
+}
+
+// nolint:lll
+type AuditLogTargetResource struct {
+	ID                 *string                    `json:"id,omitempty" description:"Indicates the unique ID of the resource."`
+	DisplayName        *string                    `json:"displayName,omitempty" description:"Indicates the visible name defined for the resource."`
+	Type               *string                    `json:"type,omitempty" description:"Describes the resource type (ie User, Group or Application)."`
+	UserPrincipalName  *string                    `json:"userPrincipalName,omitempty" description:"When type is set to User, this includes the user name that initiated the action."`
+	GroupType          *string                    `json:"groupType,omitempty" description:"When type is set to Group, this indicates the group type."`
+	ModifiedProperties []AuditLogModifiedProperty `json:"modifiedProperties,omitempty" description:"Indicates name, old value and new value of each attribute that changed."`
+}
+
+// nolint:lll
+type AuditLogModifiedProperty struct {
+	DisplayName *string `json:"displayName,omitempty" description:"Indicates the property name of the target attribute that was changed."`
+	OldValue    *string `json:"oldValue,omitempty" description:"Indicates the previous value (before the update) for the property."`
+	NewValue    *string `json:"newValue,omitempty" description:"Indicates the updated value for the property."`
+}
+
+// nolint:lll
+type AuditLogKeyValue struct {
+	Key   *string `json:"key,omitempty" description:"The key of the detail."`
+	Value *string `json:"value,omitempty" description:"The value of the detail."`
+}
+
+// AuditLogParser parses Azure AD audit logs
+type AuditLogParser struct{}
+
+var _ parsers.LogParser = (*AuditLogParser)(nil)
+
+func (p *AuditLogParser) New() parsers.LogParser {
+	return &AuditLogParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AuditLogParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	auditLogRecords := &AuditLogRecords{}
+	err := jsoniter.UnmarshalFromString(log, auditLogRecords)
+	if err != nil {
+		return nil, err
+	}
+	// Records can also be written one per line
+	if auditLogRecords.Records == nil {
+		auditLog := &AuditLog{}
+		if err := jsoniter.UnmarshalFromString(log, auditLog); err != nil {
+			return nil, err
+		}
+		auditLogRecords.Records = []*AuditLog{auditLog}
+	}
+
+	for _, event := range auditLogRecords.Records {
+		event.updatePantherFields(p)
+	}
+
+	if err := parsers.Validator.Struct(auditLogRecords); err != nil {
+		return nil, err
+	}
+	result := make([]*parsers.PantherLog, len(auditLogRecords.Records))
+	for i, event := range auditLogRecords.Records {
+		result[i] = event.Log()
+	}
+	return result, nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AuditLogParser) LogType() string {
+	return TypeAuditLog
+}
+
+func (event *AuditLog) updatePantherFields(p *AuditLogParser) {
+	event.SetCoreFields(p.LogType(), event.Time, event)
+
+	event.AppendAnyIPAddressPtr(event.CallerIPAddress)
+	props := event.Properties
+	if props == nil {
+		return
+	}
+	if initiatedBy := props.InitiatedBy; initiatedBy != nil && initiatedBy.User != nil {
+		event.AppendAnyIPAddressPtr(initiatedBy.User.IPAddress)
+		event.AppendAnyUsernamePtrs(initiatedBy.User.UserPrincipalName)
+		event.AppendAnyEmailPtrs(initiatedBy.User.UserPrincipalName)
+	}
+	for i := range props.TargetResources {
+		upn := props.TargetResources[i].UserPrincipalName
+		event.AppendAnyUsernamePtrs(upn)
+		event.AppendAnyEmailPtrs(upn)
+	}
+}
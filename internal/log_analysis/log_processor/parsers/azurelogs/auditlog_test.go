@@ -0,0 +1,171 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAuditLog(t *testing.T) {
+	// nolint:lll
+	log := `{
+  "records": [
+    {
+      "time": "2020-06-01T09:45:10.2945112Z",
+      "resourceId": "/tenants/3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b/providers/Microsoft.aadiam",
+      "operationName": "Add member to group",
+      "operationVersion": "1.0",
+      "category": "AuditLogs",
+      "tenantId": "3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b",
+      "resultSignature": "None",
+      "durationMs": 0,
+      "callerIpAddress": "<null>",
+      "correlationId": "0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e",
+      "Level": 4,
+      "properties": {
+        "id": "Directory_0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e_ABCDE_12345678",
+        "category": "GroupManagement",
+        "correlationId": "0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e",
+        "result": "success",
+        "resultReason": "",
+        "activityDisplayName": "Add member to group",
+        "activityDateTime": "2020-06-01T09:45:10.2945112+00:00",
+        "loggedByService": "Core Directory",
+        "operationType": "Assign",
+        "initiatedBy": {
+          "user": {
+            "id": "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d",
+            "displayName": null,
+            "userPrincipalName": "admin@example.com",
+            "ipAddress": "192.0.2.77",
+            "roles": []
+          }
+        },
+        "targetResources": [
+          {
+            "id": "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a",
+            "displayName": null,
+            "type": "User",
+            "userPrincipalName": "bob@example.com",
+            "modifiedProperties": [
+              {"displayName": "Group.DisplayName", "oldValue": null, "newValue": "\"Admins\""}
+            ]
+          },
+          {
+            "id": "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+            "displayName": "Admins",
+            "type": "Group",
+            "groupType": "unifiedGroups"
+          }
+        ],
+        "additionalDetails": [{"key": "User-Agent", "value": "Mozilla/5.0"}]
+      }
+    }
+  ]
+}`
+
+	tm := time.Date(2020, 6, 1, 9, 45, 10, 294511200, time.UTC)
+	duration := numerics.Int64(0)
+	level := numerics.Integer(4)
+	event := &AuditLog{
+		Time:             (*timestamp.RFC3339)(&tm),
+		ResourceID:       aws.String("/tenants/3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b/providers/Microsoft.aadiam"),
+		OperationName:    aws.String("Add member to group"),
+		OperationVersion: aws.String("1.0"),
+		Category:         aws.String("AuditLogs"),
+		TenantID:         aws.String("3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b"),
+		ResultSignature:  aws.String("None"),
+		DurationMs:       &duration,
+		CallerIPAddress:  aws.String("<null>"),
+		CorrelationID:    aws.String("0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e"),
+		Level:            &level,
+		Properties: &AuditLogProperties{
+			ID:                  aws.String("Directory_0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e_ABCDE_12345678"),
+			Category:            aws.String("GroupManagement"),
+			CorrelationID:       aws.String("0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e"),
+			Result:              aws.String("success"),
+			ResultReason:        aws.String(""),
+			ActivityDisplayName: aws.String("Add member to group"),
+			ActivityDateTime:    (*timestamp.RFC3339)(&tm),
+			LoggedByService:     aws.String("Core Directory"),
+			OperationType:       aws.String("Assign"),
+			InitiatedBy: &AuditLogInitiatedBy{
+				User: &AuditLogUserIdentity{
+					ID:                aws.String("6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d"),
+					UserPrincipalName: aws.String("admin@example.com"),
+					IPAddress:         aws.String("192.0.2.77"),
+					Roles:             jsoniter.RawMessage(`[]`),
+				},
+			},
+			TargetResources: []AuditLogTargetResource{
+				{
+					ID:                aws.String("9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a"),
+					Type:              aws.String("User"),
+					UserPrincipalName: aws.String("bob@example.com"),
+					ModifiedProperties: []AuditLogModifiedProperty{
+						{
+							DisplayName: aws.String("Group.DisplayName"),
+							NewValue:    aws.String(`"Admins"`),
+						},
+					},
+				},
+				{
+					ID:          aws.String("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"),
+					DisplayName: aws.String("Admins"),
+					Type:        aws.String("Group"),
+					GroupType:   aws.String("unifiedGroups"),
+				},
+			},
+			AdditionalDetails: []AuditLogKeyValue{
+				{
+					Key:   aws.String("User-Agent"),
+					Value: aws.String("Mozilla/5.0"),
+				},
+			},
+		},
+	}
+	event.SetCoreFields(TypeAuditLog, event.Time, event)
+	event.AppendAnyIPAddress("192.0.2.77")
+	event.AppendAnyUsernames("admin@example.com", "bob@example.com")
+	event.AppendAnyEmails("admin@example.com", "bob@example.com")
+
+	testutil.CheckPantherParser(t, log, &AuditLogParser{}, &event.PantherLog)
+}
+
+func TestAuditLogInvalid(t *testing.T) {
+	parser := (&AuditLogParser{}).New()
+	_, err := parser.Parse(`{"time":"2020-06-01T09:45:10Z","operationName":"Add member to group","category":"AuditLogs"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"time":"2020-06-01T09:45:10Z","operationName":"Sign-in activity","category":"SignInLogs","properties":{}}`)
+	require.Error(t, err)
+}
+
+func TestAuditLogLogType(t *testing.T) {
+	parser := &AuditLogParser{}
+	require.Equal(t, "Azure.AuditLog", parser.LogType())
+}
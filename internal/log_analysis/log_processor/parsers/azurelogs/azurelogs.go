@@ -0,0 +1,62 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "Azure"
+	// TypeActivity is the log type of Azure Activity log records
+	TypeActivity = PantherPrefix + ".Activity"
+	// TypeAuditLog is the log type of Azure AD audit log records
+	TypeAuditLog = PantherPrefix + ".AuditLog"
+	// TypeSignIn is the log type of Azure AD sign-in log records
+	TypeSignIn = PantherPrefix + ".SignIn"
+)
+
+func init() {
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name: TypeActivity,
+			Description: `The Azure Activity Log provides insight into subscription-level events,
+such as when a resource is modified or when a virtual machine is started.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/azure/azure-monitor/platform/activity-log-schema`,
+			Schema:       Activity{},
+			NewParser:    parsers.AdapterFactory(&ActivityParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeAuditLog,
+			Description:  `Azure AD audit logs record the changes made to users, groups, applications and other resources of an Azure AD tenant.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/azure/active-directory/reports-monitoring/reference-azure-monitor-audit-log-schema`,
+			Schema:       AuditLog{},
+			NewParser:    parsers.AdapterFactory(&AuditLogParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeSignIn,
+			Description:  `Azure AD sign-in logs record the sign-ins of users and applications to an Azure AD tenant.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/azure/active-directory/reports-monitoring/reference-azure-monitor-sign-ins-log-schema`,
+			Schema:       SignIn{},
+			NewParser:    parsers.AdapterFactory(&SignInParser{}),
+		},
+	)
+}
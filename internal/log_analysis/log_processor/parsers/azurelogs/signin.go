@@ -0,0 +1,182 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// SignInRecords is the document Azure Monitor writes to storage accounts
+type SignInRecords struct {
+	Records []*SignIn `json:"records" validate:"required,dive"`
+}
+
+// nolint:lll
+type SignIn struct {
+	Time              *timestamp.RFC3339 `json:"time" validate:"required" description:"The date and time of the sign-in in UTC."`
+	ResourceID        *string            `json:"resourceId,omitempty" description:"The resource ID of the Azure AD tenant."`
+	OperationName     *string            `json:"operationName,omitempty" description:"For sign-ins, this value is always Sign-in activity."`
+	OperationVersion  *string            `json:"operationVersion,omitempty" description:"The REST API version that's requested by the client."`
+	Category          *string            `json:"category" validate:"required,oneof=SignInLogs NonInteractiveUserSignInLogs ServicePrincipalSignInLogs ManagedIdentitySignInLogs" description:"The category of the sign-in (ie SignInLogs or NonInteractiveUserSignInLogs)."`
+	TenantID          *string            `json:"tenantId,omitempty" description:"The tenant GUID that's associated with the logs."`
+	ResultType        *string            `json:"resultType,omitempty" description:"The result of the sign-in operation, 0 for success or an error code for failure."`
+	ResultSignature   *string            `json:"resultSignature,omitempty" description:"Contains the error code, if any, for the sign-in operation."`
+	ResultDescription *string            `json:"resultDescription,omitempty" description:"Provides the error description for the sign-in operation."`
+	DurationMs        *numerics.Int64    `json:"durationMs,omitempty" description:"Duration of the operation in milliseconds."`
+	CallerIPAddress   *string            `json:"callerIpAddress,omitempty" description:"The IP address of the client that made the request."`
+	CorrelationID     *string            `json:"correlationId,omitempty" description:"The optional GUID that's passed by the client. This value can help correlate client-side operations with server-side operations."`
+	Identity          *string            `json:"identity,omitempty" description:"The identity from the token that was presented when you made the request."`
+	Level             *numerics.Integer  `json:"Level,omitempty" description:"Provides the type of message. For sign-ins, it is always 4 (Informational)."`
+	Location          *string            `json:"location,omitempty" description:"The location of the sign-in activity."`
+	Properties        *SignInProperties  `json:"properties" validate:"required" description:"Lists all the properties that are associated with sign-ins."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type SignInProperties struct {
+	ID                               *string             `json:"id,omitempty" description:"Unique ID representing the sign-in activity."`
+	CreatedDateTime                  *timestamp.RFC3339  `json:"createdDateTime,omitempty" description:"Date and time (UTC) the sign-in was initiated."`
+	UserDisplayName                  *string             `json:"userDisplayName,omitempty" description:"Display name of the user that initiated the sign-in."`
+	UserPrincipalName                *string             `json:"userPrincipalName,omitempty" description:"User principal name of the user that initiated the sign-in."`
+	UserID                           *string             `json:"userId,omitempty" description:"ID of the user that initiated the sign-in."`
+	AppID                            *string             `json:"appId,omitempty" description:"Unique GUID representing the app ID in the Azure Active Directory."`
+	AppDisplayName                   *string             `json:"appDisplayName,omitempty" description:"App name displayed in the Azure Portal."`
+	IPAddress                        *string             `json:"ipAddress,omitempty" description:"IP address of the client used to sign in."`
+	Status                           *SignInStatus       `json:"status,omitempty" description:"Sign-in status. Includes the error code and description of the error (in case of a sign-in failure)."`
+	ClientAppUsed                    *string             `json:"clientAppUsed,omitempty" description:"Identifies the legacy client used for sign-in activity (ie Browser, Exchange ActiveSync or IMAP)."`
+	UserAgent                        *string             `json:"userAgent,omitempty" description:"The user agent of the client used to sign in."`
+	DeviceDetail                     *SignInDeviceDetail `json:"deviceDetail,omitempty" description:"Device information from where the sign-in occurred."`
+	Location                         *SignInLocation     `json:"location,omitempty" description:"Provides the city, state, and country code where the sign-in originated."`
+	CorrelationID                    *string             `json:"correlationId,omitempty" description:"The request ID sent from the client when the sign-in is initiated."`
+	ConditionalAccessStatus          *string             `json:"conditionalAccessStatus,omitempty" description:"The status of the conditional access policy triggered (ie success, failure or notApplied)."`
+	AppliedConditionalAccessPolicies jsoniter.RawMessage `json:"appliedConditionalAccessPolicies,omitempty" description:"The list of conditional access policies that are triggered by the sign-in activity."`
+	IsInteractive                    *bool               `json:"isInteractive,omitempty" description:"Indicates if a sign-in is interactive or not."`
+	TokenIssuerType                  *string             `json:"tokenIssuerType,omitempty" description:"The type of identity provider that issued the token (ie AzureAD or ADFederationServices)."`
+	AuthenticationRequirement        *string             `json:"authenticationRequirement,omitempty" description:"The level of authentication required for the sign-in (ie singleFactorAuthentication or multiFactorAuthentication)."`
+	AuthenticationDetails            jsoniter.RawMessage `json:"authenticationDetails,omitempty" description:"The result of each authentication attempt."`
+	MFADetail                        *SignInMFADetail    `json:"mfaDetail,omitempty" description:"The MFA details for the sign-in."`
+	ResourceDisplayName              *string             `json:"resourceDisplayName,omitempty" description:"Name of the resource the user signed into."`
+	ResourceID                       *string             `json:"resourceId,omitempty" description:"ID of the resource the user signed into."`
+	RiskDetail                       *string             `json:"riskDetail,omitempty" description:"The reason behind a specific state of a risky user, sign-in or a risk event."`
+	RiskLevelAggregated              *string             `json:"riskLevelAggregated,omitempty" description:"Aggregated risk level (ie none, low, medium or high)."`
+	RiskLevelDuringSignIn            *string             `json:"riskLevelDuringSignIn,omitempty" description:"Risk level during sign-in (ie none, low, medium or high)."`
+	RiskState                        *string             `json:"riskState,omitempty" description:"The risk state of a risky user, sign-in or a risk event (ie none, confirmedSafe, remediated, dismissed, atRisk or confirmedCompromised)."`
+	RiskEventTypes                   []string            `json:"riskEventTypes,omitempty" description:"The risk event types associated with the sign-in."`
+	NetworkLocationDetails           jsoniter.RawMessage `json:"networkLocationDetails,omitempty" description:"The network locations of the sign-in."`
+	ProcessingTimeInMilliseconds     *numerics.Int64     `json:"processingTimeInMilliseconds,omitempty" description:"The request processing time in milliseconds in AD STS."`
+}
+
+// nolint:lll
+type SignInStatus struct {
+	ErrorCode         *numerics.Integer `json:"errorCode,omitempty" description:"The error code of the sign-in, 0 for success."`
+	FailureReason     *string           `json:"failureReason,omitempty" description:"The cause of the error of a failed sign-in."`
+	AdditionalDetails *string           `json:"additionalDetails,omitempty" description:"Details of the sign-in status."`
+}
+
+// nolint:lll
+type SignInDeviceDetail struct {
+	DeviceID        *string `json:"deviceId,omitempty" description:"ID of the device used in the sign-in."`
+	DisplayName     *string `json:"displayName,omitempty" description:"Display name of the device."`
+	OperatingSystem *string `json:"operatingSystem,omitempty" description:"The operating system of the device."`
+	Browser         *string `json:"browser,omitempty" description:"The browser used in the sign-in."`
+	IsCompliant     *bool   `json:"isCompliant,omitempty" description:"Indicates whether the device is compliant."`
+	IsManaged       *bool   `json:"isManaged,omitempty" description:"Indicates whether the device is managed."`
+	TrustType       *string `json:"trustType,omitempty" description:"How the device is joined to Azure AD."`
+}
+
+// nolint:lll
+type SignInLocation struct {
+	City            *string               `json:"city,omitempty" description:"The city of the sign-in."`
+	State           *string               `json:"state,omitempty" description:"The state of the sign-in."`
+	CountryOrRegion *string               `json:"countryOrRegion,omitempty" description:"The country code of the sign-in."`
+	GeoCoordinates  *SignInGeoCoordinates `json:"geoCoordinates,omitempty" description:"The latitude and longitude of the sign-in."`
+}
+
+// nolint:lll
+type SignInGeoCoordinates struct {
+	Latitude  *float64 `json:"latitude,omitempty" description:"The latitude of the sign-in."`
+	Longitude *float64 `json:"longitude,omitempty" description:"The longitude of the sign-in."`
+}
+
+// nolint:lll
+type SignInMFADetail struct {
+	AuthMethod *string `json:"authMethod,omitempty" description:"The MFA method used (ie Text message or Mobile app notification)."`
+	AuthDetail *string `json:"authDetail,omitempty" description:"Details of the MFA method (ie the phone number)."`
+}
+
+// SignInParser parses Azure AD sign-in logs
+type SignInParser struct{}
+
+var _ parsers.LogParser = (*SignInParser)(nil)
+
+func (p *SignInParser) New() parsers.LogParser {
+	return &SignInParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *SignInParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	signInRecords := &SignInRecords{}
+	err := jsoniter.UnmarshalFromString(log, signInRecords)
+	if err != nil {
+		return nil, err
+	}
+	// Records can also be written one per line
+	if signInRecords.Records == nil {
+		signIn := &SignIn{}
+		if err := jsoniter.UnmarshalFromString(log, signIn); err != nil {
+			return nil, err
+		}
+		signInRecords.Records = []*SignIn{signIn}
+	}
+
+	for _, event := range signInRecords.Records {
+		event.updatePantherFields(p)
+	}
+
+	if err := parsers.Validator.Struct(signInRecords); err != nil {
+		return nil, err
+	}
+	result := make([]*parsers.PantherLog, len(signInRecords.Records))
+	for i, event := range signInRecords.Records {
+		result[i] = event.Log()
+	}
+	return result, nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *SignInParser) LogType() string {
+	return TypeSignIn
+}
+
+func (event *SignIn) updatePantherFields(p *SignInParser) {
+	event.SetCoreFields(p.LogType(), event.Time, event)
+
+	event.AppendAnyIPAddressPtr(event.CallerIPAddress)
+	if props := event.Properties; props != nil {
+		event.AppendAnyIPAddressPtr(props.IPAddress)
+		event.AppendAnyUsernamePtrs(props.UserPrincipalName)
+		event.AppendAnyEmailPtrs(props.UserPrincipalName)
+	}
+}
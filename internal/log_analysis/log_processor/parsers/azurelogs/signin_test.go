@@ -0,0 +1,123 @@
+package azurelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSignIn(t *testing.T) {
+	// nolint:lll
+	log := `{"time":"2020-06-01T08:30:12.4852281Z","resourceId":"/tenants/3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b/providers/Microsoft.aadiam","operationName":"Sign-in activity","operationVersion":"1.0","category":"SignInLogs","tenantId":"3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b","resultType":"50126","resultSignature":"None","resultDescription":"Invalid username or password or Invalid on-premise username or password.","durationMs":0,"callerIpAddress":"198.51.100.14","correlationId":"f4e3d2c1-b0a9-4876-9543-210fedcba987","identity":"Bob Smith","Level":4,"location":"GR","properties":{"id":"a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d","createdDateTime":"2020-06-01T08:30:12.4852281+00:00","userDisplayName":"Bob Smith","userPrincipalName":"bob@example.com","userId":"9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a","appId":"c44b4083-3bb0-49c1-b47d-974e53cbdf3c","appDisplayName":"Azure Portal","ipAddress":"198.51.100.14","status":{"errorCode":50126,"failureReason":"Invalid username or password or Invalid on-premise username or password."},"clientAppUsed":"Browser","userAgent":"Mozilla/5.0","deviceDetail":{"deviceId":"","operatingSystem":"Windows 10","browser":"Chrome 83.0.4103"},"location":{"city":"Athens","state":"Attiki","countryOrRegion":"GR","geoCoordinates":{"latitude":37.98,"longitude":23.72}},"correlationId":"f4e3d2c1-b0a9-4876-9543-210fedcba987","conditionalAccessStatus":"notApplied","appliedConditionalAccessPolicies":[],"isInteractive":true,"tokenIssuerType":"AzureAD","authenticationRequirement":"singleFactorAuthentication","riskDetail":"none","riskLevelAggregated":"none","riskLevelDuringSignIn":"none","riskState":"none","riskEventTypes":[],"resourceDisplayName":"Windows Azure Service Management API","resourceId":"797f4846-ba00-4fd7-ba43-dac1f8f63013","processingTimeInMilliseconds":"104"}}`
+
+	tm := time.Date(2020, 6, 1, 8, 30, 12, 485228100, time.UTC)
+	duration := numerics.Int64(0)
+	level := numerics.Integer(4)
+	errorCode := numerics.Integer(50126)
+	processingTime := numerics.Int64(104)
+	event := &SignIn{
+		Time:              (*timestamp.RFC3339)(&tm),
+		ResourceID:        aws.String("/tenants/3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b/providers/Microsoft.aadiam"),
+		OperationName:     aws.String("Sign-in activity"),
+		OperationVersion:  aws.String("1.0"),
+		Category:          aws.String("SignInLogs"),
+		TenantID:          aws.String("3c1f2a4b-5d6e-4f70-8a9b-0c1d2e3f4a5b"),
+		ResultType:        aws.String("50126"),
+		ResultSignature:   aws.String("None"),
+		ResultDescription: aws.String("Invalid username or password or Invalid on-premise username or password."),
+		DurationMs:        &duration,
+		CallerIPAddress:   aws.String("198.51.100.14"),
+		CorrelationID:     aws.String("f4e3d2c1-b0a9-4876-9543-210fedcba987"),
+		Identity:          aws.String("Bob Smith"),
+		Level:             &level,
+		Location:          aws.String("GR"),
+		Properties: &SignInProperties{
+			ID:                aws.String("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+			CreatedDateTime:   (*timestamp.RFC3339)(&tm),
+			UserDisplayName:   aws.String("Bob Smith"),
+			UserPrincipalName: aws.String("bob@example.com"),
+			UserID:            aws.String("9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a"),
+			AppID:             aws.String("c44b4083-3bb0-49c1-b47d-974e53cbdf3c"),
+			AppDisplayName:    aws.String("Azure Portal"),
+			IPAddress:         aws.String("198.51.100.14"),
+			Status: &SignInStatus{
+				ErrorCode:     &errorCode,
+				FailureReason: aws.String("Invalid username or password or Invalid on-premise username or password."),
+			},
+			ClientAppUsed: aws.String("Browser"),
+			UserAgent:     aws.String("Mozilla/5.0"),
+			DeviceDetail: &SignInDeviceDetail{
+				DeviceID:        aws.String(""),
+				OperatingSystem: aws.String("Windows 10"),
+				Browser:         aws.String("Chrome 83.0.4103"),
+			},
+			Location: &SignInLocation{
+				City:            aws.String("Athens"),
+				State:           aws.String("Attiki"),
+				CountryOrRegion: aws.String("GR"),
+				GeoCoordinates: &SignInGeoCoordinates{
+					Latitude:  aws.Float64(37.98),
+					Longitude: aws.Float64(23.72),
+				},
+			},
+			CorrelationID:                    aws.String("f4e3d2c1-b0a9-4876-9543-210fedcba987"),
+			ConditionalAccessStatus:          aws.String("notApplied"),
+			AppliedConditionalAccessPolicies: jsoniter.RawMessage(`[]`),
+			IsInteractive:                    aws.Bool(true),
+			TokenIssuerType:                  aws.String("AzureAD"),
+			AuthenticationRequirement:        aws.String("singleFactorAuthentication"),
+			RiskDetail:                       aws.String("none"),
+			RiskLevelAggregated:              aws.String("none"),
+			RiskLevelDuringSignIn:            aws.String("none"),
+			RiskState:                        aws.String("none"),
+			RiskEventTypes:                   []string{},
+			ResourceDisplayName:              aws.String("Windows Azure Service Management API"),
+			ResourceID:                       aws.String("797f4846-ba00-4fd7-ba43-dac1f8f63013"),
+			ProcessingTimeInMilliseconds:     &processingTime,
+		},
+	}
+	event.SetCoreFields(TypeSignIn, event.Time, event)
+	event.AppendAnyIPAddress("198.51.100.14")
+	event.AppendAnyUsernames("bob@example.com")
+	event.AppendAnyEmails("bob@example.com")
+
+	testutil.CheckPantherParser(t, log, &SignInParser{}, &event.PantherLog)
+}
+
+func TestSignInInvalid(t *testing.T) {
+	parser := (&SignInParser{}).New()
+	_, err := parser.Parse(`{"time":"2020-06-01T08:30:12Z","category":"SignInLogs"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"time":"2020-06-01T08:30:12Z","category":"AuditLogs","properties":{}}`)
+	require.Error(t, err)
+}
+
+func TestSignInLogType(t *testing.T) {
+	parser := &SignInParser{}
+	require.Equal(t, "Azure.SignIn", parser.LogType())
+}
@@ -0,0 +1,475 @@
+package ceflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type CEF struct {
+	Syslog        *sysloglogs.Header `json:"syslog,omitempty" description:"The syslog header of CEF events embedded in RFC3164 or RFC5424 syslog messages."`
+	Version       *int               `json:"version" validate:"required" description:"The version of the CEF format."`
+	DeviceVendor  *string            `json:"deviceVendor" validate:"required" description:"The vendor of the sending device."`
+	DeviceProduct *string            `json:"deviceProduct" validate:"required" description:"The product name of the sending device."`
+	DeviceVersion *string            `json:"deviceVersion" validate:"required" description:"The version of the sending device."`
+	SignatureID   *string            `json:"signatureId" validate:"required" description:"The Device Event Class ID, a unique identifier per event type."`
+	Name          *string            `json:"name" validate:"required" description:"A human-readable description of the event."`
+	Severity      *string            `json:"severity" validate:"required" description:"The importance of the event, either an integer from 0 to 10 or one of Unknown, Low, Medium, High and Very-High."`
+	Extensions    map[string]string  `json:"extensions,omitempty" description:"All the key/value pairs of the CEF extension, by key."`
+
+	DeviceAction                 *string            `json:"deviceAction,omitempty" description:"The action taken by the device (act)."`
+	ApplicationProtocol          *string            `json:"applicationProtocol,omitempty" description:"The application level protocol, eg. HTTP, HTTPS, SSHv2, Telnet, POP, IMAP (app)."`
+	DeviceEventCategory          *string            `json:"deviceEventCategory,omitempty" description:"The category assigned by the originating device (cat)."`
+	BaseEventCount               *int64             `json:"baseEventCount,omitempty" description:"The number of times this same event was observed (cnt)."`
+	Message                      *string            `json:"message,omitempty" description:"A message that gives more details about the event (msg)."`
+	EventOutcome                 *string            `json:"eventOutcome,omitempty" description:"The outcome of the event, eg. success or failure (outcome)."`
+	Reason                       *string            `json:"reason,omitempty" description:"The reason an audit event was generated."`
+	TransportProtocol            *string            `json:"transportProtocol,omitempty" description:"The Layer-4 protocol used, eg. TCP or UDP (proto)."`
+	DeviceDirection              *int               `json:"deviceDirection,omitempty" description:"The direction of the observed communication, 0 for inbound and 1 for outbound."`
+	DeviceReceiptTime            *timestamp.RFC3339 `json:"deviceReceiptTime,omitempty" description:"The time at which the event related to the activity was received (rt)."`
+	StartTime                    *timestamp.RFC3339 `json:"startTime,omitempty" description:"The time when the activity the event referred to started (start)."`
+	EndTime                      *timestamp.RFC3339 `json:"endTime,omitempty" description:"The time at which the activity related to the event ended (end)."`
+	DeviceAddress                *string            `json:"deviceAddress,omitempty" description:"The IP address of the device that generated the event (dvc)."`
+	DeviceHostName               *string            `json:"deviceHostName,omitempty" description:"The hostname of the device that generated the event (dvchost)."`
+	DeviceExternalID             *string            `json:"deviceExternalId,omitempty" description:"A name that uniquely identifies the device generating the event."`
+	DeviceProcessName            *string            `json:"deviceProcessName,omitempty" description:"The name of the process generating the event."`
+	DeviceInboundInterface       *string            `json:"deviceInboundInterface,omitempty" description:"The interface on which the packet or data entered the device."`
+	DeviceOutboundInterface      *string            `json:"deviceOutboundInterface,omitempty" description:"The interface on which the packet or data left the device."`
+	ExternalID                   *string            `json:"externalId,omitempty" description:"The ID used by the originating device, typically an incrementing number."`
+	SourceAddress                *string            `json:"sourceAddress,omitempty" description:"The source IP address (src)."`
+	SourceHostName               *string            `json:"sourceHostName,omitempty" description:"The source hostname (shost)."`
+	SourceMacAddress             *string            `json:"sourceMacAddress,omitempty" description:"The source MAC address (smac)."`
+	SourcePort                   *uint16            `json:"sourcePort,omitempty" description:"The source port (spt)."`
+	SourceTranslatedAddress      *string            `json:"sourceTranslatedAddress,omitempty" description:"The translated source IP address, eg. after NAT."`
+	SourceTranslatedPort         *uint16            `json:"sourceTranslatedPort,omitempty" description:"The translated source port, eg. after NAT."`
+	SourceDNSDomain              *string            `json:"sourceDnsDomain,omitempty" description:"The DNS domain part of the source FQDN."`
+	SourceNtDomain               *string            `json:"sourceNtDomain,omitempty" description:"The Windows domain name of the source address (sntdom)."`
+	SourceUserID                 *string            `json:"sourceUserId,omitempty" description:"The ID of the source user (suid)."`
+	SourceUserName               *string            `json:"sourceUserName,omitempty" description:"The name of the source user (suser)."`
+	SourceUserPrivileges         *string            `json:"sourceUserPrivileges,omitempty" description:"The privileges of the source user, eg. Administrator, User or Guest (spriv)."`
+	SourceProcessID              *int64             `json:"sourceProcessId,omitempty" description:"The ID of the source process (spid)."`
+	SourceProcessName            *string            `json:"sourceProcessName,omitempty" description:"The name of the source process (sproc)."`
+	DestinationAddress           *string            `json:"destinationAddress,omitempty" description:"The destination IP address (dst)."`
+	DestinationHostName          *string            `json:"destinationHostName,omitempty" description:"The destination hostname (dhost)."`
+	DestinationMacAddress        *string            `json:"destinationMacAddress,omitempty" description:"The destination MAC address (dmac)."`
+	DestinationPort              *uint16            `json:"destinationPort,omitempty" description:"The destination port (dpt)."`
+	DestinationTranslatedAddress *string            `json:"destinationTranslatedAddress,omitempty" description:"The translated destination IP address, eg. after NAT."`
+	DestinationTranslatedPort    *uint16            `json:"destinationTranslatedPort,omitempty" description:"The translated destination port, eg. after NAT."`
+	DestinationDNSDomain         *string            `json:"destinationDnsDomain,omitempty" description:"The DNS domain part of the destination FQDN."`
+	DestinationNtDomain          *string            `json:"destinationNtDomain,omitempty" description:"The Windows domain name of the destination address (dntdom)."`
+	DestinationUserID            *string            `json:"destinationUserId,omitempty" description:"The ID of the destination user (duid)."`
+	DestinationUserName          *string            `json:"destinationUserName,omitempty" description:"The name of the destination user (duser)."`
+	DestinationUserPrivileges    *string            `json:"destinationUserPrivileges,omitempty" description:"The privileges of the destination user, eg. Administrator, User or Guest (dpriv)."`
+	DestinationProcessID         *int64             `json:"destinationProcessId,omitempty" description:"The ID of the destination process (dpid)."`
+	DestinationProcessName       *string            `json:"destinationProcessName,omitempty" description:"The name of the destination process (dproc)."`
+	BytesIn                      *int64             `json:"bytesIn,omitempty" description:"The number of bytes transferred inbound (in)."`
+	BytesOut                     *int64             `json:"bytesOut,omitempty" description:"The number of bytes transferred outbound (out)."`
+	RequestURL                   *string            `json:"requestUrl,omitempty" description:"The URL accessed for an HTTP request (request)."`
+	RequestMethod                *string            `json:"requestMethod,omitempty" description:"The method used to access a URL, eg. POST or GET."`
+	RequestClientApplication     *string            `json:"requestClientApplication,omitempty" description:"The User-Agent associated with the request."`
+	FileName                     *string            `json:"fileName,omitempty" description:"The name of the file (fname)."`
+	FilePath                     *string            `json:"filePath,omitempty" description:"The full path to the file, including the file name itself."`
+	FileHash                     *string            `json:"fileHash,omitempty" description:"The hash of the file."`
+	FileSize                     *int64             `json:"fileSize,omitempty" description:"The size of the file (fsize)."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// CEFParser parses CEF events, standalone or embedded in syslog messages
+type CEFParser struct {
+	syslog *sysloglogs.HeaderParser
+}
+
+var _ parsers.LogParser = (*CEFParser)(nil)
+
+// New returns an initialized LogParser for CEF events
+func (p *CEFParser) New() parsers.LogParser {
+	return &CEFParser{
+		syslog: sysloglogs.NewHeaderParser(),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *CEFParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	if p.syslog == nil {
+		return nil, errors.New("nil parser")
+	}
+	header, payload, err := p.syslog.Split(log, cefPrefix)
+	if err != nil {
+		return nil, err
+	}
+	event, err := parseCEF(payload)
+	if err != nil {
+		return nil, err
+	}
+	event.Syslog = header
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *CEFParser) LogType() string {
+	return TypeEvent
+}
+
+func (event *CEF) updatePantherFields(p *CEFParser) {
+	eventTime := event.DeviceReceiptTime
+	if eventTime == nil {
+		eventTime = event.StartTime
+	}
+	if eventTime == nil && event.Syslog != nil {
+		eventTime = event.Syslog.Timestamp
+	}
+	event.SetCoreFields(p.LogType(), eventTime, event)
+
+	event.AppendAnyIPAddressPtr(event.DeviceAddress)
+	event.AppendAnyIPAddressPtr(event.SourceAddress)
+	event.AppendAnyIPAddressPtr(event.SourceTranslatedAddress)
+	event.AppendAnyIPAddressPtr(event.DestinationAddress)
+	event.AppendAnyIPAddressPtr(event.DestinationTranslatedAddress)
+	for _, host := range []*string{event.DeviceHostName, event.SourceHostName, event.DestinationHostName} {
+		if !event.AppendAnyIPAddressPtr(host) {
+			event.AppendAnyDomainNamePtrs(host)
+		}
+	}
+	if event.Syslog != nil && !event.AppendAnyIPAddressPtr(event.Syslog.Hostname) {
+		event.AppendAnyDomainNamePtrs(event.Syslog.Hostname)
+	}
+	event.AppendAnyDomainNamePtrs(event.SourceDNSDomain, event.DestinationDNSDomain)
+	if event.RequestURL != nil {
+		if u, err := url.Parse(*event.RequestURL); err == nil && u.Hostname() != "" {
+			if !event.AppendAnyIPAddress(u.Hostname()) {
+				event.AppendAnyDomainNames(u.Hostname())
+			}
+		}
+	}
+	event.AppendAnyUsernamePtrs(event.SourceUserName, event.DestinationUserName)
+}
+
+const (
+	cefPrefix = "CEF:"
+	// The number of '|' separated fields in the header, the last one is the extension
+	cefHeaderFields = 8
+)
+
+// parseCEF parses a CEF event starting with the 'CEF:' prefix
+func parseCEF(payload string) (*CEF, error) {
+	fields, err := splitHeader(strings.TrimPrefix(payload, cefPrefix))
+	if err != nil {
+		return nil, err
+	}
+	version, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, errors.Errorf("invalid CEF version %q", fields[0])
+	}
+	extensions, err := parseExtension(strings.TrimSpace(fields[7]))
+	if err != nil {
+		return nil, err
+	}
+	event := &CEF{
+		Version:       &version,
+		DeviceVendor:  &fields[1],
+		DeviceProduct: &fields[2],
+		DeviceVersion: &fields[3],
+		SignatureID:   &fields[4],
+		Name:          &fields[5],
+		Severity:      &fields[6],
+		Extensions:    extensions,
+	}
+	for key, value := range extensions {
+		event.setExtensionField(key, value)
+	}
+	return event, nil
+}
+
+// splitHeader splits the '|' separated header fields, unescaping '\|' and '\\'.
+// The extension is returned unmodified as the last field.
+func splitHeader(s string) ([]string, error) {
+	fields := make([]string, 0, cefHeaderFields)
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && (s[i+1] == '|' || s[i+1] == '\\'):
+			i++
+			b.WriteByte(s[i])
+		case c == '|':
+			fields = append(fields, b.String())
+			b.Reset()
+			if len(fields) == cefHeaderFields-1 {
+				return append(fields, s[i+1:]), nil
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return nil, errors.Errorf("invalid CEF header, expected %d fields got %d", cefHeaderFields, len(fields)+1)
+}
+
+// extensionKeyRegex matches the keys of extension fields, including vendor specific ones (ie. 'ad.DestinationHost')
+var extensionKeyRegex = regexp.MustCompile(`^[\w.\-\[\]]+$`)
+
+// parseExtension parses the space separated key=value pairs of a CEF extension.
+// Values can contain spaces, so a value ends where the next key starts.
+func parseExtension(ext string) (map[string]string, error) {
+	if ext == "" {
+		return nil, nil
+	}
+	type pair struct {
+		keyStart, eq int
+	}
+	var pairs []pair
+	valueStart := 0
+	for i := 0; i < len(ext); i++ {
+		switch ext[i] {
+		case '\\':
+			i++ // skip escaped character
+		case '=':
+			keyStart := strings.LastIndexByte(ext[:i], ' ') + 1
+			if keyStart < valueStart || !extensionKeyRegex.MatchString(ext[keyStart:i]) {
+				// Unescaped '=' in a value
+				continue
+			}
+			pairs = append(pairs, pair{keyStart: keyStart, eq: i})
+			valueStart = i + 1
+		}
+	}
+	if len(pairs) == 0 || pairs[0].keyStart != 0 {
+		return nil, errors.Errorf("invalid CEF extension %q", ext)
+	}
+	extensions := make(map[string]string, len(pairs))
+	for i, pair := range pairs {
+		valueEnd := len(ext)
+		if i+1 < len(pairs) {
+			valueEnd = pairs[i+1].keyStart
+		}
+		value := strings.TrimRight(ext[pair.eq+1:valueEnd], " ")
+		extensions[ext[pair.keyStart:pair.eq]] = unescapeExtensionValue(value)
+	}
+	return extensions, nil
+}
+
+// unescapeExtensionValue replaces the escape sequences allowed in extension values
+func unescapeExtensionValue(value string) string {
+	if strings.IndexByte(value, '\\') == -1 {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) {
+			switch value[i+1] {
+			case '\\', '=':
+				c = value[i+1]
+				i++
+			case 'n':
+				c = '\n'
+				i++
+			case 'r':
+				c = '\r'
+				i++
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// setExtensionField sets the typed column of an extension field, keys can be either the short or the full name.
+// Values that do not match the type of the column are only available in the extensions map.
+func (event *CEF) setExtensionField(key, value string) {
+	switch key {
+	case "act", "deviceAction":
+		event.DeviceAction = &value
+	case "app", "applicationProtocol":
+		event.ApplicationProtocol = &value
+	case "cat", "deviceEventCategory":
+		event.DeviceEventCategory = &value
+	case "cnt", "baseEventCount":
+		event.BaseEventCount = parseInt64(value)
+	case "msg", "message":
+		event.Message = &value
+	case "outcome", "eventOutcome":
+		event.EventOutcome = &value
+	case "reason":
+		event.Reason = &value
+	case "proto", "transportProtocol":
+		event.TransportProtocol = &value
+	case "deviceDirection":
+		if direction, err := strconv.Atoi(value); err == nil {
+			event.DeviceDirection = &direction
+		}
+	case "rt", "deviceReceiptTime":
+		event.DeviceReceiptTime = parseTime(value)
+	case "start", "startTime":
+		event.StartTime = parseTime(value)
+	case "end", "endTime":
+		event.EndTime = parseTime(value)
+	case "dvc", "deviceAddress":
+		event.DeviceAddress = &value
+	case "dvchost", "deviceHostName":
+		event.DeviceHostName = &value
+	case "deviceExternalId":
+		event.DeviceExternalID = &value
+	case "deviceProcessName":
+		event.DeviceProcessName = &value
+	case "deviceInboundInterface":
+		event.DeviceInboundInterface = &value
+	case "deviceOutboundInterface":
+		event.DeviceOutboundInterface = &value
+	case "externalId":
+		event.ExternalID = &value
+	case "src", "sourceAddress":
+		event.SourceAddress = &value
+	case "shost", "sourceHostName":
+		event.SourceHostName = &value
+	case "smac", "sourceMacAddress":
+		event.SourceMacAddress = &value
+	case "spt", "sourcePort":
+		event.SourcePort = parsePort(value)
+	case "sourceTranslatedAddress":
+		event.SourceTranslatedAddress = &value
+	case "sourceTranslatedPort":
+		event.SourceTranslatedPort = parsePort(value)
+	case "sourceDnsDomain":
+		event.SourceDNSDomain = &value
+	case "sntdom", "sourceNtDomain":
+		event.SourceNtDomain = &value
+	case "suid", "sourceUserId":
+		event.SourceUserID = &value
+	case "suser", "sourceUserName":
+		event.SourceUserName = &value
+	case "spriv", "sourceUserPrivileges":
+		event.SourceUserPrivileges = &value
+	case "spid", "sourceProcessId":
+		event.SourceProcessID = parseInt64(value)
+	case "sproc", "sourceProcessName":
+		event.SourceProcessName = &value
+	case "dst", "destinationAddress":
+		event.DestinationAddress = &value
+	case "dhost", "destinationHostName":
+		event.DestinationHostName = &value
+	case "dmac", "destinationMacAddress":
+		event.DestinationMacAddress = &value
+	case "dpt", "destinationPort":
+		event.DestinationPort = parsePort(value)
+	case "destinationTranslatedAddress":
+		event.DestinationTranslatedAddress = &value
+	case "destinationTranslatedPort":
+		event.DestinationTranslatedPort = parsePort(value)
+	case "destinationDnsDomain":
+		event.DestinationDNSDomain = &value
+	case "dntdom", "destinationNtDomain":
+		event.DestinationNtDomain = &value
+	case "duid", "destinationUserId":
+		event.DestinationUserID = &value
+	case "duser", "destinationUserName":
+		event.DestinationUserName = &value
+	case "dpriv", "destinationUserPrivileges":
+		event.DestinationUserPrivileges = &value
+	case "dpid", "destinationProcessId":
+		event.DestinationProcessID = parseInt64(value)
+	case "dproc", "destinationProcessName":
+		event.DestinationProcessName = &value
+	case "in", "bytesIn":
+		event.BytesIn = parseInt64(value)
+	case "out", "bytesOut":
+		event.BytesOut = parseInt64(value)
+	case "request", "requestUrl":
+		event.RequestURL = &value
+	case "requestMethod":
+		event.RequestMethod = &value
+	case "requestClientApplication":
+		event.RequestClientApplication = &value
+	case "fname", "fileName":
+		event.FileName = &value
+	case "filePath":
+		event.FilePath = &value
+	case "fileHash":
+		event.FileHash = &value
+	case "fsize", "fileSize":
+		event.FileSize = parseInt64(value)
+	}
+}
+
+func parseInt64(value string) *int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func parsePort(value string) *uint16 {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return nil
+	}
+	port := uint16(n)
+	return &port
+}
+
+// timeLayouts are the formats of CEF timestamps besides milliseconds since epoch, the current year is assumed when missing
+var timeLayouts = []string{
+	"Jan _2 2006 15:04:05.000 MST",
+	"Jan _2 2006 15:04:05 MST",
+	"Jan _2 2006 15:04:05.000",
+	"Jan _2 2006 15:04:05",
+	"Jan _2 15:04:05.000 MST",
+	"Jan _2 15:04:05 MST",
+	"Jan _2 15:04:05.000",
+	"Jan _2 15:04:05",
+	time.RFC3339Nano,
+}
+
+func parseTime(value string) *timestamp.RFC3339 {
+	if msec, err := strconv.ParseInt(value, 10, 64); err == nil {
+		ts := timestamp.Unix(0, msec*int64(time.Millisecond))
+		return &ts
+	}
+	for _, layout := range timeLayouts {
+		ts, err := timestamp.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if t := (*time.Time)(&ts); t.Year() == 0 {
+			ts = timestamp.RFC3339(t.AddDate(time.Now().UTC().Year(), 0, 0))
+		}
+		return &ts
+	}
+	return nil
+}
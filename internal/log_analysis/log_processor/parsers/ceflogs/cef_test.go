@@ -0,0 +1,207 @@
+package ceflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestCEF(t *testing.T) {
+	// nolint:lll
+	log := `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232 suser=jdoe rt=1591024182000 msg=Detected a threat. No action needed.`
+
+	tm := time.Unix(1591024182, 0).UTC()
+	event := &CEF{
+		Version:            aws.Int(0),
+		DeviceVendor:       aws.String("Security"),
+		DeviceProduct:      aws.String("threatmanager"),
+		DeviceVersion:      aws.String("1.0"),
+		SignatureID:        aws.String("100"),
+		Name:               aws.String("worm successfully stopped"),
+		Severity:           aws.String("10"),
+		SourceAddress:      aws.String("10.0.0.1"),
+		DestinationAddress: aws.String("2.1.2.2"),
+		SourcePort:         aws.Uint16(1232),
+		SourceUserName:     aws.String("jdoe"),
+		DeviceReceiptTime:  (*timestamp.RFC3339)(&tm),
+		Message:            aws.String("Detected a threat. No action needed."),
+		Extensions: map[string]string{
+			"src":   "10.0.0.1",
+			"dst":   "2.1.2.2",
+			"spt":   "1232",
+			"suser": "jdoe",
+			"rt":    "1591024182000",
+			"msg":   "Detected a threat. No action needed.",
+		},
+	}
+	event.SetCoreFields(TypeEvent, event.DeviceReceiptTime, event)
+	event.AppendAnyIPAddress("10.0.0.1")
+	event.AppendAnyIPAddress("2.1.2.2")
+	event.AppendAnyUsernames("jdoe")
+
+	testutil.CheckPantherParser(t, log, &CEFParser{}, &event.PantherLog)
+}
+
+func TestCEFEscapes(t *testing.T) {
+	// nolint:lll
+	log := `CEF:0|security\|corp|threat\\manager|1.0|100|detected a \| in message|Very-High|fname=C:\\Windows\\System32 cs1=a\=b cs1Label=Equation request=https://example.com/?a=b&c=d msg=line1\nline2 with spaces `
+
+	event := &CEF{
+		Version:       aws.Int(0),
+		DeviceVendor:  aws.String("security|corp"),
+		DeviceProduct: aws.String(`threat\manager`),
+		DeviceVersion: aws.String("1.0"),
+		SignatureID:   aws.String("100"),
+		Name:          aws.String("detected a | in message"),
+		Severity:      aws.String("Very-High"),
+		FileName:      aws.String(`C:\Windows\System32`),
+		RequestURL:    aws.String("https://example.com/?a=b&c=d"),
+		Message:       aws.String("line1\nline2 with spaces"),
+		Extensions: map[string]string{
+			"fname":    `C:\Windows\System32`,
+			"cs1":      "a=b",
+			"cs1Label": "Equation",
+			"request":  "https://example.com/?a=b&c=d",
+			"msg":      "line1\nline2 with spaces",
+		},
+	}
+	event.SetCoreFields(TypeEvent, nil, event)
+	event.AppendAnyDomainNames("example.com")
+
+	results, err := (&CEFParser{}).New().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	// The event time defaults to the parse time
+	event.PantherEventTime = results[0].PantherEventTime
+	testutil.EqualPantherLog(t, &event.PantherLog, results, nil)
+}
+
+func TestCEFRFC3164(t *testing.T) {
+	// nolint:lll
+	log := `<134>Feb 14 19:04:54 fw01.example.com CEF:0|Palo Alto Networks|PAN-OS|9.1.0|end|TRAFFIC|1|rt=Feb 14 2020 19:04:54 GMT dvchost=fw01.example.com act=allow proto=tcp src=192.168.1.10 dst=8.8.8.8 spt=50123 dpt=53`
+
+	tm := time.Date(2020, 2, 14, 19, 4, 54, 0, time.UTC)
+	syslogTime := time.Date(time.Now().UTC().Year(), 2, 14, 19, 4, 54, 0, time.UTC)
+	event := &CEF{
+		Syslog: &sysloglogs.Header{
+			Priority:  aws.Uint8(134),
+			Facility:  aws.Uint8(16),
+			Severity:  aws.Uint8(6),
+			Timestamp: (*timestamp.RFC3339)(&syslogTime),
+			Hostname:  aws.String("fw01.example.com"),
+		},
+		Version:            aws.Int(0),
+		DeviceVendor:       aws.String("Palo Alto Networks"),
+		DeviceProduct:      aws.String("PAN-OS"),
+		DeviceVersion:      aws.String("9.1.0"),
+		SignatureID:        aws.String("end"),
+		Name:               aws.String("TRAFFIC"),
+		Severity:           aws.String("1"),
+		DeviceReceiptTime:  (*timestamp.RFC3339)(&tm),
+		DeviceHostName:     aws.String("fw01.example.com"),
+		DeviceAction:       aws.String("allow"),
+		TransportProtocol:  aws.String("tcp"),
+		SourceAddress:      aws.String("192.168.1.10"),
+		DestinationAddress: aws.String("8.8.8.8"),
+		SourcePort:         aws.Uint16(50123),
+		DestinationPort:    aws.Uint16(53),
+		Extensions: map[string]string{
+			"rt":      "Feb 14 2020 19:04:54 GMT",
+			"dvchost": "fw01.example.com",
+			"act":     "allow",
+			"proto":   "tcp",
+			"src":     "192.168.1.10",
+			"dst":     "8.8.8.8",
+			"spt":     "50123",
+			"dpt":     "53",
+		},
+	}
+	event.SetCoreFields(TypeEvent, event.DeviceReceiptTime, event)
+	event.AppendAnyIPAddress("192.168.1.10")
+	event.AppendAnyIPAddress("8.8.8.8")
+	event.AppendAnyDomainNames("fw01.example.com")
+
+	testutil.CheckPantherParser(t, log, &CEFParser{}, &event.PantherLog)
+}
+
+func TestCEFRFC5424(t *testing.T) {
+	// nolint:lll
+	log := `<14>1 2020-06-01T15:09:42Z edr01 agent 4242 ALERT - CEF:1|Example|EDR|3.2|malware|Malware detected|High|shost=laptop-12 duser=EXAMPLE\\jdoe fileHash=44d88612fea8a8f36de82e1278abb02f filePath=/tmp/eicar.com`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 42, 0, time.UTC)
+	event := &CEF{
+		Syslog: &sysloglogs.Header{
+			Priority:  aws.Uint8(14),
+			Facility:  aws.Uint8(1),
+			Severity:  aws.Uint8(6),
+			Timestamp: (*timestamp.RFC3339)(&tm),
+			Hostname:  aws.String("edr01"),
+			Appname:   aws.String("agent"),
+			ProcID:    aws.String("4242"),
+			MsgID:     aws.String("ALERT"),
+		},
+		Version:             aws.Int(1),
+		DeviceVendor:        aws.String("Example"),
+		DeviceProduct:       aws.String("EDR"),
+		DeviceVersion:       aws.String("3.2"),
+		SignatureID:         aws.String("malware"),
+		Name:                aws.String("Malware detected"),
+		Severity:            aws.String("High"),
+		SourceHostName:      aws.String("laptop-12"),
+		DestinationUserName: aws.String(`EXAMPLE\jdoe`),
+		FileHash:            aws.String("44d88612fea8a8f36de82e1278abb02f"),
+		FilePath:            aws.String("/tmp/eicar.com"),
+		Extensions: map[string]string{
+			"shost":    "laptop-12",
+			"duser":    `EXAMPLE\jdoe`,
+			"fileHash": "44d88612fea8a8f36de82e1278abb02f",
+			"filePath": "/tmp/eicar.com",
+		},
+	}
+	event.SetCoreFields(TypeEvent, event.Syslog.Timestamp, event)
+	event.AppendAnyDomainNames("laptop-12", "edr01")
+	event.AppendAnyUsernames(`EXAMPLE\jdoe`)
+
+	testutil.CheckPantherParser(t, log, &CEFParser{}, &event.PantherLog)
+}
+
+func TestCEFInvalid(t *testing.T) {
+	parser := (&CEFParser{}).New()
+	_, err := parser.Parse(`LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=10.50.1.1`)
+	require.Error(t, err)
+	_, err = parser.Parse(`CEF:0|Security|threatmanager|1.0|100|worm successfully stopped`)
+	require.Error(t, err)
+	_, err = parser.Parse(`CEF:x|Security|threatmanager|1.0|100|worm successfully stopped|10|`)
+	require.Error(t, err)
+	_, err = parser.Parse(`CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|not an extension`)
+	require.Error(t, err)
+}
+
+func TestCEFLogType(t *testing.T) {
+	parser := &CEFParser{}
+	require.Equal(t, "CEF.Event", parser.LogType())
+}
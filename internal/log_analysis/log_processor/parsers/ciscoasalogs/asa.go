@@ -0,0 +1,195 @@
+package ciscoasalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Event struct {
+	Priority  *uint8             `json:"priority,omitempty" description:"The syslog priority of the message, calculated by (Facility * 8 + Severity)."`
+	Timestamp *timestamp.RFC3339 `json:"timestamp,omitempty" description:"The time the message was logged, set when logging timestamps are enabled or by the syslog server."`
+	Hostname  *string            `json:"hostname,omitempty" description:"The hostname or device ID of the appliance, set when the logging device-id is enabled or by the syslog server."`
+	Level     *uint8             `json:"level" validate:"required,max=7" description:"The severity level of the message, from 0 (emergencies) to 7 (debugging)."`
+	MessageID *string            `json:"messageId" validate:"required" description:"The six digit number identifying the message, ie 302013."`
+	Message   *string            `json:"message" validate:"required" description:"The text of the message."`
+
+	Action                   *string `json:"action,omitempty" description:"The action of parsed messages, ie built, teardown, deny, permit, session-started or authentication-failure."`
+	Direction                *string `json:"direction,omitempty" description:"The direction of the connection, inbound or outbound."`
+	Protocol                 *string `json:"protocol,omitempty" description:"The protocol of the connection, ie TCP, UDP or icmp."`
+	ConnectionID             *int64  `json:"connectionId,omitempty" description:"The unique ID of the connection."`
+	SourceInterface          *string `json:"sourceInterface,omitempty" description:"The name of the interface of the source of the connection."`
+	SourceAddress            *string `json:"sourceAddress,omitempty" description:"The real IP address of the source. For outbound connections built the endpoints of the message are swapped so the source is the initiator."`
+	SourcePort               *uint16 `json:"sourcePort,omitempty" description:"The real port of the source."`
+	SourceMappedAddress      *string `json:"sourceMappedAddress,omitempty" description:"The NAT mapped IP address of the source."`
+	SourceMappedPort         *uint16 `json:"sourceMappedPort,omitempty" description:"The NAT mapped port of the source."`
+	SourceUser               *string `json:"sourceUser,omitempty" description:"The identity firewall user of the source."`
+	DestinationInterface     *string `json:"destinationInterface,omitempty" description:"The name of the interface of the destination of the connection."`
+	DestinationAddress       *string `json:"destinationAddress,omitempty" description:"The real IP address of the destination."`
+	DestinationPort          *uint16 `json:"destinationPort,omitempty" description:"The real port of the destination."`
+	DestinationMappedAddress *string `json:"destinationMappedAddress,omitempty" description:"The NAT mapped IP address of the destination."`
+	DestinationMappedPort    *uint16 `json:"destinationMappedPort,omitempty" description:"The NAT mapped port of the destination."`
+	DestinationUser          *string `json:"destinationUser,omitempty" description:"The identity firewall user of the destination."`
+	Duration                 *string `json:"duration,omitempty" description:"The duration of the connection or VPN session, ie 0:01:30 or 0h:01m:30s."`
+	Bytes                    *int64  `json:"bytes,omitempty" description:"The number of bytes transferred in the connection."`
+	BytesSent                *int64  `json:"bytesSent,omitempty" description:"The number of bytes transmitted in the VPN session."`
+	BytesReceived            *int64  `json:"bytesReceived,omitempty" description:"The number of bytes received in the VPN session."`
+	Reason                   *string `json:"reason,omitempty" description:"The reason the connection or VPN session was terminated or the authentication failed."`
+	TCPFlags                 *string `json:"tcpFlags,omitempty" description:"The TCP flags of the denied packet."`
+	AccessList               *string `json:"accessList,omitempty" description:"The name of the access list that matched the connection."`
+	HitCount                 *int64  `json:"hitCount,omitempty" description:"The number of times the access list entry was hit in the reporting interval."`
+	Group                    *string `json:"group,omitempty" description:"The VPN tunnel group of the session."`
+	Username                 *string `json:"username,omitempty" description:"The name of the VPN or management user."`
+	SessionType              *string `json:"sessionType,omitempty" description:"The type of the VPN session, ie AnyConnect-Parent or IPsec."`
+	AssignedAddress          *string `json:"assignedAddress,omitempty" description:"The IP address assigned to the VPN client."`
+	Server                   *string `json:"server,omitempty" description:"The IP address of the AAA server."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+const messagePrefix = "%ASA-"
+
+var (
+	// messageRegex matches the level, ID and text of a message, ie `%ASA-6-302013: Built outbound TCP connection ...`
+	messageRegex = regexp.MustCompile(`^%ASA-(\d)-(\d{6}): ?(.*)$`)
+	// headerRegex matches the syslog priority, timestamp and hostname in front of a message
+	// nolint:lll
+	headerRegex = regexp.MustCompile(`^(?:<(\d{1,3})>)?\s*(?:([A-Z][a-z]{2} +\d{1,2}(?: \d{4})? \d{2}:\d{2}:\d{2}(?:\.\d+)?|\d{4}-\d{2}-\d{2}T\S+?)(?: [A-Z]{3,4})?\s*:?\s+)?(?:([^\s:]+)\s*:?\s*)?$`)
+)
+
+// timeLayouts are the layouts of ASA timestamps, timestamps without a timezone are in UTC
+var timeLayouts = []string{
+	"Jan _2 2006 15:04:05",
+	"Jan _2 15:04:05",
+	time.RFC3339,
+}
+
+// EventParser parses Cisco ASA syslog messages
+type EventParser struct{}
+
+var _ parsers.LogParser = (*EventParser)(nil)
+
+// New returns an initialized LogParser for Cisco ASA syslog messages
+func (p *EventParser) New() parsers.LogParser {
+	return &EventParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *EventParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event, err := parseEvent(strings.TrimRight(log, "\r\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *EventParser) LogType() string {
+	return TypeEvent
+}
+
+func parseEvent(log string) (*Event, error) {
+	pos := strings.Index(log, messagePrefix)
+	if pos == -1 {
+		return nil, errors.Errorf("%q not found in log line", messagePrefix)
+	}
+	match := messageRegex.FindStringSubmatch(log[pos:])
+	if match == nil {
+		return nil, errors.New("invalid ASA message")
+	}
+	header := headerRegex.FindStringSubmatch(log[:pos])
+	if header == nil {
+		return nil, errors.New("invalid ASA message header")
+	}
+	level, _ := strconv.ParseUint(match[1], 10, 8)
+	event := &Event{
+		Level:     uint8Ptr(level),
+		MessageID: &match[2],
+		Message:   &match[3],
+	}
+	if priority, err := strconv.ParseUint(header[1], 10, 8); err == nil {
+		event.Priority = uint8Ptr(priority)
+	}
+	if header[2] != "" {
+		event.Timestamp = parseTime(header[2])
+		if event.Timestamp == nil {
+			return nil, errors.Errorf("invalid ASA timestamp %q", header[2])
+		}
+	}
+	if header[3] != "" {
+		event.Hostname = &header[3]
+	}
+	event.parseMessage()
+	return event, nil
+}
+
+func parseTime(value string) *timestamp.RFC3339 {
+	for _, layout := range timeLayouts {
+		ts, err := timestamp.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if t := (*time.Time)(&ts); t.Year() == 0 {
+			ts = timestamp.RFC3339(t.AddDate(time.Now().UTC().Year(), 0, 0))
+		}
+		return &ts
+	}
+	return nil
+}
+
+func uint8Ptr(n uint64) *uint8 {
+	u := uint8(n)
+	return &u
+}
+
+func (event *Event) updatePantherFields(p *EventParser) {
+	event.SetCoreFields(p.LogType(), event.Timestamp, event)
+
+	if !event.AppendAnyIPAddressPtr(event.Hostname) {
+		event.AppendAnyDomainNamePtrs(event.Hostname)
+	}
+	for _, addr := range []*string{
+		event.SourceAddress,
+		event.SourceMappedAddress,
+		event.DestinationAddress,
+		event.DestinationMappedAddress,
+		event.AssignedAddress,
+		event.Server,
+	} {
+		event.AppendAnyIPAddressPtr(addr)
+	}
+	event.AppendAnyUsernamePtrs(event.SourceUser, event.DestinationUser, event.Username)
+}
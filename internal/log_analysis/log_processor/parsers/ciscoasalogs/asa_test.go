@@ -0,0 +1,152 @@
+package ciscoasalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestEventBuiltOutbound(t *testing.T) {
+	// nolint:lll
+	log := `<166>Jun 01 2020 12:09:42: %ASA-6-302013: Built outbound TCP connection 12345 for outside:203.0.113.10/443 (203.0.113.10/443) to inside:10.0.0.5/51234 (198.51.100.1/31234)`
+
+	tm := time.Date(2020, 6, 1, 12, 9, 42, 0, time.UTC)
+	event := &Event{
+		Priority:                 aws.Uint8(166),
+		Timestamp:                (*timestamp.RFC3339)(&tm),
+		Level:                    aws.Uint8(6),
+		MessageID:                aws.String("302013"),
+		Message:                  aws.String("Built outbound TCP connection 12345 for outside:203.0.113.10/443 (203.0.113.10/443) to inside:10.0.0.5/51234 (198.51.100.1/31234)"),
+		Action:                   aws.String("built"),
+		Direction:                aws.String("outbound"),
+		Protocol:                 aws.String("TCP"),
+		ConnectionID:             aws.Int64(12345),
+		SourceInterface:          aws.String("inside"),
+		SourceAddress:            aws.String("10.0.0.5"),
+		SourcePort:               aws.Uint16(51234),
+		SourceMappedAddress:      aws.String("198.51.100.1"),
+		SourceMappedPort:         aws.Uint16(31234),
+		DestinationInterface:     aws.String("outside"),
+		DestinationAddress:       aws.String("203.0.113.10"),
+		DestinationPort:          aws.Uint16(443),
+		DestinationMappedAddress: aws.String("203.0.113.10"),
+		DestinationMappedPort:    aws.Uint16(443),
+	}
+	event.SetCoreFields(TypeEvent, event.Timestamp, event)
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyIPAddress("198.51.100.1")
+	event.AppendAnyIPAddress("203.0.113.10")
+
+	testutil.CheckPantherParser(t, log, &EventParser{}, &event.PantherLog)
+}
+
+func TestEventSyslogHeader(t *testing.T) {
+	// nolint:lll
+	log := `Jun  1 12:10:00 fw01.example.com %ASA-4-106023: Deny tcp src outside:203.0.113.50/40000 dst inside:10.0.0.5/22 by access-group "outside_access_in" [0x0, 0x0]`
+
+	tm := time.Date(time.Now().UTC().Year(), 6, 1, 12, 10, 0, 0, time.UTC)
+	event := &Event{
+		Timestamp:            (*timestamp.RFC3339)(&tm),
+		Hostname:             aws.String("fw01.example.com"),
+		Level:                aws.Uint8(4),
+		MessageID:            aws.String("106023"),
+		Message:              aws.String(`Deny tcp src outside:203.0.113.50/40000 dst inside:10.0.0.5/22 by access-group "outside_access_in" [0x0, 0x0]`),
+		Action:               aws.String("deny"),
+		Protocol:             aws.String("tcp"),
+		SourceInterface:      aws.String("outside"),
+		SourceAddress:        aws.String("203.0.113.50"),
+		SourcePort:           aws.Uint16(40000),
+		DestinationInterface: aws.String("inside"),
+		DestinationAddress:   aws.String("10.0.0.5"),
+		DestinationPort:      aws.Uint16(22),
+		AccessList:           aws.String("outside_access_in"),
+	}
+	event.SetCoreFields(TypeEvent, event.Timestamp, event)
+	event.AppendAnyDomainNames("fw01.example.com")
+	event.AppendAnyIPAddress("203.0.113.50")
+	event.AppendAnyIPAddress("10.0.0.5")
+
+	testutil.CheckPantherParser(t, log, &EventParser{}, &event.PantherLog)
+}
+
+func TestEventUnknownMessage(t *testing.T) {
+	log := `<166>Jun 01 2020 12:09:42 fw01 : %ASA-5-111008: User 'enable_15' executed the 'write memory' command.`
+
+	tm := time.Date(2020, 6, 1, 12, 9, 42, 0, time.UTC)
+	event := &Event{
+		Priority:  aws.Uint8(166),
+		Timestamp: (*timestamp.RFC3339)(&tm),
+		Hostname:  aws.String("fw01"),
+		Level:     aws.Uint8(5),
+		MessageID: aws.String("111008"),
+		Message:   aws.String("User 'enable_15' executed the 'write memory' command."),
+	}
+	event.SetCoreFields(TypeEvent, event.Timestamp, event)
+	event.AppendAnyDomainNames("fw01")
+
+	testutil.CheckPantherParser(t, log, &EventParser{}, &event.PantherLog)
+}
+
+func TestEventHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		log       string
+		timestamp string
+		hostname  string
+	}{
+		{`%ASA-6-302013: Built`, "", ""},
+		{`<166>%ASA-6-302013: Built`, "", ""},
+		{`Jun 01 2020 12:09:42.123 UTC: %ASA-6-302013: Built`, "2020-06-01T12:09:42.123Z", ""},
+		{`2020-06-01T12:09:42Z fw01 : %ASA-6-302013: Built`, "2020-06-01T12:09:42Z", "fw01"},
+		{`<166>Jun 01 2020 12:09:42 10.0.0.1 : %ASA-6-302013: Built`, "2020-06-01T12:09:42Z", "10.0.0.1"},
+	} {
+		event, err := parseEvent(tc.log)
+		require.NoError(t, err, tc.log)
+		if tc.timestamp == "" {
+			require.Nil(t, event.Timestamp, tc.log)
+		} else {
+			require.Equal(t, tc.timestamp, (*time.Time)(event.Timestamp).Format(time.RFC3339Nano), tc.log)
+		}
+		require.Equal(t, tc.hostname, aws.StringValue(event.Hostname), tc.log)
+	}
+}
+
+func TestEventInvalid(t *testing.T) {
+	parser := (&EventParser{}).New()
+	for _, log := range []string{
+		`Jun  1 12:10:00 fw01 sshd[123]: Accepted publickey for jdoe`,
+		`%ASA-6-30201: Built`,
+		`%ASA-9-302013: Built`,
+		`not a header %ASA-6-302013: Built`,
+	} {
+		_, err := parser.Parse(log)
+		require.Error(t, err, log)
+	}
+}
+
+func TestEventLogType(t *testing.T) {
+	parser := &EventParser{}
+	require.Equal(t, "CiscoASA.Event", parser.LogType())
+}
@@ -0,0 +1,287 @@
+package ciscoasalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Actions of the parsed messages
+const (
+	actionBuilt                 = "built"
+	actionTeardown              = "teardown"
+	actionDeny                  = "deny"
+	actionPermit                = "permit"
+	actionSessionStarted        = "session-started"
+	actionSessionTerminated     = "session-terminated"
+	actionSessionDisconnected   = "session-disconnected"
+	actionAddressAssigned       = "address-assigned"
+	actionAuthenticationSuccess = "authentication-success"
+	actionAuthenticationFailure = "authentication-failure"
+
+	directionOutbound = "outbound"
+)
+
+// Patterns of the endpoints of connection messages, ie `outside:203.0.113.10/443` or `outside:203.0.113.10/443(LOCAL\jdoe)`
+const (
+	rxSource      = `(?P<srcIface>[^:\s]+):(?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+)`
+	rxSourceUser  = `(?: ?\((?P<srcUser>[^)/,]*)\))?`
+	rxDestination = `(?P<dstIface>[^:\s]+):(?P<dstAddr>[^/\s]+)/(?P<dstPort>\d+)`
+	rxDestUser    = `(?: ?\((?P<dstUser>[^)/,]*)\))?`
+	rxVPNSession  = `^Group <(?P<group>[^>]*)> User <(?P<username>[^>]*)> IP <(?P<srcAddr>[^>]*)> `
+)
+
+// message is a parsed ASA message
+type message struct {
+	action string
+	regex  *regexp.Regexp
+}
+
+// nolint:lll
+var (
+	builtConnection = &message{
+		action: actionBuilt,
+		regex:  regexp.MustCompile(`^Built (?P<direction>inbound|outbound) (?P<protocol>TCP|UDP) connection (?P<connectionId>\d+) for ` + rxSource + ` \((?P<srcMappedAddr>[^/\s]+)/(?P<srcMappedPort>\d+)\)` + rxSourceUser + ` to ` + rxDestination + ` \((?P<dstMappedAddr>[^/\s]+)/(?P<dstMappedPort>\d+)\)` + rxDestUser),
+	}
+	teardownConnection = &message{
+		action: actionTeardown,
+		regex:  regexp.MustCompile(`^Teardown (?P<protocol>TCP|UDP) connection (?P<connectionId>\d+) for ` + rxSource + rxSourceUser + ` to ` + rxDestination + rxDestUser + ` duration (?P<duration>\d+:\d{2}:\d{2}) bytes (?P<bytes>\d+)(?: (?P<reason>.+))?$`),
+	}
+	denyAccessGroup = &message{
+		action: actionDeny,
+		regex:  regexp.MustCompile(`^Deny (?P<protocol>\S+) src (?P<srcIface>[^:\s]+):(?P<srcAddr>[^/\s]+)(?:/(?P<srcPort>\d+))?` + rxSourceUser + ` dst (?P<dstIface>[^:\s]+):(?P<dstAddr>[^/\s]+)(?:/(?P<dstPort>\d+))?` + rxDestUser + `(?: \(type \d+, code \d+\))? by access-group "(?P<accessList>[^"]*)"`),
+	}
+	denyConnection = &message{
+		action: actionDeny,
+		regex:  regexp.MustCompile(`^(?P<direction>Inbound|Outbound) (?P<protocol>TCP) connection denied from (?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+) to (?P<dstAddr>[^/\s]+)/(?P<dstPort>\d+) flags (?P<tcpFlags>.*?) +on interface (?P<srcIface>\S+)`),
+	}
+	denyUDP = &message{
+		action: actionDeny,
+		regex:  regexp.MustCompile(`^Deny (?P<direction>inbound|outbound) (?P<protocol>UDP) from (?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+) to (?P<dstAddr>[^/\s]+)/(?P<dstPort>\d+) (?:due to DNS (?:Query|Response) )?on interface (?P<srcIface>\S+)`),
+	}
+	denyNoConnection = &message{
+		action: actionDeny,
+		regex:  regexp.MustCompile(`^Deny (?P<protocol>TCP) \(no connection\) from (?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+) to (?P<dstAddr>[^/\s]+)/(?P<dstPort>\d+) flags (?P<tcpFlags>.*?) +on interface (?P<srcIface>\S+)`),
+	}
+	accessList = &message{
+		regex: regexp.MustCompile(`^access-list (?P<accessList>\S+) (?P<action>permitted|denied|est-allowed) (?P<protocol>\S+) (?P<srcIface>[^/\s]+)/(?P<srcAddr>[^(\s]+)\((?P<srcPort>\d+)\)` + rxSourceUser + ` -> (?P<dstIface>[^/\s]+)/(?P<dstAddr>[^(\s]+)\((?P<dstPort>\d+)\)` + rxDestUser + ` hit-cnt (?P<hitCount>\d+)`),
+	}
+	denyACL = &message{
+		action: actionDeny,
+		regex:  regexp.MustCompile(`^(?P<protocol>TCP|UDP) access denied by ACL from (?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+) to ` + rxDestination),
+	}
+	vpnSessionDisconnected = &message{
+		action: actionSessionDisconnected,
+		regex:  regexp.MustCompile(`^Group = (?P<group>[^,]*), Username = (?P<username>[^,]*), IP = (?P<srcAddr>[^,]*), Session disconnected\. Session Type: (?P<sessionType>[^,]*), Duration: (?P<duration>[^,]*), Bytes xmt: (?P<bytesSent>\d+), Bytes rcv: (?P<bytesReceived>\d+), Reason: (?P<reason>.*)$`),
+	}
+	anyConnectSessionStarted = &message{
+		action: actionSessionStarted,
+		regex:  regexp.MustCompile(rxVPNSession + `AnyConnect parent session started\.`),
+	}
+	webVPNSessionStarted = &message{
+		action: actionSessionStarted,
+		regex:  regexp.MustCompile(rxVPNSession + `WebVPN session started\.`),
+	}
+	webVPNSessionTerminated = &message{
+		action: actionSessionTerminated,
+		regex:  regexp.MustCompile(rxVPNSession + `WebVPN session terminated: (?P<reason>.*?)\.?$`),
+	}
+	vpnAddressAssigned = &message{
+		action: actionAddressAssigned,
+		regex:  regexp.MustCompile(rxVPNSession + `(?:IPv4 )?Address <(?P<assignedAddr>[^>]*)>.* assigned to session`),
+	}
+	aaaSuccess = &message{
+		action: actionAuthenticationSuccess,
+		regex:  regexp.MustCompile(`^AAA user authentication Successful : server = (?P<server>\S+) : user = (?P<username>.*)$`),
+	}
+	aaaRejected = &message{
+		action: actionAuthenticationFailure,
+		regex:  regexp.MustCompile(`^AAA user authentication Rejected : reason = (?P<reason>.*?) : server = (?P<server>\S+) : user = (?P<username>.*?)(?: : user IP = (?P<srcAddr>\S+))?$`),
+	}
+	userAuthSuccess = &message{
+		action: actionAuthenticationSuccess,
+		regex:  regexp.MustCompile(`^User authentication succeeded: IP address: (?P<srcAddr>[^,]+), Uname: (?P<username>.*)$`),
+	}
+	userAuthFailure = &message{
+		action: actionAuthenticationFailure,
+		regex:  regexp.MustCompile(`^User authentication failed: IP address: (?P<srcAddr>[^,]+), Uname: (?P<username>.*)$`),
+	}
+	loginDenied = &message{
+		action: actionAuthenticationFailure,
+		regex:  regexp.MustCompile(`^Login denied from (?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+) to (?P<dstIface>[^:\s]+):(?P<dstAddr>[^/\s]+)/(?P<protocol>\S+) for user "(?P<username>[^"]*)"`),
+	}
+	loginPermitted = &message{
+		action: actionAuthenticationSuccess,
+		regex:  regexp.MustCompile(`^Login permitted from (?P<srcAddr>[^/\s]+)/(?P<srcPort>\d+) to (?P<dstIface>[^:\s]+):(?P<dstAddr>[^/\s]+)/(?P<protocol>\S+) for user "(?P<username>[^"]*)"`),
+	}
+)
+
+// messages are the parsed messages by ID
+var messages = map[string]*message{
+	"106001": denyConnection,
+	"106006": denyUDP,
+	"106007": denyUDP,
+	"106015": denyNoConnection,
+	"106023": denyAccessGroup,
+	"106100": accessList,
+	"113004": aaaSuccess,
+	"113005": aaaRejected,
+	"113019": vpnSessionDisconnected,
+	"113039": anyConnectSessionStarted,
+	"302013": builtConnection,
+	"302014": teardownConnection,
+	"302015": builtConnection,
+	"302016": teardownConnection,
+	"605004": loginDenied,
+	"605005": loginPermitted,
+	"611101": userAuthSuccess,
+	"611102": userAuthFailure,
+	"710003": denyACL,
+	"716001": webVPNSessionStarted,
+	"716002": webVPNSessionTerminated,
+	"722051": vpnAddressAssigned,
+}
+
+// accessListActions normalizes the actions of access list messages
+var accessListActions = map[string]string{
+	"permitted":   actionPermit,
+	"est-allowed": actionPermit,
+	"denied":      actionDeny,
+}
+
+// parseMessage sets the columns of the known messages, other messages only have their text
+func (event *Event) parseMessage() {
+	msg, ok := messages[*event.MessageID]
+	if !ok {
+		return
+	}
+	match := msg.regex.FindStringSubmatch(*event.Message)
+	if match == nil {
+		return
+	}
+	if msg.action != "" {
+		action := msg.action
+		event.Action = &action
+	}
+	for i, name := range msg.regex.SubexpNames() {
+		if name != "" && match[i] != "" {
+			event.setField(name, match[i])
+		}
+	}
+	if event.Direction != nil && *event.Direction == directionOutbound && msg == builtConnection {
+		event.swapEndpoints()
+	}
+}
+
+func (event *Event) setField(name, value string) {
+	switch name {
+	case "action":
+		if action, ok := accessListActions[value]; ok {
+			value = action
+		}
+		event.Action = &value
+	case "direction":
+		value = strings.ToLower(value)
+		event.Direction = &value
+	case "protocol":
+		event.Protocol = &value
+	case "connectionId":
+		event.ConnectionID = parseInt64(value)
+	case "srcIface":
+		event.SourceInterface = &value
+	case "srcAddr":
+		event.SourceAddress = &value
+	case "srcPort":
+		event.SourcePort = parseUint16(value)
+	case "srcMappedAddr":
+		event.SourceMappedAddress = &value
+	case "srcMappedPort":
+		event.SourceMappedPort = parseUint16(value)
+	case "srcUser":
+		event.SourceUser = &value
+	case "dstIface":
+		event.DestinationInterface = &value
+	case "dstAddr":
+		event.DestinationAddress = &value
+	case "dstPort":
+		event.DestinationPort = parseUint16(value)
+	case "dstMappedAddr":
+		event.DestinationMappedAddress = &value
+	case "dstMappedPort":
+		event.DestinationMappedPort = parseUint16(value)
+	case "dstUser":
+		event.DestinationUser = &value
+	case "duration":
+		event.Duration = &value
+	case "bytes":
+		event.Bytes = parseInt64(value)
+	case "bytesSent":
+		event.BytesSent = parseInt64(value)
+	case "bytesReceived":
+		event.BytesReceived = parseInt64(value)
+	case "reason":
+		event.Reason = &value
+	case "tcpFlags":
+		event.TCPFlags = &value
+	case "accessList":
+		event.AccessList = &value
+	case "hitCount":
+		event.HitCount = parseInt64(value)
+	case "group":
+		event.Group = &value
+	case "username":
+		event.Username = &value
+	case "sessionType":
+		event.SessionType = &value
+	case "assignedAddr":
+		event.AssignedAddress = &value
+	case "server":
+		event.Server = &value
+	}
+}
+
+// swapEndpoints swaps the source and destination of outbound connections.
+// ASA logs the endpoint on the lower security interface first, for outbound connections it is the responder.
+func (event *Event) swapEndpoints() {
+	event.SourceInterface, event.DestinationInterface = event.DestinationInterface, event.SourceInterface
+	event.SourceAddress, event.DestinationAddress = event.DestinationAddress, event.SourceAddress
+	event.SourcePort, event.DestinationPort = event.DestinationPort, event.SourcePort
+	event.SourceMappedAddress, event.DestinationMappedAddress = event.DestinationMappedAddress, event.SourceMappedAddress
+	event.SourceMappedPort, event.DestinationMappedPort = event.DestinationMappedPort, event.SourceMappedPort
+	event.SourceUser, event.DestinationUser = event.DestinationUser, event.SourceUser
+}
+
+func parseInt64(value string) *int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func parseUint16(value string) *uint16 {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return nil
+	}
+	port := uint16(n)
+	return &port
+}
@@ -0,0 +1,262 @@
+package ciscoasalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+// nolint:lll
+func TestParseMessage(t *testing.T) {
+	for _, tc := range []struct {
+		id      string
+		message string
+		expect  Event
+	}{
+		{
+			id:      "302015",
+			message: `Built inbound UDP connection 200 for outside:203.0.113.10/53000 (203.0.113.10/53000)(LOCAL\jdoe) to dmz:10.1.0.10/53 (198.51.100.10/53)`,
+			expect: Event{
+				Action:                   aws.String("built"),
+				Direction:                aws.String("inbound"),
+				Protocol:                 aws.String("UDP"),
+				ConnectionID:             aws.Int64(200),
+				SourceInterface:          aws.String("outside"),
+				SourceAddress:            aws.String("203.0.113.10"),
+				SourcePort:               aws.Uint16(53000),
+				SourceMappedAddress:      aws.String("203.0.113.10"),
+				SourceMappedPort:         aws.Uint16(53000),
+				SourceUser:               aws.String(`LOCAL\jdoe`),
+				DestinationInterface:     aws.String("dmz"),
+				DestinationAddress:       aws.String("10.1.0.10"),
+				DestinationPort:          aws.Uint16(53),
+				DestinationMappedAddress: aws.String("198.51.100.10"),
+				DestinationMappedPort:    aws.Uint16(53),
+			},
+		},
+		{
+			id:      "302014",
+			message: `Teardown TCP connection 12345 for outside:203.0.113.10/443 to inside:10.0.0.5/51234 duration 0:01:30 bytes 6144 TCP FINs`,
+			expect: Event{
+				Action:               aws.String("teardown"),
+				Protocol:             aws.String("TCP"),
+				ConnectionID:         aws.Int64(12345),
+				SourceInterface:      aws.String("outside"),
+				SourceAddress:        aws.String("203.0.113.10"),
+				SourcePort:           aws.Uint16(443),
+				DestinationInterface: aws.String("inside"),
+				DestinationAddress:   aws.String("10.0.0.5"),
+				DestinationPort:      aws.Uint16(51234),
+				Duration:             aws.String("0:01:30"),
+				Bytes:                aws.Int64(6144),
+				Reason:               aws.String("TCP FINs"),
+			},
+		},
+		{
+			id:      "106023",
+			message: `Deny icmp src outside:203.0.113.50 dst inside:10.0.0.5 (type 8, code 0) by access-group "outside_access_in" [0x0, 0x0]`,
+			expect: Event{
+				Action:               aws.String("deny"),
+				Protocol:             aws.String("icmp"),
+				SourceInterface:      aws.String("outside"),
+				SourceAddress:        aws.String("203.0.113.50"),
+				DestinationInterface: aws.String("inside"),
+				DestinationAddress:   aws.String("10.0.0.5"),
+				AccessList:           aws.String("outside_access_in"),
+			},
+		},
+		{
+			id:      "106001",
+			message: `Inbound TCP connection denied from 203.0.113.50/40000 to 10.0.0.5/23 flags SYN  on interface outside`,
+			expect: Event{
+				Action:             aws.String("deny"),
+				Direction:          aws.String("inbound"),
+				Protocol:           aws.String("TCP"),
+				SourceAddress:      aws.String("203.0.113.50"),
+				SourcePort:         aws.Uint16(40000),
+				DestinationAddress: aws.String("10.0.0.5"),
+				DestinationPort:    aws.Uint16(23),
+				TCPFlags:           aws.String("SYN"),
+				SourceInterface:    aws.String("outside"),
+			},
+		},
+		{
+			id:      "106006",
+			message: `Deny inbound UDP from 203.0.113.50/5353 to 10.0.0.5/161 on interface outside`,
+			expect: Event{
+				Action:             aws.String("deny"),
+				Direction:          aws.String("inbound"),
+				Protocol:           aws.String("UDP"),
+				SourceAddress:      aws.String("203.0.113.50"),
+				SourcePort:         aws.Uint16(5353),
+				DestinationAddress: aws.String("10.0.0.5"),
+				DestinationPort:    aws.Uint16(161),
+				SourceInterface:    aws.String("outside"),
+			},
+		},
+		{
+			id:      "106015",
+			message: `Deny TCP (no connection) from 10.0.0.5/51234 to 203.0.113.10/443 flags RST  on interface inside`,
+			expect: Event{
+				Action:             aws.String("deny"),
+				Protocol:           aws.String("TCP"),
+				SourceAddress:      aws.String("10.0.0.5"),
+				SourcePort:         aws.Uint16(51234),
+				DestinationAddress: aws.String("203.0.113.10"),
+				DestinationPort:    aws.Uint16(443),
+				TCPFlags:           aws.String("RST"),
+				SourceInterface:    aws.String("inside"),
+			},
+		},
+		{
+			id:      "106100",
+			message: `access-list inside_access_in permitted tcp inside/10.0.0.5(51234) -> outside/203.0.113.10(443) hit-cnt 1 first hit [0x8f6f2bd4, 0x0]`,
+			expect: Event{
+				Action:               aws.String("permit"),
+				AccessList:           aws.String("inside_access_in"),
+				Protocol:             aws.String("tcp"),
+				SourceInterface:      aws.String("inside"),
+				SourceAddress:        aws.String("10.0.0.5"),
+				SourcePort:           aws.Uint16(51234),
+				DestinationInterface: aws.String("outside"),
+				DestinationAddress:   aws.String("203.0.113.10"),
+				DestinationPort:      aws.Uint16(443),
+				HitCount:             aws.Int64(1),
+			},
+		},
+		{
+			id:      "710003",
+			message: `TCP access denied by ACL from 203.0.113.50/40000 to outside:198.51.100.1/22`,
+			expect: Event{
+				Action:               aws.String("deny"),
+				Protocol:             aws.String("TCP"),
+				SourceAddress:        aws.String("203.0.113.50"),
+				SourcePort:           aws.Uint16(40000),
+				DestinationInterface: aws.String("outside"),
+				DestinationAddress:   aws.String("198.51.100.1"),
+				DestinationPort:      aws.Uint16(22),
+			},
+		},
+		{
+			id:      "113019",
+			message: `Group = RemoteAccess, Username = jdoe, IP = 203.0.113.60, Session disconnected. Session Type: AnyConnect-Parent, Duration: 1h:02m:03s, Bytes xmt: 1000, Bytes rcv: 2000, Reason: User Requested`,
+			expect: Event{
+				Action:        aws.String("session-disconnected"),
+				Group:         aws.String("RemoteAccess"),
+				Username:      aws.String("jdoe"),
+				SourceAddress: aws.String("203.0.113.60"),
+				SessionType:   aws.String("AnyConnect-Parent"),
+				Duration:      aws.String("1h:02m:03s"),
+				BytesSent:     aws.Int64(1000),
+				BytesReceived: aws.Int64(2000),
+				Reason:        aws.String("User Requested"),
+			},
+		},
+		{
+			id:      "113039",
+			message: `Group <RemoteAccess> User <jdoe> IP <203.0.113.60> AnyConnect parent session started.`,
+			expect: Event{
+				Action:        aws.String("session-started"),
+				Group:         aws.String("RemoteAccess"),
+				Username:      aws.String("jdoe"),
+				SourceAddress: aws.String("203.0.113.60"),
+			},
+		},
+		{
+			id:      "716002",
+			message: `Group <RemoteAccess> User <jdoe> IP <203.0.113.60> WebVPN session terminated: User Requested.`,
+			expect: Event{
+				Action:        aws.String("session-terminated"),
+				Group:         aws.String("RemoteAccess"),
+				Username:      aws.String("jdoe"),
+				SourceAddress: aws.String("203.0.113.60"),
+				Reason:        aws.String("User Requested"),
+			},
+		},
+		{
+			id:      "722051",
+			message: `Group <RemoteAccess> User <jdoe> IP <203.0.113.60> IPv4 Address <172.16.0.10> IPv6 address <::> assigned to session`,
+			expect: Event{
+				Action:          aws.String("address-assigned"),
+				Group:           aws.String("RemoteAccess"),
+				Username:        aws.String("jdoe"),
+				SourceAddress:   aws.String("203.0.113.60"),
+				AssignedAddress: aws.String("172.16.0.10"),
+			},
+		},
+		{
+			id:      "113005",
+			message: `AAA user authentication Rejected : reason = AAA failure : server = 10.0.0.20 : user = jdoe : user IP = 203.0.113.60`,
+			expect: Event{
+				Action:        aws.String("authentication-failure"),
+				Reason:        aws.String("AAA failure"),
+				Server:        aws.String("10.0.0.20"),
+				Username:      aws.String("jdoe"),
+				SourceAddress: aws.String("203.0.113.60"),
+			},
+		},
+		{
+			id:      "113004",
+			message: `AAA user authentication Successful : server = 10.0.0.20 : user = jdoe`,
+			expect: Event{
+				Action:   aws.String("authentication-success"),
+				Server:   aws.String("10.0.0.20"),
+				Username: aws.String("jdoe"),
+			},
+		},
+		{
+			id:      "611102",
+			message: `User authentication failed: IP address: 10.0.0.8, Uname: admin`,
+			expect: Event{
+				Action:        aws.String("authentication-failure"),
+				SourceAddress: aws.String("10.0.0.8"),
+				Username:      aws.String("admin"),
+			},
+		},
+		{
+			id:      "605005",
+			message: `Login permitted from 10.0.0.8/52000 to inside:10.0.0.1/ssh for user "admin"`,
+			expect: Event{
+				Action:               aws.String("authentication-success"),
+				SourceAddress:        aws.String("10.0.0.8"),
+				SourcePort:           aws.Uint16(52000),
+				DestinationInterface: aws.String("inside"),
+				DestinationAddress:   aws.String("10.0.0.1"),
+				Protocol:             aws.String("ssh"),
+				Username:             aws.String("admin"),
+			},
+		},
+		{
+			// known message ID with an unexpected format only keeps the text
+			id:      "302013",
+			message: `Built outbound TCP connection`,
+			expect:  Event{},
+		},
+	} {
+		event, err := parseEvent("%ASA-6-" + tc.id + ": " + tc.message)
+		require.NoError(t, err, tc.id)
+		require.Equal(t, tc.id, *event.MessageID)
+		require.Equal(t, tc.message, *event.Message)
+		event.Level, event.MessageID, event.Message = nil, nil, nil
+		require.Equal(t, &tc.expect, event, tc.id)
+	}
+}
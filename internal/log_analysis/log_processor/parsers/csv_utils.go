@@ -64,6 +64,17 @@ func CsvStringToInt16Pointer(value string) *int16 {
 	return &returnValue
 }
 
+func CsvStringToInt64Pointer(value string) *int64 {
+	if value == "-" {
+		return nil
+	}
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &result
+}
+
 func CsvStringToFloat64Pointer(value string) *float64 {
 	if value == "-" {
 		return nil
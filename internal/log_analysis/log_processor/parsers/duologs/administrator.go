@@ -0,0 +1,76 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Administrator struct {
+	Action       *string              `json:"action" validate:"required,oneof=activation_begin activation_create_link activation_delete_link activation_send_link admin_2fa_error admin_create admin_delete admin_factor_restrictions_update admin_lockout admin_login admin_login_error admin_reactivates_duo_push admin_reset_password admin_self_activate admin_send_reset_password_email admin_update bypass_create bypass_delete bypass_detail_view bypass_view customer_update group_create group_delete group_update hardkey_assign hardkey_create hardkey_delete hardkey_unassign integration_create integration_delete integration_policy_assign integration_policy_unassign integration_skey_view integration_update phone_activation_create phone_create phone_delete phone_new_sms_passcode phone_update policy_create policy_delete policy_update user_bulk_activate user_bulk_enroll user_create user_delete user_import user_pending_delete user_update" description:"The type of change that was performed (ie admin_login, user_update or integration_create)."`
+	Username     *string              `json:"username" validate:"required" description:"The full name of the administrator who performed the action in the Duo Admin Panel, or API if the action was performed with the API."`
+	Timestamp    *timestamp.UnixFloat `json:"timestamp" validate:"required" description:"The Unix timestamp of the event."`
+	ISOTimestamp *timestamp.RFC3339   `json:"isotimestamp,omitempty" description:"ISO8601 timestamp of the event."`
+	Object       *string              `json:"object,omitempty" description:"The object that was acted on (ie a username, phone number or integration name)."`
+	Description  *string              `json:"description,omitempty" description:"String detailing what changed, as a JSON object."`
+	Host         *string              `json:"host,omitempty" description:"The API hostname of the Duo account."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// AdministratorParser parses Duo administrator logs
+type AdministratorParser struct{}
+
+var _ parsers.LogParser = (*AdministratorParser)(nil)
+
+func (p *AdministratorParser) New() parsers.LogParser {
+	return &AdministratorParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AdministratorParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Administrator{}
+	if err := jsoniter.UnmarshalFromString(log, event); err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AdministratorParser) LogType() string {
+	return TypeAdministrator
+}
+
+func (event *Administrator) updatePantherFields(p *AdministratorParser) {
+	event.SetCoreFields(p.LogType(), eventTime(event.ISOTimestamp, event.Timestamp), event)
+
+	event.AppendAnyUsernamePtrs(event.Username)
+	event.AppendAnyDomainNamePtrs(event.Host)
+}
@@ -0,0 +1,67 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAdministrator(t *testing.T) {
+	// nolint:lll
+	log := `{"action":"user_update","description":"{\"notes\": \"Joe asked for their nickname to be displayed instead of Joseph.\", \"realname\": \"Joe Smith\"}","isotimestamp":"2020-06-01T15:09:42+00:00","object":"jsmith","timestamp":1591024182,"username":"Jane Admin","host":"api-a1b2c3d4.duosecurity.com"}`
+
+	tm := time.Unix(1591024182, 0).UTC()
+	event := &Administrator{
+		Action:       aws.String("user_update"),
+		Username:     aws.String("Jane Admin"),
+		Timestamp:    (*timestamp.UnixFloat)(&tm),
+		ISOTimestamp: (*timestamp.RFC3339)(&tm),
+		Object:       aws.String("jsmith"),
+		Description:  aws.String(`{"notes": "Joe asked for their nickname to be displayed instead of Joseph.", "realname": "Joe Smith"}`),
+		Host:         aws.String("api-a1b2c3d4.duosecurity.com"),
+	}
+	event.SetCoreFields(TypeAdministrator, event.ISOTimestamp, event)
+	event.AppendAnyUsernames("Jane Admin")
+	event.AppendAnyDomainNames("api-a1b2c3d4.duosecurity.com")
+
+	testutil.CheckPantherParser(t, log, &AdministratorParser{}, &event.PantherLog)
+}
+
+func TestAdministratorInvalid(t *testing.T) {
+	parser := (&AdministratorParser{}).New()
+	_, err := parser.Parse(`{"action":"user_update","timestamp":1591024182}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"txid":"340a23e3","timestamp":1591037780,"event_type":"authentication","result":"success"}`)
+	require.Error(t, err)
+	// Logs of other services with action, username and timestamp fields are not Duo administrator logs
+	_, err = parser.Parse(`{"action":"repo.create","username":"octocat","timestamp":1591024182,"repo":"octo-org/octo-repo"}`)
+	require.Error(t, err)
+}
+
+func TestAdministratorLogType(t *testing.T) {
+	parser := &AdministratorParser{}
+	require.Equal(t, "Duo.Administrator", parser.LogType())
+}
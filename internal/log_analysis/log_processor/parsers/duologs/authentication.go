@@ -0,0 +1,140 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Authentication struct {
+	TxID                  *string              `json:"txid" validate:"required" description:"The transaction ID of the event."`
+	Timestamp             *timestamp.UnixFloat `json:"timestamp" validate:"required" description:"The authentication event's Unix timestamp."`
+	ISOTimestamp          *timestamp.RFC3339   `json:"isotimestamp,omitempty" description:"ISO8601 timestamp of the event."`
+	EventType             *string              `json:"event_type" validate:"required,oneof=authentication enrollment" description:"The type of activity logged (ie authentication or enrollment)."`
+	Result                *string              `json:"result" validate:"required" description:"The result of the authentication attempt (ie success, denied, failure, error or fraud)."`
+	Reason                *string              `json:"reason,omitempty" description:"The reason for the authentication attempt result (ie user_approved, user_marked_fraud or deny_unenrolled_user)."`
+	Factor                *string              `json:"factor,omitempty" description:"The second factor used in the authentication attempt (ie duo_push, phone_call or passcode)."`
+	User                  *User                `json:"user,omitempty" description:"The user that attempted to authenticate."`
+	Alias                 *string              `json:"alias,omitempty" description:"The username alias used to log in. No value if the user logged in with their username instead of a username alias."`
+	Email                 *string              `json:"email,omitempty" description:"The email address of the user."`
+	Application           *Application         `json:"application,omitempty" description:"The application the user attempted to authenticate to."`
+	AccessDevice          *AccessDevice        `json:"access_device,omitempty" description:"The device used to access the application."`
+	AuthDevice            *AuthDevice          `json:"auth_device,omitempty" description:"The device used to approve the authentication with the second factor."`
+	TrustedEndpointStatus *string              `json:"trusted_endpoint_status,omitempty" description:"Status of the trusted endpoint (ie trusted, not trusted or unknown)."`
+	OODSoftware           *string              `json:"ood_software,omitempty" description:"If the authentication was denied due to out-of-date software, the name of the software."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type User struct {
+	Key    *string  `json:"key,omitempty" description:"The user's ID."`
+	Name   *string  `json:"name,omitempty" description:"The user's username."`
+	Groups []string `json:"groups,omitempty" description:"The groups the user is a member of."`
+}
+
+// nolint:lll
+type Application struct {
+	Key  *string `json:"key,omitempty" description:"The application's integration key."`
+	Name *string `json:"name,omitempty" description:"The application's name."`
+}
+
+// nolint:lll
+type Location struct {
+	City    *string `json:"city,omitempty" description:"The city name."`
+	State   *string `json:"state,omitempty" description:"The state, county, province, or prefecture."`
+	Country *string `json:"country,omitempty" description:"The country name."`
+}
+
+// nolint:lll
+type AccessDevice struct {
+	IP                  *string             `json:"ip,omitempty" description:"The IP address of the access device."`
+	Hostname            *string             `json:"hostname,omitempty" description:"The hostname of the access device, if available."`
+	Location            *Location           `json:"location,omitempty" description:"The GeoIP location of the access device."`
+	Browser             *string             `json:"browser,omitempty" description:"The web browser used for access."`
+	BrowserVersion      *string             `json:"browser_version,omitempty" description:"The browser version."`
+	FlashVersion        *string             `json:"flash_version,omitempty" description:"The Flash plugin version used, if present."`
+	JavaVersion         *string             `json:"java_version,omitempty" description:"The Java plugin version used, if present."`
+	OS                  *string             `json:"os,omitempty" description:"The device operating system name."`
+	OSVersion           *string             `json:"os_version,omitempty" description:"The device operating system version."`
+	EPKey               *string             `json:"epkey,omitempty" description:"The endpoint's unique identifier."`
+	IsEncryptionEnabled jsoniter.RawMessage `json:"is_encryption_enabled,omitempty" description:"Whether the access device has disk encryption enabled (true, false or unknown)."`
+	IsFirewallEnabled   jsoniter.RawMessage `json:"is_firewall_enabled,omitempty" description:"Whether the access device has a firewall enabled (true, false or unknown)."`
+	IsPasswordSet       jsoniter.RawMessage `json:"is_password_set,omitempty" description:"Whether the access device has a password set (true, false or unknown)."`
+	SecurityAgents      jsoniter.RawMessage `json:"security_agents,omitempty" description:"The security agents present on the access device and their versions."`
+}
+
+// nolint:lll
+type AuthDevice struct {
+	IP       *string   `json:"ip,omitempty" description:"The IP address of the authentication device."`
+	Location *Location `json:"location,omitempty" description:"The GeoIP location of the authentication device."`
+	Name     *string   `json:"name,omitempty" description:"The name of the authentication device."`
+}
+
+// AuthenticationParser parses Duo authentication logs
+type AuthenticationParser struct{}
+
+var _ parsers.LogParser = (*AuthenticationParser)(nil)
+
+func (p *AuthenticationParser) New() parsers.LogParser {
+	return &AuthenticationParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AuthenticationParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Authentication{}
+	if err := jsoniter.UnmarshalFromString(log, event); err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AuthenticationParser) LogType() string {
+	return TypeAuthentication
+}
+
+func (event *Authentication) updatePantherFields(p *AuthenticationParser) {
+	event.SetCoreFields(p.LogType(), eventTime(event.ISOTimestamp, event.Timestamp), event)
+
+	if event.AccessDevice != nil {
+		event.AppendAnyIPAddressPtr(event.AccessDevice.IP)
+		event.AppendAnyDomainNamePtrs(event.AccessDevice.Hostname)
+	}
+	if event.AuthDevice != nil {
+		event.AppendAnyIPAddressPtr(event.AuthDevice.IP)
+	}
+	if event.User != nil {
+		event.AppendAnyUsernamePtrs(event.User.Name)
+		event.AppendAnyEmailPtrs(event.User.Name)
+	}
+	event.AppendAnyUsernamePtrs(event.Alias)
+	event.AppendAnyEmailPtrs(event.Email)
+}
@@ -0,0 +1,121 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAuthentication(t *testing.T) {
+	// nolint:lll
+	log := `{"access_device":{"browser":"Chrome","browser_version":"83.0.4103.61","flash_version":"uninstalled","hostname":"laptop-42.example.com","ip":"198.51.100.7","is_encryption_enabled":true,"is_firewall_enabled":"unknown","is_password_set":true,"java_version":"uninstalled","location":{"city":"Ann Arbor","country":"United States","state":"Michigan"},"os":"Mac OS X","os_version":"10.15.4","security_agents":[]},"alias":"","application":{"key":"DIY231J8BR23QK4UKBY8","name":"Microsoft Azure Active Directory"},"auth_device":{"ip":"192.0.2.54","location":{"city":"Ann Arbor","country":"United States","state":"Michigan"},"name":"My iPhone X (734-555-2342)"},"email":"narroway@example.com","event_type":"authentication","factor":"duo_push","isotimestamp":"2020-06-01T18:56:20.351346+00:00","ood_software":null,"reason":"user_approved","result":"success","timestamp":1591037780,"trusted_endpoint_status":"not trusted","txid":"340a23e3-23f3-4e4e-b1e4-5e0b4d3b4b66","user":{"groups":["Duo Users","CorpHQ Users"],"key":"DU3KC77WJ06Y5HIV7XKQ","name":"narroway@example.com"}}`
+
+	unixTime := time.Unix(1591037780, 0).UTC()
+	isoTime := time.Date(2020, 6, 1, 18, 56, 20, 351346000, time.UTC)
+	location := &Location{
+		City:    aws.String("Ann Arbor"),
+		State:   aws.String("Michigan"),
+		Country: aws.String("United States"),
+	}
+	event := &Authentication{
+		TxID:         aws.String("340a23e3-23f3-4e4e-b1e4-5e0b4d3b4b66"),
+		Timestamp:    (*timestamp.UnixFloat)(&unixTime),
+		ISOTimestamp: (*timestamp.RFC3339)(&isoTime),
+		EventType:    aws.String("authentication"),
+		Result:       aws.String("success"),
+		Reason:       aws.String("user_approved"),
+		Factor:       aws.String("duo_push"),
+		User: &User{
+			Key:    aws.String("DU3KC77WJ06Y5HIV7XKQ"),
+			Name:   aws.String("narroway@example.com"),
+			Groups: []string{"Duo Users", "CorpHQ Users"},
+		},
+		Alias: aws.String(""),
+		Email: aws.String("narroway@example.com"),
+		Application: &Application{
+			Key:  aws.String("DIY231J8BR23QK4UKBY8"),
+			Name: aws.String("Microsoft Azure Active Directory"),
+		},
+		AccessDevice: &AccessDevice{
+			IP:                  aws.String("198.51.100.7"),
+			Hostname:            aws.String("laptop-42.example.com"),
+			Location:            location,
+			Browser:             aws.String("Chrome"),
+			BrowserVersion:      aws.String("83.0.4103.61"),
+			FlashVersion:        aws.String("uninstalled"),
+			JavaVersion:         aws.String("uninstalled"),
+			OS:                  aws.String("Mac OS X"),
+			OSVersion:           aws.String("10.15.4"),
+			IsEncryptionEnabled: jsoniter.RawMessage(`true`),
+			IsFirewallEnabled:   jsoniter.RawMessage(`"unknown"`),
+			IsPasswordSet:       jsoniter.RawMessage(`true`),
+			SecurityAgents:      jsoniter.RawMessage(`[]`),
+		},
+		AuthDevice: &AuthDevice{
+			IP:       aws.String("192.0.2.54"),
+			Location: location,
+			Name:     aws.String("My iPhone X (734-555-2342)"),
+		},
+		TrustedEndpointStatus: aws.String("not trusted"),
+	}
+	event.SetCoreFields(TypeAuthentication, event.ISOTimestamp, event)
+	event.AppendAnyIPAddress("198.51.100.7")
+	event.AppendAnyIPAddress("192.0.2.54")
+	event.AppendAnyDomainNames("laptop-42.example.com")
+	event.AppendAnyUsernames("narroway@example.com")
+	event.AppendAnyEmails("narroway@example.com")
+
+	testutil.CheckPantherParser(t, log, &AuthenticationParser{}, &event.PantherLog)
+}
+
+func TestAuthenticationUnixTimestamp(t *testing.T) {
+	log := `{"txid":"340a23e3","timestamp":1591037780,"event_type":"enrollment","result":"success"}`
+
+	tm := time.Unix(1591037780, 0).UTC()
+	event := &Authentication{
+		TxID:      aws.String("340a23e3"),
+		Timestamp: (*timestamp.UnixFloat)(&tm),
+		EventType: aws.String("enrollment"),
+		Result:    aws.String("success"),
+	}
+	event.SetCoreFields(TypeAuthentication, (*timestamp.RFC3339)(&tm), event)
+
+	testutil.CheckPantherParser(t, log, &AuthenticationParser{}, &event.PantherLog)
+}
+
+func TestAuthenticationInvalid(t *testing.T) {
+	parser := (&AuthenticationParser{}).New()
+	_, err := parser.Parse(`{"txid":"340a23e3","timestamp":1591037780,"event_type":"login","result":"success"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"action":"user_update","username":"admin","timestamp":1591037780}`)
+	require.Error(t, err)
+}
+
+func TestAuthenticationLogType(t *testing.T) {
+	parser := &AuthenticationParser{}
+	require.Equal(t, "Duo.Authentication", parser.LogType())
+}
@@ -0,0 +1,61 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "Duo"
+	// TypeAdministrator is the log type of Duo administrator logs
+	TypeAdministrator = PantherPrefix + ".Administrator"
+	// TypeAuthentication is the log type of Duo authentication logs
+	TypeAuthentication = PantherPrefix + ".Authentication"
+)
+
+func init() {
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name:         TypeAdministrator,
+			Description:  `Duo administrator logs record the actions of administrators in the Duo Admin Panel.`,
+			ReferenceURL: `https://duo.com/docs/adminapi#administrator-logs`,
+			Schema:       Administrator{},
+			NewParser:    parsers.AdapterFactory(&AdministratorParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeAuthentication,
+			Description:  `Duo authentication logs record the authentication attempts of users protected by Duo.`,
+			ReferenceURL: `https://duo.com/docs/adminapi#authentication-logs`,
+			Schema:       Authentication{},
+			NewParser:    parsers.AdapterFactory(&AuthenticationParser{}),
+		},
+	)
+}
+
+// eventTime returns the ISO 8601 timestamp of Duo logs if available, it is more precise than the unix timestamp
+func eventTime(isoTimestamp *timestamp.RFC3339, unixTimestamp *timestamp.UnixFloat) *timestamp.RFC3339 {
+	if isoTimestamp != nil {
+		return isoTimestamp
+	}
+	return (*timestamp.RFC3339)(unixTimestamp)
+}
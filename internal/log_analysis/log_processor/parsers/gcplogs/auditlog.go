@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
@@ -31,7 +32,8 @@ type LogEntryAuditLog struct {
 	LogEntry
 	Payload AuditLog `json:"protoPayload" validate:"required" description:"The AuditLog payload"`
 
-	parsers.PantherLog
+	// Requests to GCP services can reference AWS resources (ie workload identity federation)
+	awslogs.AWSPantherLog
 }
 
 const (
@@ -72,12 +74,7 @@ func (p *AuditLogParser) Parse(log string) ([]*parsers.PantherLog, error) {
 			AuditLogSystemLogID,
 		})
 	}
-	ts := entry.Timestamp
-	if ts == nil {
-		// Fallback to ReceiveTimestamp which is a required field to get a timestamp hopefully closer to the actual event timestamp.
-		ts = entry.ReceiveTimestamp
-	}
-	entry.SetCoreFields(TypeAuditLog, ts, &entry)
+	entry.SetCoreFields(TypeAuditLog, entry.EventTime(), &entry)
 	if entry.HTTPRequest != nil {
 		entry.AppendAnyIPAddressPtr(entry.HTTPRequest.RemoteIP)
 		entry.AppendAnyIPAddressPtr(entry.HTTPRequest.ServerIP)
@@ -85,6 +82,11 @@ func (p *AuditLogParser) Parse(log string) ([]*parsers.PantherLog, error) {
 	if meta := entry.Payload.RequestMetadata; meta != nil {
 		entry.AppendAnyIPAddressPtr(meta.CallerIP)
 	}
+	if auth := entry.Payload.AuthenticationInfo; auth != nil {
+		entry.AppendAnyEmailPtrs(auth.PrincipalEmail)
+	}
+	request, response := string(entry.Payload.Request), string(entry.Payload.Response)
+	awslogs.NewAWSExtractor(&entry.AWSPantherLog).ExtractARNsInText(entry.Payload.ResourceName, &request, &response)
 	if err := parsers.Validator.Struct(entry); err != nil {
 		return nil, err
 	}
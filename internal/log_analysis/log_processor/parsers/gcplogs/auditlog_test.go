@@ -231,6 +231,7 @@ func TestAuditLogParserSystemEvent(t *testing.T) {
 	}
 
 	entry.SetCoreFields(TypeAuditLog, entry.Timestamp, entry)
+	entry.AppendAnyEmails("system@google.com")
 	testutil.CheckPantherParser(t, log, NewAuditLogParser(), &entry.PantherLog)
 }
 
@@ -373,5 +374,6 @@ func TestAuditLogParserActivityBug(t *testing.T) {
 
 	entry.SetCoreFields(TypeAuditLog, entry.Timestamp, entry)
 	entry.AppendAnyIPAddress("0:0:0:0:0:0:0:1")
+	entry.AppendAnyEmails("test@runpanther.io")
 	testutil.CheckPantherParser(t, log, NewAuditLogParser(), &entry.PantherLog)
 }
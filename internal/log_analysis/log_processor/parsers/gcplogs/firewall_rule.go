@@ -0,0 +1,109 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const FirewallRuleLogID = "compute.googleapis.com%2Ffirewall"
+
+type FirewallRule struct {
+	LogEntry
+	Payload FirewallRulePayload `json:"jsonPayload" validate:"required" description:"The firewall rule payload"`
+
+	parsers.PantherLog
+}
+
+type FirewallRuleParser struct{}
+
+var _ parsers.LogParser = (*FirewallRuleParser)(nil)
+
+func NewFirewallRuleParser() parsers.LogParser {
+	return &FirewallRuleParser{}
+}
+
+func (p *FirewallRuleParser) LogType() string {
+	return TypeFirewallRule
+}
+
+// New creates a new log parser instance
+func (p *FirewallRuleParser) New() parsers.LogParser {
+	return &FirewallRuleParser{}
+}
+
+// Parse implements parsers.LogParser interface
+func (p *FirewallRuleParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	entry := FirewallRule{}
+	if err := jsoniter.UnmarshalFromString(log, &entry); err != nil {
+		return nil, err
+	}
+	if id := entry.LogID(); id != FirewallRuleLogID {
+		return nil, errors.Errorf("invalid LogID %q != %s", id, FirewallRuleLogID)
+	}
+	entry.SetCoreFields(TypeFirewallRule, entry.EventTime(), &entry)
+	if conn := entry.Payload.Connection; conn != nil {
+		entry.AppendAnyIPAddressPtr(conn.SrcIP)
+		entry.AppendAnyIPAddressPtr(conn.DestIP)
+	}
+	if rule := entry.Payload.RuleDetails; rule != nil {
+		entry.AppendAnyEmails(rule.SourceServiceAccount...)
+		entry.AppendAnyEmails(rule.TargetServiceAccount...)
+	}
+	if err := parsers.Validator.Struct(entry); err != nil {
+		return nil, err
+	}
+	return entry.Logs(), nil
+}
+
+// nolint:lll
+type FirewallRulePayload struct {
+	Connection     *IPConnection        `json:"connection" validate:"required" description:"5-tuple describing the connection."`
+	Disposition    *string              `json:"disposition" validate:"required,oneof=ALLOWED DENIED" description:"Whether the connection was ALLOWED or DENIED."`
+	RuleDetails    *FirewallRuleDetails `json:"rule_details,omitempty" description:"Details of the firewall rule that was applied."`
+	Instance       *InstanceDetails     `json:"instance,omitempty" description:"The VM instance the firewall rule was applied to."`
+	VPC            *VPCDetails          `json:"vpc,omitempty" description:"The VPC network of the VM instance."`
+	RemoteInstance *InstanceDetails     `json:"remote_instance,omitempty" description:"If the remote endpoint of the connection was a VM located in the same VPC, this field is populated with VM instance details."`
+	RemoteVPC      *VPCDetails          `json:"remote_vpc,omitempty" description:"If the remote endpoint of the connection was a VM located in the same VPC, this field is populated with VPC network details."`
+	RemoteLocation *GeographicDetails   `json:"remote_location,omitempty" description:"If the remote endpoint of the connection was external to the VPC, this field is populated with available location metadata."`
+}
+
+// nolint:lll
+type FirewallRuleDetails struct {
+	Reference            *string                  `json:"reference,omitempty" description:"Reference to the firewall rule, in the form network:{network name}/firewall:{firewall_name}."`
+	Priority             *int32                   `json:"priority,omitempty" description:"The priority of the firewall rule."`
+	Action               *string                  `json:"action,omitempty" description:"The action of the firewall rule, ALLOW or DENY."`
+	Direction            *string                  `json:"direction,omitempty" description:"The direction of the firewall rule, INGRESS or EGRESS."`
+	IPPortInfo           []FirewallRuleIPPortInfo `json:"ip_port_info,omitempty" description:"The protocols and ports matched by the firewall rule."`
+	SourceRange          []string                 `json:"source_range,omitempty" description:"List of source ranges that the firewall rule applies to."`
+	DestinationRange     []string                 `json:"destination_range,omitempty" description:"List of destination ranges that the firewall rule applies to."`
+	SourceTag            []string                 `json:"source_tag,omitempty" description:"List of all the source tags that the firewall rule applies to."`
+	TargetTag            []string                 `json:"target_tag,omitempty" description:"List of all the target tags that the firewall rule applies to."`
+	SourceServiceAccount []string                 `json:"source_service_account,omitempty" description:"List of all the source service accounts that the firewall rule applies to."`
+	TargetServiceAccount []string                 `json:"target_service_account,omitempty" description:"List of all the target service accounts that the firewall rule applies to."`
+}
+
+// nolint:lll
+type FirewallRuleIPPortInfo struct {
+	IPProtocol *string  `json:"ip_protocol,omitempty" description:"The IP protocol matched by the firewall rule (ie TCP, UDP or ALL)."`
+	PortRange  []string `json:"port_range,omitempty" description:"The port ranges matched by the firewall rule."`
+}
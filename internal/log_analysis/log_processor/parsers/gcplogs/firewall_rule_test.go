@@ -0,0 +1,167 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestFirewallRuleParser(t *testing.T) {
+	log := `{
+		"insertId": "8j5vgsf1wzw4o",
+		"jsonPayload": {
+			"connection": {
+				"dest_ip": "10.128.0.5",
+				"dest_port": 3389,
+				"protocol": 6,
+				"src_ip": "198.51.100.7",
+				"src_port": 61024
+			},
+			"disposition": "DENIED",
+			"instance": {
+				"project_id": "my-project",
+				"region": "us-central1",
+				"vm_name": "windows-1",
+				"zone": "us-central1-a"
+			},
+			"remote_location": {
+				"continent": "America",
+				"country": "usa"
+			},
+			"rule_details": {
+				"action": "DENY",
+				"direction": "INGRESS",
+				"ip_port_info": [
+					{"ip_protocol": "TCP", "port_range": ["3389"]}
+				],
+				"priority": 1000,
+				"reference": "network:default/firewall:deny-rdp",
+				"source_range": ["0.0.0.0/0"],
+				"target_service_account": ["windows@my-project.iam.gserviceaccount.com"]
+			},
+			"vpc": {
+				"project_id": "my-project",
+				"subnetwork_name": "default",
+				"vpc_name": "default"
+			}
+		},
+		"logName": "projects/my-project/logs/compute.googleapis.com%2Ffirewall",
+		"receiveTimestamp": "2020-06-01T11:30:02.118Z",
+		"resource": {
+			"labels": {
+				"location": "us-central1-a",
+				"project_id": "my-project",
+				"subnetwork_id": "4823659275829472",
+				"subnetwork_name": "default"
+			},
+			"type": "gce_subnetwork"
+		},
+		"timestamp": "2020-06-01T11:29:58.043Z"
+	}`
+
+	tm := func(s string) *timestamp.RFC3339 {
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		require.NoError(t, err)
+		return (*timestamp.RFC3339)(&ts)
+	}
+	uint16Ptr := func(n uint16) *uint16 {
+		return &n
+	}
+	entry := &FirewallRule{
+		LogEntry: LogEntry{
+			LogName:          aws.String("projects/my-project/logs/compute.googleapis.com%2Ffirewall"),
+			InsertID:         aws.String("8j5vgsf1wzw4o"),
+			Timestamp:        tm("2020-06-01T11:29:58.043Z"),
+			ReceiveTimestamp: tm("2020-06-01T11:30:02.118Z"),
+			Resource: MonitoredResource{
+				Type: aws.String("gce_subnetwork"),
+				Labels: Labels{
+					"location":        "us-central1-a",
+					"project_id":      "my-project",
+					"subnetwork_id":   "4823659275829472",
+					"subnetwork_name": "default",
+				},
+			},
+		},
+		Payload: FirewallRulePayload{
+			Connection: &IPConnection{
+				Protocol: aws.Int32(6),
+				SrcIP:    aws.String("198.51.100.7"),
+				SrcPort:  uint16Ptr(61024),
+				DestIP:   aws.String("10.128.0.5"),
+				DestPort: uint16Ptr(3389),
+			},
+			Disposition: aws.String("DENIED"),
+			RuleDetails: &FirewallRuleDetails{
+				Reference: aws.String("network:default/firewall:deny-rdp"),
+				Priority:  aws.Int32(1000),
+				Action:    aws.String("DENY"),
+				Direction: aws.String("INGRESS"),
+				IPPortInfo: []FirewallRuleIPPortInfo{
+					{
+						IPProtocol: aws.String("TCP"),
+						PortRange:  []string{"3389"},
+					},
+				},
+				SourceRange:          []string{"0.0.0.0/0"},
+				TargetServiceAccount: []string{"windows@my-project.iam.gserviceaccount.com"},
+			},
+			Instance: &InstanceDetails{
+				ProjectID: aws.String("my-project"),
+				Region:    aws.String("us-central1"),
+				VMName:    aws.String("windows-1"),
+				Zone:      aws.String("us-central1-a"),
+			},
+			VPC: &VPCDetails{
+				ProjectID:      aws.String("my-project"),
+				VPCName:        aws.String("default"),
+				SubnetworkName: aws.String("default"),
+			},
+			RemoteLocation: &GeographicDetails{
+				Continent: aws.String("America"),
+				Country:   aws.String("usa"),
+			},
+		},
+	}
+
+	entry.SetCoreFields(TypeFirewallRule, entry.Timestamp, entry)
+	entry.AppendAnyIPAddress("198.51.100.7")
+	entry.AppendAnyIPAddress("10.128.0.5")
+	entry.AppendAnyEmails("windows@my-project.iam.gserviceaccount.com")
+	testutil.CheckPantherParser(t, log, NewFirewallRuleParser(), &entry.PantherLog)
+}
+
+func TestFirewallRuleParserInvalidDisposition(t *testing.T) {
+	log := `{
+		"jsonPayload": {"connection": {"src_ip": "10.0.0.1"}, "disposition": "MAYBE"},
+		"logName": "projects/my-project/logs/compute.googleapis.com%2Ffirewall",
+		"receiveTimestamp": "2020-06-01T10:00:12.481Z",
+		"resource": {"type": "gce_subnetwork", "labels": {}}
+	}`
+	results, err := NewFirewallRuleParser().Parse(log)
+	require.Error(t, err)
+	require.Nil(t, results)
+}
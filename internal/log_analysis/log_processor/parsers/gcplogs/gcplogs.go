@@ -29,7 +29,10 @@ import (
 )
 
 const (
-	TypeAuditLog = "GCP.AuditLog"
+	TypeAuditLog         = "GCP.AuditLog"
+	TypeFirewallRule     = "GCP.FirewallRule"
+	TypeHTTPLoadBalancer = "GCP.HTTPLoadBalancer"
+	TypeVPCFlow          = "GCP.VPCFlow"
 )
 
 //nolint: lll
@@ -44,6 +47,27 @@ Google Cloud services write audit log entries to these logs to help you answer t
 			Schema:       AuditLog{},
 			NewParser:    parsers.AdapterFactory(&AuditLogParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeFirewallRule,
+			Description:  `Firewall Rules Logging allows you to audit, verify, and analyze the effects of your firewall rules.`,
+			ReferenceURL: `https://cloud.google.com/vpc/docs/firewall-rules-logging`,
+			Schema:       FirewallRule{},
+			NewParser:    parsers.AdapterFactory(&FirewallRuleParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeHTTPLoadBalancer,
+			Description:  `HTTP(S) Load Balancing logs every request sent to the load balancer, including requests blocked by Cloud Armor security policies.`,
+			ReferenceURL: `https://cloud.google.com/load-balancing/docs/https/https-logging-monitoring`,
+			Schema:       HTTPLoadBalancer{},
+			NewParser:    parsers.AdapterFactory(&HTTPLoadBalancerParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeVPCFlow,
+			Description:  `VPC Flow Logs record a sample of network flows sent from and received by VM instances, including instances used as GKE nodes.`,
+			ReferenceURL: `https://cloud.google.com/vpc/docs/using-flow-logs`,
+			Schema:       VPCFlow{},
+			NewParser:    parsers.AdapterFactory(&VPCFlowParser{}),
+		},
 	)
 }
 
@@ -82,6 +106,15 @@ func (entry *LogEntry) LogID() string {
 	return ""
 }
 
+// EventTime returns the time of the entry.
+// It falls back to ReceiveTimestamp, which is a required field, to get a timestamp hopefully close to the actual event timestamp.
+func (entry *LogEntry) EventTime() *timestamp.RFC3339 {
+	if entry.Timestamp != nil {
+		return entry.Timestamp
+	}
+	return entry.ReceiveTimestamp
+}
+
 // nolint:lll
 type MonitoredResource struct {
 	Type   *string `json:"type" validate:"required" description:"Type of resource that produced this log entry"`
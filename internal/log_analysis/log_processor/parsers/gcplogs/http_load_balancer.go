@@ -0,0 +1,106 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	HTTPLoadBalancerLogID        = "requests"
+	HTTPLoadBalancerResourceType = "http_load_balancer"
+)
+
+type HTTPLoadBalancer struct {
+	LogEntry
+	Payload HTTPLoadBalancerPayload `json:"jsonPayload" validate:"required" description:"The load balancer payload"`
+
+	parsers.PantherLog
+}
+
+type HTTPLoadBalancerParser struct{}
+
+var _ parsers.LogParser = (*HTTPLoadBalancerParser)(nil)
+
+func NewHTTPLoadBalancerParser() parsers.LogParser {
+	return &HTTPLoadBalancerParser{}
+}
+
+func (p *HTTPLoadBalancerParser) LogType() string {
+	return TypeHTTPLoadBalancer
+}
+
+// New creates a new log parser instance
+func (p *HTTPLoadBalancerParser) New() parsers.LogParser {
+	return &HTTPLoadBalancerParser{}
+}
+
+// Parse implements parsers.LogParser interface
+func (p *HTTPLoadBalancerParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	entry := HTTPLoadBalancer{}
+	if err := jsoniter.UnmarshalFromString(log, &entry); err != nil {
+		return nil, err
+	}
+	if id := entry.LogID(); id != HTTPLoadBalancerLogID {
+		return nil, errors.Errorf("invalid LogID %q != %s", id, HTTPLoadBalancerLogID)
+	}
+	// The `requests` log ID is not specific to load balancers
+	if typ := entry.Resource.Type; typ == nil || *typ != HTTPLoadBalancerResourceType {
+		return nil, errors.Errorf("invalid resource type, expected %s", HTTPLoadBalancerResourceType)
+	}
+	if entry.HTTPRequest == nil {
+		return nil, errors.New("missing httpRequest")
+	}
+	entry.SetCoreFields(TypeHTTPLoadBalancer, entry.EventTime(), &entry)
+	entry.AppendAnyIPAddressPtr(entry.HTTPRequest.RemoteIP)
+	entry.AppendAnyIPAddressPtr(entry.HTTPRequest.ServerIP)
+	if entry.HTTPRequest.RequestURL != nil {
+		if u, err := url.Parse(*entry.HTTPRequest.RequestURL); err == nil && u.Hostname() != "" {
+			if !entry.AppendAnyIPAddress(u.Hostname()) {
+				entry.AppendAnyDomainNames(u.Hostname())
+			}
+		}
+	}
+	if err := parsers.Validator.Struct(entry); err != nil {
+		return nil, err
+	}
+	return entry.Logs(), nil
+}
+
+// nolint:lll
+type HTTPLoadBalancerPayload struct {
+	PayloadType            *string         `json:"@type" validate:"required,eq=type.googleapis.com/google.cloud.loadbalancing.type.LoadBalancerLogEntry" description:"The type of payload"`
+	StatusDetails          *string         `json:"statusDetails,omitempty" description:"A textual description of why the load balancer returned the HTTP status that it did (ie response_sent_by_backend)."`
+	CacheID                *string         `json:"cacheId,omitempty" description:"Indicates the location and cache instance that the cache response was served from."`
+	EnforcedSecurityPolicy *SecurityPolicy `json:"enforcedSecurityPolicy,omitempty" description:"The Cloud Armor security policy rule that was enforced on the request."`
+	PreviewSecurityPolicy  *SecurityPolicy `json:"previewSecurityPolicy,omitempty" description:"The Cloud Armor security policy rule that would have been enforced on the request if it was not in preview mode."`
+}
+
+// nolint:lll
+type SecurityPolicy struct {
+	Name             *string `json:"name,omitempty" description:"The name of the security policy."`
+	Priority         *int32  `json:"priority,omitempty" description:"The priority of the matching rule in the security policy."`
+	ConfiguredAction *string `json:"configuredAction,omitempty" description:"The name of the configured action in the matching rule (ie ALLOW, DENY or RATE_BASED_BAN)."`
+	Outcome          *string `json:"outcome,omitempty" description:"The outcome of executing the configured action (ie ACCEPT or DENY)."`
+}
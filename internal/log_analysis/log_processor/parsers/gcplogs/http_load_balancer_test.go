@@ -0,0 +1,148 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestHTTPLoadBalancerParser(t *testing.T) {
+	log := `{
+		"httpRequest": {
+			"latency": "0.041305s",
+			"remoteIp": "192.0.2.44",
+			"requestMethod": "GET",
+			"requestSize": "112",
+			"requestUrl": "https://www.example.com:8443/login?next=%2F",
+			"responseSize": "1024",
+			"serverIp": "10.128.0.9",
+			"status": 403,
+			"userAgent": "curl/7.68.0"
+		},
+		"insertId": "o4evz9g2ggf5x",
+		"jsonPayload": {
+			"@type": "type.googleapis.com/google.cloud.loadbalancing.type.LoadBalancerLogEntry",
+			"enforcedSecurityPolicy": {
+				"configuredAction": "DENY",
+				"name": "block-bad-ips",
+				"outcome": "DENY",
+				"priority": 100
+			},
+			"statusDetails": "denied_by_security_policy"
+		},
+		"logName": "projects/my-project/logs/requests",
+		"receiveTimestamp": "2020-06-01T12:00:01.998Z",
+		"resource": {
+			"labels": {
+				"backend_service_name": "web-backend",
+				"forwarding_rule_name": "web-rule",
+				"project_id": "my-project",
+				"target_proxy_name": "web-proxy",
+				"url_map_name": "web-map",
+				"zone": "global"
+			},
+			"type": "http_load_balancer"
+		},
+		"severity": "WARNING",
+		"spanId": "b1d3f5a8e0c2d4f6",
+		"timestamp": "2020-06-01T12:00:01.122Z",
+		"trace": "projects/my-project/traces/4f6d9a2b8c1e3f5a7b9d0c2e4f6a8b1d"
+	}`
+
+	tm := func(s string) *timestamp.RFC3339 {
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		require.NoError(t, err)
+		return (*timestamp.RFC3339)(&ts)
+	}
+	int64Ptr := func(n int64) *numerics.Int64 {
+		return (*numerics.Int64)(&n)
+	}
+	status := int16(403)
+	entry := &HTTPLoadBalancer{
+		LogEntry: LogEntry{
+			LogName:          aws.String("projects/my-project/logs/requests"),
+			InsertID:         aws.String("o4evz9g2ggf5x"),
+			Severity:         aws.String("WARNING"),
+			Timestamp:        tm("2020-06-01T12:00:01.122Z"),
+			ReceiveTimestamp: tm("2020-06-01T12:00:01.998Z"),
+			Trace:            aws.String("projects/my-project/traces/4f6d9a2b8c1e3f5a7b9d0c2e4f6a8b1d"),
+			SpanID:           aws.String("b1d3f5a8e0c2d4f6"),
+			Resource: MonitoredResource{
+				Type: aws.String("http_load_balancer"),
+				Labels: Labels{
+					"backend_service_name": "web-backend",
+					"forwarding_rule_name": "web-rule",
+					"project_id":           "my-project",
+					"target_proxy_name":    "web-proxy",
+					"url_map_name":         "web-map",
+					"zone":                 "global",
+				},
+			},
+			HTTPRequest: &HTTPRequest{
+				RequestMethod: aws.String("GET"),
+				RequestURL:    aws.String("https://www.example.com:8443/login?next=%2F"),
+				RequestSize:   int64Ptr(112),
+				Status:        &status,
+				ResponseSize:  int64Ptr(1024),
+				UserAgent:     aws.String("curl/7.68.0"),
+				RemoteIP:      aws.String("192.0.2.44"),
+				ServerIP:      aws.String("10.128.0.9"),
+				Latency:       aws.String("0.041305s"),
+			},
+		},
+		Payload: HTTPLoadBalancerPayload{
+			PayloadType:   aws.String("type.googleapis.com/google.cloud.loadbalancing.type.LoadBalancerLogEntry"),
+			StatusDetails: aws.String("denied_by_security_policy"),
+			EnforcedSecurityPolicy: &SecurityPolicy{
+				Name:             aws.String("block-bad-ips"),
+				Priority:         aws.Int32(100),
+				ConfiguredAction: aws.String("DENY"),
+				Outcome:          aws.String("DENY"),
+			},
+		},
+	}
+
+	entry.SetCoreFields(TypeHTTPLoadBalancer, entry.Timestamp, entry)
+	entry.AppendAnyIPAddress("192.0.2.44")
+	entry.AppendAnyIPAddress("10.128.0.9")
+	entry.AppendAnyDomainNames("www.example.com")
+	testutil.CheckPantherParser(t, log, NewHTTPLoadBalancerParser(), &entry.PantherLog)
+}
+
+func TestHTTPLoadBalancerParserInvalidResource(t *testing.T) {
+	// App Engine request logs share the `requests` log ID
+	log := `{
+		"httpRequest": {"requestMethod": "GET", "status": 200},
+		"jsonPayload": {"@type": "type.googleapis.com/google.cloud.loadbalancing.type.LoadBalancerLogEntry"},
+		"logName": "projects/my-project/logs/requests",
+		"receiveTimestamp": "2020-06-01T12:00:01.998Z",
+		"resource": {"type": "gae_app", "labels": {}}
+	}`
+	results, err := NewHTTPLoadBalancerParser().Parse(log)
+	require.Error(t, err)
+	require.Nil(t, results)
+}
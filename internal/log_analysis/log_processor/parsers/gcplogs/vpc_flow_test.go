@@ -0,0 +1,161 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestVPCFlowParser(t *testing.T) {
+	log := `{
+		"insertId": "1ohlwfnf3jkrfa",
+		"jsonPayload": {
+			"bytes_sent": "1248",
+			"connection": {
+				"dest_ip": "10.128.0.5",
+				"dest_port": 22,
+				"protocol": 6,
+				"src_ip": "203.0.113.10",
+				"src_port": 50374
+			},
+			"dest_instance": {
+				"project_id": "my-project",
+				"region": "us-central1",
+				"vm_name": "bastion",
+				"zone": "us-central1-a"
+			},
+			"dest_vpc": {
+				"project_id": "my-project",
+				"subnetwork_name": "default",
+				"vpc_name": "default"
+			},
+			"end_time": "2020-06-01T10:00:05.100Z",
+			"packets_sent": "12",
+			"reporter": "DEST",
+			"rtt_msec": "17",
+			"src_location": {
+				"asn": 64496,
+				"city": "Athens",
+				"continent": "Europe",
+				"country": "grc",
+				"region": "Attica"
+			},
+			"start_time": "2020-06-01T10:00:00.100Z"
+		},
+		"logName": "projects/my-project/logs/compute.googleapis.com%2Fvpc_flows",
+		"receiveTimestamp": "2020-06-01T10:00:12.481Z",
+		"resource": {
+			"labels": {
+				"location": "us-central1-a",
+				"project_id": "my-project",
+				"subnetwork_id": "4823659275829472",
+				"subnetwork_name": "default"
+			},
+			"type": "gce_subnetwork"
+		},
+		"timestamp": "2020-06-01T10:00:10.123Z"
+	}`
+
+	tm := func(s string) *timestamp.RFC3339 {
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		require.NoError(t, err)
+		return (*timestamp.RFC3339)(&ts)
+	}
+	int64Ptr := func(n int64) *numerics.Int64 {
+		return (*numerics.Int64)(&n)
+	}
+	uint16Ptr := func(n uint16) *uint16 {
+		return &n
+	}
+	entry := &VPCFlow{
+		LogEntry: LogEntry{
+			LogName:          aws.String("projects/my-project/logs/compute.googleapis.com%2Fvpc_flows"),
+			InsertID:         aws.String("1ohlwfnf3jkrfa"),
+			Timestamp:        tm("2020-06-01T10:00:10.123Z"),
+			ReceiveTimestamp: tm("2020-06-01T10:00:12.481Z"),
+			Resource: MonitoredResource{
+				Type: aws.String("gce_subnetwork"),
+				Labels: Labels{
+					"location":        "us-central1-a",
+					"project_id":      "my-project",
+					"subnetwork_id":   "4823659275829472",
+					"subnetwork_name": "default",
+				},
+			},
+		},
+		Payload: VPCFlowPayload{
+			Connection: &IPConnection{
+				Protocol: aws.Int32(6),
+				SrcIP:    aws.String("203.0.113.10"),
+				SrcPort:  uint16Ptr(50374),
+				DestIP:   aws.String("10.128.0.5"),
+				DestPort: uint16Ptr(22),
+			},
+			Reporter:    aws.String("DEST"),
+			StartTime:   tm("2020-06-01T10:00:00.100Z"),
+			EndTime:     tm("2020-06-01T10:00:05.100Z"),
+			BytesSent:   int64Ptr(1248),
+			PacketsSent: int64Ptr(12),
+			RTTMillis:   int64Ptr(17),
+			DestInstance: &InstanceDetails{
+				ProjectID: aws.String("my-project"),
+				Region:    aws.String("us-central1"),
+				VMName:    aws.String("bastion"),
+				Zone:      aws.String("us-central1-a"),
+			},
+			DestVPC: &VPCDetails{
+				ProjectID:      aws.String("my-project"),
+				VPCName:        aws.String("default"),
+				SubnetworkName: aws.String("default"),
+			},
+			SrcLocation: &GeographicDetails{
+				Continent: aws.String("Europe"),
+				Country:   aws.String("grc"),
+				Region:    aws.String("Attica"),
+				City:      aws.String("Athens"),
+				ASN:       int64Ptr(64496),
+			},
+		},
+	}
+
+	entry.SetCoreFields(TypeVPCFlow, entry.Timestamp, entry)
+	entry.AppendAnyIPAddress("203.0.113.10")
+	entry.AppendAnyIPAddress("10.128.0.5")
+	testutil.CheckPantherParser(t, log, NewVPCFlowParser(), &entry.PantherLog)
+}
+
+func TestVPCFlowParserInvalidLogID(t *testing.T) {
+	log := `{
+		"jsonPayload": {"connection": {"src_ip": "10.0.0.1"}, "reporter": "SRC"},
+		"logName": "projects/my-project/logs/compute.googleapis.com%2Ffirewall",
+		"receiveTimestamp": "2020-06-01T10:00:12.481Z",
+		"resource": {"type": "gce_subnetwork", "labels": {}}
+	}`
+	results, err := NewVPCFlowParser().Parse(log)
+	require.Error(t, err)
+	require.Nil(t, results)
+}
@@ -0,0 +1,115 @@
+package githublogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// Audit is a GitHub audit log event.
+// Event specific fields not listed here are dropped.
+// nolint:lll
+type Audit struct {
+	Timestamp              *timestamp.UnixMillisecond `json:"@timestamp" validate:"required" description:"The time the event occurred, in milliseconds since the epoch."`
+	DocumentID             *string                    `json:"_document_id,omitempty" description:"The unique identifier of the audit log event."`
+	Action                 *string                    `json:"action" validate:"required,contains=." description:"The name of the action that was performed, in the form category.operation (ie repo.create or org.add_member)."`
+	Actor                  *string                    `json:"actor,omitempty" description:"The login of the user that performed the action."`
+	ActorID                *int64                     `json:"actor_id,omitempty" description:"The id of the user that performed the action."`
+	ActorIP                *string                    `json:"actor_ip,omitempty" description:"The IP address the action was performed from."`
+	ActorLocation          *ActorLocation             `json:"actor_location,omitempty" description:"The location of the user that performed the action."`
+	Business               *string                    `json:"business,omitempty" description:"The name of the enterprise affected by the action."`
+	CreatedAt              *timestamp.UnixMillisecond `json:"created_at,omitempty" description:"The time the event was created, in milliseconds since the epoch."`
+	Org                    *string                    `json:"org,omitempty" description:"The name of the organization affected by the action."`
+	OrgID                  *int64                     `json:"org_id,omitempty" description:"The id of the organization affected by the action."`
+	Repo                   *string                    `json:"repo,omitempty" description:"The name of the repository affected by the action (ie octo-org/octo-repo)."`
+	Repository             *string                    `json:"repository,omitempty" description:"The name of the repository affected by the action, set by some actions instead of repo."`
+	RepositoryPublic       *bool                      `json:"repository_public,omitempty" description:"Whether the repository affected by the action is public."`
+	Visibility             *string                    `json:"visibility,omitempty" description:"The visibility of the repository affected by the action (ie public, private or internal)."`
+	Team                   *string                    `json:"team,omitempty" description:"The name of the team affected by the action."`
+	User                   *string                    `json:"user,omitempty" description:"The login of the user affected by the action."`
+	UserID                 *int64                     `json:"user_id,omitempty" description:"The id of the user affected by the action."`
+	UserAgent              *string                    `json:"user_agent,omitempty" description:"The user agent of the client that performed the action."`
+	OperationType          *string                    `json:"operation_type,omitempty" description:"The type of operation performed (ie create, access, modify, remove or authentication)."`
+	TransportProtocolName  *string                    `json:"transport_protocol_name,omitempty" description:"The protocol of git events (ie http or ssh)."`
+	ProgrammaticAccessType *string                    `json:"programmatic_access_type,omitempty" description:"The type of credential used for programmatic access (ie OAuth access token or personal access token)."`
+	HashedToken            *string                    `json:"hashed_token,omitempty" description:"The base64 encoded SHA256 hash of the access token used to perform the action."`
+	TokenScopes            *string                    `json:"token_scopes,omitempty" description:"The scopes of the access token used to perform the action."`
+	Permission             *string                    `json:"permission,omitempty" description:"The permission granted or changed by the action."`
+	Config                 jsoniter.RawMessage        `json:"config,omitempty" description:"The configuration of the hook affected by the action."`
+	Data                   jsoniter.RawMessage        `json:"data,omitempty" description:"Additional data about the action."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type ActorLocation struct {
+	CountryCode *string      `json:"country_code,omitempty" description:"The ISO country code of the location."`
+	CountryName *string      `json:"country_name,omitempty" description:"The country name of the location."`
+	Region      *string      `json:"region,omitempty" description:"The region of the location."`
+	RegionName  *string      `json:"region_name,omitempty" description:"The region name of the location."`
+	City        *string      `json:"city,omitempty" description:"The city of the location."`
+	PostalCode  *string      `json:"postal_code,omitempty" description:"The postal code of the location."`
+	Location    *Coordinates `json:"location,omitempty" description:"The coordinates of the location."`
+}
+
+// nolint:lll
+type Coordinates struct {
+	Lat *float64 `json:"lat,omitempty" description:"The latitude of the location."`
+	Lon *float64 `json:"lon,omitempty" description:"The longitude of the location."`
+}
+
+// AuditParser parses GitHub audit log events
+type AuditParser struct{}
+
+var _ parsers.LogParser = (*AuditParser)(nil)
+
+func (p *AuditParser) New() parsers.LogParser {
+	return &AuditParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AuditParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Audit{}
+	if err := jsoniter.UnmarshalFromString(log, event); err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AuditParser) LogType() string {
+	return TypeAudit
+}
+
+func (event *Audit) updatePantherFields(p *AuditParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+
+	event.AppendAnyIPAddressPtr(event.ActorIP)
+	event.AppendAnyUsernamePtrs(event.Actor, event.User)
+}
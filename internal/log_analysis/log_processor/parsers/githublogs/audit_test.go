@@ -0,0 +1,92 @@
+package githublogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAudit(t *testing.T) {
+	// nolint:lll
+	log := `{"@timestamp":1591037780123,"_document_id":"xJJFlFOhQ6b-5vaAFy9Rjw","action":"repo.access","actor":"octocat","actor_id":583231,"actor_ip":"198.51.100.7","actor_location":{"country_code":"US","country_name":"United States","region":"CA","city":"San Francisco","location":{"lat":37.7749,"lon":-122.4194}},"created_at":1591037780123,"org":"octo-org","org_id":1234,"repo":"octo-org/octo-repo","visibility":"private","user":"monalisa","user_agent":"git/2.27.0","data":{"old_visibility":"public"}}`
+
+	tm := time.Date(2020, 6, 1, 18, 56, 20, 123000000, time.UTC)
+	event := &Audit{
+		Timestamp:  (*timestamp.UnixMillisecond)(&tm),
+		DocumentID: aws.String("xJJFlFOhQ6b-5vaAFy9Rjw"),
+		Action:     aws.String("repo.access"),
+		Actor:      aws.String("octocat"),
+		ActorID:    aws.Int64(583231),
+		ActorIP:    aws.String("198.51.100.7"),
+		ActorLocation: &ActorLocation{
+			CountryCode: aws.String("US"),
+			CountryName: aws.String("United States"),
+			Region:      aws.String("CA"),
+			City:        aws.String("San Francisco"),
+			Location: &Coordinates{
+				Lat: aws.Float64(37.7749),
+				Lon: aws.Float64(-122.4194),
+			},
+		},
+		CreatedAt:  (*timestamp.UnixMillisecond)(&tm),
+		Org:        aws.String("octo-org"),
+		OrgID:      aws.Int64(1234),
+		Repo:       aws.String("octo-org/octo-repo"),
+		Visibility: aws.String("private"),
+		User:       aws.String("monalisa"),
+		UserAgent:  aws.String("git/2.27.0"),
+		Data:       jsoniter.RawMessage(`{"old_visibility":"public"}`),
+	}
+	event.SetCoreFields(TypeAudit, (*timestamp.RFC3339)(&tm), event)
+	event.AppendAnyIPAddress("198.51.100.7")
+	event.AppendAnyUsernames("octocat", "monalisa")
+
+	testutil.CheckPantherParser(t, log, &AuditParser{}, &event.PantherLog)
+}
+
+func TestAuditInvalid(t *testing.T) {
+	parser := (&AuditParser{}).New()
+	// missing timestamp
+	_, err := parser.Parse(`{"action":"repo.create","actor":"octocat"}`)
+	require.Error(t, err)
+	// actions are always of the form category.operation
+	_, err = parser.Parse(`{"@timestamp":1591037780123,"action":"user_login","actor":"octocat"}`)
+	require.Error(t, err)
+	// Slack audit log
+	// nolint:lll
+	_, err = parser.Parse(`{"id":"0123a45b","date_create":1591037780,"action":"user_login","actor":{"type":"user","user":{"id":"W123AB456","name":"Charlie Parker","email":"bird@example.com"}},"entity":{"type":"user"}}`)
+	require.Error(t, err)
+	// GitLab API log
+	// nolint:lll
+	_, err = parser.Parse(`{"time":"2020-06-01T18:56:20.123Z","severity":"INFO","duration_s":0.01,"status":200,"method":"GET","path":"/api/v4/projects","remote_ip":"198.51.100.7","username":"octocat","route":"/api/:version/projects"}`)
+	require.Error(t, err)
+}
+
+func TestAuditLogType(t *testing.T) {
+	parser := &AuditParser{}
+	require.Equal(t, "GitHub.Audit", parser.LogType())
+}
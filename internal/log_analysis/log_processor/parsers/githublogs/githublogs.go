@@ -0,0 +1,41 @@
+package githublogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "GitHub"
+	// TypeAudit is the log type of GitHub organization audit log events
+	TypeAudit = PantherPrefix + ".Audit"
+)
+
+func init() {
+	logtypes.MustRegister(logtypes.Config{
+		Name:         TypeAudit,
+		Description:  `GitHub audit log events record the actions performed by members of a GitHub organization or enterprise, as exported in JSON.`,
+		ReferenceURL: `https://docs.github.com/en/organizations/keeping-your-organization-secure/reviewing-the-audit-log-for-your-organization`,
+		Schema:       Audit{},
+		NewParser:    parsers.AdapterFactory(&AuditParser{}),
+	})
+}
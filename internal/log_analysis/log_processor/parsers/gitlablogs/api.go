@@ -22,6 +22,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
@@ -58,7 +59,8 @@ type API struct {
 	// ExceptionMessage   *string      `json:"exception.message,omitempty" description:"Message of the exception that occurred"`
 	// ExceptionBacktrace []*string    `json:"exception.backtrace,omitempty" description:"Stack trace of the exception that occurred"`
 
-	parsers.PantherLog
+	// Request parameters can reference AWS resources
+	awslogs.AWSPantherLog
 }
 
 // APIParser parses gitlab rails logs
@@ -96,4 +98,6 @@ func (p *APIParser) LogType() string {
 func (event *API) updatePantherFields(p *APIParser) {
 	event.SetCoreFields(p.LogType(), event.Time, event)
 	event.AppendAnyIPAddressPtr(event.RemoteIP)
+	event.AppendAnyUsernamePtrs(event.UserName, event.MetaUser)
+	extractAWSFields(&event.AWSPantherLog, event.Path, event.Params)
 }
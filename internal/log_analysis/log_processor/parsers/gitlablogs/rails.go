@@ -22,6 +22,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
@@ -56,7 +57,8 @@ type Production struct {
 	ExceptionMessage      *string            `json:"exception.message,omitempty" description:"Message of the exception that occurred"`
 	ExceptionBacktrace    []string           `json:"exception.backtrace,omitempty" description:"Stack trace of the exception that occurred"`
 
-	parsers.PantherLog
+	// Request parameters can reference AWS resources
+	awslogs.AWSPantherLog
 }
 
 // QueryParam is an HTTP query param as logged by LogRage
@@ -101,4 +103,16 @@ func (p *ProductionParser) LogType() string {
 func (event *Production) updatePantherFields(p *ProductionParser) {
 	event.SetCoreFields(p.LogType(), event.Time, event)
 	event.AppendAnyIPAddressPtr(event.RemoteIP)
+	event.AppendAnyUsernamePtrs(event.UserName)
+	extractAWSFields(&event.AWSPantherLog, event.Path, event.Params)
+}
+
+// extractAWSFields extracts the AWS account ids and ARNs referenced in the request path and params
+func extractAWSFields(log *awslogs.AWSPantherLog, path *string, params []QueryParam) {
+	extractor := awslogs.NewAWSExtractor(log)
+	extractor.ExtractARNsInText(path)
+	for _, param := range params {
+		value := string(param.Value)
+		extractor.ExtractARNsInText(&value)
+	}
 }
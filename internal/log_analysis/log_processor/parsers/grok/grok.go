@@ -0,0 +1,206 @@
+// Package grok compiles Grok-style patterns to regular expressions with named captures.
+//
+// A pattern is a regular expression that can reference named definitions with `%{NAME}`
+// or capture the text matched by a definition with `%{NAME:field}`.
+// Plain regular expression named groups `(?P<field>...)` are also captures.
+package grok
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxExpandedSize is the maximum size of the regular expression a pattern expands to.
+	// Definitions referencing each other multiple times grow exponentially.
+	MaxExpandedSize = 256 * 1024
+	// MaxDepth is the maximum nesting of definitions referencing other definitions
+	MaxDepth = 32
+)
+
+var (
+	// referenceRegex matches %{NAME} and %{NAME:field} references
+	referenceRegex = regexp.MustCompile(`%\{(\w+)(?::([^}]*))?\}`)
+	captureRegex   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Pattern is a compiled grok pattern
+type Pattern struct {
+	expr *regexp.Regexp
+	// fields are the names of the captures in order of appearance
+	fields []string
+	// captures maps the subexpressions of expr to an index in fields or -1
+	captures []int
+}
+
+// MustCompile compiles a pattern and panics on error
+func MustCompile(pattern string, definitions map[string]string) *Pattern {
+	p, err := Compile(pattern, definitions)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Compile compiles a pattern using the default definitions and any additional definitions.
+// Additional definitions override the default definitions with the same name.
+// Patterns must match the whole text.
+func Compile(pattern string, definitions map[string]string) (*Pattern, error) {
+	e := expander{
+		definitions: definitions,
+		expanded:    make(map[string]string),
+		pending:     make(map[string]bool),
+	}
+	expanded, err := e.expand(pattern)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := regexp.Compile(`^(?:` + expanded + `)$`)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid grok pattern")
+	}
+	p := &Pattern{
+		expr:     expr,
+		captures: make([]int, expr.NumSubexp()+1),
+	}
+	index := make(map[string]int)
+	for i, name := range expr.SubexpNames() {
+		if name == "" {
+			p.captures[i] = -1
+			continue
+		}
+		n, ok := index[name]
+		if !ok {
+			n = len(p.fields)
+			index[name] = n
+			p.fields = append(p.fields, name)
+		}
+		p.captures[i] = n
+	}
+	return p, nil
+}
+
+// String returns the expanded regular expression of the pattern
+func (p *Pattern) String() string {
+	return p.expr.String()
+}
+
+// Fields returns the names of the captures of the pattern
+func (p *Pattern) Fields() []string {
+	return p.fields
+}
+
+// MatchString checks if the pattern matches a text
+func (p *Pattern) MatchString(s string) bool {
+	return p.expr.MatchString(s)
+}
+
+// Match returns the values of the captures in the order of Fields or nil if the text does not match.
+// Captures that did not participate in the match are empty.
+// If a field is captured more than once the first non empty value is used.
+func (p *Pattern) Match(s string) []string {
+	matches := p.expr.FindStringSubmatchIndex(s)
+	if matches == nil {
+		return nil
+	}
+	values := make([]string, len(p.fields))
+	for i, n := range p.captures {
+		if n == -1 || values[n] != "" {
+			continue
+		}
+		if start, end := matches[2*i], matches[2*i+1]; start != -1 {
+			values[n] = s[start:end]
+		}
+	}
+	return values
+}
+
+type expander struct {
+	definitions map[string]string
+	expanded    map[string]string
+	// pending tracks the definitions being expanded to detect cycles
+	pending map[string]bool
+}
+
+func (e *expander) definition(name string) (string, bool) {
+	if def, ok := e.definitions[name]; ok {
+		return def, true
+	}
+	def, ok := DefaultDefinitions[name]
+	return def, ok
+}
+
+func (e *expander) expand(pattern string) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, m := range referenceRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		b.WriteString(pattern[last:m[0]])
+		last = m[1]
+		name := pattern[m[2]:m[3]]
+		expr, err := e.expandDefinition(name)
+		if err != nil {
+			return "", err
+		}
+		if m[4] == -1 {
+			b.WriteString(`(?:` + expr + `)`)
+		} else {
+			field := pattern[m[4]:m[5]]
+			if !captureRegex.MatchString(field) {
+				return "", errors.Errorf("invalid grok capture name %q", field)
+			}
+			b.WriteString(`(?P<` + field + `>` + expr + `)`)
+		}
+		if b.Len() > MaxExpandedSize {
+			return "", errors.Errorf("grok pattern expands to more than %d bytes", MaxExpandedSize)
+		}
+	}
+	b.WriteString(pattern[last:])
+	if b.Len() > MaxExpandedSize {
+		return "", errors.Errorf("grok pattern expands to more than %d bytes", MaxExpandedSize)
+	}
+	return b.String(), nil
+}
+
+func (e *expander) expandDefinition(name string) (string, error) {
+	if expr, ok := e.expanded[name]; ok {
+		return expr, nil
+	}
+	def, ok := e.definition(name)
+	if !ok {
+		return "", errors.Errorf("undefined grok pattern %q", name)
+	}
+	if e.pending[name] {
+		return "", errors.Errorf("recursive grok pattern %q", name)
+	}
+	if len(e.pending) >= MaxDepth {
+		return "", errors.Errorf("grok pattern %q is nested more than %d definitions deep", name, MaxDepth)
+	}
+	e.pending[name] = true
+	expr, err := e.expand(def)
+	if err != nil {
+		return "", err
+	}
+	delete(e.pending, name)
+	e.expanded[name] = expr
+	return expr, nil
+}
@@ -0,0 +1,127 @@
+package grok
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	p, err := Compile(`%{IP:client} %{USER:ident} \[%{HTTPDATE:time}\] "%{WORD:method} %{NOTSPACE:path}" %{INT:status} (?P<bytes>\d+|-)`, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"client", "ident", "time", "method", "path", "status", "bytes"}, p.Fields())
+
+	values := p.Match(`192.168.1.1 frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif" 200 -`)
+	require.Equal(t, []string{"192.168.1.1", "frank", "10/Oct/2000:13:55:36 -0700", "GET", "/apache_pb.gif", "200", "-"}, values)
+
+	// patterns must match the whole text
+	require.Nil(t, p.Match(`192.168.1.1 frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif" 200 - trailing`))
+	require.False(t, p.MatchString(`not a log`))
+}
+
+func TestCompileDefinitions(t *testing.T) {
+	definitions := map[string]string{
+		"LEVEL": `%{LOGLEVEL}`,
+		// overrides the default definition
+		"WORD": `[a-z]+`,
+	}
+	p, err := Compile(`%{LEVEL:level}(?: %{WORD:word})?: %{GREEDYDATA:message}`, definitions)
+	require.NoError(t, err)
+	require.Equal(t, []string{"WARN", "", "disk is full"}, p.Match(`WARN: disk is full`))
+	require.Equal(t, []string{"error", "disk", "is full"}, p.Match(`error disk: is full`))
+	require.Nil(t, p.Match(`error DISK: is full`))
+}
+
+func TestCompileDuplicateCaptures(t *testing.T) {
+	p := MustCompile(`%{IPV4:addr}|\[%{IPV6:addr}\]`, nil)
+	require.Equal(t, []string{"addr"}, p.Fields())
+	require.Equal(t, []string{"10.0.0.1"}, p.Match(`10.0.0.1`))
+	require.Equal(t, []string{"2001:db8::1"}, p.Match(`[2001:db8::1]`))
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, pattern := range []string{
+		`%{MISSING:field}`,
+		`%{WORD:invalid-name}`,
+		`%{NUMBER:n:int}`,
+		`%{LOOP}`,
+		`%{WORD:word} (`,
+	} {
+		_, err := Compile(pattern, map[string]string{"LOOP": `a%{LOOP}`})
+		require.Error(t, err, pattern)
+	}
+	require.Panics(t, func() { MustCompile(`%{MISSING}`, nil) })
+}
+
+func TestCompileLimits(t *testing.T) {
+	// each definition references the previous one twice, doubling the size of the expansion
+	definitions := map[string]string{"L0": `a+`}
+	for i := 1; i <= 30; i++ {
+		definitions[fmt.Sprintf("L%d", i)] = fmt.Sprintf(`%%{L%d}%%{L%d}`, i-1, i-1)
+	}
+	_, err := Compile(`%{L10}`, definitions)
+	require.NoError(t, err)
+	_, err = Compile(`%{L30}`, definitions)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expands to more than")
+
+	// each definition references the previous one once
+	definitions = map[string]string{"D0": `a`}
+	for i := 1; i <= MaxDepth+1; i++ {
+		definitions[fmt.Sprintf("D%d", i)] = fmt.Sprintf(`%%{D%d}`, i-1)
+	}
+	_, err = Compile(fmt.Sprintf(`%%{D%d}`, MaxDepth-1), definitions)
+	require.NoError(t, err)
+	_, err = Compile(fmt.Sprintf(`%%{D%d}`, MaxDepth+1), definitions)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "nested more than")
+}
+
+func TestDefaultDefinitions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		match   []string
+		noMatch []string
+	}{
+		"IP":                {[]string{"127.0.0.1", "::1", "fe80::1%eth0", "2001:db8:0:0:0:0:2:1"}, []string{"256.0.0.1", "example.com"}},
+		"HOSTNAME":          {[]string{"example.com", "www.example.com.", "localhost"}, []string{"-example.com"}},
+		"EMAILADDRESS":      {[]string{"jdoe@example.com", "j.doe+tag@mail.example.com"}, []string{"jdoe@", "@example.com"}},
+		"TIMESTAMP_ISO8601": {[]string{"2020-06-01T12:00:00Z", "2020-06-01 12:00:00.123+02:00"}, []string{"2020/06/01 12:00"}},
+		"SYSLOGTIMESTAMP":   {[]string{"Jun  1 12:00:00", "Dec 31 23:59:59"}, []string{"2020-06-01 12:00:00"}},
+		"URI":               {[]string{"https://user@example.com:8443/path/file.txt?a=1&b=2"}, []string{"example.com/path"}},
+		"MAC":               {[]string{"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e"}, []string{"00:1a:2b"}},
+		"NUMBER":            {[]string{"42", "-1.5", ".5"}, []string{"1e3", "abc"}},
+		"SYSLOGPROG":        {[]string{"sshd[1234]", "cron"}, []string{"sshd[]"}},
+	} {
+		p := MustCompile(`%{`+name+`}`, nil)
+		for _, s := range tc.match {
+			require.True(t, p.MatchString(s), "%s should match %q", name, s)
+		}
+		for _, s := range tc.noMatch {
+			require.False(t, p.MatchString(s), "%s should not match %q", name, s)
+		}
+	}
+	// all definitions compile
+	for name := range DefaultDefinitions {
+		_, err := Compile(`%{`+name+`}`, nil)
+		require.NoError(t, err, name)
+	}
+}
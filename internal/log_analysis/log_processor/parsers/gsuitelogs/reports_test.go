@@ -0,0 +1,97 @@
+package gsuitelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestReports(t *testing.T) {
+	// nolint:lll
+	log := `{"kind":"admin#reports#activity","id":{"time":"2020-06-01T16:05:44.512Z","uniqueQualifier":"-8143750317421695722","applicationName":"login","customerId":"C03az79cb"},"etag":"\"JDMC8884sebSctZ17CIssbQ/2iwWD3Zs5upVC2jVlDqy3hGMhh0\"","actor":{"callerType":"USER","email":"alice@example.com","profileId":"114511147312345678901"},"ipAddress":"203.0.113.42","ownerDomain":"example.com","events":[{"type":"login","name":"login_failure","parameters":[{"name":"login_type","value":"google_password"},{"name":"login_challenge_method","multiValue":["password"]},{"name":"login_failure_type","value":"login_failure_invalid_password"},{"name":"is_suspicious","boolValue":true}]}]}`
+
+	tm := time.Date(2020, 6, 1, 16, 5, 44, 512000000, time.UTC)
+	event := &Reports{
+		ID: &ID{
+			ApplicationName: aws.String("login"),
+			CustomerID:      aws.String("C03az79cb"),
+			Time:            (*timestamp.RFC3339)(&tm),
+			UniqueQualifier: aws.String("-8143750317421695722"),
+		},
+		Actor: &Actor{
+			Email:      aws.String("alice@example.com"),
+			ProfileID:  aws.String("114511147312345678901"),
+			CallerType: aws.String("USER"),
+		},
+		Kind:        aws.String("admin#reports#activity"),
+		OwnerDomain: aws.String("example.com"),
+		IPAddress:   aws.String("203.0.113.42"),
+		Events: []Event{
+			{
+				Type: aws.String("login"),
+				Name: aws.String("login_failure"),
+				Parameters: []Parameter{
+					{
+						Name:  aws.String("login_type"),
+						Value: aws.String("google_password"),
+					},
+					{
+						Name:       aws.String("login_challenge_method"),
+						MultiValue: []string{"password"},
+					},
+					{
+						Name:  aws.String("login_failure_type"),
+						Value: aws.String("login_failure_invalid_password"),
+					},
+					{
+						Name:      aws.String("is_suspicious"),
+						BoolValue: aws.Bool(true),
+					},
+				},
+			},
+		},
+	}
+	event.SetCoreFields(TypeReports, event.ID.Time, event)
+	event.AppendAnyIPAddress("203.0.113.42")
+	event.AppendAnyDomainNames("example.com")
+	event.AppendAnyUsernames("alice@example.com")
+	event.AppendAnyEmails("alice@example.com")
+
+	testutil.CheckPantherParser(t, log, &ReportsParser{}, &event.PantherLog)
+}
+
+func TestReportsInvalid(t *testing.T) {
+	parser := (&ReportsParser{}).New()
+	_, err := parser.Parse(`{"kind":"admin#reports#activity","actor":{"email":"alice@example.com"}}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"kind":"admin#reports#activities","id":{"time":"2020-06-01T16:05:44.512Z","applicationName":"login"}}`)
+	require.Error(t, err)
+}
+
+func TestReportsLogType(t *testing.T) {
+	parser := &ReportsParser{}
+	require.Equal(t, "GSuite.Reports", parser.LogType())
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
@@ -87,6 +88,8 @@ func (p *AuditParser) Parse(log string) ([]*parsers.PantherLog, error) {
 	if event.LoginIP != nil {
 		event.AppendAnyIPAddress(*event.LoginIP)
 	}
+	event.AppendAnyUsernamePtrs(event.Username)
+	awslogs.NewAWSExtractor(&event.AWSPantherLog).ExtractARNsInText(&event.Message)
 	return event.Logs(), nil
 }
 
@@ -114,7 +117,8 @@ type Audit struct {
 	LoginIP   *string           `json:"login_ip,omitempty" description:"The IP address the user performed logged in from"`
 	Username  *string           `json:"username,omitempty" description:"The user that performed the login"`
 
-	parsers.PantherLog
+	// Messages can reference AWS resources
+	awslogs.AWSPantherLog
 }
 
 var rxAudit = regexp.MustCompile(fmt.Sprintf(
@@ -44,6 +44,7 @@ func TestAuditParserParse(t *testing.T) {
 
 		event.SetCoreFields(TypeAudit, (*timestamp.RFC3339)(&tm), &event)
 		event.AppendAnyIPAddress("10.10.0.117")
+		event.AppendAnyUsernames("mykonos")
 		testutil.CheckPantherParser(t, log, NewAuditParser(), &event.PantherLog)
 	})
 	t.Run("Response deactivation", func(t *testing.T) {
@@ -76,6 +77,26 @@ func TestAuditParserParse(t *testing.T) {
 		}
 
 		event.SetCoreFields(TypeAudit, (*timestamp.RFC3339)(&tm), &event)
+		event.AppendAnyUsernames("mykonos")
+		testutil.CheckPantherParser(t, log, NewAuditParser(), &event.PantherLog)
+	})
+	t.Run("AWS ARN in message", func(t *testing.T) {
+		// nolint:lll
+		log := `Feb 14 19:02:54 my-jwas [mws-audit][INFO][mykonos] Changed configuration parameters: services.s3.role=arn:aws:iam::123456789012:role/JWASExport`
+		now := time.Now()
+		tm := time.Date(now.Year(), 2, 14, 19, 2, 54, 0, time.UTC)
+		event := Audit{
+			Timestamp: timestamp.RFC3339(tm),
+			Hostname:  "my-jwas",
+			LogLevel:  "INFO",
+			Username:  aws.String("mykonos"),
+			Message:   "Changed configuration parameters: services.s3.role=arn:aws:iam::123456789012:role/JWASExport",
+		}
+
+		event.SetCoreFields(TypeAudit, (*timestamp.RFC3339)(&tm), &event)
+		event.AppendAnyUsernames("mykonos")
+		event.AppendAnyAWSARNs("arn:aws:iam::123456789012:role/JWASExport")
+		event.AppendAnyAWSAccountIds("123456789012")
 		testutil.CheckPantherParser(t, log, NewAuditParser(), &event.PantherLog)
 	})
 }
@@ -0,0 +1,140 @@
+package kuberneteslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Audit struct {
+	Kind                     *string             `json:"kind" validate:"required,eq=Event" description:"The kind of the object, always Event."`
+	APIVersion               *string             `json:"apiVersion" validate:"required,oneof=audit.k8s.io/v1 audit.k8s.io/v1beta1" description:"The version of the audit event schema, eg. audit.k8s.io/v1."`
+	Level                    *string             `json:"level" validate:"required" description:"The audit level the event was generated at (None, Metadata, Request or RequestResponse)."`
+	AuditID                  *string             `json:"auditID" validate:"required" description:"The unique audit ID, generated for each request."`
+	Stage                    *string             `json:"stage" validate:"required" description:"The stage of the request handling when this event instance was generated (RequestReceived, ResponseStarted, ResponseComplete or Panic)."`
+	RequestURI               *string             `json:"requestURI" validate:"required" description:"The request URI as sent by the client to a server."`
+	Verb                     *string             `json:"verb" validate:"required" description:"The Kubernetes verb associated with the request, eg. get, list, create, delete or watch."`
+	User                     *UserInfo           `json:"user" validate:"required" description:"The authenticated user information."`
+	ImpersonatedUser         *UserInfo           `json:"impersonatedUser,omitempty" description:"The impersonated user information."`
+	SourceIPs                []string            `json:"sourceIPs,omitempty" description:"The source IPs, from where the request originated and intermediate proxies."`
+	UserAgent                *string             `json:"userAgent,omitempty" description:"The user agent string reported by the client."`
+	ObjectRef                *ObjectReference    `json:"objectRef,omitempty" description:"The object reference this request is targeted at."`
+	ResponseStatus           *Status             `json:"responseStatus,omitempty" description:"The response status, populated even when the response object is not a Status type."`
+	RequestObject            jsoniter.RawMessage `json:"requestObject,omitempty" description:"The API object from the request, in JSON format. Recorded at the Request level and higher."`
+	ResponseObject           jsoniter.RawMessage `json:"responseObject,omitempty" description:"The API object returned in the response, in JSON format. Recorded at the RequestResponse level."`
+	RequestReceivedTimestamp *timestamp.RFC3339  `json:"requestReceivedTimestamp" validate:"required" description:"The time the request reached the API server."`
+	StageTimestamp           *timestamp.RFC3339  `json:"stageTimestamp" validate:"required" description:"The time the request reached the current audit stage."`
+	Annotations              map[string]string   `json:"annotations,omitempty" description:"Unstructured key value map stored with the audit event, eg. the authorization decision."`
+
+	// NOTE: added to end of struct to allow expansion later
+	// EKS clusters record the IAM identity of the user, it is collected in the AWS fields
+	awslogs.AWSPantherLog
+}
+
+// UserInfo holds the information about the user making a request
+// nolint:lll
+type UserInfo struct {
+	Username *string             `json:"username,omitempty" description:"The name that uniquely identifies this user among all active users."`
+	UID      *string             `json:"uid,omitempty" description:"A unique value that identifies this user across time."`
+	Groups   []string            `json:"groups,omitempty" description:"The names of groups this user is a part of."`
+	Extra    jsoniter.RawMessage `json:"extra,omitempty" description:"Any additional information provided by the authenticator, as a JSON object of string arrays."`
+}
+
+// ObjectReference contains enough information to let you inspect or modify the referred object
+// nolint:lll
+type ObjectReference struct {
+	Resource        *string `json:"resource,omitempty" description:"The resource type, eg. pods or secrets."`
+	Namespace       *string `json:"namespace,omitempty" description:"The namespace of the object."`
+	Name            *string `json:"name,omitempty" description:"The name of the object."`
+	UID             *string `json:"uid,omitempty" description:"The UID of the object."`
+	APIGroup        *string `json:"apiGroup,omitempty" description:"The name of the API group that contains the referred object, empty for the core API group."`
+	APIVersion      *string `json:"apiVersion,omitempty" description:"The version of the API group that contains the referred object."`
+	ResourceVersion *string `json:"resourceVersion,omitempty" description:"The resource version of the object."`
+	Subresource     *string `json:"subresource,omitempty" description:"The subresource of the request, eg. exec, log or status."`
+}
+
+// Status is the return value of calls that don't return other objects
+// nolint:lll
+type Status struct {
+	Status  *string             `json:"status,omitempty" description:"The status of the operation, either Success or Failure."`
+	Message *string             `json:"message,omitempty" description:"A human-readable description of the status of this operation."`
+	Reason  *string             `json:"reason,omitempty" description:"A machine-readable description of why this operation is in the Failure status."`
+	Details jsoniter.RawMessage `json:"details,omitempty" description:"Extended data associated with the reason, as a JSON object."`
+	Code    *int32              `json:"code,omitempty" description:"The suggested HTTP return code for this status."`
+}
+
+// AuditParser parses Kubernetes audit events
+type AuditParser struct{}
+
+var _ parsers.LogParser = (*AuditParser)(nil)
+
+// New returns an initialized LogParser for Kubernetes audit events
+func (p *AuditParser) New() parsers.LogParser {
+	return &AuditParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AuditParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Audit{}
+	if err := jsoniter.UnmarshalFromString(log, event); err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AuditParser) LogType() string {
+	return TypeAudit
+}
+
+// extraARN is the key of the IAM identity ARN in the extra user information of EKS audit events
+const extraARN = "arn"
+
+func (event *Audit) updatePantherFields(p *AuditParser) {
+	event.SetCoreFields(p.LogType(), event.StageTimestamp, event)
+
+	for _, ip := range event.SourceIPs {
+		event.AppendAnyIPAddress(ip)
+	}
+	for _, user := range []*UserInfo{event.User, event.ImpersonatedUser} {
+		if user == nil {
+			continue
+		}
+		event.AppendAnyUsernamePtrs(user.Username)
+		event.AppendAnyEmailPtrs(user.Username)
+		if len(user.Extra) > 0 {
+			extra := map[string][]string{}
+			if err := jsoniter.Unmarshal(user.Extra, &extra); err == nil && len(extra[extraARN]) > 0 {
+				event.AppendAnyAWSARNs(extra[extraARN]...)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package kuberneteslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAuditExec(t *testing.T) {
+	// nolint:lll
+	log := `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Request","auditID":"6d4b4b8a-5a4e-4c2b-9b8e-0b6c4f7b1c2d","stage":"ResponseStarted","requestURI":"/api/v1/namespaces/default/pods/nginx-7bb7cd8db5-6x2tk/exec?command=sh&container=nginx&stdin=true&stdout=true&tty=true","verb":"create","user":{"username":"kubernetes-admin","uid":"heptio-authenticator-aws:123456789012:AROAEXAMPLE","groups":["system:masters","system:authenticated"],"extra":{"accessKeyId":["ASIAEXAMPLE"],"arn":["arn:aws:sts::123456789012:assumed-role/Admin/jdoe"]}},"sourceIPs":["203.0.113.10"],"userAgent":"kubectl/v1.18.3 (darwin/amd64) kubernetes/2e7996e","objectRef":{"resource":"pods","namespace":"default","name":"nginx-7bb7cd8db5-6x2tk","apiVersion":"v1","subresource":"exec"},"responseStatus":{"metadata":{},"code":101},"requestReceivedTimestamp":"2020-06-01T15:09:42.123456Z","stageTimestamp":"2020-06-01T15:09:42.234567Z","annotations":{"authorization.k8s.io/decision":"allow","authorization.k8s.io/reason":""}}`
+
+	received := time.Date(2020, 6, 1, 15, 9, 42, 123456000, time.UTC)
+	stage := time.Date(2020, 6, 1, 15, 9, 42, 234567000, time.UTC)
+	event := &Audit{
+		Kind:       aws.String("Event"),
+		APIVersion: aws.String("audit.k8s.io/v1"),
+		Level:      aws.String("Request"),
+		AuditID:    aws.String("6d4b4b8a-5a4e-4c2b-9b8e-0b6c4f7b1c2d"),
+		Stage:      aws.String("ResponseStarted"),
+		RequestURI: aws.String("/api/v1/namespaces/default/pods/nginx-7bb7cd8db5-6x2tk/exec?command=sh&container=nginx&stdin=true&stdout=true&tty=true"),
+		Verb:       aws.String("create"),
+		User: &UserInfo{
+			Username: aws.String("kubernetes-admin"),
+			UID:      aws.String("heptio-authenticator-aws:123456789012:AROAEXAMPLE"),
+			Groups:   []string{"system:masters", "system:authenticated"},
+			Extra:    jsoniter.RawMessage(`{"accessKeyId":["ASIAEXAMPLE"],"arn":["arn:aws:sts::123456789012:assumed-role/Admin/jdoe"]}`),
+		},
+		SourceIPs: []string{"203.0.113.10"},
+		UserAgent: aws.String("kubectl/v1.18.3 (darwin/amd64) kubernetes/2e7996e"),
+		ObjectRef: &ObjectReference{
+			Resource:    aws.String("pods"),
+			Namespace:   aws.String("default"),
+			Name:        aws.String("nginx-7bb7cd8db5-6x2tk"),
+			APIVersion:  aws.String("v1"),
+			Subresource: aws.String("exec"),
+		},
+		ResponseStatus: &Status{
+			Code: aws.Int32(101),
+		},
+		RequestReceivedTimestamp: (*timestamp.RFC3339)(&received),
+		StageTimestamp:           (*timestamp.RFC3339)(&stage),
+		Annotations: map[string]string{
+			"authorization.k8s.io/decision": "allow",
+			"authorization.k8s.io/reason":   "",
+		},
+	}
+	event.SetCoreFields(TypeAudit, event.StageTimestamp, event)
+	event.AppendAnyIPAddress("203.0.113.10")
+	event.AppendAnyUsernames("kubernetes-admin")
+	event.AppendAnyAWSARNs("arn:aws:sts::123456789012:assumed-role/Admin/jdoe")
+
+	testutil.CheckPantherParser(t, log, &AuditParser{}, &event.PantherLog)
+}
+
+func TestAuditRBAC(t *testing.T) {
+	// nolint:lll
+	log := `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","auditID":"0f6a6c2c-58f0-4bfa-8c4c-7a0b6d5e8f01","stage":"ResponseComplete","requestURI":"/apis/rbac.authorization.k8s.io/v1/clusterrolebindings","verb":"create","user":{"username":"jdoe@example.com","groups":["system:authenticated"]},"impersonatedUser":{"username":"system:serviceaccount:kube-system:deployer","groups":["system:serviceaccounts"]},"sourceIPs":["10.0.0.8","2001:db8::1"],"userAgent":"kubectl/v1.18.3","objectRef":{"resource":"clusterrolebindings","name":"cluster-admin-binding","apiGroup":"rbac.authorization.k8s.io","apiVersion":"v1"},"responseStatus":{"metadata":{},"status":"Failure","message":"clusterrolebindings.rbac.authorization.k8s.io \"cluster-admin-binding\" already exists","reason":"AlreadyExists","details":{"name":"cluster-admin-binding","group":"rbac.authorization.k8s.io","kind":"clusterrolebindings"},"code":409},"requestObject":{"kind":"ClusterRoleBinding","apiVersion":"rbac.authorization.k8s.io/v1","metadata":{"name":"cluster-admin-binding"},"roleRef":{"apiGroup":"rbac.authorization.k8s.io","kind":"ClusterRole","name":"cluster-admin"}},"responseObject":{"kind":"Status","apiVersion":"v1","status":"Failure","code":409},"requestReceivedTimestamp":"2020-06-01T15:10:00.000001Z","stageTimestamp":"2020-06-01T15:10:00.010203Z"}`
+
+	received := time.Date(2020, 6, 1, 15, 10, 0, 1000, time.UTC)
+	stage := time.Date(2020, 6, 1, 15, 10, 0, 10203000, time.UTC)
+	event := &Audit{
+		Kind:       aws.String("Event"),
+		APIVersion: aws.String("audit.k8s.io/v1"),
+		Level:      aws.String("RequestResponse"),
+		AuditID:    aws.String("0f6a6c2c-58f0-4bfa-8c4c-7a0b6d5e8f01"),
+		Stage:      aws.String("ResponseComplete"),
+		RequestURI: aws.String("/apis/rbac.authorization.k8s.io/v1/clusterrolebindings"),
+		Verb:       aws.String("create"),
+		User: &UserInfo{
+			Username: aws.String("jdoe@example.com"),
+			Groups:   []string{"system:authenticated"},
+		},
+		ImpersonatedUser: &UserInfo{
+			Username: aws.String("system:serviceaccount:kube-system:deployer"),
+			Groups:   []string{"system:serviceaccounts"},
+		},
+		SourceIPs: []string{"10.0.0.8", "2001:db8::1"},
+		UserAgent: aws.String("kubectl/v1.18.3"),
+		ObjectRef: &ObjectReference{
+			Resource:   aws.String("clusterrolebindings"),
+			Name:       aws.String("cluster-admin-binding"),
+			APIGroup:   aws.String("rbac.authorization.k8s.io"),
+			APIVersion: aws.String("v1"),
+		},
+		ResponseStatus: &Status{
+			Status:  aws.String("Failure"),
+			Message: aws.String(`clusterrolebindings.rbac.authorization.k8s.io "cluster-admin-binding" already exists`),
+			Reason:  aws.String("AlreadyExists"),
+			Details: jsoniter.RawMessage(`{"name":"cluster-admin-binding","group":"rbac.authorization.k8s.io","kind":"clusterrolebindings"}`),
+			Code:    aws.Int32(409),
+		},
+		RequestObject:            jsoniter.RawMessage(`{"kind":"ClusterRoleBinding","apiVersion":"rbac.authorization.k8s.io/v1","metadata":{"name":"cluster-admin-binding"},"roleRef":{"apiGroup":"rbac.authorization.k8s.io","kind":"ClusterRole","name":"cluster-admin"}}`),
+		ResponseObject:           jsoniter.RawMessage(`{"kind":"Status","apiVersion":"v1","status":"Failure","code":409}`),
+		RequestReceivedTimestamp: (*timestamp.RFC3339)(&received),
+		StageTimestamp:           (*timestamp.RFC3339)(&stage),
+	}
+	event.SetCoreFields(TypeAudit, event.StageTimestamp, event)
+	event.AppendAnyIPAddress("10.0.0.8")
+	event.AppendAnyIPAddress("2001:db8::1")
+	event.AppendAnyUsernames("jdoe@example.com")
+	event.AppendAnyEmails("jdoe@example.com")
+	event.AppendAnyUsernames("system:serviceaccount:kube-system:deployer")
+
+	testutil.CheckPantherParser(t, log, &AuditParser{}, &event.PantherLog)
+}
+
+func TestAuditInvalid(t *testing.T) {
+	parser := (&AuditParser{}).New()
+	// nolint:lll
+	_, err := parser.Parse(`{"kind":"EventList","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"1","stage":"ResponseComplete","requestURI":"/api","verb":"get","user":{},"requestReceivedTimestamp":"2020-06-01T15:10:00Z","stageTimestamp":"2020-06-01T15:10:00Z"}`)
+	require.Error(t, err)
+	// nolint:lll
+	_, err = parser.Parse(`{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"1","stage":"ResponseComplete","requestURI":"/api","verb":"get","user":{},"requestReceivedTimestamp":"2020-06-01T15:10:00Z"}`)
+	require.Error(t, err)
+}
+
+func TestAuditLogType(t *testing.T) {
+	parser := &AuditParser{}
+	require.Equal(t, "Kubernetes.Audit", parser.LogType())
+}
@@ -0,0 +1,41 @@
+package kuberneteslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "Kubernetes"
+	// TypeAudit is the log type of Kubernetes audit events
+	TypeAudit = PantherPrefix + ".Audit"
+)
+
+func init() {
+	logtypes.MustRegister(logtypes.Config{
+		Name:         TypeAudit,
+		Description:  `Kubernetes audit events record the requests made to the Kubernetes API server (audit.k8s.io Event objects).`,
+		ReferenceURL: `https://kubernetes.io/docs/reference/config-api/apiserver-audit.v1/#audit-k8s-io-v1-Event`,
+		Schema:       Audit{},
+		NewParser:    parsers.AdapterFactory(&AuditParser{}),
+	})
+}
@@ -0,0 +1,455 @@
+package leeflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type LEEF struct {
+	Syslog         *sysloglogs.Header `json:"syslog,omitempty" description:"The syslog header of LEEF events embedded in RFC3164 or RFC5424 syslog messages."`
+	Version        *string            `json:"version" validate:"required" description:"The version of the LEEF format, either 1.0 or 2.0."`
+	Vendor         *string            `json:"vendor" validate:"required" description:"The vendor of the sending device."`
+	Product        *string            `json:"product" validate:"required" description:"The product name of the sending device."`
+	ProductVersion *string            `json:"productVersion" validate:"required" description:"The version of the sending device."`
+	EventID        *string            `json:"eventId" validate:"required" description:"A unique identifier of the event type."`
+	Attributes     map[string]string  `json:"attributes,omitempty" description:"All the key/value event attributes, by key."`
+
+	Category       *string            `json:"cat,omitempty" description:"The category of the event as defined by the vendor."`
+	DevTime        *timestamp.RFC3339 `json:"devTime,omitempty" description:"The time the event occurred on the device."`
+	DevTimeFormat  *string            `json:"devTimeFormat,omitempty" description:"The Java SimpleDateFormat pattern of devTime."`
+	Protocol       *string            `json:"proto,omitempty" description:"The transport protocol, eg. TCP or UDP."`
+	Severity       *int               `json:"sev,omitempty" description:"The severity of the event from 1 to 10."`
+	Src            *string            `json:"src,omitempty" description:"The source IP address."`
+	Dst            *string            `json:"dst,omitempty" description:"The destination IP address."`
+	SrcPort        *uint16            `json:"srcPort,omitempty" description:"The source port."`
+	DstPort        *uint16            `json:"dstPort,omitempty" description:"The destination port."`
+	SrcPreNAT      *string            `json:"srcPreNAT,omitempty" description:"The source IP address before NAT."`
+	DstPreNAT      *string            `json:"dstPreNAT,omitempty" description:"The destination IP address before NAT."`
+	SrcPostNAT     *string            `json:"srcPostNAT,omitempty" description:"The source IP address after NAT."`
+	DstPostNAT     *string            `json:"dstPostNAT,omitempty" description:"The destination IP address after NAT."`
+	SrcPreNATPort  *uint16            `json:"srcPreNATPort,omitempty" description:"The source port before NAT."`
+	DstPreNATPort  *uint16            `json:"dstPreNATPort,omitempty" description:"The destination port before NAT."`
+	SrcPostNATPort *uint16            `json:"srcPostNATPort,omitempty" description:"The source port after NAT."`
+	DstPostNATPort *uint16            `json:"dstPostNATPort,omitempty" description:"The destination port after NAT."`
+	SrcMAC         *string            `json:"srcMAC,omitempty" description:"The source MAC address."`
+	DstMAC         *string            `json:"dstMAC,omitempty" description:"The destination MAC address."`
+	SrcBytes       *int64             `json:"srcBytes,omitempty" description:"The number of bytes sent by the source."`
+	DstBytes       *int64             `json:"dstBytes,omitempty" description:"The number of bytes sent by the destination."`
+	SrcPackets     *int64             `json:"srcPackets,omitempty" description:"The number of packets sent by the source."`
+	DstPackets     *int64             `json:"dstPackets,omitempty" description:"The number of packets sent by the destination."`
+	TotalPackets   *int64             `json:"totalPackets,omitempty" description:"The total number of packets of the event."`
+	UsrName        *string            `json:"usrName,omitempty" description:"The user name of the event."`
+	AccountName    *string            `json:"accountName,omitempty" description:"The account name of the event."`
+	Role           *string            `json:"role,omitempty" description:"The role of the user."`
+	Realm          *string            `json:"realm,omitempty" description:"The realm of the user."`
+	GroupID        *string            `json:"groupID,omitempty" description:"The group of the user."`
+	Domain         *string            `json:"domain,omitempty" description:"The domain of the user or device."`
+	Policy         *string            `json:"policy,omitempty" description:"The policy that applied to the event."`
+	Resource       *string            `json:"resource,omitempty" description:"The resource of the event."`
+	URL            *string            `json:"url,omitempty" description:"The URL of the event."`
+	IdentSrc       *string            `json:"identSrc,omitempty" description:"The IP address of the identity in identity events."`
+	IdentHostName  *string            `json:"identHostName,omitempty" description:"The hostname of the identity in identity events."`
+	IdentNetBios   *string            `json:"identNetBios,omitempty" description:"The NetBIOS name of the identity in identity events."`
+	IdentGrpName   *string            `json:"identGrpName,omitempty" description:"The group name of the identity in identity events."`
+	IdentMAC       *string            `json:"identMAC,omitempty" description:"The MAC address of the identity in identity events."`
+	IsLoginEvent   *bool              `json:"isLoginEvent,omitempty" description:"Indicates a login identity event."`
+	IsLogoutEvent  *bool              `json:"isLogoutEvent,omitempty" description:"Indicates a logout identity event."`
+	VSrc           *string            `json:"vSrc,omitempty" description:"The virtual (VPN) source IP address."`
+	VSrcName       *string            `json:"vSrcName,omitempty" description:"The virtual (VPN) source name."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// LEEFParser parses LEEF events, standalone or embedded in syslog messages
+type LEEFParser struct {
+	syslog *sysloglogs.HeaderParser
+}
+
+var _ parsers.LogParser = (*LEEFParser)(nil)
+
+// New returns an initialized LogParser for LEEF events
+func (p *LEEFParser) New() parsers.LogParser {
+	return &LEEFParser{
+		syslog: sysloglogs.NewHeaderParser(),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *LEEFParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	if p.syslog == nil {
+		return nil, errors.New("nil parser")
+	}
+	header, payload, err := p.syslog.Split(log, leefPrefix)
+	if err != nil {
+		return nil, err
+	}
+	event, err := parseLEEF(payload)
+	if err != nil {
+		return nil, err
+	}
+	event.Syslog = header
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *LEEFParser) LogType() string {
+	return TypeEvent
+}
+
+func (event *LEEF) updatePantherFields(p *LEEFParser) {
+	eventTime := event.DevTime
+	if eventTime == nil && event.Syslog != nil {
+		eventTime = event.Syslog.Timestamp
+	}
+	event.SetCoreFields(p.LogType(), eventTime, event)
+
+	for _, ip := range []*string{
+		event.Src, event.Dst,
+		event.SrcPreNAT, event.DstPreNAT,
+		event.SrcPostNAT, event.DstPostNAT,
+		event.IdentSrc, event.VSrc,
+	} {
+		event.AppendAnyIPAddressPtr(ip)
+	}
+	for _, host := range []*string{event.IdentHostName} {
+		if !event.AppendAnyIPAddressPtr(host) {
+			event.AppendAnyDomainNamePtrs(host)
+		}
+	}
+	if event.Syslog != nil && !event.AppendAnyIPAddressPtr(event.Syslog.Hostname) {
+		event.AppendAnyDomainNamePtrs(event.Syslog.Hostname)
+	}
+	if event.URL != nil {
+		if u, err := url.Parse(*event.URL); err == nil && u.Hostname() != "" {
+			if !event.AppendAnyIPAddress(u.Hostname()) {
+				event.AppendAnyDomainNames(u.Hostname())
+			}
+		}
+	}
+	event.AppendAnyUsernamePtrs(event.UsrName, event.AccountName)
+}
+
+const (
+	leefPrefix = "LEEF:"
+	// The number of '|' separated header fields before the attributes, not counting the LEEF 2.0 delimiter
+	leefHeaderFields = 5
+	// The attribute delimiter of LEEF 1.0 and the default one of LEEF 2.0
+	defaultDelimiter = "\t"
+)
+
+// delimiterRegex matches the LEEF 2.0 attribute delimiter, either a single character or its hex code (ie. 'x09' or '0x5E')
+var delimiterRegex = regexp.MustCompile(`^(.|0?[xX][0-9a-fA-F]{2,4})$`)
+
+// parseLEEF parses a LEEF event starting with the 'LEEF:' prefix
+func parseLEEF(payload string) (*LEEF, error) {
+	fields := strings.SplitN(strings.TrimPrefix(payload, leefPrefix), "|", leefHeaderFields+1)
+	if len(fields) != leefHeaderFields+1 {
+		return nil, errors.Errorf("invalid LEEF header, expected %d fields got %d", leefHeaderFields+1, len(fields))
+	}
+	attrs := fields[leefHeaderFields]
+	delimiter := defaultDelimiter
+	if strings.HasPrefix(fields[0], "2") {
+		// The delimiter field of LEEF 2.0 is optional
+		if pos := strings.IndexByte(attrs, '|'); pos != -1 && delimiterRegex.MatchString(attrs[:pos]) {
+			d, err := parseDelimiter(attrs[:pos])
+			if err != nil {
+				return nil, err
+			}
+			delimiter, attrs = d, attrs[pos+1:]
+		}
+	}
+	attributes, err := parseAttributes(attrs, delimiter)
+	if err != nil {
+		return nil, err
+	}
+	event := &LEEF{
+		Version:        &fields[0],
+		Vendor:         &fields[1],
+		Product:        &fields[2],
+		ProductVersion: &fields[3],
+		EventID:        &fields[4],
+		Attributes:     attributes,
+	}
+	for key, value := range attributes {
+		event.setAttributeField(key, value)
+	}
+	if devTime, ok := attributes["devTime"]; ok {
+		event.DevTime = parseTime(devTime, event.DevTimeFormat)
+	}
+	return event, nil
+}
+
+func parseDelimiter(s string) (string, error) {
+	if len(s) == 1 {
+		return s, nil
+	}
+	code, err := strconv.ParseUint(strings.TrimLeft(s, "0xX"), 16, 32)
+	if err != nil {
+		return "", errors.Errorf("invalid LEEF delimiter %q", s)
+	}
+	return string(rune(code)), nil
+}
+
+// parseAttributes parses the key=value event attributes.
+// Values can contain unescaped delimiters, so text without a key is part of the previous value.
+func parseAttributes(attrs, delimiter string) (map[string]string, error) {
+	attrs = strings.TrimSpace(attrs)
+	if attrs == "" {
+		return nil, nil
+	}
+	attributes := make(map[string]string)
+	key := ""
+	for _, attr := range strings.Split(attrs, delimiter) {
+		pos := strings.IndexByte(attr, '=')
+		if pos == -1 || strings.ContainsAny(attr[:pos], " \t") {
+			if key == "" {
+				return nil, errors.Errorf("invalid LEEF attribute %q", attr)
+			}
+			attributes[key] += delimiter + attr
+			continue
+		}
+		key = attr[:pos]
+		attributes[key] = attr[pos+1:]
+	}
+	return attributes, nil
+}
+
+// setAttributeField sets the typed column of a predefined attribute.
+// Values that do not match the type of the column are only available in the attributes map.
+func (event *LEEF) setAttributeField(key, value string) {
+	switch key {
+	case "cat":
+		event.Category = &value
+	case "devTimeFormat":
+		event.DevTimeFormat = &value
+	case "proto":
+		event.Protocol = &value
+	case "sev":
+		if sev, err := strconv.Atoi(value); err == nil {
+			event.Severity = &sev
+		}
+	case "src":
+		event.Src = &value
+	case "dst":
+		event.Dst = &value
+	case "srcPort":
+		event.SrcPort = parsePort(value)
+	case "dstPort":
+		event.DstPort = parsePort(value)
+	case "srcPreNAT":
+		event.SrcPreNAT = &value
+	case "dstPreNAT":
+		event.DstPreNAT = &value
+	case "srcPostNAT":
+		event.SrcPostNAT = &value
+	case "dstPostNAT":
+		event.DstPostNAT = &value
+	case "srcPreNATPort":
+		event.SrcPreNATPort = parsePort(value)
+	case "dstPreNATPort":
+		event.DstPreNATPort = parsePort(value)
+	case "srcPostNATPort":
+		event.SrcPostNATPort = parsePort(value)
+	case "dstPostNATPort":
+		event.DstPostNATPort = parsePort(value)
+	case "srcMAC":
+		event.SrcMAC = &value
+	case "dstMAC":
+		event.DstMAC = &value
+	case "srcBytes":
+		event.SrcBytes = parseInt64(value)
+	case "dstBytes":
+		event.DstBytes = parseInt64(value)
+	case "srcPackets":
+		event.SrcPackets = parseInt64(value)
+	case "dstPackets":
+		event.DstPackets = parseInt64(value)
+	case "totalPackets":
+		event.TotalPackets = parseInt64(value)
+	case "usrName":
+		event.UsrName = &value
+	case "accountName":
+		event.AccountName = &value
+	case "role":
+		event.Role = &value
+	case "realm":
+		event.Realm = &value
+	case "groupID":
+		event.GroupID = &value
+	case "domain":
+		event.Domain = &value
+	case "policy":
+		event.Policy = &value
+	case "resource":
+		event.Resource = &value
+	case "url":
+		event.URL = &value
+	case "identSrc":
+		event.IdentSrc = &value
+	case "identHostName":
+		event.IdentHostName = &value
+	case "identNetBios":
+		event.IdentNetBios = &value
+	case "identGrpName":
+		event.IdentGrpName = &value
+	case "identMAC":
+		event.IdentMAC = &value
+	case "isLoginEvent":
+		event.IsLoginEvent = parseBool(value)
+	case "isLogoutEvent":
+		event.IsLogoutEvent = parseBool(value)
+	case "vSrc":
+		event.VSrc = &value
+	case "vSrcName":
+		event.VSrcName = &value
+	}
+}
+
+func parseInt64(value string) *int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func parsePort(value string) *uint16 {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return nil
+	}
+	port := uint16(n)
+	return &port
+}
+
+func parseBool(value string) *bool {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil
+	}
+	return &b
+}
+
+// defaultTimeLayouts are the formats of devTime without a devTimeFormat besides milliseconds since epoch
+var defaultTimeLayouts = []string{
+	"Jan _2 2006 15:04:05.000 MST",
+	"Jan _2 2006 15:04:05 MST",
+	"Jan _2 2006 15:04:05.000",
+	"Jan _2 2006 15:04:05",
+}
+
+func parseTime(value string, format *string) *timestamp.RFC3339 {
+	if format != nil {
+		ts, err := timestamp.Parse(javaTimeLayout(*format), value)
+		if err != nil {
+			return nil
+		}
+		return &ts
+	}
+	if msec, err := strconv.ParseInt(value, 10, 64); err == nil {
+		ts := timestamp.Unix(0, msec*int64(time.Millisecond))
+		return &ts
+	}
+	for _, layout := range defaultTimeLayouts {
+		if ts, err := timestamp.Parse(layout, value); err == nil {
+			return &ts
+		}
+	}
+	return nil
+}
+
+// javaTimeLayouts maps the Java SimpleDateFormat patterns to Go time layouts, longest patterns first
+var javaTimeLayouts = []struct {
+	pattern string
+	layout  string
+}{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"M", "1"},
+	{"dd", "02"},
+	{"d", "2"},
+	{"EEEE", "Monday"},
+	{"EEE", "Mon"},
+	{"HH", "15"},
+	{"hh", "03"},
+	{"h", "3"},
+	{"mm", "04"},
+	{"ss", "05"},
+	{"SSS", "000"},
+	{"a", "PM"},
+	{"zzz", "MST"},
+	{"z", "MST"},
+	{"XXX", "Z07:00"},
+	{"Z", "-0700"},
+}
+
+// javaTimeLayout converts a Java SimpleDateFormat pattern (ie. 'MMM dd yyyy HH:mm:ss') to a Go time layout
+func javaTimeLayout(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		if format[i] == '\'' {
+			// Quoted literal text
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end == -1 {
+				b.WriteString(format[i+1:])
+				break
+			}
+			b.WriteString(format[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		matched := false
+		for _, m := range javaTimeLayouts {
+			if strings.HasPrefix(format[i:], m.pattern) {
+				b.WriteString(m.layout)
+				i += len(m.pattern)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+	return b.String()
+}
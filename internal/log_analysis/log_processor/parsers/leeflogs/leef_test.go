@@ -0,0 +1,150 @@
+package leeflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestLEEF1(t *testing.T) {
+	// nolint:lll
+	log := "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=10.50.1.1\tdst=2.10.20.20\tspt=1200\tsrcPort=1200\tusrName=jdoe\tdevTime=1591024182000\tmsg=remote login\tfrom VPN"
+
+	tm := time.Unix(1591024182, 0).UTC()
+	event := &LEEF{
+		Version:        aws.String("1.0"),
+		Vendor:         aws.String("Microsoft"),
+		Product:        aws.String("MSExchange"),
+		ProductVersion: aws.String("4.0 SP1"),
+		EventID:        aws.String("15345"),
+		Src:            aws.String("10.50.1.1"),
+		Dst:            aws.String("2.10.20.20"),
+		SrcPort:        aws.Uint16(1200),
+		UsrName:        aws.String("jdoe"),
+		DevTime:        (*timestamp.RFC3339)(&tm),
+		Attributes: map[string]string{
+			"src":     "10.50.1.1",
+			"dst":     "2.10.20.20",
+			"spt":     "1200",
+			"srcPort": "1200",
+			"usrName": "jdoe",
+			"devTime": "1591024182000",
+			"msg":     "remote login\tfrom VPN",
+		},
+	}
+	event.SetCoreFields(TypeEvent, event.DevTime, event)
+	event.AppendAnyIPAddress("10.50.1.1")
+	event.AppendAnyIPAddress("2.10.20.20")
+	event.AppendAnyUsernames("jdoe")
+
+	testutil.CheckPantherParser(t, log, &LEEFParser{}, &event.PantherLog)
+}
+
+func TestLEEF2(t *testing.T) {
+	// nolint:lll
+	log := `<13>Jun  1 15:09:42 proxy01 LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^devTime=2020-06-01 15:09:42.123 +0000^devTimeFormat=yyyy-MM-dd HH:mm:ss.SSS Z^url=https://example.com/login^isLoginEvent=true`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 42, 123000000, time.UTC)
+	syslogTime := time.Date(time.Now().UTC().Year(), 6, 1, 15, 9, 42, 0, time.UTC)
+	event := &LEEF{
+		Syslog: &sysloglogs.Header{
+			Priority:  aws.Uint8(13),
+			Facility:  aws.Uint8(1),
+			Severity:  aws.Uint8(5),
+			Timestamp: (*timestamp.RFC3339)(&syslogTime),
+			Hostname:  aws.String("proxy01"),
+		},
+		Version:        aws.String("2.0"),
+		Vendor:         aws.String("Lancope"),
+		Product:        aws.String("StealthWatch"),
+		ProductVersion: aws.String("1.0"),
+		EventID:        aws.String("41"),
+		Src:            aws.String("10.0.1.8"),
+		Dst:            aws.String("10.0.0.5"),
+		Severity:       aws.Int(5),
+		DevTime:        (*timestamp.RFC3339)(&tm),
+		DevTimeFormat:  aws.String("yyyy-MM-dd HH:mm:ss.SSS Z"),
+		URL:            aws.String("https://example.com/login"),
+		IsLoginEvent:   aws.Bool(true),
+		Attributes: map[string]string{
+			"src":           "10.0.1.8",
+			"dst":           "10.0.0.5",
+			"sev":           "5",
+			"devTime":       "2020-06-01 15:09:42.123 +0000",
+			"devTimeFormat": "yyyy-MM-dd HH:mm:ss.SSS Z",
+			"url":           "https://example.com/login",
+			"isLoginEvent":  "true",
+		},
+	}
+	event.SetCoreFields(TypeEvent, event.DevTime, event)
+	event.AppendAnyIPAddress("10.0.1.8")
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyDomainNames("proxy01", "example.com")
+
+	testutil.CheckPantherParser(t, log, &LEEFParser{}, &event.PantherLog)
+}
+
+func TestLEEF2HexDelimiter(t *testing.T) {
+	log := `LEEF:2.0|Vendor|Product|1.0|login|x7C|usrName=admin|identSrc=172.16.0.4|devTime=Jun 01 2020 15:09:42`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 42, 0, time.UTC)
+	event := &LEEF{
+		Version:        aws.String("2.0"),
+		Vendor:         aws.String("Vendor"),
+		Product:        aws.String("Product"),
+		ProductVersion: aws.String("1.0"),
+		EventID:        aws.String("login"),
+		UsrName:        aws.String("admin"),
+		IdentSrc:       aws.String("172.16.0.4"),
+		DevTime:        (*timestamp.RFC3339)(&tm),
+		Attributes: map[string]string{
+			"usrName":  "admin",
+			"identSrc": "172.16.0.4",
+			"devTime":  "Jun 01 2020 15:09:42",
+		},
+	}
+	event.SetCoreFields(TypeEvent, event.DevTime, event)
+	event.AppendAnyIPAddress("172.16.0.4")
+	event.AppendAnyUsernames("admin")
+
+	testutil.CheckPantherParser(t, log, &LEEFParser{}, &event.PantherLog)
+}
+
+func TestLEEFInvalid(t *testing.T) {
+	parser := (&LEEFParser{}).New()
+	_, err := parser.Parse(`CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1`)
+	require.Error(t, err)
+	_, err = parser.Parse(`LEEF:1.0|Microsoft|MSExchange|4.0 SP1`)
+	require.Error(t, err)
+	_, err = parser.Parse(`LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|not an attribute`)
+	require.Error(t, err)
+}
+
+func TestLEEFLogType(t *testing.T) {
+	parser := &LEEFParser{}
+	require.Equal(t, "LEEF.Event", parser.LogType())
+}
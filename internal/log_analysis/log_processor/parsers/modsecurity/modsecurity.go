@@ -0,0 +1,109 @@
+package modsecurity
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+)
+
+// marker is the start of ModSecurity messages in the error logs of web servers
+const marker = "ModSecurity: "
+
+// tagRegex matches the [name "value"] tags that follow the text of ModSecurity messages
+var tagRegex = regexp.MustCompile(`\[(\w+) "((?:[^"\\]|\\.)*)"\]`)
+
+// Message is a ModSecurity audit message written to the error log of Apache or Nginx
+// nolint:lll
+type Message struct {
+	Text     *string  `json:"text,omitempty" description:"The text of the message, including the action taken (ie Access denied with code 403 (phase 2). Matched ...)."`
+	File     *string  `json:"file,omitempty" description:"The configuration file of the rule that matched."`
+	Line     *string  `json:"line,omitempty" description:"The line of the rule in the configuration file."`
+	ID       *string  `json:"id,omitempty" description:"The id of the rule that matched."`
+	Rev      *string  `json:"rev,omitempty" description:"The revision of the rule that matched."`
+	Msg      *string  `json:"msg,omitempty" description:"The message of the rule that matched."`
+	Data     *string  `json:"data,omitempty" description:"The data of the request that matched the rule."`
+	Severity *string  `json:"severity,omitempty" description:"The severity of the rule that matched."`
+	Version  *string  `json:"ver,omitempty" description:"The version of the rule set of the rule."`
+	Maturity *string  `json:"maturity,omitempty" description:"The maturity of the rule that matched."`
+	Accuracy *string  `json:"accuracy,omitempty" description:"The accuracy of the rule that matched."`
+	Tags     []string `json:"tags,omitempty" description:"The tags of the rule that matched."`
+	Hostname *string  `json:"hostname,omitempty" description:"The hostname of the request."`
+	URI      *string  `json:"uri,omitempty" description:"The URI of the request."`
+	UniqueID *string  `json:"unique_id,omitempty" description:"The unique id of the transaction, to look it up in the audit log."`
+}
+
+// ParseMessage returns the ModSecurity message in an error log message or nil if there is none
+func ParseMessage(log string) *Message {
+	i := strings.Index(log, marker)
+	if i == -1 {
+		return nil
+	}
+	log = log[i+len(marker):]
+	msg := &Message{}
+	tags := tagRegex.FindAllStringSubmatchIndex(log, -1)
+	text := log
+	if len(tags) > 0 {
+		text = log[:tags[0][0]]
+	}
+	if text = strings.TrimSpace(text); text != "" {
+		msg.Text = &text
+	}
+	for _, tag := range tags {
+		name, value := log[tag[2]:tag[3]], log[tag[4]:tag[5]]
+		msg.setTag(name, strings.ReplaceAll(value, `\"`, `"`))
+	}
+	return msg
+}
+
+func (msg *Message) setTag(name, value string) {
+	if value == "" {
+		return
+	}
+	switch name {
+	case "tag":
+		msg.Tags = append(msg.Tags, value)
+	case "file":
+		msg.File = &value
+	case "line":
+		msg.Line = &value
+	case "id":
+		msg.ID = &value
+	case "rev":
+		msg.Rev = &value
+	case "msg":
+		msg.Msg = &value
+	case "data":
+		msg.Data = &value
+	case "severity":
+		msg.Severity = &value
+	case "ver":
+		msg.Version = &value
+	case "maturity":
+		msg.Maturity = &value
+	case "accuracy":
+		msg.Accuracy = &value
+	case "hostname":
+		msg.Hostname = &value
+	case "uri":
+		msg.URI = &value
+	case "unique_id":
+		msg.UniqueID = &value
+	}
+}
@@ -0,0 +1,60 @@
+package modsecurity
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage(t *testing.T) {
+	// nolint:lll
+	log := `[client 198.51.100.7] ModSecurity: Access denied with code 403 (phase 2). Matched "Operator ` + "`Ge'" + ` with parameter ` + "`5'" + `" [file "/etc/modsecurity/crs/REQUEST-949-BLOCKING-EVALUATION.conf"] [line "80"] [id "949110"] [rev ""] [msg "Inbound Anomaly Score Exceeded (Total Score: 5)"] [data "Matched Data: \"1' or 1=1\" found"] [severity "2"] [ver "OWASP_CRS/3.3.0"] [maturity "0"] [accuracy "0"] [tag "application-multi"] [tag "attack-generic"] [hostname "10.0.0.1"] [uri "/login"] [unique_id "159103778012.345678"] [ref ""]`
+
+	expect := &Message{
+		Text:     aws.String(`Access denied with code 403 (phase 2). Matched "Operator ` + "`Ge'" + ` with parameter ` + "`5'" + `"`),
+		File:     aws.String("/etc/modsecurity/crs/REQUEST-949-BLOCKING-EVALUATION.conf"),
+		Line:     aws.String("80"),
+		ID:       aws.String("949110"),
+		Msg:      aws.String("Inbound Anomaly Score Exceeded (Total Score: 5)"),
+		Data:     aws.String(`Matched Data: "1' or 1=1" found`),
+		Severity: aws.String("2"),
+		Version:  aws.String("OWASP_CRS/3.3.0"),
+		Maturity: aws.String("0"),
+		Accuracy: aws.String("0"),
+		Tags:     []string{"application-multi", "attack-generic"},
+		Hostname: aws.String("10.0.0.1"),
+		URI:      aws.String("/login"),
+		UniqueID: aws.String("159103778012.345678"),
+	}
+	require.Equal(t, expect, ParseMessage(log))
+}
+
+func TestParseMessageWithoutTags(t *testing.T) {
+	expect := &Message{
+		Text: aws.String("Warning. Unconditional match in SecAction."),
+	}
+	require.Equal(t, expect, ParseMessage("ModSecurity: Warning. Unconditional match in SecAction."))
+}
+
+func TestParseMessageNone(t *testing.T) {
+	require.Nil(t, ParseMessage(`File does not exist: /var/www/html/favicon.ico`))
+}
@@ -0,0 +1,155 @@
+package nginxlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/modsecurity"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	// Nginx writes the time of error logs in the local time of the server, without a time zone
+	errorTimestampFormat = "2006/01/02 15:04:05"
+	// errorContextStart is the start of the request context nginx appends to messages about client connections
+	errorContextStart = ", client: "
+)
+
+var (
+	// 2020/06/01 18:56:20 [error] 1234#5678: *42 message
+	errorRegex = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) \[(debug|info|notice|warn|error|crit|alert|emerg)\] (\d+)#(\d+): (?:\*(\d+) )?(.*)$`)
+	// , client: 198.51.100.7, server: example.com, request: "GET / HTTP/1.1", host: "example.com"
+	errorContextRegex = regexp.MustCompile(`, (client|server|request|subrequest|upstream|host|referrer): ("(?:[^"\\]|\\.)*"|[^,]*)`)
+)
+
+// nolint:lll
+type Error struct {
+	Time         *timestamp.RFC3339   `json:"time" validate:"required" description:"The time of the error, in the local time of the server (assumed to be UTC)."`
+	Level        *string              `json:"level" validate:"required" description:"The severity of the error (debug, info, notice, warn, error, crit, alert or emerg)."`
+	PID          *int64               `json:"pid" validate:"required" description:"The process id of the nginx worker."`
+	TID          *int64               `json:"tid" validate:"required" description:"The thread id of the nginx worker."`
+	ConnectionID *int64               `json:"connectionId,omitempty" description:"The serial number of the client connection."`
+	Message      *string              `json:"message" validate:"required" description:"The error message."`
+	Client       *string              `json:"client,omitempty" description:"The IP address of the client."`
+	Server       *string              `json:"server,omitempty" description:"The name of the server that accepted the request."`
+	Request      *string              `json:"request,omitempty" description:"The request line from the client. It includes the HTTP method, the resource requested, and the HTTP protocol."`
+	Subrequest   *string              `json:"subrequest,omitempty" description:"The URI of the subrequest."`
+	Upstream     *string              `json:"upstream,omitempty" description:"The address of the upstream server the request was proxied to."`
+	Host         *string              `json:"host,omitempty" description:"The Host header of the request."`
+	Referrer     *string              `json:"referrer,omitempty" description:"The HTTP referrer if any."`
+	ModSecurity  *modsecurity.Message `json:"modSecurity,omitempty" description:"The ModSecurity rule match reported by the error."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// ErrorParser parses Nginx error logs
+type ErrorParser struct{}
+
+var _ parsers.LogParser = (*ErrorParser)(nil)
+
+func (p *ErrorParser) New() parsers.LogParser {
+	return &ErrorParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ErrorParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	match := errorRegex.FindStringSubmatch(log)
+	if match == nil {
+		return nil, errors.New("invalid nginx error log")
+	}
+	// Assignment in single line right after the match avoids bounds checks on fields
+	// nolint:lll
+	errorTime, level, pid, tid, connectionID, message := match[1], match[2], match[3], match[4], match[5], match[6]
+
+	parsedTime, err := timestamp.Parse(errorTimestampFormat, errorTime)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Error{
+		Time:         &parsedTime,
+		Level:        &level,
+		PID:          parsers.CsvStringToInt64Pointer(pid),
+		TID:          parsers.CsvStringToInt64Pointer(tid),
+		ConnectionID: parsers.CsvStringToInt64Pointer(connectionID),
+	}
+	if i := strings.Index(message, errorContextStart); i != -1 {
+		event.setContext(message[i:])
+		message = message[:i]
+	}
+	event.Message = &message
+	event.ModSecurity = modsecurity.ParseMessage(message)
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+func (event *Error) setContext(context string) {
+	for _, match := range errorContextRegex.FindAllStringSubmatch(context, -1) {
+		value := stripQuotes(match[2])
+		if value == "" {
+			continue
+		}
+		switch match[1] {
+		case "client":
+			event.Client = &value
+		case "server":
+			event.Server = &value
+		case "request":
+			event.Request = &value
+		case "subrequest":
+			event.Subrequest = &value
+		case "upstream":
+			event.Upstream = &value
+		case "host":
+			event.Host = &value
+		case "referrer":
+			event.Referrer = &value
+		}
+	}
+}
+
+// stripQuotes strips the quotes nginx adds around the values of the request context
+func stripQuotes(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// LogType returns the log type supported by this parser
+func (p *ErrorParser) LogType() string {
+	return TypeError
+}
+
+func (event *Error) updatePantherFields(p *ErrorParser) {
+	event.SetCoreFields(p.LogType(), event.Time, event)
+	event.AppendAnyIPAddressPtr(event.Client)
+}
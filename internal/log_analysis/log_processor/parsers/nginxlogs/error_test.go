@@ -0,0 +1,163 @@
+package nginxlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/modsecurity"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestErrorLog(t *testing.T) {
+	//nolint:lll
+	log := `2020/06/01 18:56:20 [error] 1234#5678: *42 open() "/usr/share/nginx/html/wp-login.php" failed (2: No such file or directory), client: 198.51.100.7, server: example.com, request: "GET /wp-login.php HTTP/1.1", host: "example.com", referrer: "https://example.com/"`
+
+	expectedTime := time.Date(2020, 6, 1, 18, 56, 20, 0, time.UTC)
+	expectedEvent := &Error{
+		Time:         (*timestamp.RFC3339)(&expectedTime),
+		Level:        aws.String("error"),
+		PID:          aws.Int64(1234),
+		TID:          aws.Int64(5678),
+		ConnectionID: aws.Int64(42),
+		Message:      aws.String(`open() "/usr/share/nginx/html/wp-login.php" failed (2: No such file or directory)`),
+		Client:       aws.String("198.51.100.7"),
+		Server:       aws.String("example.com"),
+		Request:      aws.String("GET /wp-login.php HTTP/1.1"),
+		Host:         aws.String("example.com"),
+		Referrer:     aws.String("https://example.com/"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Nginx.Error")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("198.51.100.7")
+
+	checkErrorLog(t, log, expectedEvent)
+}
+
+func TestErrorLogUpstream(t *testing.T) {
+	//nolint:lll
+	log := `2020/06/01 18:56:20 [error] 1234#1234: *7 connect() failed (111: Connection refused) while connecting to upstream, client: 198.51.100.7, server: , request: "POST /api HTTP/1.1", upstream: "http://127.0.0.1:8080/api", host: "example.com"`
+
+	expectedTime := time.Date(2020, 6, 1, 18, 56, 20, 0, time.UTC)
+	expectedEvent := &Error{
+		Time:         (*timestamp.RFC3339)(&expectedTime),
+		Level:        aws.String("error"),
+		PID:          aws.Int64(1234),
+		TID:          aws.Int64(1234),
+		ConnectionID: aws.Int64(7),
+		Message:      aws.String("connect() failed (111: Connection refused) while connecting to upstream"),
+		Client:       aws.String("198.51.100.7"),
+		Request:      aws.String("POST /api HTTP/1.1"),
+		Upstream:     aws.String("http://127.0.0.1:8080/api"),
+		Host:         aws.String("example.com"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Nginx.Error")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("198.51.100.7")
+
+	checkErrorLog(t, log, expectedEvent)
+}
+
+func TestErrorLogModSecurity(t *testing.T) {
+	//nolint:lll
+	log := `2020/06/01 18:56:20 [error] 1234#1234: *42 [client 198.51.100.7] ModSecurity: Access denied with code 403 (phase 2). Matched "Operator ` + "`Ge'" + ` with parameter ` + "`5'" + `" [file "/etc/modsecurity/crs/REQUEST-949-BLOCKING-EVALUATION.conf"] [line "80"] [id "949110"] [rev ""] [msg "Inbound Anomaly Score Exceeded (Total Score: 5)"] [severity "2"] [ver "OWASP_CRS/3.3.0"] [tag "application-multi"] [hostname "10.0.0.1"] [uri "/login"] [unique_id "159103778012.345678"] [ref ""], client: 198.51.100.7, server: example.com, request: "GET /login?id=1%27 HTTP/1.1", host: "example.com"`
+
+	expectedTime := time.Date(2020, 6, 1, 18, 56, 20, 0, time.UTC)
+	expectedEvent := &Error{
+		Time:         (*timestamp.RFC3339)(&expectedTime),
+		Level:        aws.String("error"),
+		PID:          aws.Int64(1234),
+		TID:          aws.Int64(1234),
+		ConnectionID: aws.Int64(42),
+		//nolint:lll
+		Message: aws.String(`[client 198.51.100.7] ModSecurity: Access denied with code 403 (phase 2). Matched "Operator ` + "`Ge'" + ` with parameter ` + "`5'" + `" [file "/etc/modsecurity/crs/REQUEST-949-BLOCKING-EVALUATION.conf"] [line "80"] [id "949110"] [rev ""] [msg "Inbound Anomaly Score Exceeded (Total Score: 5)"] [severity "2"] [ver "OWASP_CRS/3.3.0"] [tag "application-multi"] [hostname "10.0.0.1"] [uri "/login"] [unique_id "159103778012.345678"] [ref ""]`),
+		Client:  aws.String("198.51.100.7"),
+		Server:  aws.String("example.com"),
+		Request: aws.String("GET /login?id=1%27 HTTP/1.1"),
+		Host:    aws.String("example.com"),
+		ModSecurity: &modsecurity.Message{
+			Text:     aws.String(`Access denied with code 403 (phase 2). Matched "Operator ` + "`Ge'" + ` with parameter ` + "`5'" + `"`),
+			File:     aws.String("/etc/modsecurity/crs/REQUEST-949-BLOCKING-EVALUATION.conf"),
+			Line:     aws.String("80"),
+			ID:       aws.String("949110"),
+			Msg:      aws.String("Inbound Anomaly Score Exceeded (Total Score: 5)"),
+			Severity: aws.String("2"),
+			Version:  aws.String("OWASP_CRS/3.3.0"),
+			Tags:     []string{"application-multi"},
+			Hostname: aws.String("10.0.0.1"),
+			URI:      aws.String("/login"),
+			UniqueID: aws.String("159103778012.345678"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Nginx.Error")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("198.51.100.7")
+
+	checkErrorLog(t, log, expectedEvent)
+}
+
+func TestErrorLogWithoutConnection(t *testing.T) {
+	log := `2020/06/01 18:56:20 [notice] 1#1: signal process started`
+
+	expectedTime := time.Date(2020, 6, 1, 18, 56, 20, 0, time.UTC)
+	expectedEvent := &Error{
+		Time:    (*timestamp.RFC3339)(&expectedTime),
+		Level:   aws.String("notice"),
+		PID:     aws.Int64(1),
+		TID:     aws.Int64(1),
+		Message: aws.String("signal process started"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Nginx.Error")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+
+	checkErrorLog(t, log, expectedEvent)
+}
+
+func TestErrorLogInvalid(t *testing.T) {
+	parser := &ErrorParser{}
+	_, err := parser.Parse(`2020/06/01 18:56:20 [fatal] 1#1: signal process started`)
+	require.Error(t, err)
+	_, err = parser.Parse(`[Mon Jun 01 18:56:20.123456 2020] [core:error] [pid 1234:tid 5678] [client 198.51.100.7:51234] AH00126: Invalid URI in request`)
+	require.Error(t, err)
+}
+
+func TestErrorLogType(t *testing.T) {
+	parser := &ErrorParser{}
+	require.Equal(t, "Nginx.Error", parser.LogType())
+}
+
+func checkErrorLog(t *testing.T, log string, expectedEvent *Error) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ErrorParser{}
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
@@ -26,6 +26,7 @@ import (
 
 const (
 	TypeAccess = `Nginx.Access`
+	TypeError  = `Nginx.Error`
 )
 
 func init() {
@@ -36,4 +37,11 @@ func init() {
 		Schema:       Access{},
 		NewParser:    parsers.AdapterFactory(&AccessParser{}),
 	})
+	logtypes.MustRegister(logtypes.Config{
+		Name:         TypeError,
+		Description:  `Error Logs for your Nginx server, including the ModSecurity messages of the ModSecurity-nginx connector.`,
+		ReferenceURL: `http://nginx.org/en/docs/ngx_core_module.html#error_log`,
+		Schema:       Error{},
+		NewParser:    parsers.AdapterFactory(&ErrorParser{}),
+	})
 }
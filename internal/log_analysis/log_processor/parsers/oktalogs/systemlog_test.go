@@ -0,0 +1,148 @@
+package oktalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSystemLog(t *testing.T) {
+	// nolint:lll
+	log := `{"actor":{"id":"00u1abcdefGHIJKLM357","type":"User","alternateId":"alice@example.com","displayName":"Alice Smith","detailEntry":null},"client":{"userAgent":{"rawUserAgent":"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_4) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/83.0.4103.61 Safari/537.36","os":"Mac OS X","browser":"CHROME"},"zone":"null","device":"Computer","id":null,"ipAddress":"198.51.100.20","geographicalContext":{"city":"Athens","state":"Attica","country":"Greece","postalCode":"104 31","geolocation":{"lat":37.9842,"lon":23.7353}}},"authenticationContext":{"authenticationProvider":null,"credentialProvider":null,"credentialType":null,"issuer":null,"interface":null,"authenticationStep":0,"externalSessionId":"102bZDNFfWaQSyEZQuDgWt-uQ"},"displayMessage":"User login to Okta","eventType":"user.session.start","outcome":{"result":"FAILURE","reason":"VERIFICATION_ERROR"},"published":"2020-06-01T14:22:31.342Z","securityContext":{"asNumber":64496,"asOrg":"example isp","isp":"Example ISP","domain":"example.net","isProxy":false},"severity":"WARN","debugContext":{"debugData":{"requestId":"XtUPB-abcdef","requestUri":"/api/v1/authn","url":"/api/v1/authn?"}},"legacyEventType":"core.user_auth.login_failed","transaction":{"type":"WEB","id":"XtUPB-abcdef","detail":{}},"uuid":"c4b8a5f0-a40e-11ea-b1f3-8d7c7a3d9e01","version":"0","request":{"ipChain":[{"ip":"198.51.100.20","geographicalContext":{"city":"Athens","state":"Attica","country":"Greece","postalCode":"104 31","geolocation":{"lat":37.9842,"lon":23.7353}},"version":"V4","source":null},{"ip":"10.0.0.12","version":"V4"}]},"target":[{"id":"00u2zyxwvuTSRQPON468","type":"User","alternateId":"bob@example.com","displayName":"Bob Jones"},{"id":"0oa1abcdefGHIJKLM357","type":"AppInstance","alternateId":"Salesforce","displayName":"Salesforce.com"}]}`
+
+	tm := time.Date(2020, 6, 1, 14, 22, 31, 342000000, time.UTC)
+	geo := &GeographicalContext{
+		City:       aws.String("Athens"),
+		State:      aws.String("Attica"),
+		Country:    aws.String("Greece"),
+		PostalCode: aws.String("104 31"),
+		Geolocation: &Geolocation{
+			Lat: aws.Float64(37.9842),
+			Lon: aws.Float64(23.7353),
+		},
+	}
+	step := numerics.Integer(0)
+	asNumber := numerics.Int64(64496)
+	event := &SystemLog{
+		UUID:            aws.String("c4b8a5f0-a40e-11ea-b1f3-8d7c7a3d9e01"),
+		Published:       (*timestamp.RFC3339)(&tm),
+		EventType:       aws.String("user.session.start"),
+		Version:         aws.String("0"),
+		Severity:        aws.String("WARN"),
+		LegacyEventType: aws.String("core.user_auth.login_failed"),
+		DisplayMessage:  aws.String("User login to Okta"),
+		Actor: &Actor{
+			ID:          aws.String("00u1abcdefGHIJKLM357"),
+			Type:        aws.String("User"),
+			AlternateID: aws.String("alice@example.com"),
+			DisplayName: aws.String("Alice Smith"),
+		},
+		Client: &Client{
+			UserAgent: &UserAgent{
+				RawUserAgent: aws.String("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_4) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/83.0.4103.61 Safari/537.36"),
+				OS:           aws.String("Mac OS X"),
+				Browser:      aws.String("CHROME"),
+			},
+			Zone:                aws.String("null"),
+			Device:              aws.String("Computer"),
+			IPAddress:           aws.String("198.51.100.20"),
+			GeographicalContext: geo,
+		},
+		AuthenticationContext: &AuthenticationContext{
+			AuthenticationStep: &step,
+			ExternalSessionID:  aws.String("102bZDNFfWaQSyEZQuDgWt-uQ"),
+		},
+		Outcome: &Outcome{
+			Result: aws.String("FAILURE"),
+			Reason: aws.String("VERIFICATION_ERROR"),
+		},
+		SecurityContext: &SecurityContext{
+			AsNumber: &asNumber,
+			AsOrg:    aws.String("example isp"),
+			ISP:      aws.String("Example ISP"),
+			Domain:   aws.String("example.net"),
+			IsProxy:  aws.Bool(false),
+		},
+		DebugContext: &DebugContext{
+			DebugData: jsoniter.RawMessage(`{"requestId":"XtUPB-abcdef","requestUri":"/api/v1/authn","url":"/api/v1/authn?"}`),
+		},
+		Transaction: &Transaction{
+			Type:   aws.String("WEB"),
+			ID:     aws.String("XtUPB-abcdef"),
+			Detail: jsoniter.RawMessage(`{}`),
+		},
+		Request: &Request{
+			IPChain: []IPAddress{
+				{
+					IP:                  aws.String("198.51.100.20"),
+					GeographicalContext: geo,
+					Version:             aws.String("V4"),
+				},
+				{
+					IP:      aws.String("10.0.0.12"),
+					Version: aws.String("V4"),
+				},
+			},
+		},
+		Target: []Actor{
+			{
+				ID:          aws.String("00u2zyxwvuTSRQPON468"),
+				Type:        aws.String("User"),
+				AlternateID: aws.String("bob@example.com"),
+				DisplayName: aws.String("Bob Jones"),
+			},
+			{
+				ID:          aws.String("0oa1abcdefGHIJKLM357"),
+				Type:        aws.String("AppInstance"),
+				AlternateID: aws.String("Salesforce"),
+				DisplayName: aws.String("Salesforce.com"),
+			},
+		},
+	}
+	event.SetCoreFields(TypeSystemLog, event.Published, event)
+	event.AppendAnyIPAddress("198.51.100.20")
+	event.AppendAnyIPAddress("10.0.0.12")
+	event.AppendAnyDomainNames("example.net")
+	event.AppendAnyUsernames("alice@example.com", "bob@example.com")
+	event.AppendAnyEmails("alice@example.com", "bob@example.com")
+
+	testutil.CheckPantherParser(t, log, &SystemLogParser{}, &event.PantherLog)
+}
+
+func TestSystemLogInvalid(t *testing.T) {
+	parser := (&SystemLogParser{}).New()
+	_, err := parser.Parse(`{"uuid":"c4b8a5f0","published":"2020-06-01T14:22:31.342Z","eventType":"user.session.start","version":"0","severity":"NOTICE"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"uuid":"c4b8a5f0","eventType":"user.session.start","version":"0","severity":"INFO"}`)
+	require.Error(t, err)
+}
+
+func TestSystemLogLogType(t *testing.T) {
+	parser := &SystemLogParser{}
+	require.Equal(t, "Okta.SystemLog", parser.LogType())
+}
@@ -0,0 +1,221 @@
+package osseclogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// alertPrefix is the start of alerts in the text format of alerts.log
+const alertPrefix = "** Alert "
+
+var (
+	// ** Alert 1510376401.0: mail  - syslog,errors,
+	alertHeaderRegex = regexp.MustCompile(`^\*\* Alert ((\d+)\.\d+):\s*(?:mail)?\s*- ?(.*)$`)
+	// 2017 Nov 11 00:00:01 (agent) 10.0.0.5->/var/log/messages
+	alertSourceRegex = regexp.MustCompile(`^\d{4} \w{3} \d{2} \d{2}:\d{2}:\d{2} (.+?)->(.*)$`)
+	// Rule: 1005 (level 5) -> 'Syslogd restarted.'
+	alertRuleRegex = regexp.MustCompile(`^Rule: (\d+) \(level (\d+)\) -> '(.*)'$`)
+	// (agent) 10.0.0.5
+	agentRegex = regexp.MustCompile(`^\((.+)\) (\S+)$`)
+)
+
+// syscheckRegexes extract the file integrity changes from the full log of syscheck alerts
+var syscheckRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`^(?:Integrity checksum changed for: ?|New file |File )'(?P<path>.*)'`),
+	regexp.MustCompile(`^Old md5sum was: ?'(?P<md5_before>[[:xdigit:]]*)'`),
+	regexp.MustCompile(`^New md5sum is ?: ?'(?P<md5_after>[[:xdigit:]]*)'`),
+	regexp.MustCompile(`^Old sha1sum was: ?'(?P<sha1_before>[[:xdigit:]]*)'`),
+	regexp.MustCompile(`^New sha1sum is ?: ?'(?P<sha1_after>[[:xdigit:]]*)'`),
+	regexp.MustCompile(`^Ownership was '(?P<owner_before>.*)', now it is '(?P<owner_after>.*)'`),
+	regexp.MustCompile(`^Group ownership was '(?P<gowner_before>.*)', now it is '(?P<gowner_after>.*)'`),
+}
+
+// AlertParser parses OSSEC alerts in the text format of alerts.log
+type AlertParser struct{}
+
+var _ parsers.LogParser = (*AlertParser)(nil)
+
+func (p *AlertParser) New() parsers.LogParser {
+	return &AlertParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AlertParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	if !strings.HasPrefix(log, alertPrefix) {
+		return nil, errors.New("not an OSSEC text alert")
+	}
+	eventInfo, err := parseAlert(log)
+	if err != nil {
+		return nil, err
+	}
+
+	eventInfo.updatePantherFields(p.LogType())
+
+	if err := parsers.Validator.Struct(eventInfo); err != nil {
+		return nil, err
+	}
+
+	return eventInfo.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AlertParser) LogType() string {
+	return TypeAlert
+}
+
+// parseAlert parses an alert in the text format of alerts.log
+//
+//	** Alert 1510376402.1: - syslog,sshd,authentication_failed,
+//	2017 Nov 11 00:00:02 (web01) 10.0.0.5->/var/log/secure
+//	Rule: 5716 (level 5) -> 'SSHD authentication failed.'
+//	Src IP: 198.51.100.7
+//	User: root
+//	Nov 11 00:00:02 web01 sshd[1234]: Failed password for root from 198.51.100.7 port 51234 ssh2
+func parseAlert(log string) (*EventInfo, error) {
+	lines := strings.Split(log, "\n")
+	if len(lines) < 3 {
+		return nil, errors.New("incomplete OSSEC alert")
+	}
+	header := alertHeaderRegex.FindStringSubmatch(lines[0])
+	if header == nil {
+		return nil, errors.Errorf("invalid OSSEC alert header %q", lines[0])
+	}
+	source := alertSourceRegex.FindStringSubmatch(lines[1])
+	if source == nil {
+		return nil, errors.Errorf("invalid OSSEC alert source %q", lines[1])
+	}
+	rule := alertRuleRegex.FindStringSubmatch(lines[2])
+	if rule == nil {
+		return nil, errors.Errorf("invalid OSSEC alert rule %q", lines[2])
+	}
+
+	// The id of alerts starts with the unix time of the alert, the date on the second line is in local time
+	sec, err := strconv.ParseInt(header[2], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OSSEC alert id %q", header[1])
+	}
+	tm := time.Unix(sec, 0).UTC()
+	sidID, err := strconv.Atoi(rule[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OSSEC rule id %q", rule[1])
+	}
+	level, err := strconv.Atoi(rule[2])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OSSEC rule level %q", rule[2])
+	}
+
+	event := &EventInfo{
+		ID: aws.String(header[1]),
+		Rule: &Rule{
+			Comment: aws.String(rule[3]),
+			Group:   aws.String(header[3]),
+			Level:   aws.Int(level),
+			SIDID:   aws.Int(sidID),
+		},
+		Timestamp: (*timestamp.UnixMillisecond)(&tm),
+		Hostname:  aws.String(source[1]),
+		Location:  aws.String(source[2]),
+	}
+	if agent := agentRegex.FindStringSubmatch(source[1]); agent != nil {
+		event.AgentName = aws.String(agent[1])
+		event.AgentIP = aws.String(agent[2])
+	}
+
+	lines = lines[3:]
+	for len(lines) > 0 && event.setDecodedField(lines[0]) {
+		lines = lines[1:]
+	}
+	event.FullLog = aws.String(strings.Join(lines, "\n"))
+	if *event.Location == "syscheck" {
+		event.SyscheckFile = parseSyscheckFile(lines)
+	}
+	return event, nil
+}
+
+// setDecodedField sets the fields alerts.log writes after the rule line, it returns false if the line is not one of them
+func (event *EventInfo) setDecodedField(line string) bool {
+	i := strings.Index(line, ": ")
+	if i == -1 {
+		return false
+	}
+	value := aws.String(line[i+len(": "):])
+	switch line[:i] {
+	case "Src IP":
+		event.SrcIP = value
+	case "Src Port":
+		event.SrcPort = value
+	case "Dst IP":
+		event.DstIP = value
+	case "Dst Port":
+		event.DstPort = value
+	case "User":
+		event.DstUser = value
+	default:
+		return false
+	}
+	return true
+}
+
+func parseSyscheckFile(lines []string) *FileDiff {
+	fields := map[string]string{}
+	for _, line := range lines {
+		for _, re := range syscheckRegexes {
+			match := re.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			for i, name := range re.SubexpNames() {
+				if name != "" {
+					fields[name] = match[i]
+				}
+			}
+			break
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	field := func(name string) *string {
+		if value, ok := fields[name]; ok {
+			return aws.String(value)
+		}
+		return nil
+	}
+	return &FileDiff{
+		GroupOwnerAfter:  field("gowner_after"),
+		GroupOwnerBefore: field("gowner_before"),
+		MD5After:         field("md5_after"),
+		MD5Before:        field("md5_before"),
+		OwnerAfter:       field("owner_after"),
+		OwnerBefore:      field("owner_before"),
+		Path:             field("path"),
+		SHA1After:        field("sha1_after"),
+		SHA1Before:       field("sha1_before"),
+	}
+}
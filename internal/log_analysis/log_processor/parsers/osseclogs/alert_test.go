@@ -0,0 +1,167 @@
+package osseclogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAlert(t *testing.T) {
+	log := `** Alert 1510376401.0: - syslog,errors,
+2017 Nov 11 00:00:01 ix->/var/log/messages
+Rule: 1005 (level 5) -> 'Syslogd restarted.'
+Nov 11 00:00:01 ix syslogd[72090]: restart`
+
+	expectedTime := time.Unix(1510376401, 0).UTC()
+	expectedEvent := &EventInfo{
+		Rule: &Rule{
+			Level:   aws.Int(5),
+			Comment: aws.String("Syslogd restarted."),
+			SIDID:   aws.Int(1005),
+			Group:   aws.String("syslog,errors,"),
+		},
+		ID:        aws.String("1510376401.0"),
+		Timestamp: (*timestamp.UnixMillisecond)(&expectedTime),
+		Location:  aws.String("/var/log/messages"),
+		FullLog:   aws.String("Nov 11 00:00:01 ix syslogd[72090]: restart"),
+		Hostname:  aws.String("ix"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("OSSEC.Alert")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+
+	checkAlert(t, log, expectedEvent)
+}
+
+func TestAlertWithDecodedFields(t *testing.T) {
+	// nolint:lll
+	log := `** Alert 1510376402.1034: mail  - syslog,sshd,authentication_failed,
+2017 Nov 11 00:00:02 (web01) 10.0.0.5->/var/log/secure
+Rule: 5716 (level 5) -> 'SSHD authentication failed.'
+Src IP: 198.51.100.7
+User: root
+Nov 11 00:00:02 web01 sshd[1234]: Failed password for root from 198.51.100.7 port 51234 ssh2`
+
+	expectedTime := time.Unix(1510376402, 0).UTC()
+	expectedEvent := &EventInfo{
+		Rule: &Rule{
+			Level:   aws.Int(5),
+			Comment: aws.String("SSHD authentication failed."),
+			SIDID:   aws.Int(5716),
+			Group:   aws.String("syslog,sshd,authentication_failed,"),
+		},
+		ID:        aws.String("1510376402.1034"),
+		Timestamp: (*timestamp.UnixMillisecond)(&expectedTime),
+		Location:  aws.String("/var/log/secure"),
+		FullLog:   aws.String("Nov 11 00:00:02 web01 sshd[1234]: Failed password for root from 198.51.100.7 port 51234 ssh2"),
+		Hostname:  aws.String("(web01) 10.0.0.5"),
+		AgentName: aws.String("web01"),
+		AgentIP:   aws.String("10.0.0.5"),
+		SrcIP:     aws.String("198.51.100.7"),
+		DstUser:   aws.String("root"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("OSSEC.Alert")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("198.51.100.7")
+	expectedEvent.AppendAnyUsernames("root")
+
+	checkAlert(t, log, expectedEvent)
+}
+
+func TestAlertWithSyscheckFile(t *testing.T) {
+	log := `** Alert 1540845340.16991: mail  - ossec,syscheck,
+2018 Oct 29 20:35:40 ip-172-16-2-16->syscheck
+Rule: 550 (level 7) -> 'Integrity checksum changed.'
+Integrity checksum changed for: '/usr/bin/ssm-cli'
+Size changed from '14580' to '14612'
+Ownership was '0', now it is '1000'
+Old md5sum was: '22271cce0732d887e3980e5a6868e459'
+New md5sum is : '220a8f105af5e711f99e52583209a871'
+Old sha1sum was: '4df65340f366c18f85be228c26817e20391f32c4'
+New sha1sum is : 'c7414fd048c81361720e2d9c8d2f82faf33748b6'`
+
+	expectedTime := time.Unix(1540845340, 0).UTC()
+	//nolint:lll
+	expectedEvent := &EventInfo{
+		Rule: &Rule{
+			Level:   aws.Int(7),
+			Comment: aws.String("Integrity checksum changed."),
+			SIDID:   aws.Int(550),
+			Group:   aws.String("ossec,syscheck,"),
+		},
+		ID:        aws.String("1540845340.16991"),
+		Timestamp: (*timestamp.UnixMillisecond)(&expectedTime),
+		Location:  aws.String("syscheck"),
+		FullLog:   aws.String("Integrity checksum changed for: '/usr/bin/ssm-cli'\nSize changed from '14580' to '14612'\nOwnership was '0', now it is '1000'\nOld md5sum was: '22271cce0732d887e3980e5a6868e459'\nNew md5sum is : '220a8f105af5e711f99e52583209a871'\nOld sha1sum was: '4df65340f366c18f85be228c26817e20391f32c4'\nNew sha1sum is : 'c7414fd048c81361720e2d9c8d2f82faf33748b6'"),
+		SyscheckFile: &FileDiff{
+			MD5After:    aws.String("220a8f105af5e711f99e52583209a871"),
+			MD5Before:   aws.String("22271cce0732d887e3980e5a6868e459"),
+			SHA1After:   aws.String("c7414fd048c81361720e2d9c8d2f82faf33748b6"),
+			SHA1Before:  aws.String("4df65340f366c18f85be228c26817e20391f32c4"),
+			OwnerBefore: aws.String("0"),
+			OwnerAfter:  aws.String("1000"),
+			Path:        aws.String("/usr/bin/ssm-cli"),
+		},
+		Hostname: aws.String("ip-172-16-2-16"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("OSSEC.Alert")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyMD5Hashes("220a8f105af5e711f99e52583209a871", "22271cce0732d887e3980e5a6868e459")
+	expectedEvent.AppendAnySHA1Hashes("c7414fd048c81361720e2d9c8d2f82faf33748b6", "4df65340f366c18f85be228c26817e20391f32c4")
+
+	checkAlert(t, log, expectedEvent)
+}
+
+func TestAlertInvalid(t *testing.T) {
+	parser := &AlertParser{}
+	_, err := parser.Parse(`** Alert 1510376401.0: - syslog,errors,`)
+	require.Error(t, err)
+	_, err = parser.Parse("** Alert 1510376401.0: - syslog,errors,\nix->/var/log/messages\nRule: 1005 (level 5) -> 'Syslogd restarted.'")
+	require.Error(t, err)
+	_, err = parser.Parse("** Alert 1510376401.0: - syslog,errors,\n2017 Nov 11 00:00:01 ix->/var/log/messages\nRule: 1005 -> 'Syslogd restarted.'")
+	require.Error(t, err)
+	// alerts in the JSON format have a log type of their own
+	// nolint:lll
+	_, err = parser.Parse(`{"rule":{"level":5,"comment":"Syslogd restarted.","sidid":1005,"group":"syslog,errors,"},"id":"1510376401.0","TimeStamp":1510376401000,"location":"/var/log/messages","full_log":"restart","hostname":"ix"}`)
+	require.Error(t, err)
+}
+
+func TestAlertLogType(t *testing.T) {
+	parser := &AlertParser{}
+	require.Equal(t, "OSSEC.Alert", parser.LogType())
+}
+
+func checkAlert(t *testing.T, log string, expectedEvent *EventInfo) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &AlertParser{}
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
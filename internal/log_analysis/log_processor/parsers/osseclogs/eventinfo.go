@@ -22,6 +22,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
@@ -65,7 +66,8 @@ type EventInfo struct {
 	// TimestampString    *string                    `json:"timestamp,omitempty" description:"TimestampString"`
 
 	// NOTE: added to end of struct to allow expansion later
-	parsers.PantherLog
+	// Captured logs can reference AWS resources
+	awslogs.AWSPantherLog
 }
 
 // nolint:lll
@@ -128,7 +130,7 @@ func (p *EventInfoParser) Parse(log string) ([]*parsers.PantherLog, error) {
 		return nil, err
 	}
 
-	eventInfo.updatePantherFields(p)
+	eventInfo.updatePantherFields(p.LogType())
 
 	if err := parsers.Validator.Struct(eventInfo); err != nil {
 		return nil, err
@@ -142,12 +144,14 @@ func (p *EventInfoParser) LogType() string {
 	return TypeEventInfo
 }
 
-func (event *EventInfo) updatePantherFields(p *EventInfoParser) {
-	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+func (event *EventInfo) updatePantherFields(logType string) {
+	event.SetCoreFields(logType, (*timestamp.RFC3339)(event.Timestamp), event)
 	event.AppendAnyIPAddressPtr(event.SrcIP)
 	event.AppendAnyIPAddressPtr(event.DstIP)
+	event.AppendAnyUsernamePtrs(event.SrcUser, event.DstUser)
 	if event.SyscheckFile != nil {
 		event.AppendAnyMD5HashPtrs(event.SyscheckFile.MD5Before, event.SyscheckFile.MD5After)
 		event.AppendAnySHA1HashPtrs(event.SyscheckFile.SHA1Before, event.SyscheckFile.SHA1After)
 	}
+	awslogs.NewAWSExtractor(&event.AWSPantherLog).ExtractARNsInText(event.FullLog)
 }
@@ -95,6 +95,19 @@ func TestEventInfoWithSyscheckFile(t *testing.T) {
 	checkEventInfo(t, log, expectedEvent)
 }
 
+func TestEventInfoWazuhAlert(t *testing.T) {
+	parser := &EventInfoParser{}
+	//nolint:lll
+	_, err := parser.Parse(`{"timestamp":"2020-06-01T18:56:20.351+0000","rule":{"level":5,"description":"sshd: authentication failed.","id":"5716"},"agent":{"id":"001","name":"web01"},"id":"1591037780.12345","full_log":"Failed password for root","location":"/var/log/secure"}`)
+	require.Error(t, err)
+}
+
+func TestEventInfoTextAlert(t *testing.T) {
+	parser := &EventInfoParser{}
+	_, err := parser.Parse("** Alert 1510376401.0: - syslog,errors,\n2017 Nov 11 00:00:01 ix->/var/log/messages\nRule: 1005 (level 5) -> 'Syslogd restarted.'")
+	require.Error(t, err)
+}
+
 func TestEventInfoType(t *testing.T) {
 	parser := &EventInfoParser{}
 	require.Equal(t, "OSSEC.EventInfo", parser.LogType())
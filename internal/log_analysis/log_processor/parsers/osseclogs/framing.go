@@ -0,0 +1,82 @@
+package osseclogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+)
+
+// Framing splits OSSEC alerts into events.
+// Alerts in the text format of alerts.log span multiple lines, starting with an `** Alert` line and ending
+// at the next empty line or at the start of the next alert.
+// Other lines (ie alerts in the JSON format) are read as events on their own.
+type Framing struct{}
+
+var _ common.Framing = Framing{}
+
+func (Framing) NewEventReader(r io.Reader) common.EventReader {
+	return &eventReader{
+		lines: common.NewlineFraming{}.NewEventReader(r),
+	}
+}
+
+type eventReader struct {
+	lines common.EventReader
+	next  string // the first line of the next alert
+}
+
+func (r *eventReader) ReadEvent() (string, error) {
+	var lines []string
+	for {
+		line := r.next
+		r.next = ""
+		if line == "" {
+			next, err := r.lines.ReadEvent()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", err
+			}
+			line = strings.TrimRight(next, "\r\n")
+			if strings.TrimSpace(line) == "" {
+				if len(lines) > 0 {
+					break
+				}
+				continue
+			}
+		}
+		isAlert := strings.HasPrefix(line, alertPrefix)
+		if len(lines) > 0 && isAlert {
+			r.next = line
+			break
+		}
+		lines = append(lines, line)
+		if !isAlert && len(lines) == 1 {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return "", io.EOF
+	}
+	return strings.Join(lines, "\n"), nil
+}
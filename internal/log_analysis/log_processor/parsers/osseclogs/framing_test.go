@@ -0,0 +1,67 @@
+package osseclogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFraming(t *testing.T) {
+	// nolint:lll
+	input := `** Alert 1510376401.0: - syslog,errors,
+2017 Nov 11 00:00:01 ix->/var/log/messages
+Rule: 1005 (level 5) -> 'Syslogd restarted.'
+Nov 11 00:00:01 ix syslogd[72090]: restart
+
+** Alert 1510376402.1: mail  - syslog,sshd,authentication_failed,
+2017 Nov 11 00:00:02 (web01) 10.0.0.5->/var/log/secure
+Rule: 5716 (level 5) -> 'SSHD authentication failed.'
+Src IP: 198.51.100.7
+User: root
+Nov 11 00:00:02 web01 sshd[1234]: Failed password for root from 198.51.100.7 port 51234 ssh2
+** Alert 1510376403.2: - ossec,
+2017 Nov 11 00:00:03 ix->ossec-monitord
+Rule: 502 (level 3) -> 'Ossec server started.'
+ossec: Ossec started.
+
+{"rule":{"level":5,"comment":"Syslogd restarted.","sidid":1005,"group":"syslog,errors,"},"id":"1510376401.0","TimeStamp":1510376401000,"location":"/var/log/messages","full_log":"restart","hostname":"ix"}
+`
+	events := []string{}
+	r := Framing{}.NewEventReader(strings.NewReader(input))
+	for {
+		event, err := r.ReadEvent()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+	lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+	expect := []string{
+		strings.Join(lines[0:4], "\n"),
+		strings.Join(lines[5:11], "\n"),
+		strings.Join(lines[11:15], "\n"),
+		lines[16],
+	}
+	require.Equal(t, expect, events)
+}
@@ -25,6 +25,7 @@ import (
 
 const (
 	TypeEventInfo = "OSSEC.EventInfo"
+	TypeAlert     = "OSSEC.Alert"
 )
 
 func init() {
@@ -35,4 +36,14 @@ func init() {
 		Schema:       EventInfo{},
 		NewParser:    parsers.AdapterFactory(&EventInfoParser{}),
 	})
+	// The text alerts span multiple lines, they have a log type of their own so sources combining
+	// OSSEC.EventInfo with other log types keep the default framing.
+	logtypes.MustRegister(logtypes.Config{
+		Name:         TypeAlert,
+		Description:  `OSSEC alerts in the multi-line text format of alerts.log.`,
+		ReferenceURL: `https://www.ossec.net/docs/docs/formats/alerts.html`,
+		Schema:       EventInfo{},
+		NewParser:    parsers.AdapterFactory(&AlertParser{}),
+		Framing:      Framing{},
+	})
 }
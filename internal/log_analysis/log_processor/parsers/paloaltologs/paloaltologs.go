@@ -0,0 +1,304 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "PaloAlto"
+	// TypeTraffic is the log type of PAN-OS traffic logs
+	TypeTraffic = PantherPrefix + ".Traffic"
+	// TypeThreat is the log type of PAN-OS threat logs
+	TypeThreat = PantherPrefix + ".Threat"
+	// TypeSystem is the log type of PAN-OS system logs
+	TypeSystem = PantherPrefix + ".System"
+)
+
+func init() {
+	// nolint:lll
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name:         TypeTraffic,
+			Description:  `PAN-OS traffic logs record the start and end of each session through a Palo Alto Networks firewall, in CSV or syslog format.`,
+			ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/9-1/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/traffic-log-fields.html`,
+			Schema:       Traffic{},
+			NewParser:    parsers.AdapterFactory(&TrafficParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeThreat,
+			Description:  `PAN-OS threat logs record the security threats (ie viruses, spyware, vulnerabilities and URL filtering) detected by a Palo Alto Networks firewall, in CSV or syslog format.`,
+			ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/9-1/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/threat-log-fields.html`,
+			Schema:       Threat{},
+			NewParser:    parsers.AdapterFactory(&ThreatParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeSystem,
+			Description:  `PAN-OS system logs record the system events (ie HA failures, link status changes and administrator logins) of a Palo Alto Networks firewall, in CSV or syslog format.`,
+			ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/9-1/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/system-log-fields.html`,
+			Schema:       System{},
+			NewParser:    parsers.AdapterFactory(&SystemParser{}),
+		},
+	)
+}
+
+const (
+	// layout of the timestamps of PAN-OS logs, they are in the timezone of the firewall which should be set to UTC
+	timeLayout = "2006/01/02 15:04:05"
+
+	// the type of the log is the 4th column of all PAN-OS logs
+	typeColumn = 3
+)
+
+// logStartRegex matches the first columns of PAN-OS logs (FUTURE_USE and the receive time) to split off any syslog header
+var logStartRegex = regexp.MustCompile(`(?:^| )\d+,\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2},`)
+
+// Header holds the columns common to all PAN-OS logs
+// nolint:lll
+type Header struct {
+	Syslog        *sysloglogs.Header `json:"syslog,omitempty" description:"The syslog header of logs forwarded by the firewall as syslog messages."`
+	ReceiveTime   *timestamp.RFC3339 `json:"receiveTime" validate:"required" description:"The time the log was received at the management plane."`
+	SerialNumber  *string            `json:"serialNumber" validate:"required" description:"The serial number of the firewall that generated the log."`
+	Type          *string            `json:"type" validate:"required" description:"The type of the log, ie TRAFFIC, THREAT or SYSTEM."`
+	Subtype       *string            `json:"subtype,omitempty" description:"The subtype of the log, ie start, end, drop or deny for traffic logs and url, virus or spyware for threat logs."`
+	GeneratedTime *timestamp.RFC3339 `json:"generatedTime,omitempty" description:"The time the log was generated on the dataplane."`
+}
+
+// DeviceGroup holds the columns identifying the Panorama device group and the virtual system of a log
+// nolint:lll
+type DeviceGroup struct {
+	DeviceGroupHierarchyLevel1 *int64  `json:"deviceGroupHierarchyLevel1,omitempty" description:"The ID of the first level of the device group hierarchy of the firewall in Panorama."`
+	DeviceGroupHierarchyLevel2 *int64  `json:"deviceGroupHierarchyLevel2,omitempty" description:"The ID of the second level of the device group hierarchy of the firewall in Panorama."`
+	DeviceGroupHierarchyLevel3 *int64  `json:"deviceGroupHierarchyLevel3,omitempty" description:"The ID of the third level of the device group hierarchy of the firewall in Panorama."`
+	DeviceGroupHierarchyLevel4 *int64  `json:"deviceGroupHierarchyLevel4,omitempty" description:"The ID of the fourth level of the device group hierarchy of the firewall in Panorama."`
+	VirtualSystemName          *string `json:"virtualSystemName,omitempty" description:"The name of the virtual system of the log."`
+	DeviceName                 *string `json:"deviceName,omitempty" description:"The hostname of the firewall that generated the log."`
+}
+
+// Session holds the columns common to the logs of a network session (traffic and threat logs)
+// nolint:lll
+type Session struct {
+	SourceAddress         *string `json:"sourceAddress,omitempty" description:"The original session source IP address."`
+	DestinationAddress    *string `json:"destinationAddress,omitempty" description:"The original session destination IP address."`
+	NATSourceAddress      *string `json:"natSourceAddress,omitempty" description:"The post-NAT source IP address if source NAT was performed."`
+	NATDestinationAddress *string `json:"natDestinationAddress,omitempty" description:"The post-NAT destination IP address if destination NAT was performed."`
+	RuleName              *string `json:"ruleName,omitempty" description:"The name of the security rule that the session matched."`
+	SourceUser            *string `json:"sourceUser,omitempty" description:"The username of the user who initiated the session."`
+	DestinationUser       *string `json:"destinationUser,omitempty" description:"The username of the user to which the session was destined."`
+	Application           *string `json:"application,omitempty" description:"The application associated with the session."`
+	VirtualSystem         *string `json:"virtualSystem,omitempty" description:"The virtual system associated with the session."`
+	SourceZone            *string `json:"sourceZone,omitempty" description:"The zone the session was sourced from."`
+	DestinationZone       *string `json:"destinationZone,omitempty" description:"The zone the session was destined to."`
+	InboundInterface      *string `json:"inboundInterface,omitempty" description:"The interface the session was sourced from."`
+	OutboundInterface     *string `json:"outboundInterface,omitempty" description:"The interface the session was destined to."`
+	LogAction             *string `json:"logAction,omitempty" description:"The log forwarding profile that was applied to the session."`
+	SessionID             *int64  `json:"sessionId,omitempty" description:"An internal numerical identifier applied to each session."`
+	RepeatCount           *int64  `json:"repeatCount,omitempty" description:"The number of sessions with the same source IP, destination IP, application and subtype seen within 5 seconds."`
+	SourcePort            *uint16 `json:"sourcePort,omitempty" description:"The source port utilized by the session."`
+	DestinationPort       *uint16 `json:"destinationPort,omitempty" description:"The destination port utilized by the session."`
+	NATSourcePort         *uint16 `json:"natSourcePort,omitempty" description:"The post-NAT source port."`
+	NATDestinationPort    *uint16 `json:"natDestinationPort,omitempty" description:"The post-NAT destination port."`
+	Flags                 *string `json:"flags,omitempty" description:"A 32-bit hex field that provides details on the session."`
+	Protocol              *string `json:"protocol,omitempty" description:"The IP protocol associated with the session."`
+	Action                *string `json:"action,omitempty" description:"The action taken for the session, ie allow, deny, drop or reset-both."`
+}
+
+// columns are the CSV columns of a PAN-OS log.
+// Missing columns (older PAN-OS versions) and empty columns are nil.
+type columns []string
+
+func (c columns) String(i int) *string {
+	if i >= len(c) || c[i] == "" {
+		return nil
+	}
+	value := c[i]
+	return &value
+}
+
+func (c columns) Int64(i int) *int64 {
+	if i >= len(c) {
+		return nil
+	}
+	n, err := strconv.ParseInt(c[i], 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func (c columns) Uint16(i int) *uint16 {
+	if i >= len(c) {
+		return nil
+	}
+	n, err := strconv.ParseUint(c[i], 10, 16)
+	if err != nil {
+		return nil
+	}
+	port := uint16(n)
+	return &port
+}
+
+func (c columns) Time(i int) *timestamp.RFC3339 {
+	if i >= len(c) {
+		return nil
+	}
+	tm, err := timestamp.Parse(timeLayout, c[i])
+	if err != nil {
+		return nil
+	}
+	return &tm
+}
+
+func (c columns) Header(syslog *sysloglogs.Header) Header {
+	return Header{
+		Syslog:        syslog,
+		ReceiveTime:   c.Time(1),
+		SerialNumber:  c.String(2),
+		Type:          c.String(typeColumn),
+		Subtype:       c.String(4),
+		GeneratedTime: c.Time(6),
+	}
+}
+
+// DeviceGroup returns the device group columns starting at column i
+func (c columns) DeviceGroup(i int) DeviceGroup {
+	return DeviceGroup{
+		DeviceGroupHierarchyLevel1: c.Int64(i),
+		DeviceGroupHierarchyLevel2: c.Int64(i + 1),
+		DeviceGroupHierarchyLevel3: c.Int64(i + 2),
+		DeviceGroupHierarchyLevel4: c.Int64(i + 3),
+		VirtualSystemName:          c.String(i + 4),
+		DeviceName:                 c.String(i + 5),
+	}
+}
+
+// Session returns the session columns of traffic and threat logs
+func (c columns) Session() Session {
+	return Session{
+		SourceAddress:         c.String(7),
+		DestinationAddress:    c.String(8),
+		NATSourceAddress:      c.String(9),
+		NATDestinationAddress: c.String(10),
+		RuleName:              c.String(11),
+		SourceUser:            c.String(12),
+		DestinationUser:       c.String(13),
+		Application:           c.String(14),
+		VirtualSystem:         c.String(15),
+		SourceZone:            c.String(16),
+		DestinationZone:       c.String(17),
+		InboundInterface:      c.String(18),
+		OutboundInterface:     c.String(19),
+		LogAction:             c.String(20),
+		SessionID:             c.Int64(22),
+		RepeatCount:           c.Int64(23),
+		SourcePort:            c.Uint16(24),
+		DestinationPort:       c.Uint16(25),
+		NATSourcePort:         c.Uint16(26),
+		NATDestinationPort:    c.Uint16(27),
+		Flags:                 c.String(28),
+		Protocol:              c.String(29),
+		Action:                c.String(30),
+	}
+}
+
+// logReader reads the CSV columns of PAN-OS logs, standalone or embedded in syslog messages
+type logReader struct {
+	csv    *csvstream.StreamingCSVReader
+	syslog *sysloglogs.HeaderParser
+}
+
+func newLogReader() *logReader {
+	reader := csvstream.NewStreamingCSVReader()
+	// non-default settings
+	reader.CVSReader.LazyQuotes = true
+	return &logReader{
+		csv:    reader,
+		syslog: sysloglogs.NewHeaderParser(),
+	}
+}
+
+// Read returns the syslog header and the columns of a PAN-OS log of the given type
+func (r *logReader) Read(log, logType string, minColumns int) (*sysloglogs.Header, columns, error) {
+	if r == nil {
+		return nil, nil, errors.New("nil parser")
+	}
+	loc := logStartRegex.FindStringIndex(log)
+	if loc == nil {
+		return nil, nil, errors.New("invalid PAN-OS log")
+	}
+	start := loc[0]
+	if log[start] == ' ' {
+		start++
+	}
+	var header *sysloglogs.Header
+	if start > 0 {
+		var err error
+		if header, err = r.syslog.Parse(log[:start]); err != nil {
+			return nil, nil, err
+		}
+	}
+	record, err := r.csv.Parse(log[start:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(record) < minColumns {
+		return nil, nil, errors.Errorf("invalid number of columns, expected at least %d got %d", minColumns, len(record))
+	}
+	if record[typeColumn] != logType {
+		return nil, nil, errors.Errorf("invalid log type %q, expected %q", record[typeColumn], logType)
+	}
+	// the CSV reader reuses the record
+	return header, append(columns(nil), record...), nil
+}
+
+// eventTime returns the time the log was generated, falling back to the time it was received
+func (h *Header) eventTime() *timestamp.RFC3339 {
+	if h.GeneratedTime != nil {
+		return h.GeneratedTime
+	}
+	return h.ReceiveTime
+}
+
+func (h *Header) appendIndicators(event *parsers.PantherLog) {
+	if h.Syslog != nil && !event.AppendAnyIPAddressPtr(h.Syslog.Hostname) {
+		event.AppendAnyDomainNamePtrs(h.Syslog.Hostname)
+	}
+}
+
+func (s *Session) appendIndicators(event *parsers.PantherLog) {
+	for _, addr := range []*string{s.SourceAddress, s.DestinationAddress, s.NATSourceAddress, s.NATDestinationAddress} {
+		// the NAT addresses are 0.0.0.0 when no NAT was performed
+		if addr != nil && !net.ParseIP(*addr).IsUnspecified() {
+			event.AppendAnyIPAddress(*addr)
+		}
+	}
+	event.AppendAnyUsernamePtrs(s.SourceUser, s.DestinationUser)
+}
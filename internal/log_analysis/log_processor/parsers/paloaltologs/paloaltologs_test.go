@@ -0,0 +1,47 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumns(t *testing.T) {
+	c := columns{"", "2020/06/01 12:09:42", "12", "-1", "65536", "x"}
+	require.Nil(t, c.String(0))
+	require.Equal(t, "12", *c.String(2))
+	require.Nil(t, c.String(10))
+	require.Equal(t, "2020-06-01 12:09:42 +0000 UTC", c.Time(1).String())
+	require.Nil(t, c.Time(2))
+	require.Equal(t, int64(-1), *c.Int64(3))
+	require.Nil(t, c.Int64(5))
+	require.Equal(t, uint16(12), *c.Uint16(2))
+	require.Nil(t, c.Uint16(4))
+	require.Nil(t, c.Uint16(10))
+}
+
+func TestURLHost(t *testing.T) {
+	require.Equal(t, "www.example.com", urlHost("www.example.com/index.html"))
+	require.Equal(t, "www.example.com", urlHost("www.example.com:8080/index.html"))
+	require.Equal(t, "www.example.com", urlHost("https://www.example.com?q=1"))
+	require.Equal(t, "203.0.113.10", urlHost("203.0.113.10/"))
+	require.Equal(t, "::1", urlHost("[::1]:443/"))
+}
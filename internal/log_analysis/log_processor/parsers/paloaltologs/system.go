@@ -0,0 +1,103 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	systemLogType = "SYSTEM"
+	// PAN-OS 7.0 logs up to the sequence number
+	systemMinColumns = 16
+)
+
+// nolint:lll
+type System struct {
+	Header
+	VirtualSystem  *string `json:"virtualSystem,omitempty" description:"The virtual system associated with the event."`
+	EventID        *string `json:"eventId,omitempty" description:"The name of the event, ie auth-success, link-change or ha-state-change."`
+	Object         *string `json:"object,omitempty" description:"The name of the object associated with the event."`
+	Module         *string `json:"module,omitempty" description:"The module the event is related to, ie general, management, auth, ha, upgrade or chassis."`
+	Severity       *string `json:"severity,omitempty" description:"The severity of the event, informational, low, medium, high or critical."`
+	Description    *string `json:"description,omitempty" description:"A detailed description of the event."`
+	SequenceNumber *int64  `json:"sequenceNumber,omitempty" description:"A 64-bit log entry identifier incremented sequentially, each log type has a unique number space."`
+	ActionFlags    *string `json:"actionFlags,omitempty" description:"A bit field indicating if the log was forwarded to Panorama."`
+	DeviceGroup
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// SystemParser parses PAN-OS system logs
+type SystemParser struct {
+	reader *logReader
+}
+
+var _ parsers.LogParser = (*SystemParser)(nil)
+
+// New returns an initialized LogParser for PAN-OS system logs
+func (p *SystemParser) New() parsers.LogParser {
+	return &SystemParser{
+		reader: newLogReader(),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *SystemParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	header, c, err := p.reader.Read(log, systemLogType, systemMinColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &System{
+		Header:         c.Header(header),
+		VirtualSystem:  c.String(7),
+		EventID:        c.String(8),
+		Object:         c.String(9),
+		Module:         c.String(12),
+		Severity:       c.String(13),
+		Description:    c.String(14),
+		SequenceNumber: c.Int64(15),
+		ActionFlags:    c.String(16),
+		DeviceGroup:    c.DeviceGroup(17),
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *SystemParser) LogType() string {
+	return TypeSystem
+}
+
+func (event *System) updatePantherFields(p *SystemParser) {
+	event.SetCoreFields(p.LogType(), event.eventTime(), event)
+
+	event.Header.appendIndicators(&event.PantherLog)
+	if event.Description != nil {
+		event.AppendAnyIPAddressInField(*event.Description)
+	}
+}
@@ -0,0 +1,77 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSystem(t *testing.T) {
+	// nolint:lll
+	log := `1,2020/06/01 12:12:00,001801000001,SYSTEM,auth,0,2020/06/01 12:12:00,,auth-fail,admin,0,0,general,medium,"failed authentication for user 'admin'. Reason: Invalid username/password. From: 203.0.113.40.",987657,0x0,0,0,0,0,,PA-VM`
+
+	tm := time.Date(2020, 6, 1, 12, 12, 0, 0, time.UTC)
+	event := &System{
+		Header: Header{
+			ReceiveTime:   (*timestamp.RFC3339)(&tm),
+			SerialNumber:  aws.String("001801000001"),
+			Type:          aws.String("SYSTEM"),
+			Subtype:       aws.String("auth"),
+			GeneratedTime: (*timestamp.RFC3339)(&tm),
+		},
+		EventID:        aws.String("auth-fail"),
+		Object:         aws.String("admin"),
+		Module:         aws.String("general"),
+		Severity:       aws.String("medium"),
+		Description:    aws.String("failed authentication for user 'admin'. Reason: Invalid username/password. From: 203.0.113.40."),
+		SequenceNumber: aws.Int64(987657),
+		ActionFlags:    aws.String("0x0"),
+		DeviceGroup: DeviceGroup{
+			DeviceGroupHierarchyLevel1: aws.Int64(0),
+			DeviceGroupHierarchyLevel2: aws.Int64(0),
+			DeviceGroupHierarchyLevel3: aws.Int64(0),
+			DeviceGroupHierarchyLevel4: aws.Int64(0),
+			DeviceName:                 aws.String("PA-VM"),
+		},
+	}
+	event.SetCoreFields(TypeSystem, event.GeneratedTime, event)
+	event.AppendAnyIPAddress("203.0.113.40")
+
+	testutil.CheckPantherParser(t, log, &SystemParser{}, &event.PantherLog)
+}
+
+func TestSystemInvalid(t *testing.T) {
+	parser := (&SystemParser{}).New()
+	_, err := parser.Parse(trafficLog)
+	require.Error(t, err)
+	_, err = parser.Parse(`1,2020/06/01 12:12:00,001801000001,SYSTEM,auth,0`)
+	require.Error(t, err)
+}
+
+func TestSystemLogType(t *testing.T) {
+	parser := &SystemParser{}
+	require.Equal(t, "PaloAlto.System", parser.LogType())
+}
@@ -0,0 +1,186 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	threatLogType = "THREAT"
+	// PAN-OS 7.0 logs up to the content type
+	threatMinColumns = 42
+	// the miscellaneous column holds the URL of url subtype logs
+	threatSubtypeURL = "url"
+)
+
+var sha256Regex = regexp.MustCompile(`^[[:xdigit:]]{64}$`)
+
+// nolint:lll
+type Threat struct {
+	Header
+	Session
+	Miscellaneous       *string `json:"miscellaneous,omitempty" description:"The URL for url subtype logs or the name of the file for file and virus subtype logs."`
+	ThreatID            *string `json:"threatId,omitempty" description:"The Palo Alto Networks identifier of the threat, with its description, ie 'Eicar Test File(39040)'."`
+	Category            *string `json:"category,omitempty" description:"The URL category for url subtype logs or the verdict for wildfire subtype logs."`
+	Severity            *string `json:"severity,omitempty" description:"The severity of the threat, informational, low, medium, high or critical."`
+	Direction           *string `json:"direction,omitempty" description:"The direction of the attack, client-to-server or server-to-client."`
+	SequenceNumber      *int64  `json:"sequenceNumber,omitempty" description:"A 64-bit log entry identifier incremented sequentially, each log type has a unique number space."`
+	ActionFlags         *string `json:"actionFlags,omitempty" description:"A bit field indicating if the log was forwarded to Panorama."`
+	SourceLocation      *string `json:"sourceLocation,omitempty" description:"The source country or internal region for private addresses."`
+	DestinationLocation *string `json:"destinationLocation,omitempty" description:"The destination country or internal region for private addresses."`
+	ContentType         *string `json:"contentType,omitempty" description:"The content type of the HTTP response data, for url subtype logs."`
+	PCAPID              *int64  `json:"pcapId,omitempty" description:"The ID of the packet capture associated with the threat, 0 if there is none."`
+	FileDigest          *string `json:"fileDigest,omitempty" description:"The SHA-256 hash of the file, for wildfire subtype logs."`
+	Cloud               *string `json:"cloud,omitempty" description:"The FQDN of the WildFire appliance or cloud that analyzed the file."`
+	URLIndex            *int64  `json:"urlIndex,omitempty" description:"The order of the URL logs of a session."`
+	UserAgent           *string `json:"userAgent,omitempty" description:"The User-Agent of the HTTP request, for url subtype logs."`
+	FileType            *string `json:"fileType,omitempty" description:"The type of the file, for wildfire subtype logs."`
+	XForwardedFor       *string `json:"xForwardedFor,omitempty" description:"The X-Forwarded-For header of the HTTP request, for url subtype logs."`
+	Referer             *string `json:"referer,omitempty" description:"The Referer header of the HTTP request, for url subtype logs."`
+	Sender              *string `json:"sender,omitempty" description:"The sender of an email, for wildfire subtype logs."`
+	Subject             *string `json:"subject,omitempty" description:"The subject of an email, for wildfire subtype logs."`
+	Recipient           *string `json:"recipient,omitempty" description:"The recipient of an email, for wildfire subtype logs."`
+	ReportID            *string `json:"reportId,omitempty" description:"The ID of the WildFire analysis report."`
+	DeviceGroup
+	SourceVMUUID      *string            `json:"sourceVmUuid,omitempty" description:"The UUID of the source virtual machine."`
+	DestinationVMUUID *string            `json:"destinationVmUuid,omitempty" description:"The UUID of the destination virtual machine."`
+	HTTPMethod        *string            `json:"httpMethod,omitempty" description:"The HTTP method of the request, for url subtype logs."`
+	TunnelID          *string            `json:"tunnelId,omitempty" description:"The ID of the tunnel being inspected or the IMSI of a mobile user."`
+	MonitorTag        *string            `json:"monitorTag,omitempty" description:"The monitor name configured for the tunnel inspection policy rule or the IMEI of a mobile device."`
+	ParentSessionID   *int64             `json:"parentSessionId,omitempty" description:"The ID of the session in which this session is tunneled."`
+	ParentStartTime   *timestamp.RFC3339 `json:"parentStartTime,omitempty" description:"The time the parent tunnel session began."`
+	TunnelType        *string            `json:"tunnelType,omitempty" description:"The type of tunnel, ie GRE or IPSec."`
+	ThreatCategory    *string            `json:"threatCategory,omitempty" description:"The category of the threat, ie virus, spyware or code-execution."`
+	ContentVersion    *string            `json:"contentVersion,omitempty" description:"The version of the applications and threats content that detected the threat."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// ThreatParser parses PAN-OS threat logs
+type ThreatParser struct {
+	reader *logReader
+}
+
+var _ parsers.LogParser = (*ThreatParser)(nil)
+
+// New returns an initialized LogParser for PAN-OS threat logs
+func (p *ThreatParser) New() parsers.LogParser {
+	return &ThreatParser{
+		reader: newLogReader(),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ThreatParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	header, c, err := p.reader.Read(log, threatLogType, threatMinColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Threat{
+		Header:              c.Header(header),
+		Session:             c.Session(),
+		Miscellaneous:       c.String(31),
+		ThreatID:            c.String(32),
+		Category:            c.String(33),
+		Severity:            c.String(34),
+		Direction:           c.String(35),
+		SequenceNumber:      c.Int64(36),
+		ActionFlags:         c.String(37),
+		SourceLocation:      c.String(38),
+		DestinationLocation: c.String(39),
+		ContentType:         c.String(41),
+		PCAPID:              c.Int64(42),
+		FileDigest:          c.String(43),
+		Cloud:               c.String(44),
+		URLIndex:            c.Int64(45),
+		UserAgent:           c.String(46),
+		FileType:            c.String(47),
+		XForwardedFor:       c.String(48),
+		Referer:             c.String(49),
+		Sender:              c.String(50),
+		Subject:             c.String(51),
+		Recipient:           c.String(52),
+		ReportID:            c.String(53),
+		DeviceGroup:         c.DeviceGroup(54),
+		SourceVMUUID:        c.String(61),
+		DestinationVMUUID:   c.String(62),
+		HTTPMethod:          c.String(63),
+		TunnelID:            c.String(64),
+		MonitorTag:          c.String(65),
+		ParentSessionID:     c.Int64(66),
+		ParentStartTime:     c.Time(67),
+		TunnelType:          c.String(68),
+		ThreatCategory:      c.String(69),
+		ContentVersion:      c.String(70),
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ThreatParser) LogType() string {
+	return TypeThreat
+}
+
+func (event *Threat) updatePantherFields(p *ThreatParser) {
+	event.SetCoreFields(p.LogType(), event.eventTime(), event)
+
+	event.Header.appendIndicators(&event.PantherLog)
+	event.Session.appendIndicators(&event.PantherLog)
+	if event.Subtype != nil && *event.Subtype == threatSubtypeURL && event.Miscellaneous != nil {
+		if host := urlHost(*event.Miscellaneous); host != "" && !event.AppendAnyIPAddress(host) {
+			event.AppendAnyDomainNames(host)
+		}
+	}
+	if event.FileDigest != nil && sha256Regex.MatchString(*event.FileDigest) {
+		event.AppendAnySHA256Hashes(*event.FileDigest)
+	}
+	if event.XForwardedFor != nil {
+		event.AppendAnyIPAddressInField(*event.XForwardedFor)
+	}
+	event.AppendAnyEmailPtrs(event.Sender, event.Recipient)
+}
+
+// urlHost returns the host of the URLs logged by PAN-OS, they have no scheme (ie www.example.com/index.html)
+func urlHost(url string) string {
+	if i := strings.Index(url, "://"); i != -1 {
+		url = url[i+3:]
+	}
+	if i := strings.IndexAny(url, "/?#"); i != -1 {
+		url = url[:i]
+	}
+	if host, _, err := net.SplitHostPort(url); err == nil {
+		return host
+	}
+	return url
+}
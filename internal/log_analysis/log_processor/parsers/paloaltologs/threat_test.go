@@ -0,0 +1,142 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestThreatURL(t *testing.T) {
+	// nolint:lll
+	log := `1,2020/06/01 12:10:02,001801000001,THREAT,url,2305,2020/06/01 12:10:01,10.0.0.5,203.0.113.20,198.51.100.1,203.0.113.20,allow-web,corp\jdoe,,web-browsing,vsys1,trust,untrust,ethernet1/2,ethernet1/1,default,2020/06/01 12:10:02,12346,1,51235,80,31235,80,0x40b000,tcp,alert,"malware.example.com/download.php?id=1,2",(9999),malware,informational,client-to-server,987655,0x0,10.0.0.0-10.255.255.255,United States,0,text/html,0,,,1,"Mozilla/5.0 (Windows NT 10.0; Win64; x64)",,198.51.100.77,http://www.example.com/,,,,,0,0,0,0,,PA-VM,,,,get,0,,0,,N/A,,8263-6017`
+
+	received := time.Date(2020, 6, 1, 12, 10, 2, 0, time.UTC)
+	generated := time.Date(2020, 6, 1, 12, 10, 1, 0, time.UTC)
+	event := &Threat{
+		Header: Header{
+			ReceiveTime:   (*timestamp.RFC3339)(&received),
+			SerialNumber:  aws.String("001801000001"),
+			Type:          aws.String("THREAT"),
+			Subtype:       aws.String("url"),
+			GeneratedTime: (*timestamp.RFC3339)(&generated),
+		},
+		Session: Session{
+			SourceAddress:         aws.String("10.0.0.5"),
+			DestinationAddress:    aws.String("203.0.113.20"),
+			NATSourceAddress:      aws.String("198.51.100.1"),
+			NATDestinationAddress: aws.String("203.0.113.20"),
+			RuleName:              aws.String("allow-web"),
+			SourceUser:            aws.String(`corp\jdoe`),
+			Application:           aws.String("web-browsing"),
+			VirtualSystem:         aws.String("vsys1"),
+			SourceZone:            aws.String("trust"),
+			DestinationZone:       aws.String("untrust"),
+			InboundInterface:      aws.String("ethernet1/2"),
+			OutboundInterface:     aws.String("ethernet1/1"),
+			LogAction:             aws.String("default"),
+			SessionID:             aws.Int64(12346),
+			RepeatCount:           aws.Int64(1),
+			SourcePort:            aws.Uint16(51235),
+			DestinationPort:       aws.Uint16(80),
+			NATSourcePort:         aws.Uint16(31235),
+			NATDestinationPort:    aws.Uint16(80),
+			Flags:                 aws.String("0x40b000"),
+			Protocol:              aws.String("tcp"),
+			Action:                aws.String("alert"),
+		},
+		Miscellaneous:       aws.String("malware.example.com/download.php?id=1,2"),
+		ThreatID:            aws.String("(9999)"),
+		Category:            aws.String("malware"),
+		Severity:            aws.String("informational"),
+		Direction:           aws.String("client-to-server"),
+		SequenceNumber:      aws.Int64(987655),
+		ActionFlags:         aws.String("0x0"),
+		SourceLocation:      aws.String("10.0.0.0-10.255.255.255"),
+		DestinationLocation: aws.String("United States"),
+		ContentType:         aws.String("text/html"),
+		PCAPID:              aws.Int64(0),
+		URLIndex:            aws.Int64(1),
+		UserAgent:           aws.String("Mozilla/5.0 (Windows NT 10.0; Win64; x64)"),
+		XForwardedFor:       aws.String("198.51.100.77"),
+		Referer:             aws.String("http://www.example.com/"),
+		DeviceGroup: DeviceGroup{
+			DeviceGroupHierarchyLevel1: aws.Int64(0),
+			DeviceGroupHierarchyLevel2: aws.Int64(0),
+			DeviceGroupHierarchyLevel3: aws.Int64(0),
+			DeviceGroupHierarchyLevel4: aws.Int64(0),
+			DeviceName:                 aws.String("PA-VM"),
+		},
+		HTTPMethod:      aws.String("get"),
+		TunnelID:        aws.String("0"),
+		ParentSessionID: aws.Int64(0),
+		TunnelType:      aws.String("N/A"),
+		ContentVersion:  aws.String("8263-6017"),
+	}
+	event.SetCoreFields(TypeThreat, event.GeneratedTime, event)
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyIPAddress("203.0.113.20")
+	event.AppendAnyIPAddress("198.51.100.1")
+	event.AppendAnyIPAddress("198.51.100.77")
+	event.AppendAnyUsernames(`corp\jdoe`)
+	event.AppendAnyDomainNames("malware.example.com")
+
+	testutil.CheckPantherParser(t, log, &ThreatParser{}, &event.PantherLog)
+}
+
+func TestThreatWildFire(t *testing.T) {
+	// nolint:lll
+	log := `1,2020/06/01 12:11:00,001801000001,THREAT,wildfire,2305,2020/06/01 12:11:00,203.0.113.30,10.0.0.6,0.0.0.0,0.0.0.0,allow-mail,,,smtp,vsys1,untrust,trust,ethernet1/1,ethernet1/2,default,2020/06/01 12:11:00,12347,1,25025,25,0,0,0x400000,tcp,allow,invoice.exe,Windows Executable(52020),malicious,high,server-to-client,987656,0x0,United States,10.0.0.0-10.255.255.255,0,,0,e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855,wildfire.paloaltonetworks.com,0,,pe,,,attacker@example.com,Invoice,jdoe@example.com,123456789`
+	results, err := (&ThreatParser{}).New().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	event := results[0].Event().(*Threat)
+	require.Equal(t, "invoice.exe", *event.Miscellaneous)
+	require.Equal(t, "Windows Executable(52020)", *event.ThreatID)
+	require.Equal(t, "123456789", *event.ReportID)
+	require.Nil(t, event.DeviceName)
+
+	hashes, err := jsoniter.MarshalToString(event.PantherAnySHA256Hashes)
+	require.NoError(t, err)
+	require.Equal(t, `["e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"]`, hashes)
+	ips, err := jsoniter.MarshalToString(event.PantherAnyIPAddresses)
+	require.NoError(t, err)
+	require.Equal(t, `["10.0.0.6","203.0.113.30"]`, ips)
+	emails, err := jsoniter.MarshalToString(event.PantherAnyEmails)
+	require.NoError(t, err)
+	require.Equal(t, `["attacker@example.com","jdoe@example.com"]`, emails)
+}
+
+func TestThreatInvalid(t *testing.T) {
+	parser := (&ThreatParser{}).New()
+	_, err := parser.Parse(trafficLog)
+	require.Error(t, err)
+}
+
+func TestThreatLogType(t *testing.T) {
+	parser := &ThreatParser{}
+	require.Equal(t, "PaloAlto.Threat", parser.LogType())
+}
@@ -0,0 +1,132 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	trafficLogType = "TRAFFIC"
+	// PAN-OS 7.0 logs up to the session end reason
+	trafficMinColumns = 47
+)
+
+// nolint:lll
+type Traffic struct {
+	Header
+	Session
+	Bytes               *int64             `json:"bytes,omitempty" description:"The number of total bytes (transmit and receive) for the session."`
+	BytesSent           *int64             `json:"bytesSent,omitempty" description:"The number of bytes in the client-to-server direction of the session."`
+	BytesReceived       *int64             `json:"bytesReceived,omitempty" description:"The number of bytes in the server-to-client direction of the session."`
+	Packets             *int64             `json:"packets,omitempty" description:"The number of total packets (transmit and receive) for the session."`
+	StartTime           *timestamp.RFC3339 `json:"startTime,omitempty" description:"The time of the session start."`
+	ElapsedTime         *int64             `json:"elapsedTime,omitempty" description:"The elapsed time of the session in seconds."`
+	Category            *string            `json:"category,omitempty" description:"The URL category associated with the session (if applicable)."`
+	SequenceNumber      *int64             `json:"sequenceNumber,omitempty" description:"A 64-bit log entry identifier incremented sequentially, each log type has a unique number space."`
+	ActionFlags         *string            `json:"actionFlags,omitempty" description:"A bit field indicating if the log was forwarded to Panorama."`
+	SourceLocation      *string            `json:"sourceLocation,omitempty" description:"The source country or internal region for private addresses."`
+	DestinationLocation *string            `json:"destinationLocation,omitempty" description:"The destination country or internal region for private addresses."`
+	PacketsSent         *int64             `json:"packetsSent,omitempty" description:"The number of client-to-server packets for the session."`
+	PacketsReceived     *int64             `json:"packetsReceived,omitempty" description:"The number of server-to-client packets for the session."`
+	SessionEndReason    *string            `json:"sessionEndReason,omitempty" description:"The reason a session terminated, ie tcp-fin, tcp-rst-from-client, aged-out or policy-deny."`
+	DeviceGroup
+	ActionSource      *string            `json:"actionSource,omitempty" description:"Specifies whether the action taken to allow or block an application was defined in the application or in policy."`
+	SourceVMUUID      *string            `json:"sourceVmUuid,omitempty" description:"The UUID of the source virtual machine."`
+	DestinationVMUUID *string            `json:"destinationVmUuid,omitempty" description:"The UUID of the destination virtual machine."`
+	TunnelID          *string            `json:"tunnelId,omitempty" description:"The ID of the tunnel being inspected or the IMSI of a mobile user."`
+	MonitorTag        *string            `json:"monitorTag,omitempty" description:"The monitor name configured for the tunnel inspection policy rule or the IMEI of a mobile device."`
+	ParentSessionID   *int64             `json:"parentSessionId,omitempty" description:"The ID of the session in which this session is tunneled."`
+	ParentStartTime   *timestamp.RFC3339 `json:"parentStartTime,omitempty" description:"The time the parent tunnel session began."`
+	TunnelType        *string            `json:"tunnelType,omitempty" description:"The type of tunnel, ie GRE or IPSec."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// TrafficParser parses PAN-OS traffic logs
+type TrafficParser struct {
+	reader *logReader
+}
+
+var _ parsers.LogParser = (*TrafficParser)(nil)
+
+// New returns an initialized LogParser for PAN-OS traffic logs
+func (p *TrafficParser) New() parsers.LogParser {
+	return &TrafficParser{
+		reader: newLogReader(),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *TrafficParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	header, c, err := p.reader.Read(log, trafficLogType, trafficMinColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Traffic{
+		Header:              c.Header(header),
+		Session:             c.Session(),
+		Bytes:               c.Int64(31),
+		BytesSent:           c.Int64(32),
+		BytesReceived:       c.Int64(33),
+		Packets:             c.Int64(34),
+		StartTime:           c.Time(35),
+		ElapsedTime:         c.Int64(36),
+		Category:            c.String(37),
+		SequenceNumber:      c.Int64(39),
+		ActionFlags:         c.String(40),
+		SourceLocation:      c.String(41),
+		DestinationLocation: c.String(42),
+		PacketsSent:         c.Int64(44),
+		PacketsReceived:     c.Int64(45),
+		SessionEndReason:    c.String(46),
+		DeviceGroup:         c.DeviceGroup(47),
+		ActionSource:        c.String(53),
+		SourceVMUUID:        c.String(54),
+		DestinationVMUUID:   c.String(55),
+		TunnelID:            c.String(56),
+		MonitorTag:          c.String(57),
+		ParentSessionID:     c.Int64(58),
+		ParentStartTime:     c.Time(59),
+		TunnelType:          c.String(60),
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *TrafficParser) LogType() string {
+	return TypeTraffic
+}
+
+func (event *Traffic) updatePantherFields(p *TrafficParser) {
+	event.SetCoreFields(p.LogType(), event.eventTime(), event)
+
+	event.Header.appendIndicators(&event.PantherLog)
+	event.Session.appendIndicators(&event.PantherLog)
+}
@@ -0,0 +1,147 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+const trafficLog = `1,2020/06/01 12:09:42,001801000001,TRAFFIC,end,2305,2020/06/01 12:09:41,10.0.0.5,203.0.113.10,198.51.100.1,203.0.113.10,allow-web,corp\jdoe,,ssl,vsys1,trust,untrust,ethernet1/2,ethernet1/1,default,2020/06/01 12:09:42,12345,1,51234,443,31234,443,0x400053,tcp,allow,5120,1024,4096,20,2020/06/01 12:09:30,11,computer-and-internet-info,0,987654,0x0,10.0.0.0-10.255.255.255,United States,0,10,10,tcp-fin,0,0,0,0,,PA-VM,from-policy,,,0,,0,,N/A`
+
+func expectTraffic() *Traffic {
+	received := time.Date(2020, 6, 1, 12, 9, 42, 0, time.UTC)
+	generated := time.Date(2020, 6, 1, 12, 9, 41, 0, time.UTC)
+	start := time.Date(2020, 6, 1, 12, 9, 30, 0, time.UTC)
+	return &Traffic{
+		Header: Header{
+			ReceiveTime:   (*timestamp.RFC3339)(&received),
+			SerialNumber:  aws.String("001801000001"),
+			Type:          aws.String("TRAFFIC"),
+			Subtype:       aws.String("end"),
+			GeneratedTime: (*timestamp.RFC3339)(&generated),
+		},
+		Session: Session{
+			SourceAddress:         aws.String("10.0.0.5"),
+			DestinationAddress:    aws.String("203.0.113.10"),
+			NATSourceAddress:      aws.String("198.51.100.1"),
+			NATDestinationAddress: aws.String("203.0.113.10"),
+			RuleName:              aws.String("allow-web"),
+			SourceUser:            aws.String(`corp\jdoe`),
+			Application:           aws.String("ssl"),
+			VirtualSystem:         aws.String("vsys1"),
+			SourceZone:            aws.String("trust"),
+			DestinationZone:       aws.String("untrust"),
+			InboundInterface:      aws.String("ethernet1/2"),
+			OutboundInterface:     aws.String("ethernet1/1"),
+			LogAction:             aws.String("default"),
+			SessionID:             aws.Int64(12345),
+			RepeatCount:           aws.Int64(1),
+			SourcePort:            aws.Uint16(51234),
+			DestinationPort:       aws.Uint16(443),
+			NATSourcePort:         aws.Uint16(31234),
+			NATDestinationPort:    aws.Uint16(443),
+			Flags:                 aws.String("0x400053"),
+			Protocol:              aws.String("tcp"),
+			Action:                aws.String("allow"),
+		},
+		Bytes:               aws.Int64(5120),
+		BytesSent:           aws.Int64(1024),
+		BytesReceived:       aws.Int64(4096),
+		Packets:             aws.Int64(20),
+		StartTime:           (*timestamp.RFC3339)(&start),
+		ElapsedTime:         aws.Int64(11),
+		Category:            aws.String("computer-and-internet-info"),
+		SequenceNumber:      aws.Int64(987654),
+		ActionFlags:         aws.String("0x0"),
+		SourceLocation:      aws.String("10.0.0.0-10.255.255.255"),
+		DestinationLocation: aws.String("United States"),
+		PacketsSent:         aws.Int64(10),
+		PacketsReceived:     aws.Int64(10),
+		SessionEndReason:    aws.String("tcp-fin"),
+		DeviceGroup: DeviceGroup{
+			DeviceGroupHierarchyLevel1: aws.Int64(0),
+			DeviceGroupHierarchyLevel2: aws.Int64(0),
+			DeviceGroupHierarchyLevel3: aws.Int64(0),
+			DeviceGroupHierarchyLevel4: aws.Int64(0),
+			DeviceName:                 aws.String("PA-VM"),
+		},
+		ActionSource:    aws.String("from-policy"),
+		TunnelID:        aws.String("0"),
+		ParentSessionID: aws.Int64(0),
+		TunnelType:      aws.String("N/A"),
+	}
+}
+
+func TestTraffic(t *testing.T) {
+	event := expectTraffic()
+	event.SetCoreFields(TypeTraffic, event.GeneratedTime, event)
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyIPAddress("203.0.113.10")
+	event.AppendAnyIPAddress("198.51.100.1")
+	event.AppendAnyUsernames(`corp\jdoe`)
+
+	testutil.CheckPantherParser(t, trafficLog, &TrafficParser{}, &event.PantherLog)
+}
+
+func TestTrafficSyslog(t *testing.T) {
+	log := `<14>Jun  1 12:09:42 PA-VM ` + trafficLog
+
+	syslogTime := time.Date(time.Now().UTC().Year(), 6, 1, 12, 9, 42, 0, time.UTC)
+	event := expectTraffic()
+	event.Syslog = &sysloglogs.Header{
+		Priority:  aws.Uint8(14),
+		Facility:  aws.Uint8(1),
+		Severity:  aws.Uint8(6),
+		Timestamp: (*timestamp.RFC3339)(&syslogTime),
+		Hostname:  aws.String("PA-VM"),
+	}
+	event.SetCoreFields(TypeTraffic, event.GeneratedTime, event)
+	event.AppendAnyDomainNames("PA-VM")
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyIPAddress("203.0.113.10")
+	event.AppendAnyIPAddress("198.51.100.1")
+	event.AppendAnyUsernames(`corp\jdoe`)
+
+	testutil.CheckPantherParser(t, log, &TrafficParser{}, &event.PantherLog)
+}
+
+func TestTrafficInvalid(t *testing.T) {
+	parser := (&TrafficParser{}).New()
+	// nolint:lll
+	_, err := parser.Parse(`1,2020/06/01 12:09:42,001801000001,SYSTEM,general,0,2020/06/01 12:09:42,,general,,0,0,general,informational,"User admin logged in via Web from 10.0.0.8 using https",1234,0x0,0,0,0,0,,PA-VM`)
+	require.Error(t, err)
+	_, err = parser.Parse(`1,2020/06/01 12:09:42,001801000001,TRAFFIC,end,2305,2020/06/01 12:09:41,10.0.0.5,203.0.113.10`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"type":"TRAFFIC"}`)
+	require.Error(t, err)
+}
+
+func TestTrafficLogType(t *testing.T) {
+	parser := &TrafficParser{}
+	require.Equal(t, "PaloAlto.Traffic", parser.LogType())
+}
@@ -22,6 +22,7 @@ import (
 	"net"
 	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -37,6 +38,9 @@ const (
 var (
 	ipv4Regex  = regexp.MustCompile(`(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])*`)
 	rowCounter RowID // number of rows generated in this lambda execution (used to generate p_row_id)
+	// ipv6Regex matches IPv6 candidates (full, compressed, IPv4-suffixed and zoned), matches must be checked with net.ParseIP
+	ipv6Regex  = regexp.MustCompile(`(?:[0-9A-Fa-f]{0,4}:){2,7}(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}|[0-9A-Fa-f]{0,4})(?:%[0-9A-Za-z_.\-]+)?`)
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
 )
 
 // All log parsers should extend from this to get standardized fields (all prefixed with 'p_' as JSON for uniqueness)
@@ -58,6 +62,8 @@ type PantherLog struct {
 	PantherAnySHA1Hashes   *PantherAnyString `json:"p_any_sha1_hashes,omitempty" description:"Panther added field with collection of SHA1 hashes associated with the row"`
 	PantherAnyMD5Hashes    *PantherAnyString `json:"p_any_md5_hashes,omitempty" description:"Panther added field with collection of MD5 hashes associated with the row"`
 	PantherAnySHA256Hashes *PantherAnyString `json:"p_any_sha256_hashes,omitempty" description:"Panther added field with collection of SHA256 hashes of any algorithm associated with the row"`
+	PantherAnyUsernames    *PantherAnyString `json:"p_any_usernames,omitempty" description:"Panther added field with collection of usernames associated with the row"`
+	PantherAnyEmails       *PantherAnyString `json:"p_any_emails,omitempty" description:"Panther added field with collection of email addresses associated with the row"`
 }
 
 type PantherAnyString struct { // needed to declare as struct (rather than map) for CF generation
@@ -149,9 +155,22 @@ func (pl *PantherLog) AppendAnyIPAddressInFieldPtr(value *string) bool {
 	return pl.AppendAnyIPAddressInField(*value)
 }
 
-// AppendAnyIPAddressInField extracts all IPs from the value using a regexp
+// AppendAnyIPAddressInField extracts all IPs from the value using a regexp.
+// IPv6 addresses are normalized to their canonical form without a zone.
 func (pl *PantherLog) AppendAnyIPAddressInField(value string) bool {
-	matchedIPs := ipv4Regex.FindAllString(value, -1)
+	var matchedIPs []string
+	// IPv4 addresses are searched around the IPv6 addresses so IPv4-suffixed IPv6 addresses are not matched twice
+	last := 0
+	for _, match := range ipv6Regex.FindAllStringIndex(value, -1) {
+		ip := parseIPv6InField(value, match[0], match[1])
+		if ip == nil {
+			continue
+		}
+		matchedIPs = append(matchedIPs, ipv4Regex.FindAllString(value[last:match[0]], -1)...)
+		matchedIPs = append(matchedIPs, ip.String())
+		last = match[1]
+	}
+	matchedIPs = append(matchedIPs, ipv4Regex.FindAllString(value[last:], -1)...)
 	if len(matchedIPs) == 0 {
 		return false
 	}
@@ -163,6 +182,37 @@ func (pl *PantherLog) AppendAnyIPAddressInField(value string) bool {
 	return true
 }
 
+// parseIPv6InField parses an IPv6 candidate match checking it is not part of a larger token
+func parseIPv6InField(value string, start, end int) net.IP {
+	if start > 0 && isIPv6TokenChar(value[start-1]) {
+		return nil
+	}
+	if end < len(value) && isIPv6TokenChar(value[end]) && value[end] != '.' {
+		return nil
+	}
+	candidate := value[start:end]
+	if zone := strings.IndexByte(candidate, '%'); zone != -1 {
+		candidate = candidate[:zone]
+	}
+	// the unspecified address "::" is more likely a separator than an address in text
+	ip := net.ParseIP(candidate)
+	if ip == nil || ip.IsUnspecified() {
+		return nil
+	}
+	return ip
+}
+
+func isIPv6TokenChar(c byte) bool {
+	switch {
+	case '0' <= c && c <= '9', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		return true
+	case c == '_', c == ':', c == '.', c == '%':
+		return true
+	default:
+		return false
+	}
+}
+
 func (pl *PantherLog) AppendAnyIPAddress(value string) bool {
 	if net.ParseIP(value) != nil {
 		if pl.PantherAnyIPAddresses == nil { // lazy create
@@ -234,6 +284,42 @@ func (pl *PantherLog) AppendAnySHA256HashesPtr(values ...*string) {
 	}
 }
 
+func (pl *PantherLog) AppendAnyUsernamePtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyUsernames(*value)
+		}
+	}
+}
+
+func (pl *PantherLog) AppendAnyUsernames(values ...string) {
+	if pl.PantherAnyUsernames == nil { // lazy create
+		pl.PantherAnyUsernames = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyUsernames, values...)
+}
+
+func (pl *PantherLog) AppendAnyEmailPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyEmails(*value)
+		}
+	}
+}
+
+// AppendAnyEmails adds the values that look like email addresses
+func (pl *PantherLog) AppendAnyEmails(values ...string) {
+	for _, value := range values {
+		if !emailRegex.MatchString(value) {
+			continue
+		}
+		if pl.PantherAnyEmails == nil { // lazy create
+			pl.PantherAnyEmails = NewPantherAnyString()
+		}
+		AppendAnyString(pl.PantherAnyEmails, value)
+	}
+}
+
 func AppendAnyString(any *PantherAnyString, values ...string) {
 	// add new if not present
 	for _, v := range values {
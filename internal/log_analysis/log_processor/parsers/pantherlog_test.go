@@ -172,6 +172,27 @@ func TestAppendAnyIPsInFieldMultiple(t *testing.T) {
 	require.Equal(t, expectedAny, event.PantherAnyIPAddresses)
 }
 
+func TestAppendAnyIPV6InField(t *testing.T) {
+	event := PantherLog{}
+	require.True(t, event.AppendAnyIPAddressInFieldPtr(aws.String("connection established from 2001:0DB8:0000:0000:0000:0000:0000:0001 port 22")))
+	require.True(t, event.AppendAnyIPAddressInField("Accepted publickey from fe80::1%eth0 to [2001:db8::2]:443, forwarded for 10.0.0.1"))
+	require.True(t, event.AppendAnyIPAddressInField("mapped ::ffff:192.0.2.128 and loopback ::1."))
+	require.False(t, event.AppendAnyIPAddressInField("connection established at 12:00:00 from 00:1a:2b:3c:4d:5e"))
+	require.False(t, event.AppendAnyIPAddressInField("Foo::bar :: baz dead:beef::cafe:babe:f00d:1:2:3"))
+
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			"2001:db8::1": {},
+			"fe80::1":     {},
+			"2001:db8::2": {},
+			"10.0.0.1":    {},
+			"192.0.2.128": {},
+			"::1":         {},
+		},
+	}
+	require.Equal(t, expectedAny, event.PantherAnyIPAddresses)
+}
+
 func TestAppendAnyIPV4(t *testing.T) {
 	event := PantherLog{}
 	require.True(t, event.AppendAnyIPAddressPtr(aws.String("192.168.1.1")))
@@ -249,3 +270,35 @@ func TestAppendAnyMD5Hashes(t *testing.T) {
 	event.AppendAnyMD5HashPtrs(&value)
 	require.Equal(t, expectedAny, event.PantherAnyMD5Hashes)
 }
+
+func TestAppendAnyUsernames(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyUsernames(value)
+	require.Equal(t, expectedAny, event.PantherAnyUsernames)
+
+	event = PantherLog{}
+	event.AppendAnyUsernamePtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnyUsernames)
+}
+
+func TestAppendAnyEmails(t *testing.T) {
+	event := PantherLog{}
+	value := "user@example.com"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyEmails(value, "not an email", "user@localhost")
+	require.Equal(t, expectedAny, event.PantherAnyEmails)
+
+	event = PantherLog{}
+	event.AppendAnyEmailPtrs(&value, nil)
+	require.Equal(t, expectedAny, event.PantherAnyEmails)
+}
@@ -84,6 +84,32 @@ type Interface interface {
 	ParseLog(log string) ([]*Result, error)
 }
 
+// FallbackParser is implemented by parsers of generic formats that also match logs of more specific log types
+// (ie syslog messages with a CEF or LEEF payload). The classifier tries them after all other parsers.
+type FallbackParser interface {
+	Interface
+	Fallback() bool
+}
+
+// NewFallback marks a parser as a FallbackParser
+func NewFallback(parser Interface) Interface {
+	return &fallbackParser{Interface: parser}
+}
+
+// IsFallback checks if a parser should be tried after all other parsers
+func IsFallback(parser Interface) bool {
+	p, ok := parser.(FallbackParser)
+	return ok && p.Fallback()
+}
+
+type fallbackParser struct {
+	Interface
+}
+
+func (*fallbackParser) Fallback() bool {
+	return true
+}
+
 // Result is the result of parsing a log event.
 // It contains the JSON form of the pantherlog to be stored for queries.
 type Result struct {
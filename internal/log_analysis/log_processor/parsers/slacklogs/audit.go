@@ -0,0 +1,184 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// AuditLog is an entry of the Slack Audit Logs API
+// nolint:lll
+type AuditLog struct {
+	ID         *string              `json:"id" validate:"required" description:"The unique identifier of the audit log entry."`
+	DateCreate *timestamp.UnixFloat `json:"date_create" validate:"required" description:"The time the action occurred, in seconds since the epoch."`
+	Action     *string              `json:"action" validate:"required" description:"The name of the action that was performed (ie user_login, file_downloaded or pref.sso_setting_changed)."`
+	Actor      *Actor               `json:"actor" validate:"required" description:"The user that performed the action."`
+	Entity     *Entity              `json:"entity" validate:"required" description:"The object the action was performed on."`
+	Context    *Context             `json:"context,omitempty" description:"The location and client the action was performed from."`
+	Details    jsoniter.RawMessage  `json:"details,omitempty" description:"Additional details about the action, specific to each action."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type Actor struct {
+	Type *string `json:"type" validate:"required" description:"The type of the actor (ie user)."`
+	User *User   `json:"user,omitempty" description:"The user that performed the action."`
+}
+
+// nolint:lll
+type Entity struct {
+	Type       *string    `json:"type" validate:"required" description:"The type of the entity (ie user, workspace, enterprise, channel, file, app, usergroup or workflow)."`
+	User       *User      `json:"user,omitempty" description:"The user the action was performed on."`
+	Workspace  *Workspace `json:"workspace,omitempty" description:"The workspace the action was performed on."`
+	Enterprise *Workspace `json:"enterprise,omitempty" description:"The enterprise organization the action was performed on."`
+	Channel    *Channel   `json:"channel,omitempty" description:"The channel the action was performed on."`
+	File       *File      `json:"file,omitempty" description:"The file the action was performed on."`
+	App        *App       `json:"app,omitempty" description:"The app the action was performed on."`
+	Usergroup  *Usergroup `json:"usergroup,omitempty" description:"The user group the action was performed on."`
+	Workflow   *Workflow  `json:"workflow,omitempty" description:"The workflow the action was performed on."`
+}
+
+// nolint:lll
+type User struct {
+	ID    *string `json:"id,omitempty" description:"The id of the user."`
+	Name  *string `json:"name,omitempty" description:"The name of the user."`
+	Email *string `json:"email,omitempty" description:"The email address of the user."`
+	Team  *string `json:"team,omitempty" description:"The id of the workspace of the user."`
+}
+
+// nolint:lll
+type Workspace struct {
+	ID     *string `json:"id,omitempty" description:"The id of the workspace or enterprise."`
+	Name   *string `json:"name,omitempty" description:"The name of the workspace or enterprise."`
+	Domain *string `json:"domain,omitempty" description:"The Slack domain of the workspace or enterprise."`
+}
+
+// nolint:lll
+type Channel struct {
+	ID              *string  `json:"id,omitempty" description:"The id of the channel."`
+	Name            *string  `json:"name,omitempty" description:"The name of the channel."`
+	Privacy         *string  `json:"privacy,omitempty" description:"The privacy of the channel (ie public or private)."`
+	IsShared        *bool    `json:"is_shared,omitempty" description:"Whether the channel is shared with another organization."`
+	IsOrgShared     *bool    `json:"is_org_shared,omitempty" description:"Whether the channel is shared across the workspaces of the organization."`
+	TeamsSharedWith []string `json:"teams_shared_with,omitempty" description:"The ids of the workspaces the channel is shared with."`
+}
+
+// nolint:lll
+type File struct {
+	ID       *string `json:"id,omitempty" description:"The id of the file."`
+	Name     *string `json:"name,omitempty" description:"The name of the file."`
+	Filetype *string `json:"filetype,omitempty" description:"The type of the file."`
+	Title    *string `json:"title,omitempty" description:"The title of the file."`
+}
+
+// nolint:lll
+type App struct {
+	ID                  *string  `json:"id,omitempty" description:"The id of the app."`
+	Name                *string  `json:"name,omitempty" description:"The name of the app."`
+	IsDistributed       *bool    `json:"is_distributed,omitempty" description:"Whether the app is distributed to other organizations."`
+	IsDirectoryApproved *bool    `json:"is_directory_approved,omitempty" description:"Whether the app is approved in the Slack app directory."`
+	IsWorkflowApp       *bool    `json:"is_workflow_app,omitempty" description:"Whether the app is a workflow app."`
+	Scopes              []string `json:"scopes,omitempty" description:"The OAuth scopes of the app."`
+}
+
+// nolint:lll
+type Usergroup struct {
+	ID   *string `json:"id,omitempty" description:"The id of the user group."`
+	Name *string `json:"name,omitempty" description:"The name of the user group."`
+}
+
+// nolint:lll
+type Workflow struct {
+	ID   *string `json:"id,omitempty" description:"The id of the workflow."`
+	Name *string `json:"name,omitempty" description:"The name of the workflow."`
+}
+
+// nolint:lll
+type Context struct {
+	Location  *Location       `json:"location,omitempty" description:"The workspace or enterprise the action was performed in."`
+	UA        *string         `json:"ua,omitempty" description:"The user agent of the client that performed the action."`
+	IPAddress *string         `json:"ip_address,omitempty" description:"The IP address the action was performed from."`
+	SessionID *numerics.Int64 `json:"session_id,omitempty" description:"The id of the session the action was performed in."`
+	App       *App            `json:"app,omitempty" description:"The app that performed the action on behalf of the user."`
+}
+
+// nolint:lll
+type Location struct {
+	Type   *string `json:"type,omitempty" description:"The type of the location (ie workspace or enterprise)."`
+	ID     *string `json:"id,omitempty" description:"The id of the workspace or enterprise."`
+	Name   *string `json:"name,omitempty" description:"The name of the workspace or enterprise."`
+	Domain *string `json:"domain,omitempty" description:"The Slack domain of the workspace or enterprise."`
+}
+
+// AuditLogParser parses Slack audit log entries
+type AuditLogParser struct{}
+
+var _ parsers.LogParser = (*AuditLogParser)(nil)
+
+func (p *AuditLogParser) New() parsers.LogParser {
+	return &AuditLogParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AuditLogParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &AuditLog{}
+	if err := jsoniter.UnmarshalFromString(log, event); err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AuditLogParser) LogType() string {
+	return TypeAuditLogs
+}
+
+func (event *AuditLog) updatePantherFields(p *AuditLogParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.DateCreate), event)
+
+	if event.Context != nil {
+		event.AppendAnyIPAddressPtr(event.Context.IPAddress)
+	}
+	if event.Actor != nil {
+		event.appendUser(event.Actor.User)
+	}
+	if event.Entity != nil {
+		event.appendUser(event.Entity.User)
+	}
+}
+
+func (event *AuditLog) appendUser(user *User) {
+	if user == nil {
+		return
+	}
+	event.AppendAnyUsernamePtrs(user.Name)
+	event.AppendAnyEmailPtrs(user.Email)
+}
@@ -0,0 +1,132 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAuditLog(t *testing.T) {
+	// nolint:lll
+	log := `{"id":"0123a45b-6c7d-8900-e12f-3456789gh0i1","date_create":1591037780,"action":"user_login","actor":{"type":"user","user":{"id":"W123AB456","name":"Charlie Parker","email":"bird@example.com"}},"entity":{"type":"workspace","workspace":{"id":"T123AB456","name":"Birdland","domain":"birdland"}},"context":{"location":{"type":"enterprise","id":"E1701NCCA","name":"Birdland","domain":"birdland"},"ua":"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_4)","ip_address":"198.51.100.7","session_id":847288190092},"details":{"type":"saml"}}`
+
+	tm := time.Unix(1591037780, 0).UTC()
+	sessionID := numerics.Int64(847288190092)
+	event := &AuditLog{
+		ID:         aws.String("0123a45b-6c7d-8900-e12f-3456789gh0i1"),
+		DateCreate: (*timestamp.UnixFloat)(&tm),
+		Action:     aws.String("user_login"),
+		Actor: &Actor{
+			Type: aws.String("user"),
+			User: &User{
+				ID:    aws.String("W123AB456"),
+				Name:  aws.String("Charlie Parker"),
+				Email: aws.String("bird@example.com"),
+			},
+		},
+		Entity: &Entity{
+			Type: aws.String("workspace"),
+			Workspace: &Workspace{
+				ID:     aws.String("T123AB456"),
+				Name:   aws.String("Birdland"),
+				Domain: aws.String("birdland"),
+			},
+		},
+		Context: &Context{
+			Location: &Location{
+				Type:   aws.String("enterprise"),
+				ID:     aws.String("E1701NCCA"),
+				Name:   aws.String("Birdland"),
+				Domain: aws.String("birdland"),
+			},
+			UA:        aws.String("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_4)"),
+			IPAddress: aws.String("198.51.100.7"),
+			SessionID: &sessionID,
+		},
+		Details: jsoniter.RawMessage(`{"type":"saml"}`),
+	}
+	event.SetCoreFields(TypeAuditLogs, (*timestamp.RFC3339)(&tm), event)
+	event.AppendAnyIPAddress("198.51.100.7")
+	event.AppendAnyUsernames("Charlie Parker")
+	event.AppendAnyEmails("bird@example.com")
+
+	testutil.CheckPantherParser(t, log, &AuditLogParser{}, &event.PantherLog)
+}
+
+func TestAuditLogEntityUser(t *testing.T) {
+	// nolint:lll
+	log := `{"id":"0123a45b","date_create":1591037780,"action":"role_change_to_admin","actor":{"type":"user","user":{"id":"W123AB456","name":"Charlie Parker","email":"bird@example.com"}},"entity":{"type":"user","user":{"id":"W789CD012","name":"Dizzy Gillespie","email":"dizzy@example.com","team":"T123AB456"}}}`
+
+	tm := time.Unix(1591037780, 0).UTC()
+	event := &AuditLog{
+		ID:         aws.String("0123a45b"),
+		DateCreate: (*timestamp.UnixFloat)(&tm),
+		Action:     aws.String("role_change_to_admin"),
+		Actor: &Actor{
+			Type: aws.String("user"),
+			User: &User{
+				ID:    aws.String("W123AB456"),
+				Name:  aws.String("Charlie Parker"),
+				Email: aws.String("bird@example.com"),
+			},
+		},
+		Entity: &Entity{
+			Type: aws.String("user"),
+			User: &User{
+				ID:    aws.String("W789CD012"),
+				Name:  aws.String("Dizzy Gillespie"),
+				Email: aws.String("dizzy@example.com"),
+				Team:  aws.String("T123AB456"),
+			},
+		},
+	}
+	event.SetCoreFields(TypeAuditLogs, (*timestamp.RFC3339)(&tm), event)
+	event.AppendAnyUsernames("Charlie Parker", "Dizzy Gillespie")
+	event.AppendAnyEmails("bird@example.com", "dizzy@example.com")
+
+	testutil.CheckPantherParser(t, log, &AuditLogParser{}, &event.PantherLog)
+}
+
+func TestAuditLogInvalid(t *testing.T) {
+	parser := (&AuditLogParser{}).New()
+	// missing entity
+	_, err := parser.Parse(`{"id":"0123a45b","date_create":1591037780,"action":"user_login","actor":{"type":"user"}}`)
+	require.Error(t, err)
+	// GitHub audit log
+	_, err = parser.Parse(`{"@timestamp":1591037780123,"_document_id":"xJJFlFOhQ6b","action":"repo.create","actor":"octocat"}`)
+	require.Error(t, err)
+	// GitLab audit log
+	// nolint:lll
+	_, err = parser.Parse(`{"severity":"INFO","time":"2020-06-01T18:56:20.123Z","author_id":1,"entity_id":2,"entity_type":"Project","change":"visibility","from":"Private","to":"Public","author_name":"Administrator","target_id":2,"target_type":"Project","target_details":"octo-org/octo-repo"}`)
+	require.Error(t, err)
+}
+
+func TestAuditLogLogType(t *testing.T) {
+	parser := &AuditLogParser{}
+	require.Equal(t, "Slack.AuditLogs", parser.LogType())
+}
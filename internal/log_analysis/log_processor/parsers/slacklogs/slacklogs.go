@@ -0,0 +1,41 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "Slack"
+	// TypeAuditLogs is the log type of Slack Enterprise Grid audit log entries
+	TypeAuditLogs = PantherPrefix + ".AuditLogs"
+)
+
+func init() {
+	logtypes.MustRegister(logtypes.Config{
+		Name:         TypeAuditLogs,
+		Description:  `Slack audit logs record the actions of users and apps in a Slack Enterprise Grid organization, as returned by the Audit Logs API.`,
+		ReferenceURL: `https://api.slack.com/admins/audit-logs`,
+		Schema:       AuditLog{},
+		NewParser:    parsers.AdapterFactory(&AuditLogParser{}),
+	})
+}
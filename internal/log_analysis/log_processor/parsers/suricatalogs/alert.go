@@ -0,0 +1,117 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+//nolint:lll
+type Alert struct {
+	Alert            *AlertDetails                `json:"alert" validate:"required,dive" description:"Suricata Alert Alert"`
+	AppProto         *string                      `json:"app_proto,omitempty" description:"Suricata Alert AppProto"`
+	CommunityID      *string                      `json:"community_id,omitempty" description:"Suricata Alert CommunityID"`
+	DestIP           *string                      `json:"dest_ip" validate:"required" description:"Suricata Alert DestIP"`
+	DestPort         *uint16                      `json:"dest_port,omitempty" description:"Suricata Alert DestPort"`
+	Email            *SMTPEmail                   `json:"email,omitempty" validate:"omitempty,dive" description:"Suricata Alert Email"`
+	EventType        *string                      `json:"event_type" validate:"required,eq=alert" description:"Suricata Alert EventType"`
+	FileInfo         *FileInfoDetails             `json:"fileinfo,omitempty" validate:"omitempty,dive" description:"Suricata Alert FileInfo"`
+	Flow             *FlowDetails                 `json:"flow,omitempty" validate:"omitempty,dive" description:"Suricata Alert Flow"`
+	FlowID           *int                         `json:"flow_id,omitempty" description:"Suricata Alert FlowID"`
+	HTTP             *HTTPDetails                 `json:"http,omitempty" validate:"omitempty,dive" description:"Suricata Alert HTTP"`
+	IcmpCode         *int                         `json:"icmp_code,omitempty" description:"Suricata Alert IcmpCode"`
+	IcmpType         *int                         `json:"icmp_type,omitempty" description:"Suricata Alert IcmpType"`
+	InIface          *string                      `json:"in_iface,omitempty" description:"Suricata Alert InIface"`
+	Packet           *string                      `json:"packet,omitempty" description:"Suricata Alert Packet"`
+	PacketInfo       *AnomalyPacketInfo           `json:"packet_info,omitempty" validate:"omitempty,dive" description:"Suricata Alert PacketInfo"`
+	Payload          *string                      `json:"payload,omitempty" description:"Suricata Alert Payload"`
+	PayloadPrintable *string                      `json:"payload_printable,omitempty" description:"Suricata Alert PayloadPrintable"`
+	PcapCnt          *int                         `json:"pcap_cnt,omitempty" description:"Suricata Alert PcapCnt"`
+	PcapFilename     *string                      `json:"pcap_filename,omitempty" description:"Suricata Alert PcapFilename"`
+	Proto            *numerics.Integer            `json:"proto" validate:"required" description:"Suricata Alert Proto"`
+	SMTP             *SMTPDetails                 `json:"smtp,omitempty" validate:"omitempty,dive" description:"Suricata Alert SMTP"`
+	SrcIP            *string                      `json:"src_ip" validate:"required" description:"Suricata Alert SrcIP"`
+	SrcPort          *uint16                      `json:"src_port,omitempty" description:"Suricata Alert SrcPort"`
+	Stream           *int                         `json:"stream,omitempty" description:"Suricata Alert Stream"`
+	Timestamp        *timestamp.SuricataTimestamp `json:"timestamp" validate:"required" description:"Suricata Alert Timestamp"`
+	TLS              *TLSDetails                  `json:"tls,omitempty" validate:"omitempty,dive" description:"Suricata Alert TLS"`
+	TxID             *int                         `json:"tx_id,omitempty" description:"Suricata Alert TxID"`
+	Vlan             []int                        `json:"vlan,omitempty" description:"Suricata Alert Vlan"`
+
+	parsers.PantherLog
+}
+
+//nolint:lll
+type AlertDetails struct {
+	Action      *string              `json:"action,omitempty" description:"Suricata AlertDetails Action"`
+	Category    *string              `json:"category,omitempty" description:"Suricata AlertDetails Category"`
+	GID         *int                 `json:"gid,omitempty" description:"Suricata AlertDetails GID"`
+	Metadata    *jsoniter.RawMessage `json:"metadata,omitempty" description:"Suricata AlertDetails Metadata"`
+	Rev         *int                 `json:"rev,omitempty" description:"Suricata AlertDetails Rev"`
+	Severity    *int                 `json:"severity,omitempty" description:"Suricata AlertDetails Severity"`
+	Signature   *string              `json:"signature,omitempty" description:"Suricata AlertDetails Signature"`
+	SignatureID *int                 `json:"signature_id,omitempty" description:"Suricata AlertDetails SignatureID"`
+}
+
+// AlertParser parses Suricata Alert events in the JSON format
+type AlertParser struct{}
+
+var _ parsers.LogParser = (*AlertParser)(nil)
+
+func (p *AlertParser) New() parsers.LogParser {
+	return &AlertParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AlertParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Alert{}
+
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AlertParser) LogType() string {
+	return TypeAlert
+}
+
+func (event *Alert) updatePantherFields(p *AlertParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.AppendAnyIPAddressPtr(event.SrcIP)
+	event.AppendAnyIPAddressPtr(event.DestIP)
+	// Alerts include the application layer metadata of the flow that triggered them
+	event.HTTP.appendAnyFields(&event.PantherLog)
+	event.TLS.appendAnyFields(&event.PantherLog)
+	event.SMTP.appendAnyFields(&event.PantherLog)
+	event.FileInfo.appendAnyFields(&event.PantherLog)
+}
@@ -0,0 +1,101 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAlert(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T06:31:07.153025+0000", "flow_id": 2045163405765417, "pcap_cnt": 229139, "event_type": "alert", "src_ip": "192.168.88.61", "src_port": 49159, "dest_ip": "31.3.245.133", "dest_port": 80, "proto": "006", "tx_id": 0, "alert": {"action": "allowed", "gid": 1, "signature_id": 2100498, "rev": 7, "signature": "GPL ATTACK_RESPONSE id check returned root", "category": "Potentially Bad Traffic", "severity": 2, "metadata": {"updated_at": ["2010_09_23"]}}, "http": {"hostname": "testmyids.com", "url": "/", "http_user_agent": "curl/7.58.0", "http_content_type": "text/html", "http_method": "GET", "protocol": "HTTP/1.1", "status": 200, "length": 39}, "app_proto": "http", "flow": {"pkts_toserver": 4, "pkts_toclient": 3, "bytes_toserver": 347, "bytes_toclient": 400, "start": "2015-10-22T06:31:07.046346+0000"}, "payload_printable": "uid=0(root) gid=0(root) groups=0(root)", "stream": 1, "pcap_filename": "/pcaps/4SICS-GeekLounge-151022.pcap"}`
+
+	expectedTime := time.Date(2015, 10, 22, 6, 31, 7, 153025000, time.UTC)
+	flowStart := time.Date(2015, 10, 22, 6, 31, 7, 46346000, time.UTC)
+	metadata := []byte(`{"updated_at": ["2010_09_23"]}`)
+	expectedEvent := &Alert{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(2045163405765417),
+		PcapCnt:   aws.Int(229139),
+		EventType: aws.String("alert"),
+		SrcIP:     aws.String("192.168.88.61"),
+		SrcPort:   aws.Uint16(49159),
+		DestIP:    aws.String("31.3.245.133"),
+		DestPort:  aws.Uint16(80),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		TxID:      aws.Int(0),
+		Alert: &AlertDetails{
+			Action:      aws.String("allowed"),
+			GID:         aws.Int(1),
+			SignatureID: aws.Int(2100498),
+			Rev:         aws.Int(7),
+			Signature:   aws.String("GPL ATTACK_RESPONSE id check returned root"),
+			Category:    aws.String("Potentially Bad Traffic"),
+			Severity:    aws.Int(2),
+			Metadata:    (*jsoniter.RawMessage)(&metadata),
+		},
+		HTTP: &HTTPDetails{
+			Hostname:        aws.String("testmyids.com"),
+			URL:             aws.String("/"),
+			HTTPUserAgent:   aws.String("curl/7.58.0"),
+			HTTPContentType: aws.String("text/html"),
+			HTTPMethod:      aws.String("GET"),
+			Protocol:        aws.String("HTTP/1.1"),
+			Status:          aws.Int(200),
+			Length:          aws.Int(39),
+		},
+		AppProto: aws.String("http"),
+		Flow: &FlowDetails{
+			PktsToServer:  aws.Int(4),
+			PktsToClient:  aws.Int(3),
+			BytesToServer: aws.Int(347),
+			BytesToClient: aws.Int(400),
+			Start:         (*timestamp.SuricataTimestamp)(&flowStart),
+		},
+		PayloadPrintable: aws.String("uid=0(root) gid=0(root) groups=0(root)"),
+		Stream:           aws.Int(1),
+		PcapFilename:     aws.String("/pcaps/4SICS-GeekLounge-151022.pcap"),
+	}
+	expectedEvent.SetCoreFields("Suricata.Alert", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("192.168.88.61")
+	expectedEvent.AppendAnyIPAddress("31.3.245.133")
+	expectedEvent.AppendAnyDomainNames("testmyids.com")
+
+	parser := (&AlertParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+
+	// Alerts embed application layer metadata, make sure they are not classified as HTTP events
+	_, err = (&HTTPParser{}).New().Parse(log)
+	require.Error(t, err)
+}
+
+func TestAlertType(t *testing.T) {
+	parser := &AlertParser{}
+	require.Equal(t, "Suricata.Alert", parser.LogType())
+}
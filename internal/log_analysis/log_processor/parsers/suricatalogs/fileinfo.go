@@ -0,0 +1,117 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+//nolint:lll
+type FileInfo struct {
+	AppProto     *string                      `json:"app_proto,omitempty" description:"Suricata FileInfo AppProto"`
+	CommunityID  *string                      `json:"community_id,omitempty" description:"Suricata FileInfo CommunityID"`
+	DestIP       *string                      `json:"dest_ip" validate:"required" description:"Suricata FileInfo DestIP"`
+	DestPort     *uint16                      `json:"dest_port,omitempty" description:"Suricata FileInfo DestPort"`
+	Email        *SMTPEmail                   `json:"email,omitempty" validate:"omitempty,dive" description:"Suricata FileInfo Email"`
+	EventType    *string                      `json:"event_type" validate:"required,eq=fileinfo" description:"Suricata FileInfo EventType"`
+	FileInfo     *FileInfoDetails             `json:"fileinfo" validate:"required,dive" description:"Suricata FileInfo FileInfo"`
+	FlowID       *int                         `json:"flow_id,omitempty" description:"Suricata FileInfo FlowID"`
+	HTTP         *HTTPDetails                 `json:"http,omitempty" validate:"omitempty,dive" description:"Suricata FileInfo HTTP"`
+	InIface      *string                      `json:"in_iface,omitempty" description:"Suricata FileInfo InIface"`
+	PcapCnt      *int                         `json:"pcap_cnt,omitempty" description:"Suricata FileInfo PcapCnt"`
+	PcapFilename *string                      `json:"pcap_filename,omitempty" description:"Suricata FileInfo PcapFilename"`
+	Proto        *numerics.Integer            `json:"proto" validate:"required" description:"Suricata FileInfo Proto"`
+	SMTP         *SMTPDetails                 `json:"smtp,omitempty" validate:"omitempty,dive" description:"Suricata FileInfo SMTP"`
+	SrcIP        *string                      `json:"src_ip" validate:"required" description:"Suricata FileInfo SrcIP"`
+	SrcPort      *uint16                      `json:"src_port,omitempty" description:"Suricata FileInfo SrcPort"`
+	Timestamp    *timestamp.SuricataTimestamp `json:"timestamp" validate:"required" description:"Suricata FileInfo Timestamp"`
+	Vlan         []int                        `json:"vlan,omitempty" description:"Suricata FileInfo Vlan"`
+
+	parsers.PantherLog
+}
+
+//nolint:lll
+type FileInfoDetails struct {
+	FileID   *int    `json:"file_id,omitempty" description:"Suricata FileInfoDetails FileID"`
+	Filename *string `json:"filename,omitempty" description:"Suricata FileInfoDetails Filename"`
+	Gaps     *bool   `json:"gaps,omitempty" description:"Suricata FileInfoDetails Gaps"`
+	Magic    *string `json:"magic,omitempty" description:"Suricata FileInfoDetails Magic"`
+	MD5      *string `json:"md5,omitempty" description:"Suricata FileInfoDetails MD5"`
+	SHA1     *string `json:"sha1,omitempty" description:"Suricata FileInfoDetails SHA1"`
+	SHA256   *string `json:"sha256,omitempty" description:"Suricata FileInfoDetails SHA256"`
+	Sid      []int   `json:"sid,omitempty" description:"Suricata FileInfoDetails Sid"`
+	Size     *int    `json:"size,omitempty" description:"Suricata FileInfoDetails Size"`
+	State    *string `json:"state,omitempty" description:"Suricata FileInfoDetails State"`
+	Stored   *bool   `json:"stored,omitempty" description:"Suricata FileInfoDetails Stored"`
+	TxID     *int    `json:"tx_id,omitempty" description:"Suricata FileInfoDetails TxID"`
+}
+
+// FileInfoParser parses Suricata FileInfo events in the JSON format
+type FileInfoParser struct{}
+
+var _ parsers.LogParser = (*FileInfoParser)(nil)
+
+func (p *FileInfoParser) New() parsers.LogParser {
+	return &FileInfoParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *FileInfoParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &FileInfo{}
+
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *FileInfoParser) LogType() string {
+	return TypeFileInfo
+}
+
+func (event *FileInfo) updatePantherFields(p *FileInfoParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.AppendAnyIPAddressPtr(event.SrcIP)
+	event.AppendAnyIPAddressPtr(event.DestIP)
+	event.FileInfo.appendAnyFields(&event.PantherLog)
+	event.HTTP.appendAnyFields(&event.PantherLog)
+	event.SMTP.appendAnyFields(&event.PantherLog)
+}
+
+func (details *FileInfoDetails) appendAnyFields(event *parsers.PantherLog) {
+	if details == nil {
+		return
+	}
+	event.AppendAnyMD5HashPtrs(details.MD5)
+	event.AppendAnySHA1HashPtrs(details.SHA1)
+	event.AppendAnySHA256HashesPtr(details.SHA256)
+}
@@ -0,0 +1,87 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestFileInfo(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T06:31:07.153025+0000", "flow_id": 2045163405765417, "pcap_cnt": 229140, "event_type": "fileinfo", "src_ip": "31.3.245.133", "src_port": 80, "dest_ip": "192.168.88.61", "dest_port": 49159, "proto": "006", "http": {"hostname": "testmyids.com", "url": "/", "http_method": "GET", "protocol": "HTTP/1.1", "status": 200, "length": 39}, "app_proto": "http", "fileinfo": {"filename": "/", "magic": "ASCII text", "gaps": false, "state": "CLOSED", "md5": "2a61cd6c5ad5f5c3bb1d0d5d4a8d2f52", "sha1": "e6f0a9a5f0e9e5a7c4e5df1f4a0d1be27b6e8f0d", "sha256": "3f0e9c7d1c4a2d9ebf4f8f1d57c2fd4d6a2c8b61ab2a7a0b53b0c4b9a3bc1e2d", "stored": false, "size": 39, "tx_id": 0}, "pcap_filename": "/pcaps/4SICS-GeekLounge-151022.pcap"}`
+
+	expectedTime := time.Date(2015, 10, 22, 6, 31, 7, 153025000, time.UTC)
+	expectedEvent := &FileInfo{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(2045163405765417),
+		PcapCnt:   aws.Int(229140),
+		EventType: aws.String("fileinfo"),
+		SrcIP:     aws.String("31.3.245.133"),
+		SrcPort:   aws.Uint16(80),
+		DestIP:    aws.String("192.168.88.61"),
+		DestPort:  aws.Uint16(49159),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		HTTP: &HTTPDetails{
+			Hostname:   aws.String("testmyids.com"),
+			URL:        aws.String("/"),
+			HTTPMethod: aws.String("GET"),
+			Protocol:   aws.String("HTTP/1.1"),
+			Status:     aws.Int(200),
+			Length:     aws.Int(39),
+		},
+		AppProto: aws.String("http"),
+		FileInfo: &FileInfoDetails{
+			Filename: aws.String("/"),
+			Magic:    aws.String("ASCII text"),
+			Gaps:     aws.Bool(false),
+			State:    aws.String("CLOSED"),
+			MD5:      aws.String("2a61cd6c5ad5f5c3bb1d0d5d4a8d2f52"),
+			SHA1:     aws.String("e6f0a9a5f0e9e5a7c4e5df1f4a0d1be27b6e8f0d"),
+			SHA256:   aws.String("3f0e9c7d1c4a2d9ebf4f8f1d57c2fd4d6a2c8b61ab2a7a0b53b0c4b9a3bc1e2d"),
+			Stored:   aws.Bool(false),
+			Size:     aws.Int(39),
+			TxID:     aws.Int(0),
+		},
+		PcapFilename: aws.String("/pcaps/4SICS-GeekLounge-151022.pcap"),
+	}
+	expectedEvent.SetCoreFields("Suricata.FileInfo", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("31.3.245.133")
+	expectedEvent.AppendAnyIPAddress("192.168.88.61")
+	expectedEvent.AppendAnyDomainNames("testmyids.com")
+	expectedEvent.AppendAnyMD5Hashes("2a61cd6c5ad5f5c3bb1d0d5d4a8d2f52")
+	expectedEvent.AppendAnySHA1Hashes("e6f0a9a5f0e9e5a7c4e5df1f4a0d1be27b6e8f0d")
+	expectedEvent.AppendAnySHA256Hashes("3f0e9c7d1c4a2d9ebf4f8f1d57c2fd4d6a2c8b61ab2a7a0b53b0c4b9a3bc1e2d")
+
+	parser := (&FileInfoParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func TestFileInfoType(t *testing.T) {
+	parser := &FileInfoParser{}
+	require.Equal(t, "Suricata.FileInfo", parser.LogType())
+}
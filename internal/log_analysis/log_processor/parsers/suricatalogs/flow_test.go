@@ -0,0 +1,86 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestFlow(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T06:32:11.043422+0000", "flow_id": 1311290432617337, "event_type": "flow", "src_ip": "192.168.88.61", "src_port": 49160, "dest_ip": "31.3.245.133", "dest_port": 80, "proto": "006", "app_proto": "http", "flow": {"pkts_toserver": 4, "pkts_toclient": 3, "bytes_toserver": 347, "bytes_toclient": 400, "start": "2015-10-22T06:31:07.046346+0000", "end": "2015-10-22T06:31:07.153114+0000", "age": 0, "state": "closed", "reason": "timeout", "alerted": true}, "tcp": {"tcp_flags": "1b", "tcp_flags_ts": "1b", "tcp_flags_tc": "1b", "syn": true, "fin": true, "psh": true, "ack": true, "state": "closed"}, "pcap_filename": "/pcaps/4SICS-GeekLounge-151022.pcap"}`
+
+	expectedTime := time.Date(2015, 10, 22, 6, 32, 11, 43422000, time.UTC)
+	flowStart := time.Date(2015, 10, 22, 6, 31, 7, 46346000, time.UTC)
+	flowEnd := time.Date(2015, 10, 22, 6, 31, 7, 153114000, time.UTC)
+	expectedEvent := &Flow{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(1311290432617337),
+		EventType: aws.String("flow"),
+		SrcIP:     aws.String("192.168.88.61"),
+		SrcPort:   aws.Uint16(49160),
+		DestIP:    aws.String("31.3.245.133"),
+		DestPort:  aws.Uint16(80),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		AppProto:  aws.String("http"),
+		Flow: &FlowDetails{
+			PktsToServer:  aws.Int(4),
+			PktsToClient:  aws.Int(3),
+			BytesToServer: aws.Int(347),
+			BytesToClient: aws.Int(400),
+			Start:         (*timestamp.SuricataTimestamp)(&flowStart),
+			End:           (*timestamp.SuricataTimestamp)(&flowEnd),
+			Age:           aws.Int(0),
+			State:         aws.String("closed"),
+			Reason:        aws.String("timeout"),
+			Alerted:       aws.Bool(true),
+		},
+		TCP: &FlowTCP{
+			TCPFlags:   aws.String("1b"),
+			TCPFlagsTs: aws.String("1b"),
+			TCPFlagsTc: aws.String("1b"),
+			Syn:        aws.Bool(true),
+			Fin:        aws.Bool(true),
+			Psh:        aws.Bool(true),
+			Ack:        aws.Bool(true),
+			State:      aws.String("closed"),
+		},
+		PcapFilename: aws.String("/pcaps/4SICS-GeekLounge-151022.pcap"),
+	}
+	expectedEvent.SetCoreFields("Suricata.Flow", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("192.168.88.61")
+	expectedEvent.AppendAnyIPAddress("31.3.245.133")
+
+	parser := (&FlowParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func TestFlowType(t *testing.T) {
+	parser := &FlowParser{}
+	require.Equal(t, "Suricata.Flow", parser.LogType())
+}
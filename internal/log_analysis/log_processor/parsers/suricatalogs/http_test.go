@@ -0,0 +1,116 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestHTTP(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T06:31:07.153025+0000", "flow_id": 2045163405765417, "pcap_cnt": 229139, "event_type": "http", "src_ip": "192.168.88.61", "src_port": 49159, "dest_ip": "31.3.245.133", "dest_port": 80, "proto": "006", "tx_id": 0, "http": {"hostname": "testmyids.com", "url": "/", "http_user_agent": "curl/7.58.0", "http_content_type": "text/html", "xff": "10.1.1.1, 10.2.2.2", "http_method": "GET", "protocol": "HTTP/1.1", "status": 200, "length": 39, "request_headers": [{"name": "Host", "value": "testmyids.com"}]}, "pcap_filename": "/pcaps/4SICS-GeekLounge-151022.pcap"}`
+
+	expectedTime := time.Date(2015, 10, 22, 6, 31, 7, 153025000, time.UTC)
+	expectedEvent := &HTTP{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(2045163405765417),
+		PcapCnt:   aws.Int(229139),
+		EventType: aws.String("http"),
+		SrcIP:     aws.String("192.168.88.61"),
+		SrcPort:   aws.Uint16(49159),
+		DestIP:    aws.String("31.3.245.133"),
+		DestPort:  aws.Uint16(80),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		TxID:      aws.Int(0),
+		HTTP: &HTTPDetails{
+			Hostname:        aws.String("testmyids.com"),
+			URL:             aws.String("/"),
+			HTTPUserAgent:   aws.String("curl/7.58.0"),
+			HTTPContentType: aws.String("text/html"),
+			XFF:             aws.String("10.1.1.1, 10.2.2.2"),
+			HTTPMethod:      aws.String("GET"),
+			Protocol:        aws.String("HTTP/1.1"),
+			Status:          aws.Int(200),
+			Length:          aws.Int(39),
+			RequestHeaders: []HTTPDetailsHeader{
+				{Name: aws.String("Host"), Value: aws.String("testmyids.com")},
+			},
+		},
+		PcapFilename: aws.String("/pcaps/4SICS-GeekLounge-151022.pcap"),
+	}
+	expectedEvent.SetCoreFields("Suricata.HTTP", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("192.168.88.61")
+	expectedEvent.AppendAnyIPAddress("31.3.245.133")
+	expectedEvent.AppendAnyIPAddress("10.1.1.1")
+	expectedEvent.AppendAnyIPAddress("10.2.2.2")
+	expectedEvent.AppendAnyDomainNames("testmyids.com")
+
+	parser := (&HTTPParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func TestHTTPIPv6(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T06:31:07.153025+0000", "flow_id": 2045163405765417, "event_type": "http", "src_ip": "2001:0db8:0000:0000:0000:0000:0000:0061", "src_port": 49159, "dest_ip": "2001:0db8:0000:0000:0000:0000:0000:0133", "dest_port": 80, "proto": "006", "tx_id": 0, "http": {"hostname": "testmyids.com", "url": "/", "xff": "2001:db8::1:1, 10.2.2.2", "http_method": "GET", "status": 200, "length": 39}}`
+
+	expectedTime := time.Date(2015, 10, 22, 6, 31, 7, 153025000, time.UTC)
+	expectedEvent := &HTTP{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(2045163405765417),
+		EventType: aws.String("http"),
+		SrcIP:     aws.String("2001:0db8:0000:0000:0000:0000:0000:0061"),
+		SrcPort:   aws.Uint16(49159),
+		DestIP:    aws.String("2001:0db8:0000:0000:0000:0000:0000:0133"),
+		DestPort:  aws.Uint16(80),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		TxID:      aws.Int(0),
+		HTTP: &HTTPDetails{
+			Hostname:   aws.String("testmyids.com"),
+			URL:        aws.String("/"),
+			XFF:        aws.String("2001:db8::1:1, 10.2.2.2"),
+			HTTPMethod: aws.String("GET"),
+			Status:     aws.Int(200),
+			Length:     aws.Int(39),
+		},
+	}
+	expectedEvent.SetCoreFields("Suricata.HTTP", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("2001:0db8:0000:0000:0000:0000:0000:0061")
+	expectedEvent.AppendAnyIPAddress("2001:0db8:0000:0000:0000:0000:0000:0133")
+	expectedEvent.AppendAnyIPAddress("2001:db8::1:1")
+	expectedEvent.AppendAnyIPAddress("10.2.2.2")
+	expectedEvent.AppendAnyDomainNames("testmyids.com")
+
+	parser := (&HTTPParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func TestHTTPType(t *testing.T) {
+	parser := &HTTPParser{}
+	require.Equal(t, "Suricata.HTTP", parser.LogType())
+}
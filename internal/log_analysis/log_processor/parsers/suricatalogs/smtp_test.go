@@ -0,0 +1,75 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSMTP(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T08:12:53.514437+0000", "flow_id": 1399541138329036, "pcap_cnt": 471436, "event_type": "smtp", "src_ip": "192.168.88.25", "src_port": 51318, "dest_ip": "192.168.2.22", "dest_port": 25, "proto": "006", "tx_id": 0, "smtp": {"helo": "[192.168.88.25]", "mail_from": "<alice@example.com>", "rcpt_to": ["<bob@example.com>"]}, "email": {"status": "PARSE_DONE", "from": "Alice <alice@example.com>", "to": ["bob@example.com"], "subject": "Quarterly report", "attachment": ["report.pdf"]}, "pcap_filename": "/pcaps/4SICS-GeekLounge-151022.pcap"}`
+
+	expectedTime := time.Date(2015, 10, 22, 8, 12, 53, 514437000, time.UTC)
+	expectedEvent := &SMTP{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(1399541138329036),
+		PcapCnt:   aws.Int(471436),
+		EventType: aws.String("smtp"),
+		SrcIP:     aws.String("192.168.88.25"),
+		SrcPort:   aws.Uint16(51318),
+		DestIP:    aws.String("192.168.2.22"),
+		DestPort:  aws.Uint16(25),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		TxID:      aws.Int(0),
+		SMTP: &SMTPDetails{
+			Helo:     aws.String("[192.168.88.25]"),
+			MailFrom: aws.String("<alice@example.com>"),
+			RcptTo:   []string{"<bob@example.com>"},
+		},
+		Email: &SMTPEmail{
+			Status:     aws.String("PARSE_DONE"),
+			From:       aws.String("Alice <alice@example.com>"),
+			To:         []string{"bob@example.com"},
+			Subject:    aws.String("Quarterly report"),
+			Attachment: []string{"report.pdf"},
+		},
+		PcapFilename: aws.String("/pcaps/4SICS-GeekLounge-151022.pcap"),
+	}
+	expectedEvent.SetCoreFields("Suricata.SMTP", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("192.168.88.25")
+	expectedEvent.AppendAnyIPAddress("192.168.2.22")
+
+	parser := (&SMTPParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func TestSMTPType(t *testing.T) {
+	parser := &SMTPParser{}
+	require.Equal(t, "Suricata.SMTP", parser.LogType())
+}
@@ -24,8 +24,14 @@ import (
 )
 
 const (
-	TypeDNS     = "Suricata.DNS"
-	TypeAnomaly = "Suricata.Anomaly"
+	TypeAlert    = "Suricata.Alert"
+	TypeAnomaly  = "Suricata.Anomaly"
+	TypeDNS      = "Suricata.DNS"
+	TypeFileInfo = "Suricata.FileInfo"
+	TypeFlow     = "Suricata.Flow"
+	TypeHTTP     = "Suricata.HTTP"
+	TypeSMTP     = "Suricata.SMTP"
+	TypeTLS      = "Suricata.TLS"
 )
 
 func init() {
@@ -44,5 +50,47 @@ func init() {
 			Schema:       DNS{},
 			NewParser:    parsers.AdapterFactory(&DNSParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeAlert,
+			Description:  `Suricata parser for the Alert event type in the EVE JSON output.`,
+			ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-alert`,
+			Schema:       Alert{},
+			NewParser:    parsers.AdapterFactory(&AlertParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeHTTP,
+			Description:  `Suricata parser for the HTTP event type in the EVE JSON output.`,
+			ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-http`,
+			Schema:       HTTP{},
+			NewParser:    parsers.AdapterFactory(&HTTPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeTLS,
+			Description:  `Suricata parser for the TLS event type in the EVE JSON output.`,
+			ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-tls`,
+			Schema:       TLS{},
+			NewParser:    parsers.AdapterFactory(&TLSParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeFlow,
+			Description:  `Suricata parser for the Flow event type in the EVE JSON output.`,
+			ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-flow`,
+			Schema:       Flow{},
+			NewParser:    parsers.AdapterFactory(&FlowParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeFileInfo,
+			Description:  `Suricata parser for the FileInfo event type in the EVE JSON output.`,
+			ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-fileinfo`,
+			Schema:       FileInfo{},
+			NewParser:    parsers.AdapterFactory(&FileInfoParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeSMTP,
+			Description:  `Suricata parser for the SMTP event type in the EVE JSON output.`,
+			ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-smtp`,
+			Schema:       SMTP{},
+			NewParser:    parsers.AdapterFactory(&SMTPParser{}),
+		},
 	)
 }
@@ -0,0 +1,117 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+//nolint:lll
+type TLS struct {
+	AppProto     *string                      `json:"app_proto,omitempty" description:"Suricata TLS AppProto"`
+	CommunityID  *string                      `json:"community_id,omitempty" description:"Suricata TLS CommunityID"`
+	DestIP       *string                      `json:"dest_ip" validate:"required" description:"Suricata TLS DestIP"`
+	DestPort     *uint16                      `json:"dest_port,omitempty" description:"Suricata TLS DestPort"`
+	EventType    *string                      `json:"event_type" validate:"required,eq=tls" description:"Suricata TLS EventType"`
+	FlowID       *int                         `json:"flow_id,omitempty" description:"Suricata TLS FlowID"`
+	InIface      *string                      `json:"in_iface,omitempty" description:"Suricata TLS InIface"`
+	PcapCnt      *int                         `json:"pcap_cnt,omitempty" description:"Suricata TLS PcapCnt"`
+	PcapFilename *string                      `json:"pcap_filename,omitempty" description:"Suricata TLS PcapFilename"`
+	Proto        *numerics.Integer            `json:"proto" validate:"required" description:"Suricata TLS Proto"`
+	SrcIP        *string                      `json:"src_ip" validate:"required" description:"Suricata TLS SrcIP"`
+	SrcPort      *uint16                      `json:"src_port,omitempty" description:"Suricata TLS SrcPort"`
+	Timestamp    *timestamp.SuricataTimestamp `json:"timestamp" validate:"required" description:"Suricata TLS Timestamp"`
+	TLS          *TLSDetails                  `json:"tls" validate:"required,dive" description:"Suricata TLS TLS"`
+	Vlan         []int                        `json:"vlan,omitempty" description:"Suricata TLS Vlan"`
+
+	parsers.PantherLog
+}
+
+//nolint:lll
+type TLSDetails struct {
+	Certificate    *string        `json:"certificate,omitempty" description:"Suricata TLSDetails Certificate"`
+	Chain          []string       `json:"chain,omitempty" description:"Suricata TLSDetails Chain"`
+	Fingerprint    *string        `json:"fingerprint,omitempty" description:"Suricata TLSDetails Fingerprint"`
+	IssuerDN       *string        `json:"issuerdn,omitempty" description:"Suricata TLSDetails IssuerDN"`
+	JA3            *TLSDetailsJA3 `json:"ja3,omitempty" validate:"omitempty,dive" description:"Suricata TLSDetails JA3"`
+	JA3S           *TLSDetailsJA3 `json:"ja3s,omitempty" validate:"omitempty,dive" description:"Suricata TLSDetails JA3S"`
+	NotAfter       *string        `json:"notafter,omitempty" description:"Suricata TLSDetails NotAfter"`
+	NotBefore      *string        `json:"notbefore,omitempty" description:"Suricata TLSDetails NotBefore"`
+	Serial         *string        `json:"serial,omitempty" description:"Suricata TLSDetails Serial"`
+	SessionResumed *bool          `json:"session_resumed,omitempty" description:"Suricata TLSDetails SessionResumed"`
+	SNI            *string        `json:"sni,omitempty" description:"Suricata TLSDetails SNI"`
+	Subject        *string        `json:"subject,omitempty" description:"Suricata TLSDetails Subject"`
+	Version        *string        `json:"version,omitempty" description:"Suricata TLSDetails Version"`
+}
+
+//nolint:lll
+type TLSDetailsJA3 struct {
+	Hash   *string `json:"hash,omitempty" description:"Suricata TLSDetailsJA3 Hash"`
+	String *string `json:"string,omitempty" description:"Suricata TLSDetailsJA3 String"`
+}
+
+// TLSParser parses Suricata TLS events in the JSON format
+type TLSParser struct{}
+
+var _ parsers.LogParser = (*TLSParser)(nil)
+
+func (p *TLSParser) New() parsers.LogParser {
+	return &TLSParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *TLSParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &TLS{}
+
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *TLSParser) LogType() string {
+	return TypeTLS
+}
+
+func (event *TLS) updatePantherFields(p *TLSParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.AppendAnyIPAddressPtr(event.SrcIP)
+	event.AppendAnyIPAddressPtr(event.DestIP)
+	event.TLS.appendAnyFields(&event.PantherLog)
+}
+
+func (details *TLSDetails) appendAnyFields(event *parsers.PantherLog) {
+	if details == nil {
+		return
+	}
+	event.AppendAnyDomainNamePtrs(details.SNI)
+}
@@ -0,0 +1,77 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestTLS(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp": "2015-10-22T07:17:47.416387+0000", "flow_id": 980913389386548, "pcap_cnt": 355049, "event_type": "tls", "src_ip": "192.168.89.2", "src_port": 52142, "dest_ip": "104.16.51.111", "dest_port": 443, "proto": "006", "tls": {"subject": "CN=ssl2000.cloudflare.com", "issuerdn": "C=GB, ST=Greater Manchester, L=Salford, O=COMODO CA Limited, CN=COMODO ECC Domain Validation Secure Server CA 2", "serial": "00:AB:C4", "fingerprint": "f4:35:2a:9c:96:bc:28:e2:63:9e:63:3e:bc:4b:8b:48:8b:4a:d1:a9", "sni": "www.example.com", "version": "TLS 1.2", "notbefore": "2015-09-04T00:00:00", "notafter": "2016-03-06T23:59:59", "ja3": {"hash": "e7eca2baf4458d095b7f45da28c16c34", "string": "771,49195-49199,0-23-65281,29-23-24,0"}}, "pcap_filename": "/pcaps/4SICS-GeekLounge-151022.pcap"}`
+
+	expectedTime := time.Date(2015, 10, 22, 7, 17, 47, 416387000, time.UTC)
+	expectedEvent := &TLS{
+		Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+		FlowID:    aws.Int(980913389386548),
+		PcapCnt:   aws.Int(355049),
+		EventType: aws.String("tls"),
+		SrcIP:     aws.String("192.168.89.2"),
+		SrcPort:   aws.Uint16(52142),
+		DestIP:    aws.String("104.16.51.111"),
+		DestPort:  aws.Uint16(443),
+		Proto:     (*numerics.Integer)(aws.Int(6)),
+		TLS: &TLSDetails{
+			Subject:     aws.String("CN=ssl2000.cloudflare.com"),
+			IssuerDN:    aws.String("C=GB, ST=Greater Manchester, L=Salford, O=COMODO CA Limited, CN=COMODO ECC Domain Validation Secure Server CA 2"),
+			Serial:      aws.String("00:AB:C4"),
+			Fingerprint: aws.String("f4:35:2a:9c:96:bc:28:e2:63:9e:63:3e:bc:4b:8b:48:8b:4a:d1:a9"),
+			SNI:         aws.String("www.example.com"),
+			Version:     aws.String("TLS 1.2"),
+			NotBefore:   aws.String("2015-09-04T00:00:00"),
+			NotAfter:    aws.String("2016-03-06T23:59:59"),
+			JA3: &TLSDetailsJA3{
+				Hash:   aws.String("e7eca2baf4458d095b7f45da28c16c34"),
+				String: aws.String("771,49195-49199,0-23-65281,29-23-24,0"),
+			},
+		},
+		PcapFilename: aws.String("/pcaps/4SICS-GeekLounge-151022.pcap"),
+	}
+	expectedEvent.SetCoreFields("Suricata.TLS", (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("192.168.89.2")
+	expectedEvent.AppendAnyIPAddress("104.16.51.111")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	parser := (&TLSParser{}).New()
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func TestTLSType(t *testing.T) {
+	parser := &TLSParser{}
+	require.Equal(t, "Suricata.TLS", parser.LogType())
+}
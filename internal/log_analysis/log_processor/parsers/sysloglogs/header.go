@@ -0,0 +1,152 @@
+package sysloglogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// Header is the syslog header of a message that embeds a structured payload (ie. CEF or LEEF events)
+// nolint:lll
+type Header struct {
+	Priority  *uint8             `json:"priority,omitempty" description:"Priority is calculated by (Facility * 8 + Severity). The lower this value, the higher importance of the log message."`
+	Facility  *uint8             `json:"facility,omitempty" description:"Facility value helps determine which process created the message. Eg: 0 = kernel messages, 3 = system daemons."`
+	Severity  *uint8             `json:"severity,omitempty" description:"Severity indicates how severe the message is. Eg: 0=Emergency to 7=Debug."`
+	Timestamp *timestamp.RFC3339 `json:"timestamp,omitempty" description:"Timestamp of the syslog message in UTC."`
+	Hostname  *string            `json:"hostname,omitempty" description:"Hostname identifies the machine that originally sent the syslog message."`
+	Appname   *string            `json:"appname,omitempty" description:"Appname identifies the device or application that originated the syslog message."`
+	ProcID    *string            `json:"procid,omitempty" description:"ProcID is often the process ID, but can be any value used to enable log analyzers to detect discontinuities in syslog reporting."`
+	MsgID     *string            `json:"msgid,omitempty" description:"MsgID identifies the type of message. For example, a firewall might use the MsgID 'TCPIN' for incoming TCP traffic."`
+}
+
+// HeaderParser parses the RFC3164 or RFC5424 syslog header in front of an embedded payload
+type HeaderParser struct {
+	rfc3164 syslog.Machine
+	rfc5424 syslog.Machine
+}
+
+// rfc5424HeaderRegex matches the priority and version that start RFC5424 messages
+var rfc5424HeaderRegex = regexp.MustCompile(`^<\d{1,3}>\d{1,2} `)
+
+const (
+	// The syslog parsers expect a message after the header
+	headerPlaceholderMessage = "-"
+	// Syslog daemons writing to files drop the priority, a default one is used to parse the header
+	headerDefaultPriority = "<13>"
+)
+
+// NewHeaderParser returns an initialized syslog header parser
+func NewHeaderParser() *HeaderParser {
+	return &HeaderParser{
+		rfc3164: rfc3164.NewParser(
+			rfc3164.WithBestEffort(),
+			rfc3164.WithTimezone(time.UTC),
+			rfc3164.WithYear(rfc3164.CurrentYear{}),
+			rfc3164.WithRFC3339(),
+		),
+		rfc5424: rfc5424.NewParser(rfc5424.WithBestEffort()),
+	}
+}
+
+// Split splits a log line into the syslog header and the payload starting with marker.
+// Lines that start with the marker have no syslog header and a nil Header is returned.
+func (p *HeaderParser) Split(log, marker string) (*Header, string, error) {
+	pos := markerIndex(log, marker)
+	if pos == -1 {
+		return nil, "", errors.Errorf("%q not found in log line", marker)
+	}
+	payload := log[pos:]
+	if pos == 0 {
+		return nil, payload, nil
+	}
+	header, err := p.Parse(log[:pos])
+	if err != nil {
+		return nil, "", err
+	}
+	return header, payload, nil
+}
+
+// Parse parses a syslog header
+func (p *HeaderParser) Parse(header string) (*Header, error) {
+	if rfc5424HeaderRegex.MatchString(header) {
+		msg, err := p.rfc5424.Parse([]byte(header + headerPlaceholderMessage))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid RFC5424 syslog header")
+		}
+		m := msg.(*rfc5424.SyslogMessage)
+		return &Header{
+			Priority:  m.Priority,
+			Facility:  m.Facility,
+			Severity:  m.Severity,
+			Timestamp: (*timestamp.RFC3339)(m.Timestamp),
+			Hostname:  m.Hostname,
+			Appname:   m.Appname,
+			ProcID:    m.ProcID,
+			MsgID:     m.MsgID,
+		}, nil
+	}
+
+	hasPriority := strings.HasPrefix(header, "<")
+	if !hasPriority {
+		header = headerDefaultPriority + header
+	}
+	msg, err := p.rfc3164.Parse([]byte(header + headerPlaceholderMessage))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid RFC3164 syslog header")
+	}
+	m := msg.(*rfc3164.SyslogMessage)
+	result := &Header{
+		Timestamp: (*timestamp.RFC3339)(m.Timestamp),
+		Hostname:  m.Hostname,
+		Appname:   m.Appname,
+		ProcID:    m.ProcID,
+		MsgID:     m.MsgID,
+	}
+	if hasPriority {
+		result.Priority = m.Priority
+		result.Facility = m.Facility
+		result.Severity = m.Severity
+	}
+	return result, nil
+}
+
+// markerIndex finds the first occurrence of marker at the start of the line or after a space
+func markerIndex(log, marker string) int {
+	offset := 0
+	for {
+		pos := strings.Index(log[offset:], marker)
+		if pos == -1 {
+			return -1
+		}
+		pos += offset
+		if pos == 0 || log[pos-1] == ' ' {
+			return pos
+		}
+		offset = pos + len(marker)
+	}
+}
@@ -0,0 +1,113 @@
+package sysloglogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestHeaderParserSplit(t *testing.T) {
+	year := time.Now().UTC().Year()
+	rfc3164Time := time.Date(year, 2, 14, 19, 4, 54, 0, time.UTC)
+	rfc5424Time := time.Date(2020, 6, 1, 15, 9, 42, 0, time.UTC)
+	for _, tc := range []struct {
+		log     string
+		marker  string
+		header  *Header
+		payload string
+	}{
+		{
+			log:     `CEF:0|Vendor|Product|1.0|100|name|1|src=10.0.0.1`,
+			marker:  "CEF:",
+			payload: `CEF:0|Vendor|Product|1.0|100|name|1|src=10.0.0.1`,
+		},
+		{
+			log:    `<134>Feb 14 19:04:54 fw01 CEF:0|Vendor|Product|1.0|100|name|1|`,
+			marker: "CEF:",
+			header: &Header{
+				Priority:  aws.Uint8(134),
+				Facility:  aws.Uint8(16),
+				Severity:  aws.Uint8(6),
+				Timestamp: (*timestamp.RFC3339)(&rfc3164Time),
+				Hostname:  aws.String("fw01"),
+			},
+			payload: `CEF:0|Vendor|Product|1.0|100|name|1|`,
+		},
+		{
+			log:    `<134>Feb 14 19:04:54 fw01 app[42]: CEF:0|Vendor|Product|1.0|100|name|1|`,
+			marker: "CEF:",
+			header: &Header{
+				Priority:  aws.Uint8(134),
+				Facility:  aws.Uint8(16),
+				Severity:  aws.Uint8(6),
+				Timestamp: (*timestamp.RFC3339)(&rfc3164Time),
+				Hostname:  aws.String("fw01"),
+				Appname:   aws.String("app"),
+				ProcID:    aws.String("42"),
+			},
+			payload: `CEF:0|Vendor|Product|1.0|100|name|1|`,
+		},
+		{
+			// Syslog daemons writing to files drop the priority
+			log:    `Feb 14 19:04:54 fw01 xCEF:0 CEF:0|Vendor|Product|1.0|100|name|1|`,
+			marker: "CEF:",
+			header: &Header{
+				Timestamp: (*timestamp.RFC3339)(&rfc3164Time),
+				Hostname:  aws.String("fw01"),
+				Appname:   aws.String("xCEF"),
+			},
+			payload: `CEF:0|Vendor|Product|1.0|100|name|1|`,
+		},
+		{
+			log:    `<14>1 2020-06-01T15:09:42Z edr01 agent - ALERT [meta sequenceId="1"] LEEF:1.0|Vendor|Product|1.0|100|`,
+			marker: "LEEF:",
+			header: &Header{
+				Priority:  aws.Uint8(14),
+				Facility:  aws.Uint8(1),
+				Severity:  aws.Uint8(6),
+				Timestamp: (*timestamp.RFC3339)(&rfc5424Time),
+				Hostname:  aws.String("edr01"),
+				Appname:   aws.String("agent"),
+				MsgID:     aws.String("ALERT"),
+			},
+			payload: `LEEF:1.0|Vendor|Product|1.0|100|`,
+		},
+	} {
+		header, payload, err := NewHeaderParser().Split(tc.log, tc.marker)
+		require.NoError(t, err, tc.log)
+		require.Equal(t, tc.header, header, tc.log)
+		require.Equal(t, tc.payload, payload, tc.log)
+	}
+}
+
+func TestHeaderParserSplitInvalid(t *testing.T) {
+	parser := NewHeaderParser()
+	_, _, err := parser.Split(`<134>Feb 14 19:04:54 fw01 app: message`, "CEF:")
+	require.Error(t, err)
+	_, _, err = parser.Split(`<134>Feb 14 19:04:54 fw01 app:CEF:0|Vendor|Product|1.0|100|name|1|`, "CEF:")
+	require.Error(t, err)
+	_, _, err = parser.Split(`not a syslog header CEF:0|Vendor|Product|1.0|100|name|1|`, "CEF:")
+	require.Error(t, err)
+}
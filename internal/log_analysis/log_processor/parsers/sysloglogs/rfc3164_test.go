@@ -44,6 +44,7 @@ func TestRFC3164(t *testing.T) {
 	t.Run("Example1", testRFC3164Example1)
 	t.Run("Example2", testRFC3164Example2)
 	t.Run("Example3", testRFC3164Example3)
+	t.Run("IPv6InMessage", testRFC3164IPv6InMessage)
 }
 
 func testRFC3164Simple(t *testing.T) {
@@ -184,6 +185,35 @@ func testRFC3164Example3(t *testing.T) {
 	checkRFC3164(t, log, expectedEvent)
 }
 
+func testRFC3164IPv6InMessage(t *testing.T) {
+	//nolint:lll
+	log := `<38>Dec  2 16:31:03 2001:db8::99 sshd[4321]: Failed password for root from 2001:db8:0:0:0:0:0:7 port 22 via fe80::1%eth0`
+
+	expectedTime := time.Date(time.Now().UTC().Year(), 12, 2, 16, 31, 03, 0, time.UTC)
+
+	expectedEvent := &RFC3164{
+		Priority:  aws.Uint8(38),
+		Facility:  aws.Uint8(4),
+		Severity:  aws.Uint8(6),
+		Timestamp: (*timestamp.RFC3339)(&expectedTime),
+		Hostname:  aws.String("2001:db8::99"),
+		Appname:   aws.String("sshd"),
+		ProcID:    aws.String("4321"),
+		MsgID:     nil,
+		Message:   aws.String("Failed password for root from 2001:db8:0:0:0:0:0:7 port 22 via fe80::1%eth0"),
+	}
+
+	expectedEvent.AppendAnyIPAddress("2001:db8::99")
+	expectedEvent.AppendAnyIPAddress("2001:db8::7")
+	expectedEvent.AppendAnyIPAddress("fe80::1")
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Syslog.RFC3164")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+
+	checkRFC3164(t, log, expectedEvent)
+}
+
 func TestRFC3164Type(t *testing.T) {
 	parser := &RFC3164Parser{}
 	require.Equal(t, "Syslog.RFC3164", parser.LogType())
@@ -45,6 +45,7 @@ func TestRFC5424(t *testing.T) {
 	t.Run("NoStructuredDataNoMsgID", testRFC5424NoStructuredDataNoMsgID)
 	t.Run("WithStructuredData", testRFC5424WithStructuredData)
 	t.Run("StructuredDataOnly", testRFC5424StructuredDataOnly)
+	t.Run("IPv6InMessage", testRFC5424IPv6InMessage)
 }
 
 func testRFC5424Version4(t *testing.T) {
@@ -242,6 +243,36 @@ func testRFC5424StructuredDataOnly(t *testing.T) {
 	checkRFC5424(t, log, expectedEvent)
 }
 
+func testRFC5424IPv6InMessage(t *testing.T) {
+	//nolint:lll
+	log := `<38>1 2018-10-11T22:14:15.003Z mymach.it sshd 4321 - - Failed password for root from [2001:db8::7]:22 and ::ffff:10.0.0.7`
+
+	expectedTime, _ := time.Parse(time.RFC3339, "2018-10-11T22:14:15.003Z")
+
+	expectedEvent := &RFC5424{
+		Priority:  aws.Uint8(38),
+		Facility:  aws.Uint8(4),
+		Severity:  aws.Uint8(6),
+		Version:   aws.Uint16(1),
+		Timestamp: (*timestamp.RFC3339)(&expectedTime),
+		Hostname:  aws.String("mymach.it"),
+		Appname:   aws.String("sshd"),
+		ProcID:    aws.String("4321"),
+		MsgID:     nil,
+		Message:   aws.String("Failed password for root from [2001:db8::7]:22 and ::ffff:10.0.0.7"),
+	}
+
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.Hostname)
+	expectedEvent.AppendAnyIPAddress("2001:db8::7")
+	expectedEvent.AppendAnyIPAddress("10.0.0.7")
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Syslog.RFC5424")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+
+	checkRFC5424(t, log, expectedEvent)
+}
+
 func TestRFC5424Type(t *testing.T) {
 	parser := &RFC5424Parser{}
 	require.Equal(t, "Syslog.RFC5424", parser.LogType())
@@ -37,6 +37,8 @@ func init() {
 			ReferenceURL: `https://tools.ietf.org/html/rfc3164`,
 			Schema:       RFC3164{},
 			NewParser:    parsers.AdapterFactory(&RFC5424Parser{}),
+			// CEF and LEEF messages are sent over syslog, their log types take precedence
+			Fallback: true,
 		},
 		logtypes.Config{
 			Name:         TypeRFC5424,
@@ -44,6 +46,8 @@ func init() {
 			ReferenceURL: `https://tools.ietf.org/html/rfc5424`,
 			Schema:       RFC5424{},
 			NewParser:    parsers.AdapterFactory(&RFC5424Parser{}),
+			// CEF and LEEF messages are sent over syslog, their log types take precedence
+			Fallback: true,
 		},
 	)
 }
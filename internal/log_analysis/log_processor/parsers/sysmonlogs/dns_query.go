@@ -0,0 +1,89 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+// nolint:lll
+type DNSQuery struct {
+	windowslogs.System
+	EventData DNSQueryData `json:"EventData" description:"The fields of the DNS query event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type DNSQueryData struct {
+	RuleName     *string         `json:"RuleName,omitempty" description:"The name of the rule that triggered the event."`
+	UtcTime      *string         `json:"UtcTime,omitempty" description:"The time of the query in UTC, formatted as YYYY-MM-DD hh:mm:ss.sss."`
+	ProcessGUID  *string         `json:"ProcessGuid" validate:"required" description:"The unique ID of the process that made the query across a domain."`
+	ProcessID    *numerics.Int64 `json:"ProcessId,omitempty" description:"The ID of the process that made the query."`
+	QueryName    *string         `json:"QueryName" validate:"required" description:"The DNS name that was queried."`
+	QueryStatus  *string         `json:"QueryStatus,omitempty" description:"The status code of the query, 0 on success."`
+	QueryResults *string         `json:"QueryResults,omitempty" description:"The results of the query, separated by semicolons."`
+	Image        *string         `json:"Image,omitempty" description:"The file path of the process that made the query."`
+	User         *string         `json:"User,omitempty" description:"The name of the account of the process that made the query, as DOMAIN\\user."`
+}
+
+// DNSQueryParser parses Sysmon DNS query events
+type DNSQueryParser struct{}
+
+var _ parsers.LogParser = (*DNSQueryParser)(nil)
+
+// New returns an initialized LogParser for Sysmon DNS query events
+func (p *DNSQueryParser) New() parsers.LogParser {
+	return &DNSQueryParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *DNSQueryParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &DNSQuery{}
+	system, err := decodeEvent(log, eventIDDNSQuery, &event.EventData)
+	if err != nil {
+		return nil, err
+	}
+	event.System = *system
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *DNSQueryParser) LogType() string {
+	return TypeDNSQuery
+}
+
+func (event *DNSQuery) updatePantherFields(p *DNSQueryParser) {
+	event.SetCoreFields(p.LogType(), event.TimeCreated, event)
+
+	appendComputer(&event.PantherLog, &event.System)
+	event.AppendAnyUsernamePtrs(event.EventData.User)
+	event.AppendAnyDomainNamePtrs(event.EventData.QueryName)
+	event.AppendAnyIPAddressInFieldPtr(event.EventData.QueryResults)
+}
@@ -0,0 +1,92 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+func TestDNSQuery(t *testing.T) {
+	// nolint:lll
+	log := `{"@timestamp":"2020-06-01T15:09:43.201Z","message":"Dns query","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01.example.com","event_id":22,"provider_name":"Microsoft-Windows-Sysmon","record_id":5238,"event_data":{"RuleName":"-","UtcTime":"2020-06-01 15:09:41.911","ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}","ProcessId":"4216","QueryName":"example.org","QueryStatus":"0","QueryResults":"type:  5 www.example.org;::ffff:93.184.216.34;","Image":"C:\\Windows\\System32\\curl.exe","User":"EXAMPLE\\jdoe"}}}`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 43, 201000000, time.UTC)
+	event := &DNSQuery{
+		System: windowslogs.System{
+			TimeCreated:   (*timestamp.RFC3339)(&tm),
+			EventID:       aws.Int64(22),
+			Channel:       aws.String("Microsoft-Windows-Sysmon/Operational"),
+			Computer:      aws.String("WS01.example.com"),
+			Provider:      aws.String("Microsoft-Windows-Sysmon"),
+			EventRecordID: aws.Int64(5238),
+			Message:       aws.String("Dns query"),
+		},
+		EventData: DNSQueryData{
+			RuleName:     aws.String("-"),
+			UtcTime:      aws.String("2020-06-01 15:09:41.911"),
+			ProcessGUID:  aws.String("{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"),
+			ProcessID:    (*numerics.Int64)(aws.Int64(4216)),
+			QueryName:    aws.String("example.org"),
+			QueryStatus:  aws.String("0"),
+			QueryResults: aws.String("type:  5 www.example.org;::ffff:93.184.216.34;"),
+			Image:        aws.String(`C:\Windows\System32\curl.exe`),
+			User:         aws.String(`EXAMPLE\jdoe`),
+		},
+	}
+	event.SetCoreFields(TypeDNSQuery, event.TimeCreated, event)
+	event.AppendAnyDomainNames("WS01.example.com", "example.org")
+	event.AppendAnyUsernames(`EXAMPLE\jdoe`)
+	event.AppendAnyIPAddress("93.184.216.34")
+
+	testutil.CheckPantherParser(t, log, &DNSQueryParser{}, &event.PantherLog)
+}
+
+func TestDNSQueryInvalid(t *testing.T) {
+	parser := (&DNSQueryParser{}).New()
+	// nolint:lll
+	_, err := parser.Parse(`{"@timestamp":"2020-06-01T15:09:43.201Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01","event_id":22,"event_data":{"ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"}}}`)
+	require.Error(t, err)
+}
+
+func TestDNSQueryNotEventLog(t *testing.T) {
+	parser := (&windowslogs.EventLogParser{}).New()
+	// nolint:lll
+	_, err := parser.Parse(`{"@timestamp":"2020-06-01T15:09:43.201Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01","event_id":22,"event_data":{"QueryName":"example.org"}}}`)
+	require.Error(t, err)
+
+	// Sysmon events without a log type of their own are still parsed as Windows.EventLog
+	// nolint:lll
+	events, err := parser.Parse(`{"@timestamp":"2020-06-01T15:09:43.201Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01","event_id":5,"event_data":{"Image":"C:\\Windows\\System32\\curl.exe"}}}`)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
+
+func TestDNSQueryLogType(t *testing.T) {
+	parser := &DNSQueryParser{}
+	require.Equal(t, "Sysmon.DNSQuery", parser.LogType())
+}
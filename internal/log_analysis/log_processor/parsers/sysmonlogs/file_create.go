@@ -0,0 +1,86 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+// nolint:lll
+type FileCreate struct {
+	windowslogs.System
+	EventData FileCreateData `json:"EventData" description:"The fields of the file creation event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type FileCreateData struct {
+	RuleName        *string         `json:"RuleName,omitempty" description:"The name of the rule that triggered the event."`
+	UtcTime         *string         `json:"UtcTime,omitempty" description:"The time the file was created in UTC, formatted as YYYY-MM-DD hh:mm:ss.sss."`
+	ProcessGUID     *string         `json:"ProcessGuid" validate:"required" description:"The unique ID of the process that created the file across a domain."`
+	ProcessID       *numerics.Int64 `json:"ProcessId,omitempty" description:"The ID of the process that created the file."`
+	Image           *string         `json:"Image,omitempty" description:"The file path of the process that created the file."`
+	TargetFilename  *string         `json:"TargetFilename" validate:"required" description:"The path of the created file."`
+	CreationUtcTime *string         `json:"CreationUtcTime,omitempty" description:"The creation time of the file in UTC, formatted as YYYY-MM-DD hh:mm:ss.sss."`
+	User            *string         `json:"User,omitempty" description:"The name of the account of the process that created the file, as DOMAIN\\user."`
+}
+
+// FileCreateParser parses Sysmon file creation events
+type FileCreateParser struct{}
+
+var _ parsers.LogParser = (*FileCreateParser)(nil)
+
+// New returns an initialized LogParser for Sysmon file creation events
+func (p *FileCreateParser) New() parsers.LogParser {
+	return &FileCreateParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *FileCreateParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &FileCreate{}
+	system, err := decodeEvent(log, eventIDFileCreate, &event.EventData)
+	if err != nil {
+		return nil, err
+	}
+	event.System = *system
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *FileCreateParser) LogType() string {
+	return TypeFileCreate
+}
+
+func (event *FileCreate) updatePantherFields(p *FileCreateParser) {
+	event.SetCoreFields(p.LogType(), event.TimeCreated, event)
+
+	appendComputer(&event.PantherLog, &event.System)
+	event.AppendAnyUsernamePtrs(event.EventData.User)
+}
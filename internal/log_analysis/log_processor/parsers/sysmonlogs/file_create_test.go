@@ -0,0 +1,77 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+func TestFileCreate(t *testing.T) {
+	// nolint:lll
+	log := `{"@timestamp":"2020-06-01T15:10:02.004Z","message":"File created","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01.example.com","event_id":11,"provider_name":"Microsoft-Windows-Sysmon","record_id":5251,"event_data":{"RuleName":"-","UtcTime":"2020-06-01 15:10:02.003","ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}","ProcessId":"4216","Image":"C:\\Windows\\System32\\curl.exe","TargetFilename":"C:\\Users\\jdoe\\AppData\\Local\\Temp\\payload.exe","CreationUtcTime":"2020-06-01 15:10:02.003","User":"EXAMPLE\\jdoe"}}}`
+
+	tm := time.Date(2020, 6, 1, 15, 10, 2, 4000000, time.UTC)
+	event := &FileCreate{
+		System: windowslogs.System{
+			TimeCreated:   (*timestamp.RFC3339)(&tm),
+			EventID:       aws.Int64(11),
+			Channel:       aws.String("Microsoft-Windows-Sysmon/Operational"),
+			Computer:      aws.String("WS01.example.com"),
+			Provider:      aws.String("Microsoft-Windows-Sysmon"),
+			EventRecordID: aws.Int64(5251),
+			Message:       aws.String("File created"),
+		},
+		EventData: FileCreateData{
+			RuleName:        aws.String("-"),
+			UtcTime:         aws.String("2020-06-01 15:10:02.003"),
+			ProcessGUID:     aws.String("{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"),
+			ProcessID:       (*numerics.Int64)(aws.Int64(4216)),
+			Image:           aws.String(`C:\Windows\System32\curl.exe`),
+			TargetFilename:  aws.String(`C:\Users\jdoe\AppData\Local\Temp\payload.exe`),
+			CreationUtcTime: aws.String("2020-06-01 15:10:02.003"),
+			User:            aws.String(`EXAMPLE\jdoe`),
+		},
+	}
+	event.SetCoreFields(TypeFileCreate, event.TimeCreated, event)
+	event.AppendAnyDomainNames("WS01.example.com")
+	event.AppendAnyUsernames(`EXAMPLE\jdoe`)
+
+	testutil.CheckPantherParser(t, log, &FileCreateParser{}, &event.PantherLog)
+}
+
+func TestFileCreateInvalid(t *testing.T) {
+	parser := (&FileCreateParser{}).New()
+	// nolint:lll
+	_, err := parser.Parse(`{"@timestamp":"2020-06-01T15:10:02.004Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01","event_id":11,"event_data":{"ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"}}}`)
+	require.Error(t, err)
+}
+
+func TestFileCreateLogType(t *testing.T) {
+	parser := &FileCreateParser{}
+	require.Equal(t, "Sysmon.FileCreate", parser.LogType())
+}
@@ -0,0 +1,103 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+// nolint:lll
+type NetworkConnect struct {
+	windowslogs.System
+	EventData NetworkConnectData `json:"EventData" description:"The fields of the network connection event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type NetworkConnectData struct {
+	RuleName            *string           `json:"RuleName,omitempty" description:"The name of the rule that triggered the event."`
+	UtcTime             *string           `json:"UtcTime,omitempty" description:"The time the connection was detected in UTC, formatted as YYYY-MM-DD hh:mm:ss.sss."`
+	ProcessGUID         *string           `json:"ProcessGuid" validate:"required" description:"The unique ID of the process that made the connection across a domain."`
+	ProcessID           *numerics.Int64   `json:"ProcessId,omitempty" description:"The ID of the process that made the connection."`
+	Image               *string           `json:"Image,omitempty" description:"The file path of the process that made the connection."`
+	User                *string           `json:"User,omitempty" description:"The name of the account of the process that made the connection, as DOMAIN\\user."`
+	Protocol            *string           `json:"Protocol,omitempty" description:"The transport protocol of the connection, eg. tcp or udp."`
+	Initiated           *string           `json:"Initiated,omitempty" description:"Whether the process initiated the connection (true or false)."`
+	SourceIsIpv6        *string           `json:"SourceIsIpv6,omitempty" description:"Whether the source address is an IPv6 address (true or false)."`
+	SourceIP            *string           `json:"SourceIp,omitempty" description:"The source IP address."`
+	SourceHostname      *string           `json:"SourceHostname,omitempty" description:"The source hostname."`
+	SourcePort          *numerics.Integer `json:"SourcePort,omitempty" description:"The source port."`
+	SourcePortName      *string           `json:"SourcePortName,omitempty" description:"The name of the source port, eg. http."`
+	DestinationIsIpv6   *string           `json:"DestinationIsIpv6,omitempty" description:"Whether the destination address is an IPv6 address (true or false)."`
+	DestinationIP       *string           `json:"DestinationIp,omitempty" description:"The destination IP address."`
+	DestinationHostname *string           `json:"DestinationHostname,omitempty" description:"The destination hostname."`
+	DestinationPort     *numerics.Integer `json:"DestinationPort,omitempty" description:"The destination port."`
+	DestinationPortName *string           `json:"DestinationPortName,omitempty" description:"The name of the destination port, eg. https."`
+}
+
+// NetworkConnectParser parses Sysmon network connection events
+type NetworkConnectParser struct{}
+
+var _ parsers.LogParser = (*NetworkConnectParser)(nil)
+
+// New returns an initialized LogParser for Sysmon network connection events
+func (p *NetworkConnectParser) New() parsers.LogParser {
+	return &NetworkConnectParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *NetworkConnectParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &NetworkConnect{}
+	system, err := decodeEvent(log, eventIDNetworkConnect, &event.EventData)
+	if err != nil {
+		return nil, err
+	}
+	event.System = *system
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *NetworkConnectParser) LogType() string {
+	return TypeNetworkConnect
+}
+
+func (event *NetworkConnect) updatePantherFields(p *NetworkConnectParser) {
+	event.SetCoreFields(p.LogType(), event.TimeCreated, event)
+
+	appendComputer(&event.PantherLog, &event.System)
+	event.AppendAnyUsernamePtrs(event.EventData.User)
+	event.AppendAnyIPAddressPtr(event.EventData.SourceIP)
+	event.AppendAnyIPAddressPtr(event.EventData.DestinationIP)
+	for _, host := range []*string{event.EventData.SourceHostname, event.EventData.DestinationHostname} {
+		if host != nil && windowslogs.IsValue(*host) {
+			event.AppendAnyDomainNames(*host)
+		}
+	}
+}
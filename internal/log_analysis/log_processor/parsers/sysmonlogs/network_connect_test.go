@@ -0,0 +1,102 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+func TestNetworkConnect(t *testing.T) {
+	// nolint:lll
+	log := `{"EventTime":"2020-06-01 15:09:44","Hostname":"WS01.example.com","EventType":"INFO","SeverityValue":2,"Severity":"INFO","EventID":3,"SourceName":"Microsoft-Windows-Sysmon","ProviderGuid":"{5770385F-C22A-43E0-BF4C-06F5698FFBD9}","Version":5,"Task":3,"OpcodeValue":0,"RecordNumber":5240,"ProcessID":2960,"ThreadID":3452,"Channel":"Microsoft-Windows-Sysmon/Operational","Domain":"NT AUTHORITY","AccountName":"SYSTEM","UserID":"S-1-5-18","AccountType":"User","Message":"Network connection detected","Category":"Network connection detected (rule: NetworkConnect)","Opcode":"Info","RuleName":"-","UtcTime":"2020-06-01 15:09:43.512","ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}","ProcessId":4216,"Image":"C:\\Windows\\System32\\curl.exe","User":"EXAMPLE\\jdoe","Protocol":"tcp","Initiated":"true","SourceIsIpv6":"false","SourceIp":"10.0.0.21","SourceHostname":"WS01.example.com","SourcePort":"51234","SourcePortName":"-","DestinationIsIpv6":"false","DestinationIp":"93.184.216.34","DestinationHostname":"-","DestinationPort":"443","DestinationPortName":"https","EventReceivedTime":"2020-06-01 15:09:45","SourceModuleName":"eventlog","SourceModuleType":"im_msvistalog"}`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 44, 0, time.UTC)
+	sourcePort, destinationPort := numerics.Integer(51234), numerics.Integer(443)
+	event := &NetworkConnect{
+		System: windowslogs.System{
+			TimeCreated:   (*timestamp.RFC3339)(&tm),
+			EventID:       aws.Int64(3),
+			Channel:       aws.String("Microsoft-Windows-Sysmon/Operational"),
+			Computer:      aws.String("WS01.example.com"),
+			Provider:      aws.String("Microsoft-Windows-Sysmon"),
+			ProviderGUID:  aws.String("{5770385F-C22A-43E0-BF4C-06F5698FFBD9}"),
+			EventRecordID: aws.Int64(5240),
+			Version:       aws.Int64(5),
+			Level:         aws.String("INFO"),
+			Task:          aws.String("Network connection detected (rule: NetworkConnect)"),
+			Opcode:        aws.String("Info"),
+			ProcessID:     aws.Int64(2960),
+			ThreadID:      aws.Int64(3452),
+			UserID:        aws.String("S-1-5-18"),
+			UserName:      aws.String("SYSTEM"),
+			UserDomain:    aws.String("NT AUTHORITY"),
+			Message:       aws.String("Network connection detected"),
+		},
+		EventData: NetworkConnectData{
+			RuleName:            aws.String("-"),
+			UtcTime:             aws.String("2020-06-01 15:09:43.512"),
+			ProcessGUID:         aws.String("{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"),
+			ProcessID:           (*numerics.Int64)(aws.Int64(4216)),
+			Image:               aws.String(`C:\Windows\System32\curl.exe`),
+			User:                aws.String(`EXAMPLE\jdoe`),
+			Protocol:            aws.String("tcp"),
+			Initiated:           aws.String("true"),
+			SourceIsIpv6:        aws.String("false"),
+			SourceIP:            aws.String("10.0.0.21"),
+			SourceHostname:      aws.String("WS01.example.com"),
+			SourcePort:          &sourcePort,
+			SourcePortName:      aws.String("-"),
+			DestinationIsIpv6:   aws.String("false"),
+			DestinationIP:       aws.String("93.184.216.34"),
+			DestinationHostname: aws.String("-"),
+			DestinationPort:     &destinationPort,
+			DestinationPortName: aws.String("https"),
+		},
+	}
+	event.SetCoreFields(TypeNetworkConnect, event.TimeCreated, event)
+	event.AppendAnyDomainNames("WS01.example.com")
+	event.AppendAnyUsernames(`EXAMPLE\jdoe`)
+	event.AppendAnyIPAddress("10.0.0.21")
+	event.AppendAnyIPAddress("93.184.216.34")
+
+	testutil.CheckPantherParser(t, log, &NetworkConnectParser{}, &event.PantherLog)
+}
+
+func TestNetworkConnectInvalid(t *testing.T) {
+	parser := (&NetworkConnectParser{}).New()
+	// nolint:lll
+	_, err := parser.Parse(`{"EventTime":"2020-06-01 15:09:44","Hostname":"WS01","EventID":3,"Channel":"System","ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"EventTime":"2020-06-01 15:09:44","Hostname":"WS01","EventID":3,"Channel":"Microsoft-Windows-Sysmon/Operational"}`)
+	require.Error(t, err)
+}
+
+func TestNetworkConnectLogType(t *testing.T) {
+	parser := &NetworkConnectParser{}
+	require.Equal(t, "Sysmon.NetworkConnect", parser.LogType())
+}
@@ -0,0 +1,103 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+// nolint:lll
+type ProcessCreate struct {
+	windowslogs.System
+	EventData ProcessCreateData `json:"EventData" description:"The fields of the process creation event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type ProcessCreateData struct {
+	RuleName          *string         `json:"RuleName,omitempty" description:"The name of the rule that triggered the event."`
+	UtcTime           *string         `json:"UtcTime,omitempty" description:"The time the process was created in UTC, formatted as YYYY-MM-DD hh:mm:ss.sss."`
+	ProcessGUID       *string         `json:"ProcessGuid" validate:"required" description:"The unique ID of the process across a domain."`
+	ProcessID         *numerics.Int64 `json:"ProcessId,omitempty" description:"The ID of the process."`
+	Image             *string         `json:"Image" validate:"required" description:"The file path of the process executable."`
+	FileVersion       *string         `json:"FileVersion,omitempty" description:"The file version of the process executable."`
+	Description       *string         `json:"Description,omitempty" description:"The description of the process executable."`
+	Product           *string         `json:"Product,omitempty" description:"The product name of the process executable."`
+	Company           *string         `json:"Company,omitempty" description:"The company name of the process executable."`
+	OriginalFileName  *string         `json:"OriginalFileName,omitempty" description:"The original file name of the process executable at compile time."`
+	CommandLine       *string         `json:"CommandLine,omitempty" description:"The command line of the process."`
+	CurrentDirectory  *string         `json:"CurrentDirectory,omitempty" description:"The working directory of the process."`
+	User              *string         `json:"User,omitempty" description:"The name of the account that created the process, as DOMAIN\\user."`
+	LogonGUID         *string         `json:"LogonGuid,omitempty" description:"The logon GUID of the user session."`
+	LogonID           *string         `json:"LogonId,omitempty" description:"The logon ID of the user session."`
+	TerminalSessionID *numerics.Int64 `json:"TerminalSessionId,omitempty" description:"The ID of the terminal session of the process."`
+	IntegrityLevel    *string         `json:"IntegrityLevel,omitempty" description:"The integrity level of the process, eg. Low, Medium, High or System."`
+	Hashes            *string         `json:"Hashes,omitempty" description:"The hashes of the process executable, eg. SHA1=...,MD5=...,SHA256=...,IMPHASH=..."`
+	ParentProcessGUID *string         `json:"ParentProcessGuid,omitempty" description:"The unique ID of the parent process across a domain."`
+	ParentProcessID   *numerics.Int64 `json:"ParentProcessId,omitempty" description:"The ID of the parent process."`
+	ParentImage       *string         `json:"ParentImage,omitempty" description:"The file path of the parent process executable."`
+	ParentCommandLine *string         `json:"ParentCommandLine,omitempty" description:"The command line of the parent process."`
+}
+
+// ProcessCreateParser parses Sysmon process creation events
+type ProcessCreateParser struct{}
+
+var _ parsers.LogParser = (*ProcessCreateParser)(nil)
+
+// New returns an initialized LogParser for Sysmon process creation events
+func (p *ProcessCreateParser) New() parsers.LogParser {
+	return &ProcessCreateParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ProcessCreateParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &ProcessCreate{}
+	system, err := decodeEvent(log, eventIDProcessCreate, &event.EventData)
+	if err != nil {
+		return nil, err
+	}
+	event.System = *system
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ProcessCreateParser) LogType() string {
+	return TypeProcessCreate
+}
+
+func (event *ProcessCreate) updatePantherFields(p *ProcessCreateParser) {
+	event.SetCoreFields(p.LogType(), event.TimeCreated, event)
+
+	appendComputer(&event.PantherLog, &event.System)
+	event.AppendAnyUsernamePtrs(event.EventData.User)
+	if event.EventData.Hashes != nil {
+		windowslogs.AppendHashes(&event.PantherLog, *event.EventData.Hashes)
+	}
+}
@@ -0,0 +1,112 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+func TestProcessCreate(t *testing.T) {
+	// nolint:lll
+	log := `{"@timestamp":"2020-06-01T15:09:42.371Z","message":"Process Create","log":{"level":"information"},"winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01.example.com","event_id":1,"provider_name":"Microsoft-Windows-Sysmon","record_id":5231,"version":5,"task":"Process Create (rule: ProcessCreate)","opcode":"Info","process":{"pid":2960,"thread":{"id":3440}},"user":{"identifier":"S-1-5-18","name":"SYSTEM","domain":"NT AUTHORITY","type":"User"},"event_data":{"RuleName":"-","UtcTime":"2020-06-01 15:09:42.370","ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}","ProcessId":"4216","Image":"C:\\Windows\\System32\\cmd.exe","FileVersion":"10.0.18362.1","Description":"Windows Command Processor","Product":"Microsoft® Windows® Operating System","Company":"Microsoft Corporation","OriginalFileName":"Cmd.Exe","CommandLine":"cmd.exe /c whoami","CurrentDirectory":"C:\\Users\\jdoe\\","User":"EXAMPLE\\jdoe","LogonGuid":"{b2c5e0e1-0a0b-5ed5-0000-0020e4a31500}","LogonId":"0x15a3e4","TerminalSessionId":"1","IntegrityLevel":"Medium","Hashes":"SHA1=8DCA9749CD48D286950E7A9FA1088C937CBCCAD4,MD5=D0FCE3AFA6AA1D58CE9FA336CC2B675B,SHA256=B99D114B267FFD068C3289199B6DF95A9F9E64872D6C2B666D63974BBCE75BF2,IMPHASH=272245E2988E1E430500B852C4FB5E18","ParentProcessGuid":"{b2c5e0e1-0a15-5ed5-0000-001067d71d00}","ParentProcessId":"1780","ParentImage":"C:\\Windows\\explorer.exe","ParentCommandLine":"C:\\Windows\\Explorer.EXE"}}}`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 42, 371000000, time.UTC)
+	event := &ProcessCreate{
+		System: windowslogs.System{
+			TimeCreated:   (*timestamp.RFC3339)(&tm),
+			EventID:       aws.Int64(1),
+			Channel:       aws.String("Microsoft-Windows-Sysmon/Operational"),
+			Computer:      aws.String("WS01.example.com"),
+			Provider:      aws.String("Microsoft-Windows-Sysmon"),
+			EventRecordID: aws.Int64(5231),
+			Version:       aws.Int64(5),
+			Level:         aws.String("information"),
+			Task:          aws.String("Process Create (rule: ProcessCreate)"),
+			Opcode:        aws.String("Info"),
+			ProcessID:     aws.Int64(2960),
+			ThreadID:      aws.Int64(3440),
+			UserID:        aws.String("S-1-5-18"),
+			UserName:      aws.String("SYSTEM"),
+			UserDomain:    aws.String("NT AUTHORITY"),
+			Message:       aws.String("Process Create"),
+		},
+		EventData: ProcessCreateData{
+			RuleName:          aws.String("-"),
+			UtcTime:           aws.String("2020-06-01 15:09:42.370"),
+			ProcessGUID:       aws.String("{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"),
+			ProcessID:         (*numerics.Int64)(aws.Int64(4216)),
+			Image:             aws.String(`C:\Windows\System32\cmd.exe`),
+			FileVersion:       aws.String("10.0.18362.1"),
+			Description:       aws.String("Windows Command Processor"),
+			Product:           aws.String("Microsoft® Windows® Operating System"),
+			Company:           aws.String("Microsoft Corporation"),
+			OriginalFileName:  aws.String("Cmd.Exe"),
+			CommandLine:       aws.String("cmd.exe /c whoami"),
+			CurrentDirectory:  aws.String(`C:\Users\jdoe\`),
+			User:              aws.String(`EXAMPLE\jdoe`),
+			LogonGUID:         aws.String("{b2c5e0e1-0a0b-5ed5-0000-0020e4a31500}"),
+			LogonID:           aws.String("0x15a3e4"),
+			TerminalSessionID: (*numerics.Int64)(aws.Int64(1)),
+			IntegrityLevel:    aws.String("Medium"),
+			Hashes:            aws.String("SHA1=8DCA9749CD48D286950E7A9FA1088C937CBCCAD4,MD5=D0FCE3AFA6AA1D58CE9FA336CC2B675B,SHA256=B99D114B267FFD068C3289199B6DF95A9F9E64872D6C2B666D63974BBCE75BF2,IMPHASH=272245E2988E1E430500B852C4FB5E18"),
+			ParentProcessGUID: aws.String("{b2c5e0e1-0a15-5ed5-0000-001067d71d00}"),
+			ParentProcessID:   (*numerics.Int64)(aws.Int64(1780)),
+			ParentImage:       aws.String(`C:\Windows\explorer.exe`),
+			ParentCommandLine: aws.String(`C:\Windows\Explorer.EXE`),
+		},
+	}
+	event.SetCoreFields(TypeProcessCreate, event.TimeCreated, event)
+	event.AppendAnyDomainNames("WS01.example.com")
+	event.AppendAnyUsernames(`EXAMPLE\jdoe`)
+	event.AppendAnySHA1Hashes("8DCA9749CD48D286950E7A9FA1088C937CBCCAD4")
+	event.AppendAnyMD5Hashes("D0FCE3AFA6AA1D58CE9FA336CC2B675B")
+	event.AppendAnySHA256Hashes("B99D114B267FFD068C3289199B6DF95A9F9E64872D6C2B666D63974BBCE75BF2")
+
+	testutil.CheckPantherParser(t, log, &ProcessCreateParser{}, &event.PantherLog)
+}
+
+func TestProcessCreateInvalid(t *testing.T) {
+	parser := (&ProcessCreateParser{}).New()
+	// Not a Sysmon event
+	// nolint:lll
+	_, err := parser.Parse(`{"@timestamp":"2020-06-01T15:09:42.371Z","winlog":{"channel":"Security","computer_name":"WS01","event_id":1,"event_data":{"ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}","Image":"cmd.exe"}}}`)
+	require.Error(t, err)
+	// Another Sysmon event
+	// nolint:lll
+	_, err = parser.Parse(`{"@timestamp":"2020-06-01T15:09:42.371Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01","event_id":5,"event_data":{"ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}","Image":"cmd.exe"}}}`)
+	require.Error(t, err)
+	// Missing image
+	// nolint:lll
+	_, err = parser.Parse(`{"@timestamp":"2020-06-01T15:09:42.371Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","computer_name":"WS01","event_id":1,"event_data":{"ProcessGuid":"{b2c5e0e1-0a16-5ed5-0000-0010b3d81d00}"}}}`)
+	require.Error(t, err)
+}
+
+func TestProcessCreateLogType(t *testing.T) {
+	parser := &ProcessCreateParser{}
+	require.Equal(t, "Sysmon.ProcessCreate", parser.LogType())
+}
@@ -0,0 +1,113 @@
+package sysmonlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
+)
+
+const (
+	// PantherPrefix is the prefix of all logs parsed by this package
+	PantherPrefix = "Sysmon"
+	// TypeProcessCreate is the log type of Sysmon process creation events (event ID 1)
+	TypeProcessCreate = PantherPrefix + ".ProcessCreate"
+	// TypeNetworkConnect is the log type of Sysmon network connection events (event ID 3)
+	TypeNetworkConnect = PantherPrefix + ".NetworkConnect"
+	// TypeFileCreate is the log type of Sysmon file creation events (event ID 11)
+	TypeFileCreate = PantherPrefix + ".FileCreate"
+	// TypeDNSQuery is the log type of Sysmon DNS query events (event ID 22)
+	TypeDNSQuery = PantherPrefix + ".DNSQuery"
+
+	// Channel is the Windows event log channel of Sysmon events
+	Channel = "Microsoft-Windows-Sysmon/Operational"
+
+	eventIDProcessCreate  = 1
+	eventIDNetworkConnect = 3
+	eventIDFileCreate     = 11
+	eventIDDNSQuery       = 22
+)
+
+func init() {
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name:         TypeProcessCreate,
+			Description:  `Sysmon process creation events (event ID 1) shipped as JSON by Winlogbeat or NXLog.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/sysinternals/downloads/sysmon#event-id-1-process-creation`,
+			Schema:       ProcessCreate{},
+			NewParser:    parsers.AdapterFactory(&ProcessCreateParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeNetworkConnect,
+			Description:  `Sysmon network connection events (event ID 3) shipped as JSON by Winlogbeat or NXLog.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/sysinternals/downloads/sysmon#event-id-3-network-connection`,
+			Schema:       NetworkConnect{},
+			NewParser:    parsers.AdapterFactory(&NetworkConnectParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeFileCreate,
+			Description:  `Sysmon file creation events (event ID 11) shipped as JSON by Winlogbeat or NXLog.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/sysinternals/downloads/sysmon#event-id-11-filecreate`,
+			Schema:       FileCreate{},
+			NewParser:    parsers.AdapterFactory(&FileCreateParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeDNSQuery,
+			Description:  `Sysmon DNS query events (event ID 22) shipped as JSON by Winlogbeat or NXLog.`,
+			ReferenceURL: `https://docs.microsoft.com/en-us/sysinternals/downloads/sysmon#event-id-22-dnsevent-dns-query`,
+			Schema:       DNSQuery{},
+			NewParser:    parsers.AdapterFactory(&DNSQueryParser{}),
+		},
+	)
+	// the events above are not classified as Windows.EventLog too
+	windowslogs.ExcludeEvents(Channel, eventIDProcessCreate, eventIDNetworkConnect, eventIDFileCreate, eventIDDNSQuery)
+}
+
+// decodeEvent decodes a Sysmon event with the expected event ID into the System fields and the event data
+func decodeEvent(log string, eventID int64, eventData interface{}) (*windowslogs.System, error) {
+	record, err := windowslogs.DecodeRecord(log)
+	if err != nil {
+		return nil, err
+	}
+	system := &record.System
+	if system.Channel == nil || *system.Channel != Channel {
+		return nil, errors.New("not a Sysmon event")
+	}
+	if system.EventID == nil || *system.EventID != eventID {
+		return nil, errors.Errorf("not a Sysmon event with ID %d", eventID)
+	}
+	if len(record.EventData) == 0 {
+		return nil, errors.New("missing Sysmon event data")
+	}
+	if err := jsoniter.Unmarshal(record.EventData, eventData); err != nil {
+		return nil, err
+	}
+	return system, nil
+}
+
+// appendComputer adds the computer name of the event to the indicator fields
+func appendComputer(event *parsers.PantherLog, system *windowslogs.System) {
+	if !event.AppendAnyIPAddressPtr(system.Computer) {
+		event.AppendAnyDomainNamePtrs(system.Computer)
+	}
+}
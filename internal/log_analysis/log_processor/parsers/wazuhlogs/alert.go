@@ -0,0 +1,195 @@
+package wazuhlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Alert struct {
+	// Wazuh timestamps have the same layout as Suricata timestamps (ie 2020-06-01T18:56:20.351+0000)
+	Timestamp      *timestamp.SuricataTimestamp `json:"timestamp" validate:"required" description:"The time the alert was generated."`
+	ID             *string                      `json:"id" validate:"required" description:"Unique id of the alert."`
+	Rule           *Rule                        `json:"rule" validate:"required" description:"Information about the rule that created the alert."`
+	Agent          *Agent                       `json:"agent" validate:"required" description:"The agent that collected the event."`
+	Manager        *Manager                     `json:"manager,omitempty" description:"The manager that generated the alert."`
+	Cluster        *Cluster                     `json:"cluster,omitempty" description:"The cluster node that generated the alert."`
+	FullLog        *string                      `json:"full_log,omitempty" description:"The full captured log of the event."`
+	PreviousOutput *string                      `json:"previous_output,omitempty" description:"The full captured logs of the previous events of frequency rules."`
+	Predecoder     *Predecoder                  `json:"predecoder,omitempty" description:"The fields extracted from the syslog header of the event."`
+	Decoder        *Decoder                     `json:"decoder,omitempty" description:"The decoder used to parse the event."`
+	Data           jsoniter.RawMessage          `json:"data,omitempty" description:"The fields extracted from the event by the decoder (ie srcip, dstuser or win.eventdata)."`
+	Location       *string                      `json:"location" validate:"required" description:"Source of the event (filename, command, etc)."`
+	Syscheck       *Syscheck                    `json:"syscheck,omitempty" description:"Information about a file integrity check."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type Rule struct {
+	ID          *string  `json:"id" validate:"required" description:"The ID of the rule."`
+	Level       *int     `json:"level" validate:"required" description:"The level of the rule (0 to 15)."`
+	Description *string  `json:"description" validate:"required" description:"The rule description."`
+	Groups      []string `json:"groups,omitempty" description:"The groups of the rule."`
+	Firedtimes  *int     `json:"firedtimes,omitempty" description:"The number of times the rule fired."`
+	Frequency   *int     `json:"frequency,omitempty" description:"The number of times the rule must have matched before firing."`
+	Mail        *bool    `json:"mail,omitempty" description:"Whether the alert was sent by email."`
+	Info        *string  `json:"info,omitempty" description:"Additional information or reference about the rule."`
+	CVE         *string  `json:"cve,omitempty" description:"A Common Vulnerabilities and Exposures (CVE) identifier relevant to the rule."`
+	MITRE       *MITRE   `json:"mitre,omitempty" description:"The MITRE ATT&CK techniques of the rule."`
+	CIS         []string `json:"cis,omitempty" description:"A list of Center for Internet Security (CIS) checks relevant to the rule."`
+	PCIDSS      []string `json:"pci_dss,omitempty" description:"A list of Payment Card Industry Data Security Standard (PCI DSS) requirements relevant to the rule."`
+	GDPR        []string `json:"gdpr,omitempty" description:"A list of General Data Protection Regulation (GDPR) articles relevant to the rule."`
+	HIPAA       []string `json:"hipaa,omitempty" description:"A list of Health Insurance Portability and Accountability Act (HIPAA) sections relevant to the rule."`
+	NIST80053   []string `json:"nist_800_53,omitempty" description:"A list of NIST 800-53 controls relevant to the rule."`
+	TSC         []string `json:"tsc,omitempty" description:"A list of Trust Services Criteria (TSC) relevant to the rule."`
+	GPG13       []string `json:"gpg13,omitempty" description:"A list of Good Practice Guide 13 (GPG13) controls relevant to the rule."`
+}
+
+// nolint:lll
+type MITRE struct {
+	ID        []string `json:"id,omitempty" description:"The ids of the MITRE ATT&CK techniques (ie T1110)."`
+	Tactic    []string `json:"tactic,omitempty" description:"The MITRE ATT&CK tactics (ie Credential Access)."`
+	Technique []string `json:"technique,omitempty" description:"The names of the MITRE ATT&CK techniques (ie Brute Force)."`
+}
+
+// nolint:lll
+type Agent struct {
+	ID   *string `json:"id" validate:"required" description:"The id of the agent, 000 for the manager itself."`
+	Name *string `json:"name,omitempty" description:"The name of the agent."`
+	IP   *string `json:"ip,omitempty" description:"The IP address of the agent."`
+}
+
+// nolint:lll
+type Manager struct {
+	Name *string `json:"name,omitempty" description:"The name of the manager."`
+}
+
+// nolint:lll
+type Cluster struct {
+	Name *string `json:"name,omitempty" description:"The name of the cluster."`
+	Node *string `json:"node,omitempty" description:"The name of the cluster node."`
+}
+
+// nolint:lll
+type Predecoder struct {
+	ProgramName *string `json:"program_name,omitempty" description:"The program name in the syslog header of the event."`
+	Timestamp   *string `json:"timestamp,omitempty" description:"The timestamp in the syslog header of the event."`
+	Hostname    *string `json:"hostname,omitempty" description:"The hostname in the syslog header of the event."`
+}
+
+// nolint:lll
+type Decoder struct {
+	Name       *string `json:"name,omitempty" description:"The name of the decoder."`
+	Parent     *string `json:"parent,omitempty" description:"In the case of a nested decoder, the name of it's parent."`
+	Accumulate *int    `json:"accumulate,omitempty" description:"True if Wazuh tracks events over multiple log messages based on decoded id."`
+	FTS        *int    `json:"fts,omitempty" description:"The First Time Seen option inside of analysisd."`
+	FTSComment *string `json:"ftscomment,omitempty" description:"Unused at this time."`
+}
+
+// nolint:lll
+type Syscheck struct {
+	Path              *string  `json:"path,omitempty" description:"The path to the file."`
+	Event             *string  `json:"event,omitempty" description:"The type of change (added, modified or deleted)."`
+	Mode              *string  `json:"mode,omitempty" description:"The monitoring mode that detected the change (scheduled, realtime or whodata)."`
+	ChangedAttributes []string `json:"changed_attributes,omitempty" description:"The attributes of the file that changed."`
+	SizeBefore        *string  `json:"size_before,omitempty" description:"The size of the file before modification."`
+	SizeAfter         *string  `json:"size_after,omitempty" description:"The size of the file after modification."`
+	PermBefore        *string  `json:"perm_before,omitempty" description:"The permissions of the file before modification."`
+	PermAfter         *string  `json:"perm_after,omitempty" description:"The permissions of the file after modification."`
+	UIDBefore         *string  `json:"uid_before,omitempty" description:"The user id of the file owner before modification."`
+	UIDAfter          *string  `json:"uid_after,omitempty" description:"The user id of the file owner after modification."`
+	GIDBefore         *string  `json:"gid_before,omitempty" description:"The group id of the file owner before modification."`
+	GIDAfter          *string  `json:"gid_after,omitempty" description:"The group id of the file owner after modification."`
+	UnameBefore       *string  `json:"uname_before,omitempty" description:"The name of the file owner before modification."`
+	UnameAfter        *string  `json:"uname_after,omitempty" description:"The name of the file owner after modification."`
+	GnameBefore       *string  `json:"gname_before,omitempty" description:"The group name of the file owner before modification."`
+	GnameAfter        *string  `json:"gname_after,omitempty" description:"The group name of the file owner after modification."`
+	MD5Before         *string  `json:"md5_before,omitempty" description:"MD5 hash of the file before modification."`
+	MD5After          *string  `json:"md5_after,omitempty" description:"MD5 hash of the file after modification."`
+	SHA1Before        *string  `json:"sha1_before,omitempty" description:"SHA1 hash of the file before modification."`
+	SHA1After         *string  `json:"sha1_after,omitempty" description:"SHA1 hash of the file after modification."`
+	SHA256Before      *string  `json:"sha256_before,omitempty" description:"SHA256 hash of the file before modification."`
+	SHA256After       *string  `json:"sha256_after,omitempty" description:"SHA256 hash of the file after modification."`
+	Diff              *string  `json:"diff,omitempty" description:"The changes of the file content, if reporting changes is enabled."`
+}
+
+// data holds the decoded fields of alerts used as indicators
+type data struct {
+	SrcIP   *string `json:"srcip"`
+	DstIP   *string `json:"dstip"`
+	SrcUser *string `json:"srcuser"`
+	DstUser *string `json:"dstuser"`
+}
+
+// AlertParser parses Wazuh alerts
+type AlertParser struct{}
+
+var _ parsers.LogParser = (*AlertParser)(nil)
+
+func (p *AlertParser) New() parsers.LogParser {
+	return &AlertParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AlertParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Alert{}
+	if err := jsoniter.UnmarshalFromString(log, event); err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *AlertParser) LogType() string {
+	return TypeAlert
+}
+
+func (event *Alert) updatePantherFields(p *AlertParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+
+	if event.Agent != nil {
+		event.AppendAnyIPAddressPtr(event.Agent.IP)
+	}
+	if len(event.Data) > 0 {
+		// Decoded fields are not part of the schema, a failure to read them is not an error
+		fields := data{}
+		if err := jsoniter.Unmarshal(event.Data, &fields); err == nil {
+			event.AppendAnyIPAddressPtr(fields.SrcIP)
+			event.AppendAnyIPAddressPtr(fields.DstIP)
+			event.AppendAnyUsernamePtrs(fields.SrcUser, fields.DstUser)
+		}
+	}
+	if event.Syscheck != nil {
+		event.AppendAnyMD5HashPtrs(event.Syscheck.MD5Before, event.Syscheck.MD5After)
+		event.AppendAnySHA1HashPtrs(event.Syscheck.SHA1Before, event.Syscheck.SHA1After)
+		event.AppendAnySHA256HashesPtr(event.Syscheck.SHA256Before, event.Syscheck.SHA256After)
+	}
+}
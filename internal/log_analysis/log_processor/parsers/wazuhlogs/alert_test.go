@@ -0,0 +1,156 @@
+package wazuhlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAlert(t *testing.T) {
+	// nolint:lll
+	log := `{"timestamp":"2020-06-01T18:56:20.351+0000","rule":{"level":5,"description":"sshd: authentication failed.","id":"5716","mitre":{"id":["T1110"],"tactic":["Credential Access"],"technique":["Brute Force"]},"firedtimes":1,"mail":false,"groups":["syslog","sshd","authentication_failed"],"pci_dss":["10.2.4","10.2.5"],"gdpr":["IV_35.7.d","IV_32.2"]},"agent":{"id":"001","name":"web01","ip":"10.0.0.5"},"manager":{"name":"wazuh-manager"},"id":"1591037780.12345","cluster":{"name":"wazuh","node":"master"},"full_log":"Jun  1 18:56:20 web01 sshd[1234]: Failed password for root from 198.51.100.7 port 51234 ssh2","predecoder":{"program_name":"sshd","timestamp":"Jun  1 18:56:20","hostname":"web01"},"decoder":{"parent":"sshd","name":"sshd"},"data":{"srcip":"198.51.100.7","srcport":"51234","dstuser":"root"},"location":"/var/log/secure"}`
+
+	tm := time.Date(2020, 6, 1, 18, 56, 20, 351000000, time.UTC)
+	event := &Alert{
+		Timestamp: (*timestamp.SuricataTimestamp)(&tm),
+		ID:        aws.String("1591037780.12345"),
+		Rule: &Rule{
+			ID:          aws.String("5716"),
+			Level:       aws.Int(5),
+			Description: aws.String("sshd: authentication failed."),
+			Groups:      []string{"syslog", "sshd", "authentication_failed"},
+			Firedtimes:  aws.Int(1),
+			Mail:        aws.Bool(false),
+			MITRE: &MITRE{
+				ID:        []string{"T1110"},
+				Tactic:    []string{"Credential Access"},
+				Technique: []string{"Brute Force"},
+			},
+			PCIDSS: []string{"10.2.4", "10.2.5"},
+			GDPR:   []string{"IV_35.7.d", "IV_32.2"},
+		},
+		Agent: &Agent{
+			ID:   aws.String("001"),
+			Name: aws.String("web01"),
+			IP:   aws.String("10.0.0.5"),
+		},
+		Manager: &Manager{
+			Name: aws.String("wazuh-manager"),
+		},
+		Cluster: &Cluster{
+			Name: aws.String("wazuh"),
+			Node: aws.String("master"),
+		},
+		FullLog: aws.String("Jun  1 18:56:20 web01 sshd[1234]: Failed password for root from 198.51.100.7 port 51234 ssh2"),
+		Predecoder: &Predecoder{
+			ProgramName: aws.String("sshd"),
+			Timestamp:   aws.String("Jun  1 18:56:20"),
+			Hostname:    aws.String("web01"),
+		},
+		Decoder: &Decoder{
+			Name:   aws.String("sshd"),
+			Parent: aws.String("sshd"),
+		},
+		Data:     jsoniter.RawMessage(`{"srcip":"198.51.100.7","srcport":"51234","dstuser":"root"}`),
+		Location: aws.String("/var/log/secure"),
+	}
+	event.SetCoreFields(TypeAlert, (*timestamp.RFC3339)(&tm), event)
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyIPAddress("198.51.100.7")
+	event.AppendAnyUsernames("root")
+
+	testutil.CheckPantherParser(t, log, &AlertParser{}, &event.PantherLog)
+}
+
+func TestAlertWithSyscheck(t *testing.T) {
+	// nolint:lll
+	log := `{"timestamp":"2020-06-01T18:56:20.351+0000","rule":{"level":7,"description":"Integrity checksum changed.","id":"550","groups":["ossec","syscheck"]},"agent":{"id":"000","name":"wazuh-manager"},"manager":{"name":"wazuh-manager"},"id":"1591037780.23456","full_log":"File '/etc/hosts' modified","syscheck":{"path":"/etc/hosts","mode":"realtime","size_before":"150","size_after":"170","perm_after":"rw-r--r--","uid_after":"0","gid_after":"0","md5_before":"22271cce0732d887e3980e5a6868e459","md5_after":"220a8f105af5e711f99e52583209a871","sha1_before":"4df65340f366c18f85be228c26817e20391f32c4","sha1_after":"c7414fd048c81361720e2d9c8d2f82faf33748b6","sha256_before":"9d7ecf2d1b1fd5ef2b6e2fa8e4e1fbf2a6f6b3d07b5e4b7e8d0f0b1a2c3d4e5f","sha256_after":"0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0","changed_attributes":["size","md5","sha1","sha256"],"event":"modified"},"decoder":{"name":"syscheck_integrity_changed"},"location":"syscheck"}`
+
+	tm := time.Date(2020, 6, 1, 18, 56, 20, 351000000, time.UTC)
+	event := &Alert{
+		Timestamp: (*timestamp.SuricataTimestamp)(&tm),
+		ID:        aws.String("1591037780.23456"),
+		Rule: &Rule{
+			ID:          aws.String("550"),
+			Level:       aws.Int(7),
+			Description: aws.String("Integrity checksum changed."),
+			Groups:      []string{"ossec", "syscheck"},
+		},
+		Agent: &Agent{
+			ID:   aws.String("000"),
+			Name: aws.String("wazuh-manager"),
+		},
+		Manager: &Manager{
+			Name: aws.String("wazuh-manager"),
+		},
+		FullLog: aws.String("File '/etc/hosts' modified"),
+		Syscheck: &Syscheck{
+			Path:              aws.String("/etc/hosts"),
+			Event:             aws.String("modified"),
+			Mode:              aws.String("realtime"),
+			ChangedAttributes: []string{"size", "md5", "sha1", "sha256"},
+			SizeBefore:        aws.String("150"),
+			SizeAfter:         aws.String("170"),
+			PermAfter:         aws.String("rw-r--r--"),
+			UIDAfter:          aws.String("0"),
+			GIDAfter:          aws.String("0"),
+			MD5Before:         aws.String("22271cce0732d887e3980e5a6868e459"),
+			MD5After:          aws.String("220a8f105af5e711f99e52583209a871"),
+			SHA1Before:        aws.String("4df65340f366c18f85be228c26817e20391f32c4"),
+			SHA1After:         aws.String("c7414fd048c81361720e2d9c8d2f82faf33748b6"),
+			SHA256Before:      aws.String("9d7ecf2d1b1fd5ef2b6e2fa8e4e1fbf2a6f6b3d07b5e4b7e8d0f0b1a2c3d4e5f"),
+			SHA256After:       aws.String("0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"),
+		},
+		Decoder: &Decoder{
+			Name: aws.String("syscheck_integrity_changed"),
+		},
+		Location: aws.String("syscheck"),
+	}
+	event.SetCoreFields(TypeAlert, (*timestamp.RFC3339)(&tm), event)
+	event.AppendAnyMD5Hashes("22271cce0732d887e3980e5a6868e459", "220a8f105af5e711f99e52583209a871")
+	event.AppendAnySHA1Hashes("4df65340f366c18f85be228c26817e20391f32c4", "c7414fd048c81361720e2d9c8d2f82faf33748b6")
+	event.AppendAnySHA256Hashes("9d7ecf2d1b1fd5ef2b6e2fa8e4e1fbf2a6f6b3d07b5e4b7e8d0f0b1a2c3d4e5f", "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0")
+
+	testutil.CheckPantherParser(t, log, &AlertParser{}, &event.PantherLog)
+}
+
+func TestAlertInvalid(t *testing.T) {
+	parser := (&AlertParser{}).New()
+	// missing agent
+	// nolint:lll
+	_, err := parser.Parse(`{"timestamp":"2020-06-01T18:56:20.351+0000","rule":{"level":5,"description":"sshd: authentication failed.","id":"5716"},"id":"1591037780.12345","location":"/var/log/secure"}`)
+	require.Error(t, err)
+	// OSSEC alert
+	// nolint:lll
+	_, err = parser.Parse(`{"rule":{"level":5,"comment":"Syslogd restarted.","sidid":1005,"group":"syslog,errors,"},"id":"1510376401.0","TimeStamp":1510376401000,"location":"/var/log/messages","full_log":"Nov 11 00:00:01 ix syslogd[72090]: restart","hostname":"ix","program_name":"syslogd"}`)
+	require.Error(t, err)
+}
+
+func TestAlertLogType(t *testing.T) {
+	parser := &AlertParser{}
+	require.Equal(t, "Wazuh.Alert", parser.LogType())
+}
@@ -0,0 +1,175 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// nolint:lll
+type EventLog struct {
+	System
+	EventData map[string]string   `json:"EventData,omitempty" description:"The event specific fields, by name. Numeric values are converted to strings."`
+	UserData  jsoniter.RawMessage `json:"UserData,omitempty" description:"The event specific fields of events that do not use EventData, as a JSON object."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// EventLogParser parses Windows event log records shipped by Winlogbeat or NXLog
+type EventLogParser struct{}
+
+// excludedEvents are the event IDs by channel of events parsed by log types of their own
+var excludedEvents = map[string]map[int64]bool{}
+
+// ExcludeEvents makes EventLogParser reject the events of a channel with the given IDs.
+// Packages parsing specific events in log types of their own (ie Sysmon.*) call it in their init() block,
+// so that these events are only classified as the dedicated log type.
+func ExcludeEvents(channel string, eventIDs ...int64) {
+	if excludedEvents[channel] == nil {
+		excludedEvents[channel] = make(map[int64]bool, len(eventIDs))
+	}
+	for _, eventID := range eventIDs {
+		excludedEvents[channel][eventID] = true
+	}
+}
+
+var _ parsers.LogParser = (*EventLogParser)(nil)
+
+// New returns an initialized LogParser for Windows event log records
+func (p *EventLogParser) New() parsers.LogParser {
+	return &EventLogParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *EventLogParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	record, err := DecodeRecord(log)
+	if err != nil {
+		return nil, err
+	}
+	if channel, eventID := record.System.Channel, record.System.EventID; channel != nil && eventID != nil && excludedEvents[*channel][*eventID] {
+		return nil, errors.Errorf("%s event %d is parsed by a dedicated log type", *channel, *eventID)
+	}
+	eventData, err := decodeEventData(record.EventData)
+	if err != nil {
+		return nil, err
+	}
+	event := &EventLog{
+		System:    record.System,
+		EventData: eventData,
+		UserData:  record.UserData,
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *EventLogParser) LogType() string {
+	return TypeEventLog
+}
+
+// decodeEventData decodes the event data fields, keeping the JSON text of non string values
+func decodeEventData(data jsoniter.RawMessage) (map[string]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	fields := map[string]jsoniter.RawMessage{}
+	if err := jsoniter.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	eventData := make(map[string]string, len(fields))
+	for name, value := range fields {
+		switch jsoniter.Get(value).ValueType() {
+		case jsoniter.NilValue:
+			continue
+		case jsoniter.StringValue:
+			eventData[name] = jsoniter.Get(value).ToString()
+		default:
+			eventData[name] = string(value)
+		}
+	}
+	return eventData, nil
+}
+
+// Well known event data fields, added to the indicator fields
+var (
+	ipAddressFields = []string{"IpAddress", "ClientAddress", "SourceAddress", "DestAddress", "SourceIp", "DestinationIp"}
+	domainFields    = []string{"SourceHostname", "DestinationHostname", "QueryName"}
+	usernameFields  = []string{"SubjectUserName", "TargetUserName", "User"}
+)
+
+func (event *EventLog) updatePantherFields(p *EventLogParser) {
+	event.SetCoreFields(p.LogType(), event.TimeCreated, event)
+
+	if !event.AppendAnyIPAddressPtr(event.Computer) {
+		event.AppendAnyDomainNamePtrs(event.Computer)
+	}
+	event.AppendAnyUsernamePtrs(event.UserName)
+
+	for _, name := range ipAddressFields {
+		event.AppendAnyIPAddress(event.EventData[name])
+	}
+	for _, name := range domainFields {
+		if value := event.EventData[name]; IsValue(value) && !event.AppendAnyIPAddress(value) {
+			event.AppendAnyDomainNames(value)
+		}
+	}
+	for _, name := range usernameFields {
+		if value := event.EventData[name]; IsValue(value) {
+			event.AppendAnyUsernames(value)
+		}
+	}
+	if hashes := event.EventData["Hashes"]; hashes != "" {
+		AppendHashes(&event.PantherLog, hashes)
+	}
+}
+
+// IsValue checks if an event data value is set, Windows uses '-' for empty values
+func IsValue(value string) bool {
+	return value != "" && value != "-"
+}
+
+// AppendHashes adds the hashes of a Sysmon 'Hashes' field (ie. 'SHA1=...,MD5=...,SHA256=...,IMPHASH=...') to the indicator fields
+func AppendHashes(event *parsers.PantherLog, hashes string) {
+	for _, hash := range strings.Split(hashes, ",") {
+		pos := strings.IndexByte(hash, '=')
+		if pos == -1 {
+			continue
+		}
+		switch value := hash[pos+1:]; strings.ToUpper(hash[:pos]) {
+		case "MD5":
+			event.AppendAnyMD5Hashes(value)
+		case "SHA1":
+			event.AppendAnySHA1Hashes(value)
+		case "SHA256":
+			event.AppendAnySHA256Hashes(value)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestEventLogWinlogbeat(t *testing.T) {
+	// nolint:lll
+	log := `{"@timestamp":"2020-06-01T15:09:42.123Z","message":"An account was successfully logged on.","log":{"level":"information"},"event":{"code":4624,"kind":"event","provider":"Microsoft-Windows-Security-Auditing","action":"logged-in"},"winlog":{"channel":"Security","computer_name":"DC01.example.com","event_id":4624,"provider_name":"Microsoft-Windows-Security-Auditing","provider_guid":"{54849625-5478-4994-A5BA-3E3B0328C30D}","record_id":1035,"version":2,"task":"Logon","opcode":"Info","keywords":["Audit Success"],"process":{"pid":636,"thread":{"id":4120}},"api":"wineventlog","event_data":{"SubjectUserSid":"S-1-5-18","SubjectUserName":"DC01$","SubjectDomainName":"EXAMPLE","TargetUserName":"jdoe","TargetDomainName":"EXAMPLE","LogonType":"3","WorkstationName":"-","IpAddress":"10.0.0.5","IpPort":"49812"}},"host":{"name":"DC01"}}`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 42, 123000000, time.UTC)
+	event := &EventLog{
+		System: System{
+			TimeCreated:   (*timestamp.RFC3339)(&tm),
+			EventID:       aws.Int64(4624),
+			Channel:       aws.String("Security"),
+			Computer:      aws.String("DC01.example.com"),
+			Provider:      aws.String("Microsoft-Windows-Security-Auditing"),
+			ProviderGUID:  aws.String("{54849625-5478-4994-A5BA-3E3B0328C30D}"),
+			EventRecordID: aws.Int64(1035),
+			Version:       aws.Int64(2),
+			Level:         aws.String("information"),
+			Task:          aws.String("Logon"),
+			Opcode:        aws.String("Info"),
+			Keywords:      []string{"Audit Success"},
+			ProcessID:     aws.Int64(636),
+			ThreadID:      aws.Int64(4120),
+			Message:       aws.String("An account was successfully logged on."),
+		},
+		EventData: map[string]string{
+			"SubjectUserSid":    "S-1-5-18",
+			"SubjectUserName":   "DC01$",
+			"SubjectDomainName": "EXAMPLE",
+			"TargetUserName":    "jdoe",
+			"TargetDomainName":  "EXAMPLE",
+			"LogonType":         "3",
+			"WorkstationName":   "-",
+			"IpAddress":         "10.0.0.5",
+			"IpPort":            "49812",
+		},
+	}
+	event.SetCoreFields(TypeEventLog, event.TimeCreated, event)
+	event.AppendAnyDomainNames("DC01.example.com")
+	event.AppendAnyIPAddress("10.0.0.5")
+	event.AppendAnyUsernames("DC01$", "jdoe")
+
+	testutil.CheckPantherParser(t, log, &EventLogParser{}, &event.PantherLog)
+}
+
+func TestEventLogNXLog(t *testing.T) {
+	// nolint:lll
+	log := `{"EventTime":"2020-06-01 15:09:42","Hostname":"WS01.example.com","Keywords":-9218868437227405312,"EventType":"AUDIT_FAILURE","SeverityValue":4,"Severity":"ERROR","EventID":4625,"SourceName":"Microsoft-Windows-Security-Auditing","ProviderGuid":"{54849625-5478-4994-A5BA-3E3B0328C30D}","Version":0,"Task":12544,"OpcodeValue":0,"RecordNumber":2841,"ProcessID":588,"ThreadID":3512,"Channel":"Security","Message":"An account failed to log on.","Category":"Logon","Opcode":"Info","SubjectUserName":"-","TargetUserName":"administrator","LogonType":"10","Status":"0xc000006d","IpAddress":"203.0.113.7","IpPort":"0","EventReceivedTime":"2020-06-01 15:09:43","SourceModuleName":"eventlog","SourceModuleType":"im_msvistalog"}`
+
+	tm := time.Date(2020, 6, 1, 15, 9, 42, 0, time.UTC)
+	event := &EventLog{
+		System: System{
+			TimeCreated:   (*timestamp.RFC3339)(&tm),
+			EventID:       aws.Int64(4625),
+			Channel:       aws.String("Security"),
+			Computer:      aws.String("WS01.example.com"),
+			Provider:      aws.String("Microsoft-Windows-Security-Auditing"),
+			ProviderGUID:  aws.String("{54849625-5478-4994-A5BA-3E3B0328C30D}"),
+			EventRecordID: aws.Int64(2841),
+			Version:       aws.Int64(0),
+			Level:         aws.String("ERROR"),
+			Task:          aws.String("Logon"),
+			Opcode:        aws.String("Info"),
+			Keywords:      []string{"Audit Failure"},
+			ProcessID:     aws.Int64(588),
+			ThreadID:      aws.Int64(3512),
+			Message:       aws.String("An account failed to log on."),
+		},
+		EventData: map[string]string{
+			"SubjectUserName": "-",
+			"TargetUserName":  "administrator",
+			"LogonType":       "10",
+			"Status":          "0xc000006d",
+			"IpAddress":       "203.0.113.7",
+			"IpPort":          "0",
+		},
+	}
+	event.SetCoreFields(TypeEventLog, event.TimeCreated, event)
+	event.AppendAnyDomainNames("WS01.example.com")
+	event.AppendAnyIPAddress("203.0.113.7")
+	event.AppendAnyUsernames("administrator")
+
+	testutil.CheckPantherParser(t, log, &EventLogParser{}, &event.PantherLog)
+}
+
+func TestEventLogInvalid(t *testing.T) {
+	parser := (&EventLogParser{}).New()
+	_, err := parser.Parse(`{"EventTime":"2020-06-01 15:09:42","Hostname":"WS01"}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"winlog":{"channel":"Security","event_id":4624}}`)
+	require.Error(t, err)
+	_, err = parser.Parse(`{"EventTime":"yesterday","Hostname":"WS01","EventID":4625,"Channel":"Security"}`)
+	require.Error(t, err)
+}
+
+func TestEventLogLogType(t *testing.T) {
+	parser := &EventLogParser{}
+	require.Equal(t, "Windows.EventLog", parser.LogType())
+}
@@ -0,0 +1,270 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// System holds the fields common to all Windows events, named after the System element of the event schema
+// nolint:lll
+type System struct {
+	TimeCreated   *timestamp.RFC3339 `json:"TimeCreated" validate:"required" description:"The time the event was logged."`
+	EventID       *int64             `json:"EventID" validate:"required" description:"The identifier of the event type within the provider."`
+	Channel       *string            `json:"Channel" validate:"required" description:"The channel the event was logged to, eg. Security, System or Microsoft-Windows-Sysmon/Operational."`
+	Computer      *string            `json:"Computer" validate:"required" description:"The name of the computer on which the event occurred."`
+	Provider      *string            `json:"Provider,omitempty" description:"The name of the provider that logged the event."`
+	ProviderGUID  *string            `json:"ProviderGuid,omitempty" description:"The GUID of the provider that logged the event."`
+	EventRecordID *int64             `json:"EventRecordID,omitempty" description:"The record number assigned to the event when it was logged."`
+	Version       *int64             `json:"Version,omitempty" description:"The version number of the event definition."`
+	Level         *string            `json:"Level,omitempty" description:"The severity level of the event, eg. Information, Warning or Error."`
+	Task          *string            `json:"Task,omitempty" description:"The task or category of the event, eg. Logon."`
+	Opcode        *string            `json:"Opcode,omitempty" description:"The activity or point within an activity that the application was performing when it raised the event."`
+	Keywords      []string           `json:"Keywords,omitempty" description:"The keywords used to classify the event, eg. Audit Success or Audit Failure."`
+	ActivityID    *string            `json:"ActivityID,omitempty" description:"The GUID of the activity the event belongs to."`
+	ProcessID     *int64             `json:"ProcessID,omitempty" description:"The ID of the process that logged the event."`
+	ThreadID      *int64             `json:"ThreadID,omitempty" description:"The ID of the thread that logged the event."`
+	UserID        *string            `json:"UserID,omitempty" description:"The security identifier (SID) of the user the event was logged for."`
+	UserName      *string            `json:"UserName,omitempty" description:"The name of the user the event was logged for."`
+	UserDomain    *string            `json:"UserDomain,omitempty" description:"The domain of the user the event was logged for."`
+	Message       *string            `json:"Message,omitempty" description:"The rendered message of the event."`
+}
+
+// Record is a Windows event log record decoded from the JSON documents of Winlogbeat or NXLog
+type Record struct {
+	System System
+	// EventData is the JSON object of the event specific fields
+	EventData jsoniter.RawMessage
+	// UserData is the JSON object of the event specific fields of events that do not use EventData
+	UserData jsoniter.RawMessage
+}
+
+// DecodeRecord decodes a Winlogbeat or NXLog (im_msvistalog) JSON document
+func DecodeRecord(log string) (*Record, error) {
+	fields := map[string]jsoniter.RawMessage{}
+	if err := jsoniter.UnmarshalFromString(log, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields[winlogbeatField]; ok {
+		return decodeWinlogbeat(log)
+	}
+	if _, ok := fields[nxlogEventIDField]; ok {
+		return decodeNXLog(fields)
+	}
+	return nil, errors.New("not a Winlogbeat or NXLog Windows event")
+}
+
+const winlogbeatField = "winlog"
+
+// nolint:lll
+type winlogbeatEvent struct {
+	Timestamp *timestamp.RFC3339 `json:"@timestamp"`
+	Message   *string            `json:"message"`
+	Log       struct {
+		Level *string `json:"level"`
+	} `json:"log"`
+	Winlog struct {
+		Channel      *string         `json:"channel"`
+		ComputerName *string         `json:"computer_name"`
+		EventID      *numerics.Int64 `json:"event_id"`
+		ProviderName *string         `json:"provider_name"`
+		ProviderGUID *string         `json:"provider_guid"`
+		RecordID     *numerics.Int64 `json:"record_id"`
+		Version      *numerics.Int64 `json:"version"`
+		Task         *string         `json:"task"`
+		Opcode       *string         `json:"opcode"`
+		Keywords     []string        `json:"keywords"`
+		ActivityID   *string         `json:"activity_id"`
+		Process      struct {
+			PID    *numerics.Int64 `json:"pid"`
+			Thread struct {
+				ID *numerics.Int64 `json:"id"`
+			} `json:"thread"`
+		} `json:"process"`
+		User struct {
+			Identifier *string `json:"identifier"`
+			Name       *string `json:"name"`
+			Domain     *string `json:"domain"`
+		} `json:"user"`
+		EventData jsoniter.RawMessage `json:"event_data"`
+		UserData  jsoniter.RawMessage `json:"user_data"`
+	} `json:"winlog"`
+}
+
+func decodeWinlogbeat(log string) (*Record, error) {
+	event := winlogbeatEvent{}
+	if err := jsoniter.UnmarshalFromString(log, &event); err != nil {
+		return nil, err
+	}
+	winlog := &event.Winlog
+	return &Record{
+		System: System{
+			TimeCreated:   event.Timestamp,
+			EventID:       (*int64)(winlog.EventID),
+			Channel:       winlog.Channel,
+			Computer:      winlog.ComputerName,
+			Provider:      winlog.ProviderName,
+			ProviderGUID:  winlog.ProviderGUID,
+			EventRecordID: (*int64)(winlog.RecordID),
+			Version:       (*int64)(winlog.Version),
+			Level:         event.Log.Level,
+			Task:          winlog.Task,
+			Opcode:        winlog.Opcode,
+			Keywords:      winlog.Keywords,
+			ActivityID:    winlog.ActivityID,
+			ProcessID:     (*int64)(winlog.Process.PID),
+			ThreadID:      (*int64)(winlog.Process.Thread.ID),
+			UserID:        winlog.User.Identifier,
+			UserName:      winlog.User.Name,
+			UserDomain:    winlog.User.Domain,
+			Message:       event.Message,
+		},
+		EventData: winlog.EventData,
+		UserData:  winlog.UserData,
+	}, nil
+}
+
+// NXLog adds the event data fields to the top level of the document, along with these fields
+const (
+	nxlogEventIDField = "EventID"
+
+	nxlogEventTimeField     = "EventTime"
+	nxlogHostnameField      = "Hostname"
+	nxlogChannelField       = "Channel"
+	nxlogSourceNameField    = "SourceName"
+	nxlogProviderGUIDField  = "ProviderGuid"
+	nxlogRecordNumberField  = "RecordNumber"
+	nxlogVersionField       = "Version"
+	nxlogSeverityField      = "Severity"
+	nxlogCategoryField      = "Category"
+	nxlogOpcodeField        = "Opcode"
+	nxlogEventTypeField     = "EventType"
+	nxlogActivityIDField    = "ActivityID"
+	nxlogProcessIDField     = "ProcessID"
+	nxlogThreadIDField      = "ThreadID"
+	nxlogUserIDField        = "UserID"
+	nxlogAccountNameField   = "AccountName"
+	nxlogDomainField        = "Domain"
+	nxlogMessageField       = "Message"
+	nxlogEventReceivedField = "EventReceivedTime"
+)
+
+// nxlogMetadataFields are the fields of NXLog documents that are neither system nor event data fields
+var nxlogMetadataFields = []string{
+	nxlogEventReceivedField,
+	"SourceModuleName",
+	"SourceModuleType",
+	"SeverityValue",
+	"OpcodeValue",
+	"Task",
+	"Keywords",
+	"AccountType",
+	"RelatedActivityID",
+}
+
+// nxlogEventTypeKeywords maps the NXLog event types of audit events to the keywords of the event
+var nxlogEventTypeKeywords = map[string]string{
+	"AUDIT_SUCCESS": "Audit Success",
+	"AUDIT_FAILURE": "Audit Failure",
+}
+
+// nxlogTimeLayouts are the formats of NXLog timestamps, NXLog writes local time without a time zone by default
+var nxlogTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+}
+
+func decodeNXLog(fields map[string]jsoniter.RawMessage) (*Record, error) {
+	system := System{}
+	for field, dst := range map[string]interface{}{
+		nxlogEventIDField:      &system.EventID,
+		nxlogHostnameField:     &system.Computer,
+		nxlogChannelField:      &system.Channel,
+		nxlogSourceNameField:   &system.Provider,
+		nxlogProviderGUIDField: &system.ProviderGUID,
+		nxlogRecordNumberField: &system.EventRecordID,
+		nxlogVersionField:      &system.Version,
+		nxlogSeverityField:     &system.Level,
+		nxlogCategoryField:     &system.Task,
+		nxlogOpcodeField:       &system.Opcode,
+		nxlogActivityIDField:   &system.ActivityID,
+		nxlogProcessIDField:    &system.ProcessID,
+		nxlogThreadIDField:     &system.ThreadID,
+		nxlogUserIDField:       &system.UserID,
+		nxlogAccountNameField:  &system.UserName,
+		nxlogDomainField:       &system.UserDomain,
+		nxlogMessageField:      &system.Message,
+	} {
+		if value, ok := fields[field]; ok {
+			if err := jsoniter.Unmarshal(value, dst); err != nil {
+				return nil, errors.Wrapf(err, "invalid NXLog field %q", field)
+			}
+			delete(fields, field)
+		}
+	}
+
+	if value, ok := fields[nxlogEventTimeField]; ok {
+		eventTime := ""
+		if err := jsoniter.Unmarshal(value, &eventTime); err != nil {
+			return nil, errors.Wrapf(err, "invalid NXLog field %q", nxlogEventTimeField)
+		}
+		for _, layout := range nxlogTimeLayouts {
+			if ts, err := timestamp.Parse(layout, eventTime); err == nil {
+				system.TimeCreated = &ts
+				break
+			}
+		}
+		if system.TimeCreated == nil {
+			return nil, errors.Errorf("invalid NXLog event time %q", eventTime)
+		}
+		delete(fields, nxlogEventTimeField)
+	}
+
+	if value, ok := fields[nxlogEventTypeField]; ok {
+		eventType := ""
+		if err := jsoniter.Unmarshal(value, &eventType); err == nil {
+			if keyword, ok := nxlogEventTypeKeywords[eventType]; ok {
+				system.Keywords = []string{keyword}
+			}
+		}
+		delete(fields, nxlogEventTypeField)
+	}
+
+	for _, field := range nxlogMetadataFields {
+		delete(fields, field)
+	}
+
+	record := &Record{
+		System: system,
+	}
+	if len(fields) > 0 {
+		eventData, err := jsoniter.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		record.EventData = eventData
+	}
+	return record, nil
+}
@@ -0,0 +1,96 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekConn struct {
+	TS            *timestamp.UnixFloat `json:"ts,omitempty" validate:"required" description:"This is the time of the first packet."`
+	UID           *string              `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH       *string              `json:"id.orig_h" validate:"required" description:"The originator’s IP address."`
+	IDOrigP       *uint16              `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH       *string              `json:"id.resp_h" validate:"required" description:"The responder’s IP address."`
+	IDRespP       *uint16              `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Proto         *string              `json:"proto" validate:"required" description:"The transport layer protocol of the connection."`
+	Service       *string              `json:"service,omitempty" description:"An identification of an application protocol being sent over the connection."`
+	Duration      *float64             `json:"duration,omitempty" description:"How long the connection lasted (in seconds)."`
+	OrigBytes     *uint64              `json:"orig_bytes,omitempty" description:"The number of payload bytes the originator sent."`
+	RespBytes     *uint64              `json:"resp_bytes,omitempty" description:"The number of payload bytes the responder sent."`
+	ConnState     *string              `json:"conn_state" validate:"required" description:"Connection state code (S0, S1, SF, REJ, S2, S3, RSTO, RSTR, RSTOS0, RSTRH, SH, SHR, OTH)."`
+	LocalOrig     *bool                `json:"local_orig,omitempty" description:"If the connection is originated locally, this value will be true."`
+	LocalResp     *bool                `json:"local_resp,omitempty" description:"If the connection is responded to locally, this value will be true."`
+	MissedBytes   *uint64              `json:"missed_bytes,omitempty" description:"Indicates the number of bytes missed in content gaps, which is representative of packet loss."`
+	History       *string              `json:"history,omitempty" description:"Records the state history of connections as a string of letters."`
+	OrigPkts      *uint64              `json:"orig_pkts,omitempty" description:"Number of packets that the originator sent."`
+	OrigIPBytes   *uint64              `json:"orig_ip_bytes,omitempty" description:"Number of IP level bytes that the originator sent (as seen on the wire, taken from the IP total_length header field)."`
+	RespPkts      *uint64              `json:"resp_pkts,omitempty" description:"Number of packets that the responder sent."`
+	RespIPBytes   *uint64              `json:"resp_ip_bytes,omitempty" description:"Number of IP level bytes that the responder sent (as seen on the wire, taken from the IP total_length header field)."`
+	TunnelParents []string             `json:"tunnel_parents,omitempty" description:"If this connection was over a tunnel, indicate the uid values for any encapsulating parent connections used over the lifetime of this inner connection."`
+	OrigL2Addr    *string              `json:"orig_l2_addr,omitempty" description:"Link-layer address of the originator, if available."`
+	RespL2Addr    *string              `json:"resp_l2_addr,omitempty" description:"Link-layer address of the responder, if available."`
+	VLAN          *int                 `json:"vlan,omitempty" description:"The outer VLAN for this connection, if applicable."`
+	InnerVLAN     *int                 `json:"inner_vlan,omitempty" description:"The inner VLAN for this connection, if applicable."`
+	CommunityID   *string              `json:"community_id,omitempty" description:"The Community ID flow hash of the connection."`
+	parsers.PantherLog
+}
+
+// ZeekConnParser parses zeek conn logs
+type ZeekConnParser struct{}
+
+var _ parsers.LogParser = (*ZeekConnParser)(nil)
+
+func (p *ZeekConnParser) New() parsers.LogParser {
+	return &ZeekConnParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekConnParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekConn := &ZeekConn{}
+
+	err := jsoniter.UnmarshalFromString(log, zeekConn)
+	if err != nil {
+		return nil, err
+	}
+
+	zeekConn.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(zeekConn); err != nil {
+		return nil, err
+	}
+
+	return zeekConn.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekConnParser) LogType() string {
+	return TypeZeekConn
+}
+
+func (event *ZeekConn) updatePantherFields(p *ZeekConnParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	event.AppendAnyIPAddressPtr(event.IDOrigH)
+	event.AppendAnyIPAddressPtr(event.IDRespH)
+}
@@ -40,7 +40,7 @@ type ZeekDNS struct {
 	IDRespH    *string              `json:"id.resp_h" validate:"required" description:"The responder’s IP address."`
 	IDRespP    *uint16              `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
 	Proto      *string              `json:"proto" validate:"required" description:"The transport layer protocol of the connection."`
-	TransID    *uint16              `json:"trans_id,omitempty" description:"A 16-bit identifier assigned by the program that generated the DNS query. Also used in responses to match up replies to outstanding queries."`
+	TransID    *uint16              `json:"trans_id" validate:"required" description:"A 16-bit identifier assigned by the program that generated the DNS query. Also used in responses to match up replies to outstanding queries."`
 	Query      *string              `json:"query,omitempty" description:"The domain name that is the subject of the DNS query."`
 	QClass     *uint64              `json:"qclass,omitempty" description:"The QCLASS value specifying the class of the query."`
 	QClassName *string              `json:"qclass_name,omitempty" description:"A descriptive name for the class of the query."`
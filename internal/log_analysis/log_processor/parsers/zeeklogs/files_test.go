@@ -0,0 +1,78 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekFiles(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1591367999.574719,"fuid":"FEEsZS1w0Z0VJIb5x4","tx_hosts":["31.3.245.133"],"rx_hosts":["192.168.4.76"],"conn_uids":["C5bLoe2Mvxqhawzqqd"],"source":"HTTP","depth":0,"analyzers":["MD5","SHA1"],"mime_type":"text/plain","duration":0.0,"is_orig":false,"seen_bytes":39,"total_bytes":39,"missing_bytes":0,"overflow_bytes":0,"timedout":false,"md5":"2a61cd6c5ad5f5c3bb1d0d5d4a8d2f52","sha1":"e6f0a9a5f0e9e5a7c4e5df1f4a0d1be27b6e8f0d"}`
+
+	expectedTime := time.Unix(1591367999, 574718952).UTC()
+	expectedEvent := &ZeekFiles{
+		TS:            (*timestamp.UnixFloat)(&expectedTime),
+		FUID:          aws.String("FEEsZS1w0Z0VJIb5x4"),
+		TxHosts:       []string{"31.3.245.133"},
+		RxHosts:       []string{"192.168.4.76"},
+		ConnUIDs:      []string{"C5bLoe2Mvxqhawzqqd"},
+		Source:        aws.String("HTTP"),
+		Depth:         aws.Int(0),
+		Analyzers:     []string{"MD5", "SHA1"},
+		MIMEType:      aws.String("text/plain"),
+		Duration:      aws.Float64(0),
+		IsOrig:        aws.Bool(false),
+		SeenBytes:     aws.Uint64(39),
+		TotalBytes:    aws.Uint64(39),
+		MissingBytes:  aws.Uint64(0),
+		OverflowBytes: aws.Uint64(0),
+		TimedOut:      aws.Bool(false),
+		MD5:           aws.String("2a61cd6c5ad5f5c3bb1d0d5d4a8d2f52"),
+		SHA1:          aws.String("e6f0a9a5f0e9e5a7c4e5df1f4a0d1be27b6e8f0d"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Files")
+	expectedEvent.AppendAnyIPAddress("31.3.245.133")
+	expectedEvent.AppendAnyIPAddress("192.168.4.76")
+	expectedEvent.AppendAnyMD5HashPtrs(expectedEvent.MD5)
+	expectedEvent.AppendAnySHA1HashPtrs(expectedEvent.SHA1)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekFiles(t, log, expectedEvent)
+}
+
+func TestZeekFilesType(t *testing.T) {
+	parser := &ZeekFilesParser{}
+	require.Equal(t, "Zeek.Files", parser.LogType())
+}
+
+func checkZeekFiles(t *testing.T, log string, expectedEvent *ZeekFiles) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekFilesParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
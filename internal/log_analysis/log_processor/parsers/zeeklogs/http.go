@@ -0,0 +1,110 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekHTTP struct {
+	TS              *timestamp.UnixFloat `json:"ts,omitempty" validate:"required" description:"Timestamp for when the request happened."`
+	UID             *string              `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH         *string              `json:"id.orig_h" validate:"required" description:"The originator’s IP address."`
+	IDOrigP         *uint16              `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH         *string              `json:"id.resp_h" validate:"required" description:"The responder’s IP address."`
+	IDRespP         *uint16              `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	TransDepth      *int                 `json:"trans_depth" validate:"required" description:"Represents the pipelined depth into the connection of this request/response transaction."`
+	Method          *string              `json:"method,omitempty" description:"Verb used in the HTTP request (GET, POST, HEAD, etc.)."`
+	Host            *string              `json:"host,omitempty" description:"Value of the HOST header."`
+	URI             *string              `json:"uri,omitempty" description:"URI used in the request."`
+	Referrer        *string              `json:"referrer,omitempty" description:"Value of the “referer” header."`
+	Version         *string              `json:"version,omitempty" description:"Value of the version portion of the request."`
+	UserAgent       *string              `json:"user_agent,omitempty" description:"Value of the User-Agent header from the client."`
+	Origin          *string              `json:"origin,omitempty" description:"Value of the Origin header from the client."`
+	RequestBodyLen  *uint64              `json:"request_body_len,omitempty" description:"Actual uncompressed content size of the data transferred from the client."`
+	ResponseBodyLen *uint64              `json:"response_body_len,omitempty" description:"Actual uncompressed content size of the data transferred from the server."`
+	StatusCode      *int                 `json:"status_code,omitempty" description:"Status code returned by the server."`
+	StatusMsg       *string              `json:"status_msg,omitempty" description:"Status message returned by the server."`
+	InfoCode        *int                 `json:"info_code,omitempty" description:"Last seen 1xx informational reply code returned by the server."`
+	InfoMsg         *string              `json:"info_msg,omitempty" description:"Last seen 1xx informational reply message returned by the server."`
+	Tags            []string             `json:"tags,omitempty" description:"A set of indicators of various attributes discovered and related to a particular request/response pair."`
+	Username        *string              `json:"username,omitempty" description:"Username if basic-auth is performed for the request."`
+	Password        *string              `json:"password,omitempty" description:"Password if basic-auth is performed for the request."`
+	Proxied         []string             `json:"proxied,omitempty" description:"All of the headers that may indicate if the request was proxied."`
+	OrigFUIDs       []string             `json:"orig_fuids,omitempty" description:"An ordered vector of file unique IDs from the originator."`
+	OrigFilenames   []string             `json:"orig_filenames,omitempty" description:"An ordered vector of filenames from the client."`
+	OrigMIMETypes   []string             `json:"orig_mime_types,omitempty" description:"An ordered vector of mime types from the originator."`
+	RespFUIDs       []string             `json:"resp_fuids,omitempty" description:"An ordered vector of file unique IDs from the responder."`
+	RespFilenames   []string             `json:"resp_filenames,omitempty" description:"An ordered vector of filenames from the server."`
+	RespMIMETypes   []string             `json:"resp_mime_types,omitempty" description:"An ordered vector of mime types from the responder."`
+	parsers.PantherLog
+}
+
+// ZeekHTTPParser parses zeek http logs
+type ZeekHTTPParser struct{}
+
+var _ parsers.LogParser = (*ZeekHTTPParser)(nil)
+
+func (p *ZeekHTTPParser) New() parsers.LogParser {
+	return &ZeekHTTPParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekHTTPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekHTTP := &ZeekHTTP{}
+
+	err := jsoniter.UnmarshalFromString(log, zeekHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	zeekHTTP.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(zeekHTTP); err != nil {
+		return nil, err
+	}
+
+	return zeekHTTP.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekHTTPParser) LogType() string {
+	return TypeZeekHTTP
+}
+
+func (event *ZeekHTTP) updatePantherFields(p *ZeekHTTPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	event.AppendAnyIPAddressPtr(event.IDOrigH)
+	event.AppendAnyIPAddressPtr(event.IDRespH)
+
+	// The Host header might be an IP or a domain name
+	if event.Host != nil && !event.AppendAnyIPAddress(*event.Host) {
+		event.AppendAnyDomainNames(*event.Host)
+	}
+
+	// Proxy headers are logged as 'HEADER -> value' strings
+	for _, proxied := range event.Proxied {
+		event.AppendAnyIPAddressInField(proxied)
+	}
+}
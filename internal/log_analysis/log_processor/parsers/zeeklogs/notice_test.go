@@ -0,0 +1,73 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekNotice(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1591368008.452914,"uid":"CHhAvVGS1DHFjwGM9","id.orig_h":"10.0.0.12","id.orig_p":49872,"id.resp_h":"10.0.0.1","id.resp_p":22,"proto":"tcp","note":"SSH::Password_Guessing","msg":"10.0.0.12 appears to be guessing SSH passwords (seen in 30 connections).","sub":"Sampled servers:  10.0.0.1","src":"10.0.0.12","peer_descr":"worker-1","actions":["Notice::ACTION_LOG"],"suppress_for":3600.0,"dropped":false}`
+
+	expectedTime := time.Unix(1591368008, 452913999).UTC()
+	expectedEvent := &ZeekNotice{
+		TS:          (*timestamp.UnixFloat)(&expectedTime),
+		UID:         aws.String("CHhAvVGS1DHFjwGM9"),
+		IDOrigH:     aws.String("10.0.0.12"),
+		IDOrigP:     aws.Uint16(49872),
+		IDRespH:     aws.String("10.0.0.1"),
+		IDRespP:     aws.Uint16(22),
+		Proto:       aws.String("tcp"),
+		Note:        aws.String("SSH::Password_Guessing"),
+		Msg:         aws.String("10.0.0.12 appears to be guessing SSH passwords (seen in 30 connections)."),
+		Sub:         aws.String("Sampled servers:  10.0.0.1"),
+		Src:         aws.String("10.0.0.12"),
+		PeerDescr:   aws.String("worker-1"),
+		Actions:     []string{"Notice::ACTION_LOG"},
+		SuppressFor: aws.Float64(3600),
+		Dropped:     aws.Bool(false),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Notice")
+	expectedEvent.AppendAnyIPAddress("10.0.0.12")
+	expectedEvent.AppendAnyIPAddress("10.0.0.1")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekNotice(t, log, expectedEvent)
+}
+
+func TestZeekNoticeType(t *testing.T) {
+	parser := &ZeekNoticeParser{}
+	require.Equal(t, "Zeek.Notice", parser.LogType())
+}
+
+func checkZeekNotice(t *testing.T, log string, expectedEvent *ZeekNotice) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekNoticeParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
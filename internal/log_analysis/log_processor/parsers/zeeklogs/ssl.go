@@ -0,0 +1,94 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSSL struct {
+	TS                   *timestamp.UnixFloat `json:"ts,omitempty" validate:"required" description:"Time when the SSL connection was first detected."`
+	UID                  *string              `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH              *string              `json:"id.orig_h" validate:"required" description:"The originator’s IP address."`
+	IDOrigP              *uint16              `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH              *string              `json:"id.resp_h" validate:"required" description:"The responder’s IP address."`
+	IDRespP              *uint16              `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Version              *string              `json:"version,omitempty" description:"SSL/TLS version that the server chose."`
+	Cipher               *string              `json:"cipher,omitempty" description:"SSL/TLS cipher suite that the server chose."`
+	Curve                *string              `json:"curve,omitempty" description:"Elliptic curve the server chose when using ECDH/ECDHE."`
+	ServerName           *string              `json:"server_name,omitempty" description:"Value of the Server Name Indicator SSL/TLS extension."`
+	Resumed              *bool                `json:"resumed,omitempty" description:"Flag to indicate if the session was resumed reusing the key material exchanged in an earlier connection."`
+	LastAlert            *string              `json:"last_alert,omitempty" description:"Last alert that was seen during the connection."`
+	NextProtocol         *string              `json:"next_protocol,omitempty" description:"Next protocol the server chose using the application layer next protocol extension, if present."`
+	Established          *bool                `json:"established" validate:"required" description:"Flag to indicate if this ssl session has been established successfully, or if it was aborted during the handshake."`
+	CertChainFUIDs       []string             `json:"cert_chain_fuids,omitempty" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the server."`
+	ClientCertChainFUIDs []string             `json:"client_cert_chain_fuids,omitempty" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the client."`
+	Subject              *string              `json:"subject,omitempty" description:"Subject of the X.509 certificate offered by the server."`
+	Issuer               *string              `json:"issuer,omitempty" description:"Subject of the signer of the X.509 certificate offered by the server."`
+	ClientSubject        *string              `json:"client_subject,omitempty" description:"Subject of the X.509 certificate offered by the client."`
+	ClientIssuer         *string              `json:"client_issuer,omitempty" description:"Subject of the signer of the X.509 certificate offered by the client."`
+	ValidationStatus     *string              `json:"validation_status,omitempty" description:"Result of certificate validation for this connection."`
+	JA3                  *string              `json:"ja3,omitempty" description:"JA3 fingerprint of the client hello."`
+	JA3S                 *string              `json:"ja3s,omitempty" description:"JA3S fingerprint of the server hello."`
+	parsers.PantherLog
+}
+
+// ZeekSSLParser parses zeek ssl logs
+type ZeekSSLParser struct{}
+
+var _ parsers.LogParser = (*ZeekSSLParser)(nil)
+
+func (p *ZeekSSLParser) New() parsers.LogParser {
+	return &ZeekSSLParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSSLParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSSL := &ZeekSSL{}
+
+	err := jsoniter.UnmarshalFromString(log, zeekSSL)
+	if err != nil {
+		return nil, err
+	}
+
+	zeekSSL.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(zeekSSL); err != nil {
+		return nil, err
+	}
+
+	return zeekSSL.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSSLParser) LogType() string {
+	return TypeZeekSSL
+}
+
+func (event *ZeekSSL) updatePantherFields(p *ZeekSSLParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	event.AppendAnyIPAddressPtr(event.IDOrigH)
+	event.AppendAnyIPAddressPtr(event.IDRespH)
+	event.AppendAnyDomainNamePtrs(event.ServerName)
+}
@@ -0,0 +1,78 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSSL(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1591368000.120234,"uid":"CsukF91Bx9mrqdEaH9","id.orig_h":"192.168.4.49","id.orig_p":56718,"id.resp_h":"13.32.202.10","id.resp_p":443,"version":"TLSv12","cipher":"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256","curve":"secp256r1","server_name":"www.taosecurity.com","resumed":false,"next_protocol":"h2","established":true,"cert_chain_fuids":["F2XEvj1CahhdhtfvT4","FZ7ygD3ERPfEVVohG9"],"client_cert_chain_fuids":[],"subject":"CN=www.taosecurity.com","issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","validation_status":"ok","ja3":"e6573e91e6eb777c0933c5b8f97f10cd","ja3s":"623de93db17d313345d7ea481e7443cf"}`
+
+	expectedTime := time.Unix(1591368000, 120234012).UTC()
+	expectedEvent := &ZeekSSL{
+		TS:               (*timestamp.UnixFloat)(&expectedTime),
+		UID:              aws.String("CsukF91Bx9mrqdEaH9"),
+		IDOrigH:          aws.String("192.168.4.49"),
+		IDOrigP:          aws.Uint16(56718),
+		IDRespH:          aws.String("13.32.202.10"),
+		IDRespP:          aws.Uint16(443),
+		Version:          aws.String("TLSv12"),
+		Cipher:           aws.String("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"),
+		Curve:            aws.String("secp256r1"),
+		ServerName:       aws.String("www.taosecurity.com"),
+		Resumed:          aws.Bool(false),
+		NextProtocol:     aws.String("h2"),
+		Established:      aws.Bool(true),
+		CertChainFUIDs:   []string{"F2XEvj1CahhdhtfvT4", "FZ7ygD3ERPfEVVohG9"},
+		Subject:          aws.String("CN=www.taosecurity.com"),
+		Issuer:           aws.String("CN=Amazon,OU=Server CA 1B,O=Amazon,C=US"),
+		ValidationStatus: aws.String("ok"),
+		JA3:              aws.String("e6573e91e6eb777c0933c5b8f97f10cd"),
+		JA3S:             aws.String("623de93db17d313345d7ea481e7443cf"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SSL")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.ServerName)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSSL(t, log, expectedEvent)
+}
+
+func TestZeekSSLType(t *testing.T) {
+	parser := &ZeekSSLParser{}
+	require.Equal(t, "Zeek.SSL", parser.LogType())
+}
+
+func checkZeekSSL(t *testing.T, log string, expectedEvent *ZeekSSL) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSSLParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
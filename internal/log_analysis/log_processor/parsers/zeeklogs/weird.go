@@ -0,0 +1,81 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekWeird struct {
+	TS      *timestamp.UnixFloat `json:"ts,omitempty" validate:"required" description:"The time when the weird occurred."`
+	UID     *string              `json:"uid,omitempty" description:"If a connection is associated with this weird, this will be the connection’s unique ID."`
+	IDOrigH *string              `json:"id.orig_h,omitempty" description:"The originator’s IP address."`
+	IDOrigP *uint16              `json:"id.orig_p,omitempty" description:"The originator’s port number."`
+	IDRespH *string              `json:"id.resp_h,omitempty" description:"The responder’s IP address."`
+	IDRespP *uint16              `json:"id.resp_p,omitempty" description:"The responder’s port number."`
+	Name    *string              `json:"name" validate:"required" description:"The name of the weird that occurred."`
+	Addl    *string              `json:"addl,omitempty" description:"Additional information accompanying the weird if any."`
+	Notice  *bool                `json:"notice" validate:"required" description:"Indicate if this weird was also turned into a notice."`
+	Peer    *string              `json:"peer,omitempty" description:"The peer that originated this weird."`
+	Source  *string              `json:"source,omitempty" description:"The source of the weird."`
+	parsers.PantherLog
+}
+
+// ZeekWeirdParser parses zeek weird logs
+type ZeekWeirdParser struct{}
+
+var _ parsers.LogParser = (*ZeekWeirdParser)(nil)
+
+func (p *ZeekWeirdParser) New() parsers.LogParser {
+	return &ZeekWeirdParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekWeirdParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekWeird := &ZeekWeird{}
+
+	err := jsoniter.UnmarshalFromString(log, zeekWeird)
+	if err != nil {
+		return nil, err
+	}
+
+	zeekWeird.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(zeekWeird); err != nil {
+		return nil, err
+	}
+
+	return zeekWeird.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekWeirdParser) LogType() string {
+	return TypeZeekWeird
+}
+
+func (event *ZeekWeird) updatePantherFields(p *ZeekWeirdParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	event.AppendAnyIPAddressPtr(event.IDOrigH)
+	event.AppendAnyIPAddressPtr(event.IDRespH)
+}
@@ -0,0 +1,68 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekWeird(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1591368001.913307,"uid":"CbRcGT2eeLpr9Umgfc","id.orig_h":"192.168.4.76","id.orig_p":38432,"id.resp_h":"52.8.156.230","id.resp_p":443,"name":"bad_TCP_checksum","notice":false,"peer":"zeek","source":"TCP"}`
+
+	expectedTime := time.Unix(1591368001, 913306951).UTC()
+	expectedEvent := &ZeekWeird{
+		TS:      (*timestamp.UnixFloat)(&expectedTime),
+		UID:     aws.String("CbRcGT2eeLpr9Umgfc"),
+		IDOrigH: aws.String("192.168.4.76"),
+		IDOrigP: aws.Uint16(38432),
+		IDRespH: aws.String("52.8.156.230"),
+		IDRespP: aws.Uint16(443),
+		Name:    aws.String("bad_TCP_checksum"),
+		Notice:  aws.Bool(false),
+		Peer:    aws.String("zeek"),
+		Source:  aws.String("TCP"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Weird")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekWeird(t, log, expectedEvent)
+}
+
+func TestZeekWeirdType(t *testing.T) {
+	parser := &ZeekWeirdParser{}
+	require.Equal(t, "Zeek.Weird", parser.LogType())
+}
+
+func checkZeekWeird(t *testing.T, log string, expectedEvent *ZeekWeird) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekWeirdParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
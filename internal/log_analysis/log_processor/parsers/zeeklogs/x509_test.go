@@ -0,0 +1,76 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekX509(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1591368000.172406,"id":"F2XEvj1CahhdhtfvT4","certificate.version":3,"certificate.serial":"0CE9A6D1F1A2D3C0B9C5B08D8E8B0F7A","certificate.subject":"CN=www.taosecurity.com","certificate.issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","certificate.not_valid_before":1585699200.0,"certificate.not_valid_after":1620043200.0,"certificate.key_alg":"rsaEncryption","certificate.sig_alg":"sha256WithRSAEncryption","certificate.key_type":"rsa","certificate.key_length":2048,"certificate.exponent":"65537","san.dns":["www.taosecurity.com","taosecurity.com"],"san.ip":["13.32.202.10"],"basic_constraints.ca":false}`
+
+	expectedTime := time.Unix(1591368000, 172405958).UTC()
+	notBefore := time.Unix(1585699200, 0).UTC()
+	notAfter := time.Unix(1620043200, 0).UTC()
+	expectedEvent := &ZeekX509{
+		TS:                        (*timestamp.UnixFloat)(&expectedTime),
+		ID:                        aws.String("F2XEvj1CahhdhtfvT4"),
+		CertificateVersion:        aws.Int(3),
+		CertificateSerial:         aws.String("0CE9A6D1F1A2D3C0B9C5B08D8E8B0F7A"),
+		CertificateSubject:        aws.String("CN=www.taosecurity.com"),
+		CertificateIssuer:         aws.String("CN=Amazon,OU=Server CA 1B,O=Amazon,C=US"),
+		CertificateNotValidBefore: (*timestamp.UnixFloat)(&notBefore),
+		CertificateNotValidAfter:  (*timestamp.UnixFloat)(&notAfter),
+		CertificateKeyAlg:         aws.String("rsaEncryption"),
+		CertificateSigAlg:         aws.String("sha256WithRSAEncryption"),
+		CertificateKeyType:        aws.String("rsa"),
+		CertificateKeyLength:      aws.Int(2048),
+		CertificateExponent:       aws.String("65537"),
+		SANDNS:                    []string{"www.taosecurity.com", "taosecurity.com"},
+		SANIP:                     []string{"13.32.202.10"},
+		BasicConstraintsCA:        aws.Bool(false),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.X509")
+	expectedEvent.AppendAnyIPAddress("13.32.202.10")
+	expectedEvent.AppendAnyDomainNames("www.taosecurity.com", "taosecurity.com")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekX509(t, log, expectedEvent)
+}
+
+func TestZeekX509Type(t *testing.T) {
+	parser := &ZeekX509Parser{}
+	require.Equal(t, "Zeek.X509", parser.LogType())
+}
+
+func checkZeekX509(t *testing.T, log string, expectedEvent *ZeekX509) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekX509Parser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -24,7 +24,14 @@ import (
 )
 
 const (
-	TypeZeekDNS = "Zeek.DNS"
+	TypeZeekConn   = "Zeek.Conn"
+	TypeZeekDNS    = "Zeek.DNS"
+	TypeZeekFiles  = "Zeek.Files"
+	TypeZeekHTTP   = "Zeek.HTTP"
+	TypeZeekNotice = "Zeek.Notice"
+	TypeZeekSSL    = "Zeek.SSL"
+	TypeZeekWeird  = "Zeek.Weird"
+	TypeZeekX509   = "Zeek.X509"
 )
 
 func init() {
@@ -35,5 +42,55 @@ func init() {
 			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/dns/main.zeek.html#type-DNS::Info`,
 			Schema:       &ZeekDNS{},
 			NewParser:    parsers.AdapterFactory(&ZeekDNSParser{}),
-		})
+		},
+		logtypes.Config{
+			Name:         TypeZeekConn,
+			Description:  `Zeek IP, TCP, UDP and ICMP connection activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/conn/main.zeek.html#type-Conn::Info`,
+			Schema:       &ZeekConn{},
+			NewParser:    parsers.AdapterFactory(&ZeekConnParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekHTTP,
+			Description:  `Zeek HTTP request/reply activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/http/main.zeek.html#type-HTTP::Info`,
+			Schema:       &ZeekHTTP{},
+			NewParser:    parsers.AdapterFactory(&ZeekHTTPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSSL,
+			Description:  `Zeek SSL/TLS handshake activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ssl/main.zeek.html#type-SSL::Info`,
+			Schema:       &ZeekSSL{},
+			NewParser:    parsers.AdapterFactory(&ZeekSSLParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekFiles,
+			Description:  `Zeek file analysis results`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/files/main.zeek.html#type-Files::Info`,
+			Schema:       &ZeekFiles{},
+			NewParser:    parsers.AdapterFactory(&ZeekFilesParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekX509,
+			Description:  `Zeek X.509 certificate info`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/files/x509/main.zeek.html#type-X509::Info`,
+			Schema:       &ZeekX509{},
+			NewParser:    parsers.AdapterFactory(&ZeekX509Parser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekNotice,
+			Description:  `Zeek notices raised by the notice framework`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/notice/main.zeek.html#type-Notice::Info`,
+			Schema:       &ZeekNotice{},
+			NewParser:    parsers.AdapterFactory(&ZeekNoticeParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekWeird,
+			Description:  `Zeek unexpected network-level activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/notice/weird.zeek.html#type-Weird::Info`,
+			Schema:       &ZeekWeird{},
+			NewParser:    parsers.AdapterFactory(&ZeekWeirdParser{}),
+		},
+	)
 }
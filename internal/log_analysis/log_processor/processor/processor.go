@@ -19,10 +19,10 @@ package processor
  */
 
 import (
-	"bufio"
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -31,7 +31,9 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
 
@@ -49,17 +51,35 @@ var (
 	// to avoid using up lot of memory.
 	// see also: https://golang.org/doc/effective_go.html#channels
 	ParsedEventBufferSize = 1000
+
+	// used to simplify mocking during testing
+	sourceLogTypesFunc = sources.LogTypes
 )
 
 // Process orchestrates the tasks of parsing logs, classification, normalization
 // and forwarding the logs to the appropriate destination. Any errors will cause Lambda invocation to fail
 func Process(dataStreams chan *common.DataStream, destination destinations.Destination) error {
-	factory := func(r *common.DataStream) *Processor {
-		// By initializing the global parsers here we can constrain the proliferation of globals throughout the code.
-		allParsers := registry.AvailableParsers()
-		return NewProcessor(r, allParsers)
+	return process(dataStreams, destination, newStreamProcessor)
+}
+
+// newStreamProcessor creates a processor for a data stream using the parsers and framing of its log types
+func newStreamProcessor(r *common.DataStream) *Processor {
+	// The log types of the stream can select how the stream is split into events
+	if r.Framing == nil {
+		framing, err := registry.Framing(r.LogTypes...)
+		if err != nil { // the source API rejects these sources, fallback to the default framing
+			zap.L().Warn("data stream log types need different framings", zap.Strings("logTypes", r.LogTypes), zap.Error(err))
+		}
+		r.Framing = framing
+	}
+	// By initializing the global parsers here we can constrain the proliferation of globals throughout the code.
+	// Only the log types the stream may contain are offered to the classifier.
+	streamParsers := registry.Parsers(r.LogTypes...)
+	if len(streamParsers) == 0 { // none of the log types of the source is available, fallback to all of them
+		zap.L().Warn("no parsers available for data stream log types", zap.Strings("logTypes", r.LogTypes))
+		streamParsers = registry.AvailableParsers()
 	}
-	return process(dataStreams, destination, factory)
+	return NewProcessor(r, streamParsers)
 }
 
 // entry point to allow customizing processor for testing
@@ -112,29 +132,104 @@ func process(dataStreams chan *common.DataStream, destination destinations.Desti
 // processStream reads the data from an S3 the dataStream, parses it and writes events to the output channel
 func (p *Processor) run(outputChan chan *parsers.Result) error {
 	var err error
-	stream := bufio.NewReader(p.input.Reader)
+	framing := p.input.Framing
+	if framing == nil {
+		framing = common.DefaultFraming{}
+	}
+	events := framing.NewEventReader(p.input.Reader)
 	for {
-		var line string
-		line, err = stream.ReadString(common.EventDelimiter)
+		var event string
+		event, err = events.ReadEvent()
 		if err != nil {
 			if err == io.EOF { // we are done
 				err = nil // not really an error
-				p.processLogLine(line, outputChan)
 			}
 			break
 		}
-		p.processLogLine(line, outputChan)
-	}
-	if err != nil {
-		err = errors.Wrap(err, "failed to ReadString()")
+		if p.reprocess {
+			if err = p.processQuarantinedLine(event, outputChan); err != nil {
+				break
+			}
+			continue
+		}
+		if common.IsCloudWatchLogsData(event) {
+			p.processCloudWatchLogsData(event, outputChan)
+			continue
+		}
+		p.processLogLine(event, outputChan)
 	}
 	p.logStats(err) // emit log line describing the processing of the file and any errors
 	return err
 }
 
+// processCloudWatchLogsData unwraps the log events of CloudWatch Logs subscription envelopes and processes each message
+func (p *Processor) processCloudWatchLogsData(event string, outputChan chan *parsers.Result) {
+	envelopes, err := common.DecodeCloudWatchLogsData(event)
+	if err != nil { // not an envelope after all, process as is
+		p.processLogLine(event, outputChan)
+		return
+	}
+	defer func() {
+		p.input.Hints.CloudWatchLogs = nil
+	}()
+	for _, envelope := range envelopes {
+		if envelope.MessageType != common.CloudWatchLogsDataMessage { // control messages only check the subscription is reachable
+			continue
+		}
+		p.input.Hints.CloudWatchLogs = envelope.Hints()
+		for _, logEvent := range envelope.LogEvents {
+			p.processLogLine(logEvent.Message, outputChan)
+		}
+	}
+}
+
+// processQuarantinedLine classifies a quarantined line again using the log types its source is configured with
+func (p *Processor) processQuarantinedLine(event string, outputChan chan *parsers.Result) error {
+	if len(strings.TrimSpace(event)) == 0 {
+		return nil
+	}
+	line, err := quarantine.ParseLine(event)
+	if err != nil {
+		return err
+	}
+	streamClassifier := p.classifier
+	defer func() {
+		p.classifier = streamClassifier
+		p.reprocessed = nil
+	}()
+	p.classifier = p.sourceClassifier(line)
+	p.reprocessed = line
+	p.processLogLine(line.Line, outputChan)
+	return nil
+}
+
+// sourceClassifier returns a classifier for the log types of the source a quarantined line was read from
+func (p *Processor) sourceClassifier(line *quarantine.Line) classification.ClassifierAPI {
+	if line.Bucket == "" {
+		return p.classifier
+	}
+	logTypes, err := sourceLogTypesFunc(line.Bucket, line.Key)
+	if err != nil { // the source may have been removed since, try all log types
+		p.operation.LogWarn(err, zap.String("bucket", line.Bucket), zap.String("key", line.Key))
+		return p.classifier
+	}
+	cacheKey := strings.Join(logTypes, ",")
+	if classifier, ok := p.sourceClassifiers[cacheKey]; ok {
+		return classifier
+	}
+	sourceParsers := registry.Parsers(logTypes...)
+	if len(sourceParsers) == 0 { // none of the log types of the source is available, fallback to all of them
+		sourceParsers = registry.AvailableParsers()
+	}
+	classifier := classification.NewClassifier(sourceParsers)
+	p.sourceClassifiers[cacheKey] = classifier
+	return classifier
+}
+
 func (p *Processor) processLogLine(line string, outputChan chan *parsers.Result) {
 	classificationResult := p.classifyLogLine(line)
 	if classificationResult.LogType == nil { // unable to classify, no error, keep parsing (best effort, will be logged)
+		p.quarantineLogLine(line, outputChan)
 		return
 	}
 	p.sendEvents(classificationResult, outputChan)
@@ -144,15 +239,55 @@ func (p *Processor) classifyLogLine(line string) *classification.ClassifierResul
 	result := p.classifier.Classify(line)
 	if result.LogType == nil && len(strings.TrimSpace(line)) != 0 { // only if line is not empty do we log (often we get trailing \n's)
 		if p.input.Hints.S3 != nil { // make easy to troubleshoot but do not add log line (even partial) to avoid leaking data into CW
-			p.operation.LogWarn(errors.New("failed to classify log line"),
+			fields := []zap.Field{
 				zap.Uint64("lineNum", p.classifier.Stats().LogLineCount),
 				zap.String("bucket", p.input.Hints.S3.Bucket),
-				zap.String("key", p.input.Hints.S3.Key))
+				zap.String("key", p.input.Hints.S3.Key),
+			}
+			if hints := p.input.Hints.CloudWatchLogs; hints != nil {
+				fields = append(fields,
+					zap.String("logGroup", hints.LogGroup),
+					zap.String("logStream", hints.LogStream))
+			}
+			p.operation.LogWarn(errors.New("failed to classify log line"), fields...)
 		}
 	}
 	return result
 }
 
+// quarantineLogLine sends a line that failed classification to the destination so it can be inspected and reprocessed
+func (p *Processor) quarantineLogLine(line string, outputChan chan *parsers.Result) {
+	if len(strings.TrimSpace(line)) == 0 {
+		return
+	}
+	var quarantined *quarantine.Line
+	if p.reprocessed != nil { // keep pointing to the object the line was originally read from
+		original := *p.reprocessed
+		original.QuarantinedAt = time.Now().UTC()
+		quarantined = &original
+	} else {
+		quarantined = &quarantine.Line{
+			LineNumber:    p.classifier.Stats().LogLineCount,
+			QuarantinedAt: time.Now().UTC(),
+			Line:          strings.TrimSuffix(line, string(common.EventDelimiter)),
+		}
+		if p.input.Hints.S3 != nil {
+			quarantined.Bucket = p.input.Hints.S3.Bucket
+			quarantined.Key = p.input.Hints.S3.Key
+		}
+		if p.input.Hints.CloudWatchLogs != nil {
+			quarantined.LogGroup = p.input.Hints.CloudWatchLogs.LogGroup
+			quarantined.LogStream = p.input.Hints.CloudWatchLogs.LogStream
+		}
+	}
+	result, err := quarantined.Result()
+	if err != nil {
+		p.operation.LogWarn(err, zap.Uint64("lineNum", quarantined.LineNumber))
+		return
+	}
+	outputChan <- result
+}
+
 func (p *Processor) sendEvents(result *classification.ClassifierResult, outputChan chan *parsers.Result) {
 	for _, event := range result.Events {
 		outputChan <- event
@@ -161,9 +296,15 @@ func (p *Processor) sendEvents(result *classification.ClassifierResult, outputCh
 
 func (p *Processor) logStats(err error) {
 	p.operation.Stop()
-	p.operation.Log(err, zap.Any(statsKey, *p.classifier.Stats()))
-	for _, parserStats := range p.classifier.ParserStats() {
-		p.operation.Log(err, zap.Any(statsKey, *parserStats))
+	classifiers := []classification.ClassifierAPI{p.classifier}
+	for _, classifier := range p.sourceClassifiers {
+		classifiers = append(classifiers, classifier)
+	}
+	for _, classifier := range classifiers {
+		p.operation.Log(err, zap.Any(statsKey, *classifier.Stats()))
+		for _, parserStats := range classifier.ParserStats() {
+			p.operation.Log(err, zap.Any(statsKey, *parserStats))
+		}
 	}
 }
 
@@ -171,12 +312,18 @@ type Processor struct {
 	input      *common.DataStream
 	classifier classification.ClassifierAPI
 	operation  *oplog.Operation
+	// quarantined objects hold lines of many sources, each line is classified with the log types of its source
+	reprocess         bool
+	reprocessed       *quarantine.Line
+	sourceClassifiers map[string]classification.ClassifierAPI
 }
 
 func NewProcessor(input *common.DataStream, parsers map[string]parsers.Interface) *Processor {
 	return &Processor{
-		input:      input,
-		classifier: classification.NewClassifier(parsers),
-		operation:  common.OpLogManager.Start(operationName),
+		input:             input,
+		classifier:        classification.NewClassifier(parsers),
+		operation:         common.OpLogManager.Start(operationName),
+		reprocess:         input.Hints.S3 != nil && quarantine.IsQuarantined(input.Hints.S3.Bucket, input.Hints.S3.Key),
+		sourceClassifiers: make(map[string]classification.ClassifierAPI),
 	}
 }
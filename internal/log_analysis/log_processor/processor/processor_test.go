@@ -37,7 +37,9 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
 
@@ -170,6 +172,198 @@ func TestProcessDataStreamErrorNoChannelBuffers(t *testing.T) {
 	TestProcessDataStreamError(t)
 }
 
+func TestProcessJSONRecords(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	// the records are split over lines and surrounded by other fields to make sure they are decoded as a stream
+	dataStream := &common.DataStream{
+		Reader: strings.NewReader(` {"Records": [
+{"eventName":"one","nested":{"Records":[1,2]}},
+{"eventName":"two"}
+],
+"Other": {"eventName":"skipped"}}
+`),
+		LogType: &testLogType,
+		Hints:   common.DataStreamHints{S3: s3Hint},
+	}
+	p := NewProcessor(dataStream, registry.AvailableParsers())
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	mockClassifier.On("Classify", `{"Records":[{"eventName":"one","nested":{"Records":[1,2]}}]}`).Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Classify", `{"Records":[{"eventName":"two"}]}`).Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	streamChan := make(chan *common.DataStream, 1)
+	streamChan <- dataStream
+	close(streamChan)
+	err := process(streamChan, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), destination.nEvents)
+	mockClassifier.AssertExpectations(t)
+}
+
+func TestProcessJSONRecordsError(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	dataStream := &common.DataStream{
+		Reader:  strings.NewReader(`{"Records":[{"eventName":"one"},{"eventName":`),
+		LogType: &testLogType,
+		Hints:   common.DataStreamHints{S3: s3Hint},
+	}
+	p := NewProcessor(dataStream, registry.AvailableParsers())
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	mockClassifier.On("Classify", `{"Records":[{"eventName":"one"}]}`).Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	streamChan := make(chan *common.DataStream, 1)
+	streamChan <- dataStream
+	close(streamChan)
+	err := process(streamChan, destination, newProcessorFunc)
+	require.Error(t, err)
+	mockClassifier.AssertExpectations(t)
+}
+
+func TestProcessCloudWatchLogsData(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	// nolint:lll
+	dataStream := &common.DataStream{
+		Reader: strings.NewReader(`{"messageType":"CONTROL_MESSAGE","owner":"CloudwatchLogs","logGroup":"","logStream":"","subscriptionFilters":[],"logEvents":[{"id":"","timestamp":1585735200000,"message":"CWL CONTROL MESSAGE: Checking health of destination Firehose."}]}` +
+			`{"messageType":"DATA_MESSAGE","owner":"123456789012","logGroup":"test","logStream":"stream","subscriptionFilters":["panther"],"logEvents":[{"id":"1","timestamp":1585735200000,"message":"one"},{"id":"2","timestamp":1585735200001,"message":"two"}]}`),
+		LogType: &testLogType,
+		Hints:   common.DataStreamHints{S3: s3Hint},
+	}
+	p := NewProcessor(dataStream, registry.AvailableParsers())
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	mockClassifier.On("Classify", "one").Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		LogType: &testLogType,
+	}).Run(func(args mock.Arguments) {
+		require.Equal(t, "test", dataStream.Hints.CloudWatchLogs.LogGroup)
+		require.Equal(t, "stream", dataStream.Hints.CloudWatchLogs.LogStream)
+	}).Once()
+	mockClassifier.On("Classify", "two").Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	streamChan := make(chan *common.DataStream, 1)
+	streamChan <- dataStream
+	close(streamChan)
+	err := process(streamChan, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), destination.nEvents)
+	require.Nil(t, dataStream.Hints.CloudWatchLogs)
+	mockClassifier.AssertExpectations(t)
+}
+
+func TestNewStreamProcessorFraming(t *testing.T) {
+	// a single log type selects its framing
+	p := newStreamProcessor(&common.DataStream{LogTypes: []string{"Auditd.Event"}})
+	require.Equal(t, registry.Lookup("Auditd.Event").Framing(), p.input.Framing)
+
+	// log types without a framing of their own use the default framing
+	p = newStreamProcessor(&common.DataStream{LogTypes: []string{"AWS.CloudTrail", "Nginx.Access"}})
+	require.Nil(t, p.input.Framing)
+
+	// log types needing different framings fallback to the default framing
+	p = newStreamProcessor(&common.DataStream{LogTypes: []string{"Auditd.Event", "Nginx.Access"}})
+	require.Nil(t, p.input.Framing)
+
+	// the framing of the source takes precedence
+	p = newStreamProcessor(&common.DataStream{LogTypes: []string{"Auditd.Event"}, Framing: common.NewlineFraming{}})
+	require.Equal(t, common.NewlineFraming{}, p.input.Framing)
+}
+
+func TestProcessQuarantinedLines(t *testing.T) {
+	common.Config.ProcessedDataBucket = "processed"
+	defer func() {
+		sourceLogTypesFunc = sources.LogTypes
+	}()
+	sourceLogTypesFunc = func(bucket, key string) ([]string, error) {
+		require.Equal(t, "mybucket", bucket)
+		require.Equal(t, "nginx/access.log", key)
+		return []string{"Nginx.Access"}, nil
+	}
+
+	// nolint:lll
+	dataStream := &common.DataStream{
+		Reader: strings.NewReader(`{"bucket":"mybucket","key":"nginx/access.log","lineNumber":3,"quarantinedAt":"2020-01-01T00:01:01Z","line":"one"}
+{"bucket":"mybucket","key":"nginx/access.log","lineNumber":7,"quarantinedAt":"2020-01-01T00:01:01Z","line":"two"}
+`),
+		Framing: common.NewlineFraming{},
+		Hints: common.DataStreamHints{
+			S3: &common.S3DataStreamHints{
+				Bucket: "processed",
+				Key:    quarantine.ObjectKeyPrefix(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) + "file.json.gz",
+			},
+		},
+	}
+	p := NewProcessor(dataStream, registry.AvailableParsers())
+	streamClassifier := &testClassifier{}
+	streamClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	streamClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+	p.classifier = streamClassifier
+	sourceClassifier := &testClassifier{}
+	sourceClassifier.On("Classify", "one").Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	sourceClassifier.On("Classify", "two").Return(&classification.ClassifierResult{}).Once()
+	sourceClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	sourceClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+	p.sourceClassifiers["Nginx.Access"] = sourceClassifier
+
+	var results []*parsers.Result
+	destination := &testDestination{}
+	destination.On("SendEvents", mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+		for result := range args.Get(0).(chan *parsers.Result) {
+			results = append(results, result)
+		}
+	})
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	streamChan := make(chan *common.DataStream, 1)
+	streamChan <- dataStream
+	close(streamChan)
+	err := process(streamChan, destination, newProcessorFunc)
+	require.NoError(t, err)
+	sourceClassifier.AssertExpectations(t)
+	require.Len(t, results, 2)
+	require.Equal(t, testLogType, results[0].LogType)
+
+	// the line failing again points to the object it was originally read from
+	require.Equal(t, quarantine.LogType, results[1].LogType)
+	line, err := quarantine.ParseLine(string(results[1].JSON))
+	require.NoError(t, err)
+	require.Equal(t, "mybucket", line.Bucket)
+	require.Equal(t, "nginx/access.log", line.Key)
+	require.Equal(t, uint64(7), line.LineNumber)
+	require.Equal(t, "two", line.Line)
+	require.True(t, line.QuarantinedAt.After(time.Date(2020, 1, 1, 0, 1, 1, 0, time.UTC)))
+}
+
 func TestProcessDestinationError(t *testing.T) {
 	// error in Send events
 	sendEventsErr := errors.New("fail SendEvents")
@@ -265,6 +459,7 @@ func TestProcessClassifyFailure(t *testing.T) {
 	close(streamChan)
 	err := process(streamChan, destination, newProcessorFunc)
 	require.NoError(t, err)
+	require.Equal(t, testLogEvents, destination.nEvents) // the line that failed classification is quarantined
 
 	actual := logs.AllUntimed()
 	expected := []observer.LoggedEntry{
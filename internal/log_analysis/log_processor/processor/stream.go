@@ -25,6 +25,8 @@ import (
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/pkg/errors"
@@ -32,8 +34,10 @@ import (
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
+	"github.com/panther-labs/panther/pkg/awsbatch/s3batch"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 )
 
@@ -43,6 +47,8 @@ const (
 
 	sqsMaxBatchSize    = 10 // max messages per read for SQS (can't find an sqs constant to refer to)
 	sqsWaitTimeSeconds = 20 //  note: 20 is max for sqs
+
+	deleteQuarantinedObjectsMaxTime = time.Minute
 )
 
 /*
@@ -67,7 +73,8 @@ func streamEvents(sqsClient sqsiface.SQSAPI, deadlineTime time.Time, event event
 	streamChan := make(chan *common.DataStream, 2*sqsMaxBatchSize) // use small buffer to pipeline events
 	processingDeadlineTime := deadlineTime.Add(-time.Duration(float32(time.Since(deadlineTime)) * processingTimeLimitScalar))
 
-	var accumulatedMessageReceipts []*string // accumulate message receipts for delete at the end
+	var accumulatedMessageReceipts []*string      // accumulate message receipts for delete at the end
+	var quarantinedObjects []*s3.ObjectIdentifier // reprocessed quarantined objects are deleted at the end
 
 	readEventErrorChan := make(chan error, 1) // below go routine closes over this for errors, 1 deep buffer
 	go func() {
@@ -86,6 +93,7 @@ func streamEvents(sqsClient sqsiface.SQSAPI, deadlineTime time.Time, event event
 		// process lambda events
 		sqsMessageCount += len(dataStreams)
 		for _, dataStream := range dataStreams {
+			quarantinedObjects = appendQuarantinedObject(quarantinedObjects, dataStream)
 			streamChan <- dataStream
 		}
 
@@ -139,6 +147,7 @@ func streamEvents(sqsClient sqsiface.SQSAPI, deadlineTime time.Time, event event
 			// process sqs messages
 			sqsMessageCount += len(dataStreams)
 			for _, dataStream := range dataStreams {
+				quarantinedObjects = appendQuarantinedObject(quarantinedObjects, dataStream)
 				streamChan <- dataStream
 			}
 		}
@@ -156,9 +165,39 @@ func streamEvents(sqsClient sqsiface.SQSAPI, deadlineTime time.Time, event event
 
 	// delete messages from sqs q on success (best effort)
 	sqsbatch.DeleteMessageBatch(sqsClient, common.Config.SqsQueueURL, accumulatedMessageReceipts)
+
+	// delete reprocessed quarantined objects on success so they are not reprocessed again (best effort)
+	deleteQuarantinedObjects(common.S3Client, quarantinedObjects)
 	return sqsMessageCount, nil
 }
 
+// appendQuarantinedObject remembers the quarantined object a data stream reads so it can be deleted when done
+func appendQuarantinedObject(objects []*s3.ObjectIdentifier, dataStream *common.DataStream) []*s3.ObjectIdentifier {
+	if dataStream == nil || dataStream.Hints.S3 == nil {
+		return objects
+	}
+	if !quarantine.IsQuarantined(dataStream.Hints.S3.Bucket, dataStream.Hints.S3.Key) {
+		return objects
+	}
+	return append(objects, &s3.ObjectIdentifier{Key: aws.String(dataStream.Hints.S3.Key)})
+}
+
+func deleteQuarantinedObjects(s3Client s3iface.S3API, objects []*s3.ObjectIdentifier) {
+	if len(objects) == 0 {
+		return
+	}
+	input := &s3.DeleteObjectsInput{
+		Bucket: aws.String(common.Config.ProcessedDataBucket),
+		Delete: &s3.Delete{Objects: objects},
+	}
+	if err := s3batch.DeleteObjects(s3Client, deleteQuarantinedObjectsMaxTime, input); err != nil {
+		zap.L().Error("failure deleting reprocessed quarantined objects",
+			zap.String("guidance", "the quarantined objects must be deleted before reprocessing them again"),
+			zap.Int("numberOfObjects", len(objects)),
+			zap.Error(err))
+	}
+}
+
 func lambdaDataStreams(event events.SQSEvent,
 	readSnsMessagesFunc func([]string) ([]*common.DataStream, error)) ([]*common.DataStream, error) {
 
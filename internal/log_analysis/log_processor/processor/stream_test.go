@@ -26,6 +26,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -214,6 +215,37 @@ func TestStreamEventsDeleteSQSError(t *testing.T) {
 	streamTestSqsClient.AssertExpectations(t)
 }
 
+func TestStreamEventsDeleteQuarantinedObjects(t *testing.T) {
+	initTest()
+	common.Config.ProcessedDataBucket = "processed"
+	s3Client := &testutils.S3Mock{}
+	common.S3Client = s3Client
+
+	// only lambda events
+	deadline := streamTestDeadline.Add(-defaultTestTimeLimit) // polling loop should not be entered
+	quarantinedKey := "quarantine/year=2020/month=01/day=01/hour=00/file.json.gz"
+	readSnsMessagesFunc := func(messages []string) ([]*common.DataStream, error) {
+		return []*common.DataStream{
+			{Hints: common.DataStreamHints{S3: &common.S3DataStreamHints{Bucket: "processed", Key: quarantinedKey}}},
+			{Hints: common.DataStreamHints{S3: &common.S3DataStreamHints{Bucket: "mybucket", Key: quarantinedKey}}},
+		}, nil
+	}
+	expectInput := &s3.DeleteObjectsInput{
+		Bucket: aws.String("processed"),
+		Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String(quarantinedKey)}}},
+	}
+	s3Client.On("DeleteObjects", expectInput).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+
+	_, err := streamEvents(streamTestSqsClient, deadline, streamTestLambdaEvent, noopProcessorFunc, readSnsMessagesFunc)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+
+	// quarantined objects are kept if processing fails
+	_, err = streamEvents(streamTestSqsClient, deadline, streamTestLambdaEvent, failProcessorFunc, readSnsMessagesFunc)
+	require.Error(t, err)
+	s3Client.AssertExpectations(t)
+}
+
 func initTest() {
 	common.Config.AwsLambdaFunctionMemorySize = 1024
 	common.Config.SqsQueueURL = "https://fakesqsurl"
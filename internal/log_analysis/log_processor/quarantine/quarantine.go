@@ -0,0 +1,88 @@
+package quarantine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Log lines that fail classification are quarantined in the processed data bucket so they can be inspected
+// and pushed back through the log processor once a parser is fixed or a new log type is added.
+
+const (
+	// LogType is the pseudo log type of quarantined lines, it is never registered so no tables are created for it
+	LogType = "Panther.Quarantine"
+	// KeyPrefix is the prefix of quarantined objects in the processed data bucket
+	KeyPrefix = "quarantine/"
+
+	// keyPrefixFormat partitions quarantined objects by the hour they were quarantined
+	keyPrefixFormat = KeyPrefix + "year=%d/month=%02d/day=%02d/hour=%02d/"
+)
+
+// Line is a log line that failed classification along with the S3 object it was read from
+type Line struct {
+	Bucket        string    `json:"bucket,omitempty"`
+	Key           string    `json:"key,omitempty"`
+	LogGroup      string    `json:"logGroup,omitempty"`
+	LogStream     string    `json:"logStream,omitempty"`
+	LineNumber    uint64    `json:"lineNumber"`
+	QuarantinedAt time.Time `json:"quarantinedAt"`
+	Line          string    `json:"line"`
+}
+
+// Result converts a quarantined line to a result that can be sent to a destination
+func (line *Line) Result() (*parsers.Result, error) {
+	data, err := jsoniter.Marshal(line)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal quarantined line")
+	}
+	return &parsers.Result{
+		LogType:   LogType,
+		EventTime: line.QuarantinedAt,
+		JSON:      data,
+	}, nil
+}
+
+// ObjectKeyPrefix returns the prefix of the objects holding lines quarantined at a given hour
+func ObjectKeyPrefix(hour time.Time) string {
+	hour = hour.UTC()
+	return fmt.Sprintf(keyPrefixFormat, hour.Year(), hour.Month(), hour.Day(), hour.Hour())
+}
+
+// IsQuarantined checks if an S3 object holds quarantined lines
+func IsQuarantined(bucket, key string) bool {
+	return bucket == common.Config.ProcessedDataBucket && strings.HasPrefix(key, KeyPrefix)
+}
+
+// ParseLine reads a line of a quarantined object
+func ParseLine(event string) (*Line, error) {
+	line := Line{}
+	if err := jsoniter.UnmarshalFromString(event, &line); err != nil {
+		return nil, errors.Wrap(err, "failed to read quarantined line")
+	}
+	return &line, nil
+}
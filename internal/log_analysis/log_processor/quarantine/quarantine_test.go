@@ -0,0 +1,72 @@
+package quarantine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+)
+
+func TestLineResult(t *testing.T) {
+	line := &Line{
+		Bucket:        "bucket",
+		Key:           "key",
+		LineNumber:    42,
+		QuarantinedAt: time.Date(2020, 1, 1, 0, 1, 1, 0, time.UTC),
+		Line:          `{"foo":"bar"}`,
+	}
+	result, err := line.Result()
+	require.NoError(t, err)
+	require.Equal(t, LogType, result.LogType)
+	require.Equal(t, line.QuarantinedAt, result.EventTime)
+	expectJSON := `{"bucket":"bucket","key":"key","lineNumber":42,"quarantinedAt":"2020-01-01T00:01:01Z","line":"{\"foo\":\"bar\"}"}`
+	require.JSONEq(t, expectJSON, string(result.JSON))
+}
+
+func TestObjectKeyPrefix(t *testing.T) {
+	hour := time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)
+	require.Equal(t, "quarantine/year=2020/month=01/day=02/hour=03/", ObjectKeyPrefix(hour))
+}
+
+func TestIsQuarantined(t *testing.T) {
+	common.Config.ProcessedDataBucket = "processed"
+	require.True(t, IsQuarantined("processed", "quarantine/year=2020/month=01/day=02/hour=03/file.json.gz"))
+	require.False(t, IsQuarantined("processed", "logs/aws_cloudtrail/year=2020/month=01/day=02/hour=03/file.json.gz"))
+	require.False(t, IsQuarantined("source", "quarantine/file.json.gz"))
+}
+
+func TestParseLine(t *testing.T) {
+	line, err := ParseLine(`{"bucket":"bucket","key":"key","lineNumber":2,"quarantinedAt":"2020-01-01T00:01:01Z","line":"bar\nbaz"}`)
+	require.NoError(t, err)
+	expect := &Line{
+		Bucket:        "bucket",
+		Key:           "key",
+		LineNumber:    2,
+		QuarantinedAt: time.Date(2020, 1, 1, 0, 1, 1, 0, time.UTC),
+		Line:          "bar\nbaz",
+	}
+	require.Equal(t, expect, line)
+
+	_, err = ParseLine("not json")
+	require.Error(t, err)
+}
@@ -19,23 +19,42 @@ package registry
  */
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 
 	// Register log types in init() blocks
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/apachelogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/auditdlogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/azurelogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/ceflogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/ciscoasalogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/duologs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fluentdsyslogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/githublogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gitlablogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gsuitelogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/juniperlogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/kuberneteslogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/leeflogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/nginxlogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/oktalogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osquerylogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osseclogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/paloaltologs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/slacklogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/suricatalogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysmonlogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/wazuhlogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/zeeklogs"
 )
 
@@ -68,7 +87,14 @@ func AvailableTables() (tables []*awsglue.GlueTableMetadata) {
 // Available parsers returns log parsers for all available log types with nil parameters.
 // Panics if a parser factory in the default registry fails with nil params.
 func AvailableParsers() map[string]parsers.Interface {
-	entries := logtypes.DefaultRegistry().Entries()
+	return Parsers()
+}
+
+// Parsers returns log parsers for the given log types with nil parameters.
+// Log types that are not registered are skipped, if no log types are given all available log types are used.
+// Panics if a parser factory in the default registry fails with nil params.
+func Parsers(logTypes ...string) map[string]parsers.Interface {
+	entries := logtypes.DefaultRegistry().Entries(logTypes...)
 	available := make(map[string]parsers.Interface, len(entries))
 	for _, entry := range entries {
 		logType := entry.Describe().Name
@@ -80,3 +106,25 @@ func AvailableParsers() map[string]parsers.Interface {
 	}
 	return available
 }
+
+// Framing returns the event framing shared by the given log types.
+// It returns nil if none of the log types registers a framing, in which case the default framing applies.
+// Log types that are not registered (ie custom log types not loaded yet) use the default framing.
+// It fails if the log types need different framings since a single stream cannot be split in more than one way.
+func Framing(logTypes ...string) (common.Framing, error) {
+	var framing common.Framing
+	for i, logType := range logTypes {
+		var logTypeFraming common.Framing
+		if entry := logtypes.DefaultRegistry().Get(logType); entry != nil {
+			logTypeFraming = entry.Framing()
+		}
+		if i == 0 {
+			framing = logTypeFraming
+			continue
+		}
+		if !reflect.DeepEqual(framing, logTypeFraming) {
+			return nil, errors.Errorf("log types %s need a different event framing", strings.Join(logTypes, ", "))
+		}
+	}
+	return framing, nil
+}
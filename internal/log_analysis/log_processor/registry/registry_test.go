@@ -27,3 +27,38 @@ import (
 func TestPanic(t *testing.T) {
 	assert.Panics(t, func() { Lookup("doesnotexist") }, "Failed to panic, this is very dangerous!")
 }
+
+func TestParsers(t *testing.T) {
+	parsers := Parsers("AWS.CloudTrail", "Nginx.Access", "doesnotexist")
+	assert.Len(t, parsers, 2)
+	assert.Contains(t, parsers, "AWS.CloudTrail")
+	assert.Contains(t, parsers, "Nginx.Access")
+
+	assert.Len(t, Parsers(), len(AvailableLogTypes()))
+}
+
+func TestFraming(t *testing.T) {
+	framing, err := Framing("AWS.CloudTrail", "Nginx.Access")
+	assert.NoError(t, err)
+	assert.Nil(t, framing)
+
+	framing, err = Framing()
+	assert.NoError(t, err)
+	assert.Nil(t, framing)
+
+	framing, err = Framing("Auditd.Event", "Auditd.Event")
+	assert.NoError(t, err)
+	assert.Equal(t, Lookup("Auditd.Event").Framing(), framing)
+
+	_, err = Framing("Auditd.Event", "OSSEC.Alert")
+	assert.Error(t, err)
+	// OSSEC alerts in the JSON format can be mixed with other log types
+	framing, err = Framing("OSSEC.EventInfo", "Syslog.RFC5424", "AWS.CloudTrail")
+	assert.NoError(t, err)
+	assert.Nil(t, framing)
+	_, err = Framing("Auditd.Event", "Nginx.Access")
+	assert.Error(t, err)
+	// log types that are not registered use the default framing
+	_, err = Framing("Auditd.Event", "doesnotexist")
+	assert.Error(t, err)
+}
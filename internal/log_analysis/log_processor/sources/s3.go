@@ -30,12 +30,15 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 )
 
 const (
@@ -128,11 +131,32 @@ func readS3Object(s3Object *S3ObjectInfo) (dataStream *common.DataStream, err er
 			zap.String("key", s3Object.S3ObjectKey))
 	}()
 
-	s3Client, _, err := getS3Client(s3Object)
-	if err != nil {
-		err = errors.Wrapf(err, "failed to get S3 client for s3://%s/%s",
-			s3Object.S3Bucket, s3Object.S3ObjectKey)
-		return nil, err
+	var (
+		s3Client s3iface.S3API
+		framing  common.Framing
+		logTypes []string
+	)
+	if quarantine.IsQuarantined(s3Object.S3Bucket, s3Object.S3ObjectKey) {
+		// quarantined lines are in the processed data bucket, no source is configured for it.
+		// Each line is classified using the log types of the source it was originally read from.
+		s3Client = newS3ClientFunc(nil, nil)
+		framing = common.NewlineFraming{}
+	} else {
+		var source *models.SourceIntegration
+		s3Client, source, err = getS3Client(s3Object)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to get S3 client for s3://%s/%s",
+				s3Object.S3Bucket, s3Object.S3ObjectKey)
+			return nil, err
+		}
+
+		framing, err = getSourceFraming(source)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to get event framing for s3://%s/%s",
+				s3Object.S3Bucket, s3Object.S3ObjectKey)
+			return nil, err
+		}
+		logTypes = getSourceLogTypes(source, s3Object.S3ObjectKey)
 	}
 
 	getObjectInput := &s3.GetObjectInput{
@@ -182,7 +206,9 @@ func readS3Object(s3Object *S3ObjectInfo) (dataStream *common.DataStream, err er
 	}
 
 	dataStream = &common.DataStream{
-		Reader: streamReader,
+		Reader:   streamReader,
+		Framing:  framing,
+		LogTypes: logTypes,
 		Hints: common.DataStreamHints{
 			S3: &common.S3DataStreamHints{
 				Bucket:      s3Object.S3Bucket,
@@ -191,9 +217,54 @@ func readS3Object(s3Object *S3ObjectInfo) (dataStream *common.DataStream, err er
 			},
 		},
 	}
+	if len(logTypes) == 1 {
+		dataStream.LogType = &logTypes[0]
+	}
 	return dataStream, err
 }
 
+// LogTypes returns the log types currently configured for an S3 object by its source
+func LogTypes(bucket, key string) ([]string, error) {
+	s3Object := &S3ObjectInfo{
+		S3Bucket:    bucket,
+		S3ObjectKey: key,
+	}
+	source, err := getSourceInfo(s3Object)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the source of S3 object %#v", s3Object)
+	}
+	if source == nil {
+		return nil, errors.Errorf("there is no source configured for S3 object %#v", s3Object)
+	}
+	return getSourceLogTypes(source, key), nil
+}
+
+// getSourceLogTypes returns the log types configured for an S3 object key of a source.
+// The log types of the longest matching prefix are preferred over the log types of the source.
+func getSourceLogTypes(source *models.SourceIntegration, key string) []string {
+	var match *models.S3PrefixLogTypes
+	for _, prefix := range source.S3PrefixLogTypes {
+		if !strings.HasPrefix(key, aws.StringValue(prefix.S3Prefix)) {
+			continue
+		}
+		if match == nil || len(aws.StringValue(prefix.S3Prefix)) > len(aws.StringValue(match.S3Prefix)) {
+			match = prefix
+		}
+	}
+	if match != nil {
+		return aws.StringValueSlice(match.LogTypes)
+	}
+	return aws.StringValueSlice(source.LogTypes)
+}
+
+// getSourceFraming returns the event framing configured for a source or nil if the default framing should be used
+func getSourceFraming(source *models.SourceIntegration) (common.Framing, error) {
+	if source.EventFraming == nil {
+		return nil, nil
+	}
+	return common.NewFraming(*source.EventFraming, aws.StringValue(source.EventFramingPattern))
+}
+
 // ParseNotification parses a message received
 func ParseNotification(message string) ([]*S3ObjectInfo, error) {
 	s3Objects := parseCloudTrailNotification(message)
@@ -34,6 +34,8 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/box"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
@@ -95,15 +97,15 @@ func init() {
 
 // getS3Client Fetches
 // 1. S3 client with permissions to read data from the account that contains the event
-// 2. The type of the integration
-func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, string, error) {
+// 2. The source integration the object belongs to
+func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, *models.SourceIntegration, error) {
 	sourceInfo, err := getSourceInfo(s3Object)
 	if err != nil {
-		return nil, "", errors.Wrapf(err, "failed to fetch the appropriate role arn to retrieve S3 object %#v", s3Object)
+		return nil, nil, errors.Wrapf(err, "failed to fetch the appropriate role arn to retrieve S3 object %#v", s3Object)
 	}
 
 	if sourceInfo == nil {
-		return nil, "", errors.Errorf("there is no source configured for S3 object %#v", s3Object)
+		return nil, nil, errors.Errorf("there is no source configured for S3 object %#v", s3Object)
 	}
 	var awsCreds *credentials.Credentials // lazy create below
 	roleArn := getSourceLogProcessingRole(sourceInfo)
@@ -113,12 +115,12 @@ func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, string, error) {
 		zap.L().Debug("bucket region was not cached, fetching it", zap.String("bucket", s3Object.S3Bucket))
 		awsCreds = getAwsCredentials(roleArn)
 		if awsCreds == nil {
-			return nil, "", errors.Errorf("failed to fetch credentials for assumed role %s to read %#v",
+			return nil, nil, errors.Errorf("failed to fetch credentials for assumed role %s to read %#v",
 				roleArn, s3Object)
 		}
 		bucketRegion, err = getBucketRegion(s3Object.S3Bucket, awsCreds)
 		if err != nil {
-			return nil, "", err
+			return nil, nil, err
 		}
 		bucketCache.Add(s3Object.S3Bucket, bucketRegion)
 	}
@@ -135,14 +137,14 @@ func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, string, error) {
 		if awsCreds == nil {
 			awsCreds = getAwsCredentials(roleArn)
 			if awsCreds == nil {
-				return nil, "", errors.Errorf("failed to fetch credentials for assumed role %s to read %#v",
+				return nil, nil, errors.Errorf("failed to fetch credentials for assumed role %s to read %#v",
 					roleArn, s3Object)
 			}
 		}
 		client = newS3ClientFunc(box.String(cacheKey.awsRegion), awsCreds)
 		s3ClientCache.Add(cacheKey, client)
 	}
-	return client.(s3iface.S3API), *sourceInfo.IntegrationType, nil
+	return client.(s3iface.S3API), sourceInfo, nil
 }
 
 func getBucketRegion(s3Bucket string, awsCreds *credentials.Credentials) (string, error) {
@@ -187,6 +189,10 @@ func getSourceInfo(s3Object *S3ObjectInfo) (result *models.SourceIntegration, er
 		if err != nil {
 			return nil, err
 		}
+		// Custom log types are refreshed along with the sources that use them
+		if _, err = customlogs.Load(common.LambdaClient, registry.Default()); err != nil {
+			return nil, err
+		}
 		sourceCache.cacheUpdateTime = now
 		sourceCache.sources = output
 	}
@@ -72,7 +72,9 @@ func TestGetS3Client(t *testing.T) {
 
 	// First invocation should be to get the list of available sources
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
-	// Second invocation would be to update the status
+	// Second invocation should be to get the custom log types
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("[]")}, nil).Once()
+	// Third invocation would be to update the status
 	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Once()
 	s3Mock.On("GetBucketLocation", expectedGetBucketLocationInput).Return(
 		&s3.GetBucketLocationOutput{LocationConstraint: aws.String("us-west-2")}, nil).Once()
@@ -86,19 +88,19 @@ func TestGetS3Client(t *testing.T) {
 		S3Bucket:    "test-bucket",
 		S3ObjectKey: "prefix/key",
 	}
-	result, sourceType, err := getS3Client(s3Object)
+	result, source, err := getS3Client(s3Object)
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	require.Equal(t, models.IntegrationTypeAWS3, sourceType)
+	require.Equal(t, models.IntegrationTypeAWS3, *source.IntegrationType)
 
 	// Subsequent calls should use cache
-	result, sourceType, err = getS3Client(s3Object)
+	result, source, err = getS3Client(s3Object)
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	require.Equal(t, models.IntegrationTypeAWS3, sourceType)
+	require.Equal(t, models.IntegrationTypeAWS3, *source.IntegrationType)
 
 	// verify that we have updated the source with the last time scanned status
-	updateStatusInvokeInput := lambdaMock.Calls[1].Arguments.Get(0).(*lambda.InvokeInput)
+	updateStatusInvokeInput := lambdaMock.Calls[2].Arguments.Get(0).(*lambda.InvokeInput)
 	var updateStatusInput models.LambdaInput
 	require.NoError(t, jsoniter.Unmarshal(updateStatusInvokeInput.Payload, &updateStatusInput))
 	require.Equal(t, "3e4b1734-e678-4581-b291-4b8a176219e9", updateStatusInput.UpdateStatus.IntegrationID)
@@ -126,6 +128,7 @@ func TestGetS3ClientUnknownBucket(t *testing.T) {
 	}
 
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("[]")}, nil).Once()
 
 	newCredentialsFunc =
 		func(c client.ConfigProvider, roleARN string, options ...func(*stscreds.AssumeRoleProvider)) *credentials.Credentials {
@@ -137,10 +140,10 @@ func TestGetS3ClientUnknownBucket(t *testing.T) {
 		S3ObjectKey: "prefix/key",
 	}
 
-	result, sourceType, err := getS3Client(s3Object)
+	result, source, err := getS3Client(s3Object)
 	require.Error(t, err)
 	require.Nil(t, result)
-	require.Equal(t, "", sourceType)
+	require.Nil(t, source)
 
 	s3Mock.AssertExpectations(t)
 	lambdaMock.AssertExpectations(t)
@@ -174,7 +177,9 @@ func TestGetS3ClientSourceNoPrefix(t *testing.T) {
 
 	// First invocation should be to get the list of available sources
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
-	// Second invocation would be to update the status
+	// Second invocation should be to get the custom log types
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("[]")}, nil).Once()
+	// Third invocation would be to update the status
 	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Once()
 
 	expectedGetBucketLocationInput := &s3.GetBucketLocationInput{Bucket: aws.String("test-bucket")}
@@ -191,10 +196,10 @@ func TestGetS3ClientSourceNoPrefix(t *testing.T) {
 		S3ObjectKey: "test",
 	}
 
-	result, sourceType, err := getS3Client(s3Object)
+	result, source, err := getS3Client(s3Object)
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	require.Equal(t, models.IntegrationTypeAWS3, sourceType)
+	require.Equal(t, models.IntegrationTypeAWS3, *source.IntegrationType)
 
 	s3Mock.AssertExpectations(t)
 	lambdaMock.AssertExpectations(t)
@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
@@ -143,7 +144,9 @@ func TestHandleUnsupportedFileType(t *testing.T) {
 
 	// First invocation should be to get the list of available sources
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
-	// Second invocation would be to update the status
+	// Second invocation should be to get the custom log types
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("[]")}, nil).Once()
+	// Third invocation would be to update the status
 	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Once()
 	s3Mock.On("GetBucketLocation", mock.Anything).Return(
 		&s3.GetBucketLocationOutput{LocationConstraint: aws.String("us-west-2")}, nil).Once()
@@ -163,3 +166,54 @@ func TestHandleUnsupportedFileType(t *testing.T) {
 	// Method should not return data stream
 	require.Equal(t, 0, len(dataStreams))
 }
+
+func TestGetSourceLogTypes(t *testing.T) {
+	source := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			LogTypes: aws.StringSlice([]string{"AWS.CloudTrail", "AWS.S3ServerAccess"}),
+			S3PrefixLogTypes: []*models.S3PrefixLogTypes{
+				{
+					S3Prefix: aws.String("logs/"),
+					LogTypes: aws.StringSlice([]string{"AWS.S3ServerAccess"}),
+				},
+				{
+					S3Prefix: aws.String("logs/nginx/"),
+					LogTypes: aws.StringSlice([]string{"Nginx.Access"}),
+				},
+			},
+		},
+	}
+	require.Equal(t, []string{"AWS.CloudTrail", "AWS.S3ServerAccess"}, getSourceLogTypes(source, "cloudtrail/key"))
+	require.Equal(t, []string{"AWS.S3ServerAccess"}, getSourceLogTypes(source, "logs/key"))
+	require.Equal(t, []string{"Nginx.Access"}, getSourceLogTypes(source, "logs/nginx/key"))
+
+	require.Empty(t, getSourceLogTypes(&models.SourceIntegration{}, "key"))
+}
+
+func TestLogTypes(t *testing.T) {
+	resetCaches()
+	source := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:   aws.String("3e4b1734-e678-4581-b291-4b8a17621999"),
+			IntegrationType: aws.String(models.IntegrationTypeAWS3),
+			S3Bucket:        aws.String("mybucket"),
+			LogTypes:        aws.StringSlice([]string{"AWS.CloudTrail"}),
+			S3PrefixLogTypes: []*models.S3PrefixLogTypes{
+				{
+					S3Prefix: aws.String("nginx/"),
+					LogTypes: aws.StringSlice([]string{"Nginx.Access"}),
+				},
+			},
+		},
+	}
+	sourceCache.cacheUpdateTime = time.Now()
+	sourceCache.sources = []*models.SourceIntegration{source}
+	lastEventReceived[*source.IntegrationID] = time.Now()
+
+	logTypes, err := LogTypes("mybucket", "nginx/key")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Nginx.Access"}, logTypes)
+
+	_, err = LogTypes("otherbucket", "nginx/key")
+	require.Error(t, err)
+}
@@ -65,6 +65,11 @@ func (m *S3Mock) GetBucketLocation(input *s3.GetBucketLocationInput) (*s3.GetBuc
 	return args.Get(0).(*s3.GetBucketLocationOutput), args.Error(1)
 }
 
+func (m *S3Mock) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.DeleteObjectsOutput), args.Error(1)
+}
+
 func (m *S3Mock) ListObjectsV2Pages(input *s3.ListObjectsV2Input, f func(page *s3.ListObjectsV2Output, morePages bool) bool) error {
 	args := m.Called(input, f)
 	f(args.Get(0).(*s3.ListObjectsV2Output), false)
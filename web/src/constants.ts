@@ -91,39 +91,83 @@ export const RESOURCE_TYPES = [
 export const LOG_TYPES = [
   'Apache.AccessCombined',
   'Apache.AccessCommon',
+  'Apache.Error',
+  'Auditd.Event',
   'AWS.ALB',
   'AWS.AuroraMySQLAudit',
+  'AWS.CloudFront',
   'AWS.CloudTrail',
   'AWS.CloudTrailDigest',
   'AWS.CloudTrailInsight',
+  'AWS.ConfigHistory',
   'AWS.GuardDuty',
+  'AWS.Route53ResolverQuery',
   'AWS.S3ServerAccess',
+  'AWS.SecurityHubFinding',
   'AWS.VPCFlow',
+  'AWS.WAFWebACL',
+  'Azure.Activity',
+  'Azure.AuditLog',
+  'Azure.SignIn',
+  'CEF.Event',
+  'CiscoASA.Event',
+  'Duo.Administrator',
+  'Duo.Authentication',
   'Fluentd.Syslog3164',
   'Fluentd.Syslog5424',
+  'GitHub.Audit',
   'GitLab.API',
   'GitLab.Audit',
   'GitLab.Exceptions',
   'GitLab.Git',
   'GitLab.Integrations',
   'GitLab.Production',
+  'GSuite.Reports',
   'Juniper.Access',
   'Juniper.Audit',
   'Juniper.Firewall',
   'Juniper.MWS',
   'Juniper.Postgres',
   'Juniper.Security',
+  'Kubernetes.Audit',
+  'LEEF.Event',
   'Nginx.Access',
+  'Nginx.Error',
+  'Okta.SystemLog',
   'Osquery.Batch',
   'Osquery.Differential',
   'Osquery.Snapshot',
   'Osquery.Status',
+  'OSSEC.Alert',
   'OSSEC.EventInfo',
+  'PaloAlto.System',
+  'PaloAlto.Threat',
+  'PaloAlto.Traffic',
+  'Slack.AuditLogs',
+  'Suricata.Alert',
   'Suricata.Anomaly',
   'Suricata.DNS',
+  'Suricata.FileInfo',
+  'Suricata.Flow',
+  'Suricata.HTTP',
+  'Suricata.SMTP',
+  'Suricata.TLS',
   'Syslog.RFC3164',
   'Syslog.RFC5424',
+  'Sysmon.DNSQuery',
+  'Sysmon.FileCreate',
+  'Sysmon.NetworkConnect',
+  'Sysmon.ProcessCreate',
+  'Wazuh.Alert',
+  'Windows.EventLog',
+  'Zeek.Conn',
   'Zeek.DNS',
+  'Zeek.Files',
+  'Zeek.HTTP',
+  'Zeek.Notice',
+  'Zeek.SSL',
+  'Zeek.Weird',
+  'Zeek.X509',
 ] as const;
 
 export const SEVERITY_COLOR_MAP: { [key in SeverityEnum]: BadgeProps['color'] } = {